@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/auth"
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/manage_api_keys"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const usage = `Usage:
+  apikeys [-config path] create -name <client> -scopes <scope,...>
+  apikeys [-config path] list
+  apikeys [-config path] revoke <id>
+
+Scopes: %s
+`
+
+var configFile string
+
+func init() {
+	flag.StringVar(
+		&configFile,
+		"config",
+		"config/config.local.json",
+		"Path to configuration file",
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, strings.Join(auth.Scopes, ", "))
+		flag.PrintDefaults()
+	}
+}
+
+// Выдает, показывает и отзывает API-ключи вызывающих сервисов. Ключ печатается только при выдаче, в базе хранится
+// его хеш, поэтому потерянный ключ нужно отозвать и выдать новый. Выдача и отзыв записываются в журнал аудита.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	flag.Parse()
+
+	f := flag.Lookup(conf.Arg)
+	if f == nil {
+		return errors.New("config arg must be set")
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		return errors.New("command must be set")
+	}
+
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
+
+	logger, err := logger2.New(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Postgres.
+	db := postgres.MustConnect(config.DB.DSN)
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "close db", logfield.Error(err))
+		}
+	}()
+
+	postgres.MustMigrate(db)
+
+	service := manage_api_keys.New(logger, db)
+	auditLog := audit_log.New(logger, db)
+
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "create":
+		return create(ctx, service, auditLog, args)
+	case "list":
+		return list(ctx, service)
+	case "revoke":
+		return revoke(ctx, service, auditLog, args)
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func create(ctx context.Context, service *manage_api_keys.Service, auditLog *audit_log.Service, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	name := fs.String("name", "", "Client the key is issued to, e.g. orders")
+	scopes := fs.String("scopes", "", "Comma-separated scopes, e.g. reserve,write_off")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse create args: %v", err)
+	}
+
+	if *name == "" || *scopes == "" {
+		return errors.New("name and scopes must be set")
+	}
+
+	key, err := service.CreateKey(ctx, *name, strings.Split(*scopes, ","))
+
+	record(auditLog, "apikeys create", map[string]interface{}{
+		"name":   *name,
+		"scopes": strings.Split(*scopes, ","),
+	}, err)
+
+	if err != nil {
+		return fmt.Errorf("create key: %w", err)
+	}
+
+	fmt.Printf("id: %d\nkey: %s\n", key.ID, key.Key)
+
+	return nil
+}
+
+func list(ctx context.Context, service *manage_api_keys.Service) error {
+	keys, err := service.GetKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("get keys: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tNAME\tSCOPES\tCREATED\tREVOKED")
+
+	for _, k := range keys {
+		revoked := "-"
+		if k.RevokedAt != nil {
+			revoked = k.RevokedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			k.ID, k.Name, strings.Join(k.Scopes, ","), k.CreatedAt.Format(time.RFC3339), revoked)
+	}
+
+	return w.Flush()
+}
+
+func revoke(ctx context.Context, service *manage_api_keys.Service, auditLog *audit_log.Service, args []string) error {
+	if len(args) != 1 {
+		return errors.New("key id must be set")
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse key id: %v", err)
+	}
+
+	err = service.RevokeKey(ctx, id)
+
+	record(auditLog, "apikeys revoke", map[string]interface{}{
+		"id": id,
+	}, err)
+
+	if err != nil {
+		return fmt.Errorf("revoke key: %w", err)
+	}
+
+	return nil
+}
+
+// record записывает действие с ключами в журнал аудита от имени cli:<пользователь ОС>. Ошибку записи логирует
+// журнал, а действие уже выполнено, поэтому она не возвращается.
+func record(auditLog *audit_log.Service, action string, request map[string]interface{}, err error) {
+	body, _ := json.Marshal(request)
+
+	operator := "unknown"
+	if u, err := user.Current(); err == nil {
+		operator = u.Username
+	}
+
+	host, _ := os.Hostname()
+
+	ctx, cancel := context.WithTimeout(context.Background(), audit.RecordTimeout)
+	defer cancel()
+
+	_ = auditLog.Record(ctx, audit_log.Entry{
+		RequestID:  requestid.New(""),
+		ClientName: "cli:" + operator,
+		RemoteAddr: host,
+		Endpoint:   action,
+		Request:    audit.Sanitize(body),
+		ResultCode: resultCode(err),
+	})
+}
+
+func resultCode(err error) string {
+	switch {
+	case err == nil:
+		return audit.ResultOK
+	case errors.Is(err, servicesErrors.ErrUnknownScope), errors.Is(err, servicesErrors.ErrAPIKeyNotFound):
+		return errcodes.InvalidRequest
+	default:
+		return errcodes.InternalError
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/retry"
+	"github.com/frutonanny/wallet-service/internal/services/deliver_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/publish_events"
+	"github.com/frutonanny/wallet-service/internal/sinks"
+	"github.com/frutonanny/wallet-service/internal/webhookclient"
+)
+
+// startupBackoff – паузы между попытками подключиться к базе при запуске.
+var startupBackoff = retry.Backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second}
+
+var (
+	configFile   string
+	pollInterval time.Duration
+)
+
+func init() {
+	flag.StringVar(
+		&configFile,
+		"config",
+		"config/config.local.json",
+		"Path to configuration file",
+	)
+	flag.DurationVar(
+		&pollInterval,
+		"poll-interval",
+		time.Second,
+		"How long to wait before the next check when outbox is empty",
+	)
+}
+
+// Публикует события об изменении баланса из outbox в получатель, заданный в конфигурации, и, если включено,
+// доставляет их webhook-подписчикам. Работает постоянно; можно запускать несколько экземпляров – они не публикуют
+// одно и то же событие и не отправляют одну и ту же доставку одновременно.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	flag.Parse()
+
+	f := flag.Lookup(conf.Arg)
+	if f == nil {
+		return errors.New("config arg must be set")
+	}
+
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
+
+	logger, err := logger2.New(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Postgres. Пока база недоступна, релей ждет ее, а не падает.
+	db, err := postgres.Open(config.DB.DSN)
+	if err != nil {
+		return fmt.Errorf("open db: %v", err)
+	}
+
+	db.SetMaxOpenConns(config.DB.MaxOpenConns)
+	db.SetMaxIdleConns(config.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(config.DB.ConnMaxLifetime.Duration)
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "close db", logfield.Error(err))
+		}
+	}()
+
+	if err := retry.Do(ctx, startupBackoff, func(context.Context) error {
+		return postgres.Migrate(db)
+	}, func(err error, wait time.Duration) {
+		logger.Error(ctx, "apply migrations", logfield.Error(err), "retry_in", wait.String())
+	}); err != nil {
+		// Релей остановили, пока он ждал базу.
+		return nil
+	}
+
+	sink, closeSink, err := newSink(config.Relay)
+	if err != nil {
+		return fmt.Errorf("new sink: %w", err)
+	}
+	defer func() {
+		if err := closeSink(); err != nil {
+			logger.Error(ctx, "close sink", logfield.Error(err))
+		}
+	}()
+
+	if !config.Relay.Webhooks {
+		return publish_events.New(logger, db, sink).Run(ctx, pollInterval)
+	}
+
+	webhooks := deliver_webhooks.New(logger, db, webhookclient.New())
+
+	eg, ctx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		return publish_events.New(logger, db, sinks.NewMulti(sink, webhooks)).Run(ctx, pollInterval)
+	})
+
+	eg.Go(func() error {
+		return webhooks.Run(ctx, pollInterval)
+	})
+
+	return eg.Wait()
+}
+
+func newSink(config conf.RelayConfig) (publish_events.Sink, func() error, error) {
+	switch config.Sink {
+	case conf.SinkKafka:
+		s := sinks.NewKafka(config.Kafka.Brokers, config.Kafka.Topic)
+		return s, s.Close, nil
+
+	case conf.SinkFile:
+		s, closeFile := sinks.MustFile(config.File)
+		return s, closeFile, nil
+
+	case conf.SinkStdout, "":
+		return sinks.NewStdout(), func() error { return nil }, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown sink %q", config.Sink)
+}
@@ -9,22 +9,49 @@ import (
 	"net"
 	"os/signal"
 	"syscall"
+	"time"
 
 	conf "github.com/frutonanny/wallet-service/internal/config"
 	serverGen "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	serverGenV2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/health"
+	"github.com/frutonanny/wallet-service/internal/logfield"
 	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/minio"
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/retry"
 	"github.com/frutonanny/wallet-service/internal/services/add"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/authenticate"
 	cancelSev "github.com/frutonanny/wallet-service/internal/services/cancel"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	"github.com/frutonanny/wallet-service/internal/services/generate_statements"
 	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
 	"github.com/frutonanny/wallet-service/internal/services/get_report"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/rate_limit"
 	"github.com/frutonanny/wallet-service/internal/services/reserve"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
 	write_off "github.com/frutonanny/wallet-service/internal/services/write-off"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+	"golang.org/x/sync/errgroup"
 )
 
+// serviceName – имя сервиса в спанах.
+const serviceName = "wallet-service"
+
+// startupBackoff – паузы между попытками подключиться к базе при запуске.
+var startupBackoff = retry.Backoff{Base: 500 * time.Millisecond, Max: 30 * time.Second}
+
 var configFile string
 
 func init() {
@@ -53,19 +80,54 @@ func run() error {
 		return errors.New("config arg must be set")
 	}
 
-	config := conf.Must(f.Value.String())
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
 
-	logger := logger2.New()
+	logger, err := logger2.New(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Tracing.
+	shutdownTracing, err := tracing.Init(
+		ctx,
+		serviceName,
+		config.Tracing.Exporter,
+		config.Tracing.Endpoint,
+		config.Tracing.Insecure,
+	)
+	if err != nil {
+		return fmt.Errorf("init tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.Tracing.ShutdownTimeout.Duration)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error(ctx, "shutdown tracing", logfield.Error(err))
+		}
+	}()
+
+	// Postgres. Подключение и миграции выполняются в фоне: пока база недоступна, сервис работает, но не готов.
+	db, err := postgres.Open(config.DB.DSN)
+	if err != nil {
+		return fmt.Errorf("open db: %v", err)
+	}
+
+	db.SetMaxOpenConns(config.DB.MaxOpenConns)
+	db.SetMaxIdleConns(config.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(config.DB.ConnMaxLifetime.Duration)
 
-	// Postgres.
-	db := postgres.MustConnect(config.DB.DSN)
 	defer func() {
 		if err := db.Close(); err != nil {
-			logger.Error(fmt.Sprintf("close db error: %s", err))
+			logger.Error(ctx, "close db", logfield.Error(err))
 		}
 	}()
 
-	postgres.MustMigrate(db)
+	// Статистика пула соединений в /metrics.
+	metrics.RegisterDB(db)
 
 	// Minio.
 	minioClient := minio.Must(
@@ -74,8 +136,34 @@ func run() error {
 		config.Minio.SecretAccessKey,
 	)
 
+	// Клиент для подписи ссылок, которые отдаются наружу.
+	publicMinioClient := minio.Must(
+		config.Minio.PublicEndpoint,
+		config.Minio.AccessKeyID,
+		config.Minio.SecretAccessKey,
+	)
+
+	// Health.
+	checker := health.New(
+		logger,
+		health.Check{Name: "postgres", Check: db.PingContext},
+		health.Check{Name: "migrations", Check: func(ctx context.Context) error {
+			return postgres.CheckMigrations(ctx, db)
+		}},
+		health.Check{Name: "minio", Check: func(ctx context.Context) error {
+			return minio.CheckBuckets(
+				ctx,
+				minioClient,
+				get_report.ReportsBucketName,
+				export_statement.ExportsBucketName,
+				generate_statements.StatementsBucketName,
+			)
+		}},
+	)
+
 	// Address.
 	addr := net.JoinHostPort(config.Service.Host, config.Service.Port)
+	grpcAddr := net.JoinHostPort(config.GRPC.Host, config.GRPC.Port)
 
 	// Swagger.
 	swagger, err := serverGen.GetSwagger()
@@ -83,36 +171,144 @@ func run() error {
 		return fmt.Errorf("get swagger: %v", err)
 	}
 
+	swaggerV2, err := serverGenV2.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("get swagger v2: %v", err)
+	}
+
 	// Services.
 	getBalanceService := get_balance.New(logger, db)
+	getBalanceAt := get_balance_at.New(logger, db)
 	addService := add.New(logger, db)
 	reserveService := reserve.New(logger, db)
+	reserveCartService := reserve_cart.New(logger, db)
 	writeOffService := write_off.New(logger, db)
 	cancelService := cancelSev.New(logger, db)
 	getTransactions := get_transactions.New(logger, db)
 	getTransactionsByTime := get_transactions_by_time.New(logger, db)
+	getTransaction := get_transaction.New(logger, db)
+	getHistory := get_history.New(logger, db)
+	exportStatement := export_statement.New(logger, db, minioClient, publicMinioClient)
+	getStatements := get_statements.New(logger, db, publicMinioClient)
 	getReport := get_report.New(logger, db, minioClient, config.Minio.PublicEndpoint)
+	manageWebhooks := manage_webhooks.New(logger, db)
+	streamEvents := stream_events.New(logger, db)
+	idempotencyService := idempotency.New(logger, db)
+	authenticateService := authenticate.New(logger, db)
+	auditLog := audit_log.New(logger, db)
+
+	limits, defaultLimits, err := rateLimits(config.RateLimit)
+	if err != nil {
+		return fmt.Errorf("rate limits: %v", err)
+	}
+
+	rateLimitService := rate_limit.New(logger, db, limits, defaultLimits)
 
 	srv, err := initServer(
+		config,
 		addr,
 		swagger,
+		swaggerV2,
 		getBalanceService,
+		getBalanceAt,
 		addService,
 		reserveService,
+		reserveCartService,
 		writeOffService,
 		cancelService,
 		getTransactions,
 		getTransactionsByTime,
+		getTransaction,
+		getHistory,
+		exportStatement,
+		getStatements,
 		getReport,
+		streamEvents,
+		manageWebhooks,
+		authenticateService,
+		rateLimitService,
+		idempotencyService,
+		auditLog,
+		checker,
 	)
 
 	if err != nil {
 		return fmt.Errorf("init server: %v", err)
 	}
 
-	if err := srv.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
-		return fmt.Errorf("run server: %v", err)
+	grpcSrv := initGRPCServer(
+		logger,
+		config,
+		grpcAddr,
+		getBalanceService,
+		getBalanceAt,
+		addService,
+		reserveService,
+		reserveCartService,
+		writeOffService,
+		cancelService,
+		getTransactions,
+		getTransactionsByTime,
+		getTransaction,
+		getHistory,
+		exportStatement,
+		getStatements,
+		getReport,
+		streamEvents,
+		manageWebhooks,
+		authenticateService,
+		rateLimitService,
+		auditLog,
+	)
+
+	eg, ctx := errgroup.WithContext(ctx)
+
+	// Миграции применяются, как только база станет доступна.
+	eg.Go(func() error {
+		// Ошибку retry.Do отдает, только если сервис остановлен.
+		_ = retry.Do(ctx, startupBackoff, func(context.Context) error {
+			return postgres.Migrate(db)
+		}, func(err error, wait time.Duration) {
+			logger.Error(ctx, "apply migrations", logfield.Error(err), "retry_in", wait.String())
+		})
+
+		return nil
+	})
+
+	// Каждая реплика сама читает outbox и раздает события своим подписчикам.
+	eg.Go(func() error {
+		if err := streamEvents.Run(ctx, config.Server.StreamPollInterval.Duration); err != nil {
+			return fmt.Errorf("run stream events: %v", err)
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := rateLimitService.Run(ctx, config.Server.RateLimitCleanupInterval.Duration); err != nil {
+			return fmt.Errorf("run rate limit cleanup: %v", err)
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := srv.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("run server: %v", err)
+		}
+
+		return nil
+	})
+
+	if config.Features.GRPC {
+		eg.Go(func() error {
+			if err := grpcSrv.Run(ctx); err != nil {
+				return fmt.Errorf("run grpc server: %v", err)
+			}
+
+			return nil
+		})
 	}
 
-	return nil
+	return eg.Wait()
 }
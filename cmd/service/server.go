@@ -1,48 +1,215 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
 
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/health"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	grpcServer "github.com/frutonanny/wallet-service/internal/server/grpc/v1"
+	grpcHandlers "github.com/frutonanny/wallet-service/internal/server/grpc/v1/handlers"
 	server "github.com/frutonanny/wallet-service/internal/server/v1"
 	"github.com/frutonanny/wallet-service/internal/server/v1/handlers"
+	serverV2 "github.com/frutonanny/wallet-service/internal/server/v2"
+	handlersV2 "github.com/frutonanny/wallet-service/internal/server/v2/handlers"
 	"github.com/frutonanny/wallet-service/internal/services/add"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/authenticate"
 	"github.com/frutonanny/wallet-service/internal/services/cancel"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
 	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
 	"github.com/frutonanny/wallet-service/internal/services/get_report"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/rate_limit"
 	"github.com/frutonanny/wallet-service/internal/services/reserve"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
 	write_off "github.com/frutonanny/wallet-service/internal/services/write-off"
 )
 
 func initServer(
+	config conf.Config,
 	addr string,
 	swagger *openapi3.T,
+	swaggerV2 *openapi3.T,
 	getBalanceService *get_balance.Service,
+	getBalanceAt *get_balance_at.Service,
 	addService *add.Service,
 	reserveService *reserve.Service,
+	reserveCartService *reserve_cart.Service,
 	writeOffService *write_off.Service,
 	cancelService *cancel.Service,
 	getTransactions *get_transactions.Service,
 	getTransactionsByTime *get_transactions_by_time.Service,
+	getTransaction *get_transaction.Service,
+	getHistory *get_history.Service,
+	exportStatement *export_statement.Service,
+	getStatements *get_statements.Service,
 	getReport *get_report.Service,
+	streamEvents *stream_events.Service,
+	manageWebhooks *manage_webhooks.Service,
+	authenticateService *authenticate.Service,
+	rateLimitService *rate_limit.Service,
+	idempotencyService *idempotency.Service,
+	auditLog *audit_log.Service,
+	checker *health.Checker,
 ) (*server.Server, error) {
 	h := handlers.NewHandlers(
 		getBalanceService,
+		getBalanceAt,
 		addService,
 		reserveService,
+		reserveCartService,
 		writeOffService,
 		cancelService,
 		getTransactions,
 		getTransactionsByTime,
+		getTransaction,
+		getHistory,
+		exportStatement,
+		getStatements,
 		getReport,
+		streamEvents,
+		manageWebhooks,
+		auditLog,
 	)
 
+	var routes []func(e *echo.Echo)
+
+	if config.Features.APIV2 {
+		hV2 := handlersV2.NewHandlers(
+			getBalanceService,
+			getBalanceAt,
+			addService,
+			reserveCartService,
+			writeOffService,
+			cancelService,
+			getTransaction,
+			getHistory,
+			getStatements,
+			getReport,
+			manageWebhooks,
+		)
+
+		routes = append(routes, serverV2.Routes(hV2, swaggerV2, authenticateService, rateLimitService, auditLog))
+	}
+
 	srv := server.New(
 		addr,
 		h,
 		swagger,
-	)
+		authenticateService,
+		rateLimitService,
+		idempotencyService,
+		auditLog,
+		checker,
+		routes...,
+	).WithTimeouts(config.Server.ReadHeaderTimeout.Duration, config.Server.ShutdownTimeout.Duration)
 
 	return srv, nil
 }
+
+func initGRPCServer(
+	logger *logger2.Logger,
+	config conf.Config,
+	addr string,
+	getBalanceService *get_balance.Service,
+	getBalanceAt *get_balance_at.Service,
+	addService *add.Service,
+	reserveService *reserve.Service,
+	reserveCartService *reserve_cart.Service,
+	writeOffService *write_off.Service,
+	cancelService *cancel.Service,
+	getTransactions *get_transactions.Service,
+	getTransactionsByTime *get_transactions_by_time.Service,
+	getTransaction *get_transaction.Service,
+	getHistory *get_history.Service,
+	exportStatement *export_statement.Service,
+	getStatements *get_statements.Service,
+	getReport *get_report.Service,
+	streamEvents *stream_events.Service,
+	manageWebhooks *manage_webhooks.Service,
+	authenticateService *authenticate.Service,
+	rateLimitService *rate_limit.Service,
+	auditLog *audit_log.Service,
+) *grpcServer.Server {
+	h := grpcHandlers.NewHandlers(
+		getBalanceService,
+		getBalanceAt,
+		addService,
+		reserveService,
+		reserveCartService,
+		writeOffService,
+		cancelService,
+		getTransactions,
+		getTransactionsByTime,
+		getTransaction,
+		getHistory,
+		exportStatement,
+		getStatements,
+		getReport,
+		streamEvents,
+		manageWebhooks,
+	)
+
+	return grpcServer.New(logger, addr, h, authenticateService, rateLimitService, auditLog).
+		WithShutdownTimeout(config.Server.ShutdownTimeout.Duration)
+}
+
+// rateLimits переводит ограничения из конфигурации в ограничения сервиса.
+func rateLimits(config conf.RateLimitConfig) (map[string]rate_limit.Limits, rate_limit.Limits, error) {
+	defaults, err := operationLimits(config.Default)
+	if err != nil {
+		return nil, rate_limit.Limits{}, fmt.Errorf("default: %v", err)
+	}
+
+	operations := make(map[string]rate_limit.Limits, len(config.Operations))
+
+	for operation, l := range config.Operations {
+		limits, err := operationLimits(l)
+		if err != nil {
+			return nil, rate_limit.Limits{}, fmt.Errorf("%s: %v", operation, err)
+		}
+
+		operations[operation] = limits
+	}
+
+	return operations, defaults, nil
+}
+
+func operationLimits(config conf.OperationLimits) (rate_limit.Limits, error) {
+	client, err := limit(config.Client)
+	if err != nil {
+		return rate_limit.Limits{}, fmt.Errorf("client: %v", err)
+	}
+
+	user, err := limit(config.User)
+	if err != nil {
+		return rate_limit.Limits{}, fmt.Errorf("user: %v", err)
+	}
+
+	return rate_limit.Limits{Client: client, User: user}, nil
+}
+
+func limit(config *conf.LimitConfig) (*rate_limit.Limit, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	if config.Rate <= 0 || config.Burst < 1 {
+		return nil, fmt.Errorf("rate must be positive and burst must be at least 1, got rate %v, burst %d",
+			config.Rate, config.Burst)
+	}
+
+	return &rate_limit.Limit{Rate: config.Rate, Burst: config.Burst}, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/services/take_snapshots"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	configFile string
+	date       string
+)
+
+func init() {
+	flag.StringVar(
+		&configFile,
+		"config",
+		"config/config.local.json",
+		"Path to configuration file",
+	)
+	flag.StringVar(
+		&date,
+		"date",
+		time.Now().UTC().Format(dateLayout),
+		"Snapshots are taken at the start of this day (UTC) in YYYY-MM-DD format, today by default",
+	)
+}
+
+// Делает снимки баланса всех кошельков на начало суток. Запускается по расписанию раз в сутки, вскоре после
+// полуночи UTC. Если запуск прервался, то его можно просто повторить – кошельки, по которым снимок уже есть,
+// пропускаются.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	flag.Parse()
+
+	f := flag.Lookup(conf.Arg)
+	if f == nil {
+		return errors.New("config arg must be set")
+	}
+
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
+
+	at, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return fmt.Errorf("parse date: %v", err)
+	}
+
+	logger, err := logger2.New(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Postgres.
+	db := postgres.MustConnect(config.DB.DSN)
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "close db", logfield.Error(err))
+		}
+	}()
+
+	postgres.MustMigrate(db)
+
+	if _, err := take_snapshots.New(logger, db).TakeSnapshots(ctx, at); err != nil {
+		return fmt.Errorf("take snapshots: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/minio"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	"github.com/frutonanny/wallet-service/internal/services/generate_statements"
+)
+
+var (
+	configFile string
+	period     string
+	userID     int64
+)
+
+func init() {
+	flag.StringVar(
+		&configFile,
+		"config",
+		"config/config.local.json",
+		"Path to configuration file",
+	)
+	flag.StringVar(
+		&period,
+		"period",
+		time.Now().AddDate(0, -1, 0).Format(repoStatement.PeriodLayout),
+		"Month of statements in YYYY-MM format, previous month by default",
+	)
+	flag.Int64Var(
+		&userID,
+		"user",
+		0,
+		"Generate statement only for this user, all wallets by default",
+	)
+}
+
+// Формирует ежемесячные выписки пользователей. Запускается по расписанию после окончания месяца. Если запуск по всем
+// кошелькам прервался, то его можно просто повторить – выписки, которые уже сформированы, пропускаются.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	flag.Parse()
+
+	f := flag.Lookup(conf.Arg)
+	if f == nil {
+		return errors.New("config arg must be set")
+	}
+
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
+
+	month, err := time.Parse(repoStatement.PeriodLayout, period)
+	if err != nil {
+		return fmt.Errorf("parse period: %v", err)
+	}
+
+	logger, err := logger2.New(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Postgres.
+	db := postgres.MustConnect(config.DB.DSN)
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "close db", logfield.Error(err))
+		}
+	}()
+
+	postgres.MustMigrate(db)
+
+	// Minio.
+	minioClient := minio.Must(
+		config.Minio.Endpoint,
+		config.Minio.AccessKeyID,
+		config.Minio.SecretAccessKey,
+	)
+
+	generator := generate_statements.New(logger, db, minioClient)
+
+	if userID != 0 {
+		if err := generator.Generate(ctx, userID, month); err != nil {
+			return fmt.Errorf("generate statement: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := generator.GenerateAll(ctx, month); err != nil {
+		return fmt.Errorf("generate statements: %w", err)
+	}
+
+	return nil
+}
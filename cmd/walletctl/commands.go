@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/minio"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoReport "github.com/frutonanny/wallet-service/internal/repositories/report"
+	"github.com/frutonanny/wallet-service/internal/services/adjust"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/cancel"
+	"github.com/frutonanny/wallet-service/internal/services/get_report"
+	"github.com/frutonanny/wallet-service/internal/services/inspect_wallet"
+	"github.com/frutonanny/wallet-service/internal/services/reconcile"
+)
+
+func inspect(ctx context.Context, service *inspect_wallet.Service, args []string) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	limit := fs.Int64("orders", defaultOrders, "How many latest order items to show")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse inspect args: %v", err)
+	}
+
+	if fs.NArg() != 1 {
+		return errors.New("user id must be set")
+	}
+
+	userID, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse user id: %v", err)
+	}
+
+	wallet, err := service.Inspect(ctx, userID, *limit)
+	if err != nil {
+		return fmt.Errorf("inspect wallet: %w", err)
+	}
+
+	return render(wallet, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "user:\t%d\n", wallet.UserID)
+		fmt.Fprintf(w, "wallet:\t%d\n", wallet.WalletID)
+		fmt.Fprintf(w, "balance:\t%d\n", wallet.Balance)
+		fmt.Fprintf(w, "reservation:\t%d\n", wallet.Reservation)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, "ORDER\tSERVICE\tSTATUS\tAMOUNT\tCREATED\tUPDATED")
+
+		for _, o := range wallet.Orders {
+			fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%s\t%s\n",
+				o.OrderID, o.ServiceID, o.Status, o.Amount,
+				o.CreatedAt.Format(time.RFC3339), o.UpdatedAt.Format(time.RFC3339))
+		}
+	})
+}
+
+func adjustBalance(ctx context.Context, service *adjust.Service, auditLog *audit_log.Service, args []string) error {
+	fs := flag.NewFlagSet("adjust", flag.ContinueOnError)
+	userID := fs.Int64("user", 0, "User whose wallet is adjusted")
+	amount := fs.Int64("amount", 0, "Amount in kopecks, negative to debit the wallet")
+	reason := fs.String("reason", "", "Why the balance is adjusted, e.g. a ticket number")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse adjust args: %v", err)
+	}
+
+	if *userID == 0 || *amount == 0 || *reason == "" {
+		return errors.New("user, amount and reason must be set")
+	}
+
+	ctx, trail := audit.Start(ctx)
+
+	result, err := service.Adjust(ctx, *userID, *amount, *reason, operator())
+
+	record(trail, auditLog, "walletctl adjust", userID, map[string]interface{}{
+		"userID": *userID,
+		"amount": *amount,
+		"reason": *reason,
+	}, err)
+
+	if err != nil {
+		return fmt.Errorf("adjust balance: %w", err)
+	}
+
+	return render(result, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "transaction:\t%d\n", result.TransactionID)
+		fmt.Fprintf(w, "balance:\t%d\n", result.Balance)
+	})
+}
+
+func cancelOrder(ctx context.Context, service *cancel.Service, auditLog *audit_log.Service, args []string) error {
+	fs := flag.NewFlagSet("cancel", flag.ContinueOnError)
+	userID := fs.Int64("user", 0, "User whose reservation is cancelled")
+	orderID := fs.Int64("order", 0, "External order id")
+	serviceID := fs.Int64("service", 0, "Service of the order item, required if the order has several items")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse cancel args: %v", err)
+	}
+
+	if *userID == 0 || *orderID == 0 {
+		return errors.New("user and order must be set")
+	}
+
+	ctx, trail := audit.Start(ctx)
+
+	balance, err := service.Cancel(ctx, *userID, *serviceID, *orderID)
+
+	record(trail, auditLog, "walletctl cancel", userID, map[string]interface{}{
+		"userID":    *userID,
+		"orderID":   *orderID,
+		"serviceID": *serviceID,
+	}, err)
+
+	if err != nil {
+		return fmt.Errorf("cancel reservation: %w", err)
+	}
+
+	result := struct {
+		Balance int64 `json:"balance"`
+	}{
+		Balance: balance,
+	}
+
+	return render(result, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "balance:\t%d\n", result.Balance)
+	})
+}
+
+func report(ctx context.Context, logger *logger2.Logger, db *sql.DB, config conf.MinioConfig, args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	period := fs.String("period", "", "Report month in YYYY-MM format")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse report args: %v", err)
+	}
+
+	if _, err := time.Parse(repoReport.PeriodLayout, *period); err != nil {
+		return fmt.Errorf("period must be set in YYYY-MM format: %v", err)
+	}
+
+	if config.Endpoint == "" {
+		return errors.New("minio.endpoint must be set in the config to upload a report")
+	}
+
+	// Minio.
+	minioClient := minio.Must(
+		config.Endpoint,
+		config.AccessKeyID,
+		config.SecretAccessKey,
+	)
+
+	url, err := get_report.New(logger, db, minioClient, config.PublicEndpoint).GetReport(ctx, *period)
+	if err != nil {
+		return fmt.Errorf("get report: %w", err)
+	}
+
+	result := struct {
+		URL string `json:"url"`
+	}{
+		URL: url,
+	}
+
+	return render(result, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "url:\t%s\n", result.URL)
+	})
+}
+
+// reconcileWallets печатает расхождения и возвращает ошибку, если они есть, чтобы сверку можно было запускать
+// по расписанию и следить за кодом выхода.
+func reconcileWallets(ctx context.Context, service *reconcile.Service) error {
+	result, err := service.Reconcile(ctx)
+	if err != nil {
+		return fmt.Errorf("reconcile: %w", err)
+	}
+
+	err = render(result, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "checked:\t%d\n", result.Checked)
+		fmt.Fprintf(w, "mismatches:\t%d\n", len(result.Mismatches))
+
+		if len(result.Mismatches) == 0 {
+			return
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "USER\tWALLET\tBALANCE\tEXPECTED\tRESERVATION\tEXPECTED")
+
+		for _, m := range result.Mismatches {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%d\n",
+				m.UserID, m.WalletID, m.Balance, m.ExpectedBalance, m.Reservation, m.ExpectedReservation)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Mismatches) > 0 {
+		return fmt.Errorf("%d of %d wallets do not match the ledger", len(result.Mismatches), result.Checked)
+	}
+
+	return nil
+}
+
+func migrate(db *sql.DB, auditLog *audit_log.Service) error {
+	err := postgres.Migrate(db)
+
+	// Журнал аудита сам появляется с миграциями, поэтому неудачный первый запуск в него не попадет.
+	record(nil, auditLog, "walletctl migrate", nil, nil, err)
+
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	return nil
+}
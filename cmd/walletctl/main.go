@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"os/user"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	conf "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	logger2 "github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/internal/services/adjust"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/cancel"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/inspect_wallet"
+	"github.com/frutonanny/wallet-service/internal/services/reconcile"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const usage = `Usage:
+  walletctl [-config path] [-output text|json] inspect [-orders n] <userID>
+  walletctl [-config path] [-output text|json] adjust -user <userID> -amount <amount> -reason <reason>
+  walletctl [-config path] [-output text|json] cancel -user <userID> -order <orderID> [-service <serviceID>]
+  walletctl [-config path] [-output text|json] report -period <YYYY-MM>
+  walletctl [-config path] [-output text|json] reconcile
+  walletctl [-config path] migrate
+
+Amounts are in kopecks. A negative adjustment amount debits the wallet.
+`
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	// defaultOrders - сколько последних позиций заказов показывает inspect.
+	defaultOrders = 20
+)
+
+var (
+	configFile string
+	output     string
+)
+
+func init() {
+	flag.StringVar(
+		&configFile,
+		"config",
+		"config/config.local.json",
+		"Path to configuration file",
+	)
+	flag.StringVar(
+		&output,
+		"output",
+		outputText,
+		"Output format: text or json",
+	)
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+}
+
+// Инструмент операторов: показывает кошелек и его заказы, корректирует баланс с обязательной причиной, отменяет
+// зависший резерв, заново выгружает отчет, сверяет кошельки с журналом транзакций и применяет миграции. Работает
+// через те же сервисы, что и API, поэтому каждое изменение баланса попадает в журнал транзакций и outbox.
+// Изменения записываются в журнал аудита от имени cli:<пользователь ОС>.
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("run: %v", err)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	flag.Parse()
+
+	f := flag.Lookup(conf.Arg)
+	if f == nil {
+		return errors.New("config arg must be set")
+	}
+
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("unknown output format %q, must be text or json", output)
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		return errors.New("command must be set")
+	}
+
+	config, err := conf.Load(f.Value.String())
+	if err != nil {
+		return fmt.Errorf("load config: %v", err)
+	}
+
+	// Лог пишется в stderr, чтобы в stdout был только результат команды.
+	logger, err := logger2.NewWithWriter(os.Stderr, config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("new logger: %v", err)
+	}
+
+	// Postgres.
+	db, err := postgres.Open(config.DB.DSN)
+	if err != nil {
+		return fmt.Errorf("open db: %v", err)
+	}
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(ctx, "close db", logfield.Error(err))
+		}
+	}()
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("connect db: %v", err)
+	}
+
+	auditLog := audit_log.New(logger, db)
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+
+	if cmd == "migrate" {
+		return migrate(db, auditLog)
+	}
+
+	// Остальные команды работают со схемой, которую знает эта версия walletctl.
+	if err := postgres.CheckMigrations(ctx, db); err != nil {
+		return fmt.Errorf("check migrations, run walletctl migrate first: %w", err)
+	}
+
+	switch cmd {
+	case "inspect":
+		return inspect(ctx, inspect_wallet.New(logger, db), args)
+	case "adjust":
+		return adjustBalance(ctx, adjust.New(logger, db), auditLog, args)
+	case "cancel":
+		return cancelOrder(ctx, cancel.New(logger, db), auditLog, args)
+	case "report":
+		return report(ctx, logger, db, config.Minio, args)
+	case "reconcile":
+		return reconcileWallets(ctx, reconcile.New(logger, db))
+	default:
+		flag.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// render печатает результат команды в stdout: JSON-ом или текстом, который пишет text.
+func render(v interface{}, text func(w *tabwriter.Writer)) error {
+	if output == outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	text(w)
+
+	return w.Flush()
+}
+
+// operator - от чьего имени walletctl меняет данные: cli:<пользователь ОС>.
+func operator() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+
+	return "cli:" + name
+}
+
+// record записывает действие в журнал аудита от имени оператора вместе с транзакциями, которые отмечены в следе
+// trail. Ошибку записи логирует журнал, а действие уже выполнено, поэтому она не возвращается.
+func record(
+	trail *audit.Trail,
+	auditLog *audit_log.Service,
+	action string,
+	userID *int64,
+	request map[string]interface{},
+	err error,
+) {
+	body, _ := json.Marshal(request)
+
+	var transactionIDs, orderTransactionIDs []int64
+	if trail != nil {
+		transactionIDs, orderTransactionIDs = trail.TransactionIDs()
+	}
+
+	host, _ := os.Hostname()
+
+	ctx, cancel := context.WithTimeout(context.Background(), audit.RecordTimeout)
+	defer cancel()
+
+	_ = auditLog.Record(ctx, audit_log.Entry{
+		RequestID:           requestid.New(""),
+		ClientName:          operator(),
+		RemoteAddr:          host,
+		Endpoint:            action,
+		UserID:              userID,
+		Request:             audit.Sanitize(body),
+		ResultCode:          resultCode(err),
+		TransactionIDs:      transactionIDs,
+		OrderTransactionIDs: orderTransactionIDs,
+	})
+}
+
+func resultCode(err error) string {
+	switch {
+	case err == nil:
+		return audit.ResultOK
+	case errors.Is(err, servicesErrors.ErrWalletNotFound):
+		return errcodes.WalletNotFound
+	case errors.Is(err, servicesErrors.ErrNotEnoughCash):
+		return errcodes.NotEnoughCash
+	case errors.Is(err, servicesErrors.ErrOrderNotFound):
+		return errcodes.OrderNotFound
+	case errors.Is(err, servicesErrors.ErrAmbiguousOrder):
+		return errcodes.AmbiguousOrder
+	case errors.Is(err, servicesErrors.ErrReasonRequired), errors.Is(err, servicesErrors.ErrZeroAmount):
+		return errcodes.InvalidRequest
+	default:
+		return errcodes.InternalError
+	}
+}
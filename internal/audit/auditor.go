@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const (
+	// rejectedRate и rejectedBurst - сколько отклоненных запросов в секунду записывается в журнал. Отклоняются
+	// и запросы без API-ключа, поэтому их поток ничем не ограничен, а таблица журнала не должна расти вместе с ним.
+	rejectedRate  = 10
+	rejectedBurst = 100
+)
+
+// rejectedCodes - результаты запросов, которые отклонены до выполнения операции.
+var rejectedCodes = map[string]bool{
+	errcodes.Unauthenticated: true,
+	errcodes.Forbidden:       true,
+	errcodes.RateLimited:     true,
+	errcodes.InvalidRequest:  true,
+}
+
+// Entry - запись журнала аудита. Request - тело запроса без секретов, ResultCode - ok или код из pkg/errcodes.
+type Entry struct {
+	ID                  int64
+	CreatedAt           time.Time
+	RequestID           string
+	ClientKeyID         *int64
+	ClientName          string
+	RemoteAddr          string
+	Endpoint            string
+	UserID              *int64
+	Request             []byte
+	ResultCode          string
+	TransactionIDs      []int64
+	OrderTransactionIDs []int64
+}
+
+type Recorder interface {
+	Record(ctx context.Context, e Entry) error
+}
+
+// Call - запрос к API, который записывается в журнал. Endpoint - метод API или путь запроса для журнала,
+// Route - метод API или шаблон пути для метрик: в метках метрик не бывает путей с параметрами.
+type Call struct {
+	Endpoint   string
+	Route      string
+	RemoteAddr string
+	UserID     *int64
+	Body       []byte
+}
+
+// Auditor записывает в журнал запросы к API одного транспорта.
+type Auditor struct {
+	recorder  Recorder
+	transport string
+	rejected  *rate.Limiter
+}
+
+func NewAuditor(recorder Recorder, transport string) *Auditor {
+	return &Auditor{
+		recorder:  recorder,
+		transport: transport,
+		rejected:  rate.NewLimiter(rejectedRate, rejectedBurst),
+	}
+}
+
+// Do выполняет запрос handle со следом в контексте и записывает в журнал, кто, откуда, с каким телом и с каким
+// результатом его выполнил, а также созданные им транзакции. handle отдает код результата: ok или код ошибки.
+// Отклоненные запросы записываются не чаще rejectedRate в секунду, остальные учитывает метрика
+// wallet_audit_dropped_total.
+// Запрос мог быть отменен клиентом, а записать его нужно в любом случае, но не дольше RecordTimeout. Ошибка записи
+// не меняет ответ: ее логирует журнал и учитывает метрика wallet_audit_failures_total.
+func (a *Auditor) Do(ctx context.Context, call Call, handle func(ctx context.Context) (resultCode string)) {
+	ctx, trail := Start(ctx)
+
+	resultCode := handle(ctx)
+
+	if rejectedCodes[resultCode] && !a.rejected.Allow() {
+		metrics.ObserveAuditDropped(a.transport, call.Route)
+		return
+	}
+
+	clientKeyID, clientName := trail.Identity()
+	transactionIDs, orderTransactionIDs := trail.TransactionIDs()
+
+	recordCtx, cancel := context.WithTimeout(context.Background(), RecordTimeout)
+	defer cancel()
+
+	err := a.recorder.Record(recordCtx, Entry{
+		RequestID:           requestid.FromContext(ctx),
+		ClientKeyID:         clientKeyID,
+		ClientName:          clientName,
+		RemoteAddr:          call.RemoteAddr,
+		Endpoint:            call.Endpoint,
+		UserID:              call.UserID,
+		Request:             Sanitize(call.Body),
+		ResultCode:          resultCode,
+		TransactionIDs:      transactionIDs,
+		OrderTransactionIDs: orderTransactionIDs,
+	})
+	if err != nil {
+		metrics.ObserveAuditFailure(a.transport, call.Route)
+	}
+}
+
+// ReadBody читает тело http-запроса для журнала и подменяет его копией, чтобы запрос можно было обработать.
+func ReadBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
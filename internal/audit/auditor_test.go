@@ -0,0 +1,112 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+type recorder struct {
+	entries []audit.Entry
+	err     error
+}
+
+func (r *recorder) Record(ctx context.Context, e audit.Entry) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("record without deadline")
+	}
+
+	r.entries = append(r.entries, e)
+	return r.err
+}
+
+func TestAuditor_Do(t *testing.T) {
+	userID := int64(1)
+
+	call := audit.Call{
+		Endpoint:   "/v1/add",
+		Route:      "/v1/add",
+		RemoteAddr: "127.0.0.1",
+		UserID:     &userID,
+		Body:       []byte(`{"userID": 1, "secret": "s"}`),
+	}
+
+	t.Run("call is recorded with its trail", func(t *testing.T) {
+		rec := &recorder{}
+		auditor := audit.NewAuditor(rec, metrics.TransportHTTP)
+
+		ctx, cancel := context.WithCancel(requestid.WithID(context.Background(), "request-id"))
+
+		auditor.Do(ctx, call, func(ctx context.Context) string {
+			audit.SetClient(ctx, auth.Client{KeyID: 7, Name: "orders"})
+			audit.AddTransaction(ctx, 10)
+
+			// Клиент отменил запрос, а записать его нужно все равно.
+			cancel()
+
+			return audit.ResultOK
+		})
+
+		keyID := int64(7)
+
+		require.Len(t, rec.entries, 1)
+		assert.Equal(t, audit.Entry{
+			RequestID:      "request-id",
+			ClientKeyID:    &keyID,
+			ClientName:     "orders",
+			RemoteAddr:     "127.0.0.1",
+			Endpoint:       "/v1/add",
+			UserID:         &userID,
+			Request:        []byte(`{"secret":"[redacted]","userID":1}`),
+			ResultCode:     audit.ResultOK,
+			TransactionIDs: []int64{10},
+		}, rec.entries[0])
+	})
+
+	t.Run("record error does not stop the call", func(t *testing.T) {
+		rec := &recorder{err: errors.New("error")}
+		auditor := audit.NewAuditor(rec, metrics.TransportHTTP)
+
+		called := false
+
+		auditor.Do(context.Background(), call, func(ctx context.Context) string {
+			called = true
+			return errcodes.InternalError
+		})
+
+		assert.True(t, called)
+		assert.Len(t, rec.entries, 1)
+	})
+
+	t.Run("rejected calls are capped", func(t *testing.T) {
+		rec := &recorder{}
+		auditor := audit.NewAuditor(rec, metrics.TransportHTTP)
+
+		for i := 0; i < 1000; i++ {
+			auditor.Do(context.Background(), call, func(ctx context.Context) string {
+				return errcodes.Unauthenticated
+			})
+		}
+
+		assert.Less(t, len(rec.entries), 1000)
+		assert.GreaterOrEqual(t, len(rec.entries), 100)
+
+		// Выполненные запросы записываются всегда.
+		recorded := len(rec.entries)
+
+		auditor.Do(context.Background(), call, func(ctx context.Context) string {
+			return errcodes.NotEnoughCash
+		})
+
+		assert.Len(t, rec.entries, recorded+1)
+	})
+}
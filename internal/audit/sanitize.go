@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// ResultOK - код результата успешного запроса в журнале аудита. Для неуспешных – код из pkg/errcodes.
+	ResultOK = "ok"
+
+	// RecordTimeout - сколько ждать записи в журнал. Запись не зависит от контекста запроса, но зависшая база
+	// не должна держать запрос бесконечно.
+	RecordTimeout = 5 * time.Second
+
+	// maxBodySize - тело запроса длиннее этого в журнал не попадает целиком.
+	maxBodySize = 16 << 10
+
+	// maxFieldSize - поле слишком длинного тела длиннее этого в журнал не попадает.
+	maxFieldSize = 1 << 10
+
+	redacted = "[redacted]"
+	omitted  = "[omitted]"
+)
+
+// secretFields - поля, значения которых не попадают в журнал, в любом регистре и на любой вложенности.
+var secretFields = []string{"secret", "password", "token", "apikey", "api_key", "key"}
+
+// Sanitize готовит тело запроса к записи в журнал: скрывает значения секретных полей и обрезает слишком длинное
+// тело. Результат – всегда JSON-объект, тело-не объект записывается в поле body. Байты тела как есть в журнал
+// не попадают никогда: от тела, которое не удалось разобрать, остается только размер, а от слишком длинного –
+// размер и короткие поля верхнего уровня со скрытыми секретами.
+func Sanitize(body []byte) []byte {
+	if len(body) == 0 {
+		return []byte(`{}`)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return mustMarshal(map[string]interface{}{
+			"unparsed":  true,
+			"size":      len(body),
+			"truncated": len(body) > maxBodySize,
+		})
+	}
+
+	v = redact(v)
+
+	if len(body) > maxBodySize {
+		return mustMarshal(map[string]interface{}{
+			"truncated": true,
+			"size":      len(body),
+			"body":      shortFields(v),
+		})
+	}
+
+	if _, ok := v.(map[string]interface{}); !ok {
+		return mustMarshal(map[string]interface{}{
+			"body": v,
+		})
+	}
+
+	return mustMarshal(v)
+}
+
+// shortFields оставляет у объекта поля не длиннее maxFieldSize, значения остальных заменяет на omitted.
+// От тела-не объекта не остается ничего.
+func shortFields(v interface{}) interface{} {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return omitted
+	}
+
+	for k, item := range obj {
+		if b, err := json.Marshal(item); err != nil || len(b) > maxFieldSize {
+			obj[k] = omitted
+		}
+	}
+
+	return obj
+}
+
+func redact(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if isSecret(k) {
+				val[k] = redacted
+				continue
+			}
+
+			val[k] = redact(item)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redact(item)
+		}
+	}
+
+	return v
+}
+
+func isSecret(field string) bool {
+	for _, s := range secretFields {
+		if strings.EqualFold(field, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return []byte(`{}`)
+	}
+
+	return b
+}
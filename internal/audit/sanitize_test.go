@@ -0,0 +1,58 @@
+package audit_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		body string
+		want string
+	}{
+		{body: "", want: `{}`},
+		{body: `{"userID": 1, "amount": 100}`, want: `{"userID": 1, "amount": 100}`},
+		{
+			body: `{"url": "https://partner.example", "Secret": "s3cr3t", "nested": [{"token": "t"}]}`,
+			want: `{"url": "https://partner.example", "Secret": "[redacted]", "nested": [{"token": "[redacted]"}]}`,
+		},
+		{body: `[1, 2]`, want: `{"body": [1, 2]}`},
+		{body: `not json`, want: `{"unparsed": true, "size": 8, "truncated": false}`},
+		{body: `{"secret": "s3cr3t"`, want: `{"unparsed": true, "size": 19, "truncated": false}`},
+	}
+
+	for _, tc := range cases {
+		assert.JSONEq(t, tc.want, string(audit.Sanitize([]byte(tc.body))), tc.body)
+	}
+
+	t.Run("long body is truncated", func(t *testing.T) {
+		body := `{"data": "` + strings.Repeat("a", 20<<10) + `"}`
+
+		sanitized := string(audit.Sanitize([]byte(body)))
+		assert.Less(t, len(sanitized), len(body))
+		assert.Contains(t, sanitized, `"truncated":true`)
+	})
+
+	t.Run("long body keeps short fields with secrets redacted", func(t *testing.T) {
+		body := `{"userID": 1, "secret": "s3cr3t", "items": ["` + strings.Repeat("a", 20<<10) + `"]}`
+
+		want := fmt.Sprintf(
+			`{"truncated": true, "size": %d, "body": {"userID": 1, "secret": "[redacted]", "items": "[omitted]"}}`,
+			len(body),
+		)
+		assert.JSONEq(t, want, string(audit.Sanitize([]byte(body))))
+	})
+
+	t.Run("long unparsed body keeps only its size", func(t *testing.T) {
+		body := `{"secret": "s3cr3t", "data": "` + strings.Repeat("a", 20<<10)
+
+		sanitized := string(audit.Sanitize([]byte(body)))
+		assert.JSONEq(t, fmt.Sprintf(`{"unparsed": true, "size": %d, "truncated": true}`, len(body)), sanitized)
+		assert.NotContains(t, sanitized, "s3cr3t")
+	})
+}
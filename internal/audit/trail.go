@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+)
+
+// Trail - что сделал запрос: от чьего имени он выполнен и какие транзакции создал. Заполняется по ходу обработки
+// запроса и записывается в журнал аудита после нее. Сервисы отмечают транзакции до фиксации своей транзакции
+// в базе, поэтому у неуспешного запроса они могут ссылаться на откатившиеся строки.
+type Trail struct {
+	mu                  sync.Mutex
+	client              *auth.Client
+	transactionIDs      []int64
+	orderTransactionIDs []int64
+}
+
+type trailKey struct{}
+
+// Start кладет в контекст пустой след запроса.
+func Start(ctx context.Context) (context.Context, *Trail) {
+	t := &Trail{}
+	return context.WithValue(ctx, trailKey{}, t), t
+}
+
+// SetClient отмечает, от имени какого клиента выполняется запрос. Без следа в контексте ничего не делает.
+func SetClient(ctx context.Context, c auth.Client) {
+	if t, ok := ctx.Value(trailKey{}).(*Trail); ok {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.client = &c
+	}
+}
+
+// AddTransaction отмечает созданную запросом транзакцию. Без следа в контексте ничего не делает.
+func AddTransaction(ctx context.Context, id int64) {
+	if t, ok := ctx.Value(trailKey{}).(*Trail); ok {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.transactionIDs = append(t.transactionIDs, id)
+	}
+}
+
+// AddOrderTransaction отмечает созданную запросом операцию по заказу. Без следа в контексте ничего не делает.
+func AddOrderTransaction(ctx context.Context, id int64) {
+	if t, ok := ctx.Value(trailKey{}).(*Trail); ok {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.orderTransactionIDs = append(t.orderTransactionIDs, id)
+	}
+}
+
+// Client отдает клиента запроса. ok = false, если запрос не аутентифицирован.
+func (t *Trail) Client() (auth.Client, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == nil {
+		return auth.Client{}, false
+	}
+
+	return *t.client, true
+}
+
+// Identity отдает клиента запроса для журнала: идентификатор ключа и имя. У неаутентифицированного запроса
+// и у клиента без ключа в базе идентификатора нет.
+func (t *Trail) Identity() (keyID *int64, name string) {
+	c, ok := t.Client()
+	if !ok {
+		return nil, ""
+	}
+
+	if c.KeyID != 0 {
+		id := c.KeyID
+		keyID = &id
+	}
+
+	return keyID, c.Name
+}
+
+// TransactionIDs отдает транзакции и операции по заказам, созданные запросом.
+func (t *Trail) TransactionIDs() (transactionIDs, orderTransactionIDs []int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]int64(nil), t.transactionIDs...), append([]int64(nil), t.orderTransactionIDs...)
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// HeaderAuthorization - заголовок с API-ключом: Authorization: Bearer <ключ>.
+	HeaderAuthorization = "Authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// Client - вызывающий сервис, которому выдан API-ключ.
+type Client struct {
+	KeyID  int64
+	Name   string
+	Scopes []string
+}
+
+// Allowed сообщает, выдано ли клиенту право scope.
+func (c Client) Allowed(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type clientKey struct{}
+
+// WithClient кладет в контекст клиента, от имени которого выполняется запрос.
+func WithClient(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// ClientFrom отдает клиента, от имени которого выполняется запрос. ok = false, если запрос не аутентифицирован.
+func ClientFrom(ctx context.Context) (Client, bool) {
+	c, ok := ctx.Value(clientKey{}).(Client)
+	return c, ok
+}
+
+// HashKey - хеш API-ключа, в базе хранится только он. Ключи случайные и длинные, поэтому соль и медленный хеш
+// не нужны, а по SHA-256 ключ можно найти одним запросом.
+func HashKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// BearerKey достает API-ключ из значения заголовка Authorization. Если схема не Bearer, то ключа нет.
+func BearerKey(header string) string {
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(bearerPrefix):])
+}
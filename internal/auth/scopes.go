@@ -0,0 +1,37 @@
+package auth
+
+// Права клиента. Каждая операция API требует одно из них.
+const (
+	ScopeAdd        = "add"        // Зачисление средств.
+	ScopeReserve    = "reserve"    // Резервирование средств, в том числе под корзину.
+	ScopeWriteOff   = "write_off"  // Списание зарезервированных средств.
+	ScopeCancel     = "cancel"     // Отмена резерва.
+	ScopeRead       = "read"       // Балансы, транзакции и поток событий.
+	ScopeStatements = "statements" // Выгрузка выписки и ежемесячные выписки.
+	ScopeReport     = "report"     // Отчет по выручке.
+	ScopeAdmin      = "admin"      // Управление webhook-подписками и доставками.
+	ScopeAudit      = "audit"      // Чтение журнала аудита.
+)
+
+// Scopes - все известные права.
+var Scopes = []string{
+	ScopeAdd,
+	ScopeReserve,
+	ScopeWriteOff,
+	ScopeCancel,
+	ScopeRead,
+	ScopeStatements,
+	ScopeReport,
+	ScopeAdmin,
+	ScopeAudit,
+}
+
+func IsScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,23 +1,48 @@
+// Package config - конфигурация сервиса и команд. Конфигурация читается из файла JSON или YAML, любое поле можно
+// переопределить переменной окружения, а секреты - прочитать из файла, путь к которому задан в переменной окружения.
+// См. Load.
 package config
 
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"time"
 )
 
 const (
 	Arg = "config"
 )
 
+// Получатели событий релея.
+const (
+	SinkKafka  = "kafka"
+	SinkFile   = "file"
+	SinkStdout = "stdout"
+)
+
 type Config struct {
 	DB      DBConfig    `json:"db"`
 	Minio   MinioConfig `json:"minio"`
 	Service HttpService `json:"service"`
+	// GRPC - адрес gRPC API, оно работает рядом с http API.
+	GRPC      HttpService     `json:"grpc"`
+	Relay     RelayConfig     `json:"relay"`
+	RateLimit RateLimitConfig `json:"rate_limit"`
+	Tracing   TracingConfig   `json:"tracing"`
+	Log       LogConfig       `json:"log"`
+	Server    ServerConfig    `json:"server"`
+	Features  FeaturesConfig  `json:"features"`
 }
 
 type DBConfig struct {
 	DSN string `json:"dsn"`
+
+	// MaxOpenConns - сколько соединений с базой открывает пул, 0 - без ограничения.
+	MaxOpenConns int `json:"max_open_conns"`
+	// MaxIdleConns - сколько простаивающих соединений пул держит открытыми.
+	MaxIdleConns int `json:"max_idle_conns"`
+	// ConnMaxLifetime - через сколько соединение закрывается и открывается заново, 0 - не закрывается.
+	ConnMaxLifetime Duration `json:"conn_max_lifetime"`
 }
 
 type MinioConfig struct {
@@ -27,23 +52,141 @@ type MinioConfig struct {
 	SecretAccessKey string `json:"secret_access_key"`
 }
 
+// RelayConfig - куда релей публикует события из outbox.
+type RelayConfig struct {
+	// Sink - получатель событий: kafka, file или stdout.
+	Sink  string      `json:"sink"`
+	File  string      `json:"file"`
+	Kafka KafkaConfig `json:"kafka"`
+
+	// Webhooks - кроме получателя, ставить события в очередь доставки webhook-подписчикам и доставлять их.
+	Webhooks bool `json:"webhooks"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+}
+
+// RateLimitConfig - ограничения частоты запросов, общие для всех реплик и транспортов. Операция задается именем
+// из internal/operations (getTransactions) и ограничивается одинаково по http v1, v2 и gRPC. Default действует
+// на операции, которых нет в Operations.
+type RateLimitConfig struct {
+	Default    OperationLimits            `json:"default"`
+	Operations map[string]OperationLimits `json:"operations"`
+}
+
+// OperationLimits - ограничения операции на клиента (API-ключ) и на пользователя userID из запроса.
+// Пустое ограничение – без ограничения.
+type OperationLimits struct {
+	Client *LimitConfig `json:"client"`
+	User   *LimitConfig `json:"user"`
+}
+
+// LimitConfig - корзина токенов: пополняется на rate запросов в секунду и вмещает не больше burst.
+type LimitConfig struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// TracingConfig - куда выгружаются спаны OpenTelemetry.
+type TracingConfig struct {
+	// Exporter - otlp (коллектор по OTLP/gRPC), stdout (для локального запуска) или пусто, чтобы не выгружать.
+	Exporter string `json:"exporter"`
+	// Endpoint - адрес коллектора для otlp, например, localhost:4317.
+	Endpoint string `json:"endpoint"`
+	// Insecure - подключаться к коллектору без TLS.
+	Insecure bool `json:"insecure"`
+	// ShutdownTimeout - сколько ждать выгрузки оставшихся спанов при завершении.
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+}
+
 type HttpService struct {
 	Port string `json:"port"`
 	Host string `json:"host"`
 }
 
-func Must(path string) Config {
-	b, err := os.ReadFile(path)
-	if err != nil {
-		panic(fmt.Errorf("read config file: %w", err))
+// LogConfig - настройки лога.
+type LogConfig struct {
+	// Level - минимальный уровень записей: debug, info, warn или error, по умолчанию info.
+	Level string `json:"level"`
+}
+
+// ServerConfig - таймауты серверов и интервалы фоновых задач сервиса.
+type ServerConfig struct {
+	// ReadHeaderTimeout - сколько http-сервер ждет заголовков запроса.
+	ReadHeaderTimeout Duration `json:"read_header_timeout"`
+	// ShutdownTimeout - сколько http- и gRPC-серверы ждут завершения открытых запросов при остановке.
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+	// StreamPollInterval - как часто лента событий перечитывает outbox.
+	StreamPollInterval Duration `json:"stream_poll_interval"`
+	// RateLimitCleanupInterval - как часто удаляются давно не использованные корзины ограничений.
+	RateLimitCleanupInterval Duration `json:"rate_limit_cleanup_interval"`
+}
+
+// FeaturesConfig - что из необязательного запускает сервис.
+type FeaturesConfig struct {
+	// GRPC - gRPC API рядом с http API.
+	GRPC bool `json:"grpc"`
+	// APIV2 - ресурсное API v2 рядом с v1.
+	APIV2 bool `json:"api_v2"`
+}
+
+// Duration - длительность в формате time.ParseDuration, например, "3s" или "500ms".
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"3s\": %w", err)
 	}
 
-	c := Config{}
+	return d.UnmarshalText([]byte(s))
+}
 
-	err = json.Unmarshal(b, &c)
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
 	if err != nil {
-		panic(fmt.Errorf("unmarshall config: %w", err))
+		return err
 	}
 
-	return c
+	d.Duration = v
+
+	return nil
+}
+
+// Default - значения, которые действуют, если поле не задано ни в файле, ни в окружении.
+func Default() Config {
+	return Config{
+		DB: DBConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration{30 * time.Minute},
+		},
+		Relay: RelayConfig{
+			Sink: SinkStdout,
+		},
+		Tracing: TracingConfig{
+			ShutdownTimeout: Duration{5 * time.Second},
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Server: ServerConfig{
+			ReadHeaderTimeout:        Duration{10 * time.Second},
+			ShutdownTimeout:          Duration{3 * time.Second},
+			StreamPollInterval:       Duration{500 * time.Millisecond},
+			RateLimitCleanupInterval: Duration{10 * time.Minute},
+		},
+		Features: FeaturesConfig{
+			GRPC:  true,
+			APIV2: true,
+		},
+	}
 }
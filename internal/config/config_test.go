@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/config"
+)
+
+const jsonConfig = `{
+  "db": {"dsn": "host=localhost"},
+  "minio": {"endpoint": "localhost:9000", "access_key_id": "katya", "secret_access_key": "katyakatya"},
+  "rate_limit": {"operations": {"getTransactions": {"user": {"rate": 2, "burst": 5}}}},
+  "server": {"shutdown_timeout": "1s"}
+}`
+
+const yamlConfig = `
+db:
+  dsn: host=localhost
+relay:
+  sink: kafka
+  kafka:
+    brokers: [localhost:19092]
+    topic: wallet-events
+features:
+  grpc: false
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("json with defaults", func(t *testing.T) {
+		c, err := config.Load(writeFile(t, "config.json", jsonConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "host=localhost", c.DB.DSN)
+		assert.Equal(t, 2.0, c.RateLimit.Operations["getTransactions"].User.Rate)
+		assert.Equal(t, time.Second, c.Server.ShutdownTimeout.Duration)
+		// Чего нет в файле, берется из значений по умолчанию.
+		assert.Equal(t, 10*time.Second, c.Server.ReadHeaderTimeout.Duration)
+		assert.Equal(t, 25, c.DB.MaxOpenConns)
+		assert.True(t, c.Features.APIV2)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		c, err := config.Load(writeFile(t, "config.yaml", yamlConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"localhost:19092"}, c.Relay.Kafka.Brokers)
+		assert.Equal(t, "wallet-events", c.Relay.Kafka.Topic)
+		assert.False(t, c.Features.GRPC)
+	})
+
+	t.Run("env overrides", func(t *testing.T) {
+		t.Setenv("WALLET_DB_DSN", "host=postgres")
+		t.Setenv("WALLET_DB_MAX_OPEN_CONNS", "50")
+		t.Setenv("WALLET_RELAY_KAFKA_BROKERS", "kafka-1:9092, kafka-2:9092")
+		t.Setenv("WALLET_SERVER_STREAM_POLL_INTERVAL", "2s")
+		t.Setenv("WALLET_FEATURES_API_V2", "false")
+		t.Setenv("WALLET_RATE_LIMIT_DEFAULT_CLIENT_RATE", "100")
+		t.Setenv("WALLET_RATE_LIMIT_DEFAULT_CLIENT_BURST", "200")
+
+		c, err := config.Load(writeFile(t, "config.json", jsonConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "host=postgres", c.DB.DSN)
+		assert.Equal(t, 50, c.DB.MaxOpenConns)
+		assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, c.Relay.Kafka.Brokers)
+		assert.Equal(t, 2*time.Second, c.Server.StreamPollInterval.Duration)
+		assert.False(t, c.Features.APIV2)
+		assert.Equal(t, &config.LimitConfig{Rate: 100, Burst: 200}, c.RateLimit.Default.Client)
+		assert.Nil(t, c.RateLimit.Default.User)
+	})
+
+	t.Run("secret from file", func(t *testing.T) {
+		t.Setenv("WALLET_MINIO_SECRET_ACCESS_KEY_FILE", writeFile(t, "minio_secret", "from-file\n"))
+
+		c, err := config.Load(writeFile(t, "config.json", jsonConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "from-file", c.Minio.SecretAccessKey)
+	})
+
+	t.Run("value and file are both set", func(t *testing.T) {
+		t.Setenv("WALLET_DB_DSN", "host=postgres")
+		t.Setenv("WALLET_DB_DSN_FILE", writeFile(t, "dsn", "host=other"))
+
+		_, err := config.Load(writeFile(t, "config.json", jsonConfig))
+		assert.ErrorContains(t, err, "both WALLET_DB_DSN and WALLET_DB_DSN_FILE are set")
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv("WALLET_DB_MAX_OPEN_CONNS", "many")
+
+		_, err := config.Load(writeFile(t, "config.json", jsonConfig))
+		assert.ErrorContains(t, err, "WALLET_DB_MAX_OPEN_CONNS (db.max_open_conns)")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := config.Load(writeFile(t, "config.json", `{"db": {"dns": "host=localhost"}}`))
+		assert.ErrorContains(t, err, `unknown field "dns"`)
+	})
+
+	t.Run("repository configs are valid", func(t *testing.T) {
+		// Секреты dev-конфигурации задаются в docker-compose.
+		t.Setenv("WALLET_DB_DSN", "host=postgres")
+		t.Setenv("WALLET_MINIO_SECRET_ACCESS_KEY", "katyakatya")
+
+		for _, path := range []string{"../../config/config.local.json", "../../config/config.dev.json"} {
+			_, err := config.Load(path)
+			assert.NoError(t, err, path)
+		}
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := config.Default()
+	c.Minio.Endpoint = "localhost:9000"
+	c.Service.Port = "http"
+	c.Relay.Sink = config.SinkKafka
+	c.RateLimit.Default.User = &config.LimitConfig{Rate: 1}
+	c.RateLimit.Operations = map[string]config.OperationLimits{"/v1/getTransactions": {}}
+	c.Tracing.Exporter = "zipkin"
+	c.Log.Level = "verbose"
+	c.Server.ShutdownTimeout = config.Duration{}
+
+	err := c.Validate()
+
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	assert.Equal(t, []string{
+		"db.dsn is required, set it in the config file or WALLET_DB_DSN",
+		"minio.access_key_id is required, set it in the config file or WALLET_MINIO_ACCESS_KEY_ID",
+		"minio.secret_access_key is required, set it in the config file or WALLET_MINIO_SECRET_ACCESS_KEY",
+		`service.port must be a port number from 1 to 65535, got "http"`,
+		"relay.kafka.brokers is required for sink kafka, set it in the config file or WALLET_RELAY_KAFKA_BROKERS",
+		"relay.kafka.topic is required, set it in the config file or WALLET_RELAY_KAFKA_TOPIC",
+		"rate_limit.default.user.burst must be at least 1, got 0",
+		`rate_limit.operations has unknown operation "/v1/getTransactions", see internal/operations`,
+		`tracing.exporter must be one of otlp, stdout, got "zipkin"`,
+		`log.level must be one of debug, info, warn, error, got "verbose"`,
+		"server.shutdown_timeout must be positive, got 0s",
+	}, validationErr.Problems)
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fileSuffix - суффикс переменной окружения с путем к файлу, в котором лежит значение поля.
+const fileSuffix = "_FILE"
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// applyEnv переопределяет поля структуры v значениями из окружения. prefix - имя переменной структуры, path - путь
+// к ней для сообщений об ошибках. Отдает, задано ли в окружении хоть одно поле.
+func applyEnv(v reflect.Value, prefix, path string) (bool, error) {
+	t := v.Type()
+	set := false
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		env := prefix + "_" + strings.ToUpper(name)
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		fv := v.Field(i)
+
+		switch {
+		case reflect.PtrTo(f.Type).Implements(textUnmarshalerType):
+			ok, err := applyEnvValue(fv, env, fieldPath)
+			if err != nil {
+				return false, err
+			}
+
+			set = set || ok
+
+		case f.Type.Kind() == reflect.Struct:
+			ok, err := applyEnv(fv, env, fieldPath)
+			if err != nil {
+				return false, err
+			}
+
+			set = set || ok
+
+		case f.Type.Kind() == reflect.Ptr && f.Type.Elem().Kind() == reflect.Struct:
+			// Необязательная секция появляется, только если задано хоть одно ее поле.
+			tmp := reflect.New(f.Type.Elem())
+			if !fv.IsNil() {
+				tmp.Elem().Set(fv.Elem())
+			}
+
+			ok, err := applyEnv(tmp.Elem(), env, fieldPath)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				fv.Set(tmp)
+				set = true
+			}
+
+		case f.Type.Kind() == reflect.Map:
+			// Ключи словаря не выразить именем переменной.
+			continue
+
+		default:
+			ok, err := applyEnvValue(fv, env, fieldPath)
+			if err != nil {
+				return false, err
+			}
+
+			set = set || ok
+		}
+	}
+
+	return set, nil
+}
+
+func applyEnvValue(v reflect.Value, env, path string) (bool, error) {
+	value, ok, err := lookupEnv(env)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := setValue(v, value); err != nil {
+		return false, fmt.Errorf("%s (%s): %w", env, path, err)
+	}
+
+	return true, nil
+}
+
+// lookupEnv отдает значение переменной name или содержимое файла из переменной name_FILE.
+func lookupEnv(name string) (string, bool, error) {
+	value, ok := os.LookupEnv(name)
+	file, fileOK := os.LookupEnv(name + fileSuffix)
+
+	switch {
+	case ok && fileOK:
+		return "", false, fmt.Errorf("both %s and %s%s are set", name, name, fileSuffix)
+
+	case fileOK:
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", false, fmt.Errorf("%s%s: %w", name, fileSuffix, err)
+		}
+
+		// Редакторы и echo оставляют в конце файла перевод строки, в значении он не нужен.
+		return strings.TrimRight(string(b), "\r\n"), true, nil
+	}
+
+	return value, ok, nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+
+	case reflect.Int:
+		n, err := strconv.ParseInt(s, 10, 0)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(n)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+
+		items := make([]string, 0)
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		v.Set(reflect.ValueOf(items))
+
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
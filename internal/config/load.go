@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix - префикс переменных окружения, которые переопределяют поля конфигурации.
+const EnvPrefix = "WALLET"
+
+// Load читает конфигурацию в таком порядке, каждый следующий источник важнее предыдущего:
+//   - значения по умолчанию из Default;
+//   - файл path: YAML, если у него расширение .yaml или .yml, иначе JSON. Неизвестное поле в файле - ошибка, чтобы
+//     опечатка в имени не превращалась в молча пропущенную настройку;
+//   - переменные окружения. Имя переменной - путь к полю в верхнем регистре через _ с префиксом WALLET_:
+//     db.dsn задается переменной WALLET_DB_DSN, minio.secret_access_key - WALLET_MINIO_SECRET_ACCESS_KEY.
+//     Переменная с суффиксом _FILE, например, WALLET_DB_DSN_FILE, задает путь к файлу со значением - так читаются
+//     секреты Docker и Kubernetes. Списки задаются через запятую, длительности - в формате "3s". Ограничения
+//     отдельных операций (rate_limit.operations) задаются только в файле.
+//
+// Затем конфигурация проверяется, см. Validate.
+func Load(path string) (Config, error) {
+	c := Default()
+
+	if err := decodeFile(path, &c); err != nil {
+		return Config{}, err
+	}
+
+	if _, err := applyEnv(reflect.ValueOf(&c).Elem(), EnvPrefix, ""); err != nil {
+		return Config{}, fmt.Errorf("apply env: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return c, nil
+}
+
+// Must - Load, который паникует при ошибке. Для тестов и утилит.
+func Must(path string) Config {
+	c, err := Load(path)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func decodeFile(path string, c *Config) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// YAML приводится к JSON, чтобы имена полей в обоих форматах задавались тегами json.
+		var v interface{}
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("parse yaml config %s: %w", path, err)
+		}
+
+		if b, err = json.Marshal(v); err != nil {
+			return fmt.Errorf("convert yaml config %s: %w", path, err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/frutonanny/wallet-service/internal/operations"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+// ValidationError - все ошибки конфигурации сразу, чтобы не исправлять их по одной от запуска к запуску.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate проверяет значения полей и их сочетания, например, что для получателя kafka заданы брокеры.
+func (c Config) Validate() error {
+	v := &validator{}
+
+	v.required("db.dsn", c.DB.DSN)
+	v.nonNegative("db.max_open_conns", c.DB.MaxOpenConns)
+	v.nonNegative("db.max_idle_conns", c.DB.MaxIdleConns)
+	v.nonNegativeDuration("db.conn_max_lifetime", c.DB.ConnMaxLifetime)
+
+	if c.Minio.Endpoint != "" {
+		v.required("minio.access_key_id", c.Minio.AccessKeyID)
+		v.required("minio.secret_access_key", c.Minio.SecretAccessKey)
+	}
+
+	v.port("service.port", c.Service.Port)
+	v.port("grpc.port", c.GRPC.Port)
+
+	switch c.Relay.Sink {
+	case SinkKafka:
+		if len(c.Relay.Kafka.Brokers) == 0 {
+			v.addf("relay.kafka.brokers is required for sink kafka, set it in the config file or %s",
+				envName("relay.kafka.brokers"))
+		}
+
+		v.required("relay.kafka.topic", c.Relay.Kafka.Topic)
+	case SinkFile:
+		v.required("relay.file", c.Relay.File)
+	case SinkStdout, "":
+	default:
+		v.oneOf("relay.sink", c.Relay.Sink, SinkKafka, SinkFile, SinkStdout)
+	}
+
+	v.limits("rate_limit.default", c.RateLimit.Default)
+
+	ops := make([]string, 0, len(c.RateLimit.Operations))
+	for op := range c.RateLimit.Operations {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	for _, op := range ops {
+		if !operations.IsOperation(op) {
+			v.addf("rate_limit.operations has unknown operation %q, see internal/operations", op)
+			continue
+		}
+
+		v.limits(fmt.Sprintf("rate_limit.operations[%s]", op), c.RateLimit.Operations[op])
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterOTLP:
+		v.required("tracing.endpoint", c.Tracing.Endpoint)
+	case tracing.ExporterStdout, tracing.ExporterNone:
+	default:
+		v.oneOf("tracing.exporter", c.Tracing.Exporter, tracing.ExporterOTLP, tracing.ExporterStdout)
+	}
+
+	v.positiveDuration("tracing.shutdown_timeout", c.Tracing.ShutdownTimeout)
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	}
+
+	v.positiveDuration("server.read_header_timeout", c.Server.ReadHeaderTimeout)
+	v.positiveDuration("server.shutdown_timeout", c.Server.ShutdownTimeout)
+	v.positiveDuration("server.stream_poll_interval", c.Server.StreamPollInterval)
+	v.positiveDuration("server.rate_limit_cleanup_interval", c.Server.RateLimitCleanupInterval)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+
+	return nil
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(path, value string) {
+	if value == "" {
+		v.addf("%s is required, set it in the config file or %s", path, envName(path))
+	}
+}
+
+func (v *validator) oneOf(path, value string, allowed ...string) {
+	v.addf("%s must be one of %s, got %q", path, strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) nonNegative(path string, value int) {
+	if value < 0 {
+		v.addf("%s must not be negative, got %d", path, value)
+	}
+}
+
+func (v *validator) nonNegativeDuration(path string, d Duration) {
+	if d.Duration < 0 {
+		v.addf("%s must not be negative, got %s", path, d)
+	}
+}
+
+func (v *validator) positiveDuration(path string, d Duration) {
+	if d.Duration <= 0 {
+		v.addf("%s must be positive, got %s", path, d)
+	}
+}
+
+func (v *validator) port(path, value string) {
+	if value == "" {
+		return
+	}
+
+	if p, err := strconv.Atoi(value); err != nil || p < 1 || p > 65535 {
+		v.addf("%s must be a port number from 1 to 65535, got %q", path, value)
+	}
+}
+
+func (v *validator) limits(path string, l OperationLimits) {
+	v.limit(path+".client", l.Client)
+	v.limit(path+".user", l.User)
+}
+
+func (v *validator) limit(path string, l *LimitConfig) {
+	if l == nil {
+		return
+	}
+
+	if l.Rate <= 0 {
+		v.addf("%s.rate must be positive, got %v", path, l.Rate)
+	}
+
+	if l.Burst < 1 {
+		v.addf("%s.burst must be at least 1, got %d", path, l.Burst)
+	}
+}
+
+// envName - переменная окружения, которая задает поле path, например, WALLET_DB_DSN для db.dsn.
+func envName(path string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
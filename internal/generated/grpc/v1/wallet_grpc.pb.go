@@ -0,0 +1,951 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: wallet/v1/wallet.proto
+
+// API кошелька для внутренних сервисов. Повторяет методы http API (api/schema.yaml).
+//
+// Ошибки возвращаются статусом gRPC. Код из pkg/errcodes (например, wallet_not_found) передается в деталях статуса
+// как google.rpc.ErrorInfo: reason – код ошибки, domain – wallet-service.
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	WalletService_Add_FullMethodName                   = "/wallet.v1.WalletService/Add"
+	WalletService_GetBalance_FullMethodName            = "/wallet.v1.WalletService/GetBalance"
+	WalletService_GetBalances_FullMethodName           = "/wallet.v1.WalletService/GetBalances"
+	WalletService_GetBalanceAt_FullMethodName          = "/wallet.v1.WalletService/GetBalanceAt"
+	WalletService_GetDailyBalances_FullMethodName      = "/wallet.v1.WalletService/GetDailyBalances"
+	WalletService_Reserve_FullMethodName               = "/wallet.v1.WalletService/Reserve"
+	WalletService_ReserveCart_FullMethodName           = "/wallet.v1.WalletService/ReserveCart"
+	WalletService_WriteOff_FullMethodName              = "/wallet.v1.WalletService/WriteOff"
+	WalletService_Cancel_FullMethodName                = "/wallet.v1.WalletService/Cancel"
+	WalletService_GetTransactions_FullMethodName       = "/wallet.v1.WalletService/GetTransactions"
+	WalletService_GetTransactionsByTime_FullMethodName = "/wallet.v1.WalletService/GetTransactionsByTime"
+	WalletService_GetTransaction_FullMethodName        = "/wallet.v1.WalletService/GetTransaction"
+	WalletService_GetHistory_FullMethodName            = "/wallet.v1.WalletService/GetHistory"
+	WalletService_ExportStatement_FullMethodName       = "/wallet.v1.WalletService/ExportStatement"
+	WalletService_GetStatements_FullMethodName         = "/wallet.v1.WalletService/GetStatements"
+	WalletService_GetStatement_FullMethodName          = "/wallet.v1.WalletService/GetStatement"
+	WalletService_GetReport_FullMethodName             = "/wallet.v1.WalletService/GetReport"
+	WalletService_StreamEvents_FullMethodName          = "/wallet.v1.WalletService/StreamEvents"
+	WalletService_AddWebhook_FullMethodName            = "/wallet.v1.WalletService/AddWebhook"
+	WalletService_GetFailedDeliveries_FullMethodName   = "/wallet.v1.WalletService/GetFailedDeliveries"
+	WalletService_ReplayDelivery_FullMethodName        = "/wallet.v1.WalletService/ReplayDelivery"
+)
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WalletServiceClient interface {
+	// Зачисление средств на счет пользователя.
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
+	// Баланс пользователя.
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	// Балансы нескольких пользователей.
+	GetBalances(ctx context.Context, in *GetBalancesRequest, opts ...grpc.CallOption) (*GetBalancesResponse, error)
+	// Баланс пользователя на момент времени.
+	GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error)
+	// Баланс пользователя на конец каждого дня периода.
+	GetDailyBalances(ctx context.Context, in *GetDailyBalancesRequest, opts ...grpc.CallOption) (*GetDailyBalancesResponse, error)
+	// Резервирование средств под услугу.
+	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
+	// Резервирование средств под заказ из нескольких услуг.
+	ReserveCart(ctx context.Context, in *ReserveCartRequest, opts ...grpc.CallOption) (*ReserveCartResponse, error)
+	// Списание зарезервированных средств.
+	WriteOff(ctx context.Context, in *WriteOffRequest, opts ...grpc.CallOption) (*WriteOffResponse, error)
+	// Отмена резерва.
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	// Транзакции пользователя с сортировкой и пагинацией по смещению.
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	// Транзакции пользователя за период.
+	GetTransactionsByTime(ctx context.Context, in *GetTransactionsByTimeRequest, opts ...grpc.CallOption) (*GetTransactionsByTimeResponse, error)
+	// Транзакция пользователя.
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	// История транзакций с пагинацией по токену.
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Выписка за период. Файл отдается частями, при доставке ссылкой – одно сообщение со ссылкой.
+	ExportStatement(ctx context.Context, in *ExportStatementRequest, opts ...grpc.CallOption) (WalletService_ExportStatementClient, error)
+	// Ежемесячные выписки пользователя.
+	GetStatements(ctx context.Context, in *GetStatementsRequest, opts ...grpc.CallOption) (*GetStatementsResponse, error)
+	// Ежемесячная выписка со ссылкой на файл.
+	GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error)
+	// Отчет по услугам за месяц.
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	// Поток изменений кошелька пользователя.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (WalletService_StreamEventsClient, error)
+	// Подписка на события webhook-ом.
+	AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookResponse, error)
+	// Доставки webhook-ов, попытки которых исчерпаны.
+	GetFailedDeliveries(ctx context.Context, in *GetFailedDeliveriesRequest, opts ...grpc.CallOption) (*GetFailedDeliveriesResponse, error)
+	// Повторная доставка webhook-а.
+	ReplayDelivery(ctx context.Context, in *ReplayDeliveryRequest, opts ...grpc.CallOption) (*ReplayDeliveryResponse, error)
+}
+
+type walletServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletServiceClient(cc grpc.ClientConnInterface) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
+	out := new(AddResponse)
+	err := c.cc.Invoke(ctx, WalletService_Add_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalances(ctx context.Context, in *GetBalancesRequest, opts ...grpc.CallOption) (*GetBalancesResponse, error) {
+	out := new(GetBalancesResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetBalances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*GetBalanceAtResponse, error) {
+	out := new(GetBalanceAtResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetBalanceAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetDailyBalances(ctx context.Context, in *GetDailyBalancesRequest, opts ...grpc.CallOption) (*GetDailyBalancesResponse, error) {
+	out := new(GetDailyBalancesResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetDailyBalances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error) {
+	out := new(ReserveResponse)
+	err := c.cc.Invoke(ctx, WalletService_Reserve_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReserveCart(ctx context.Context, in *ReserveCartRequest, opts ...grpc.CallOption) (*ReserveCartResponse, error) {
+	out := new(ReserveCartResponse)
+	err := c.cc.Invoke(ctx, WalletService_ReserveCart_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WriteOff(ctx context.Context, in *WriteOffRequest, opts ...grpc.CallOption) (*WriteOffResponse, error) {
+	out := new(WriteOffResponse)
+	err := c.cc.Invoke(ctx, WalletService_WriteOff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, WalletService_Cancel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransactionsByTime(ctx context.Context, in *GetTransactionsByTimeRequest, opts ...grpc.CallOption) (*GetTransactionsByTimeResponse, error) {
+	out := new(GetTransactionsByTimeResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetTransactionsByTime_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExportStatement(ctx context.Context, in *ExportStatementRequest, opts ...grpc.CallOption) (WalletService_ExportStatementClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], WalletService_ExportStatement_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceExportStatementClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_ExportStatementClient interface {
+	Recv() (*ExportStatementResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceExportStatementClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceExportStatementClient) Recv() (*ExportStatementResponse, error) {
+	m := new(ExportStatementResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) GetStatements(ctx context.Context, in *GetStatementsRequest, opts ...grpc.CallOption) (*GetStatementsResponse, error) {
+	out := new(GetStatementsResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetStatements_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error) {
+	out := new(GetStatementResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetStatement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (WalletService_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[1], WalletService_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type walletServiceStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*AddWebhookResponse, error) {
+	out := new(AddWebhookResponse)
+	err := c.cc.Invoke(ctx, WalletService_AddWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetFailedDeliveries(ctx context.Context, in *GetFailedDeliveriesRequest, opts ...grpc.CallOption) (*GetFailedDeliveriesResponse, error) {
+	out := new(GetFailedDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetFailedDeliveries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReplayDelivery(ctx context.Context, in *ReplayDeliveryRequest, opts ...grpc.CallOption) (*ReplayDeliveryResponse, error) {
+	out := new(ReplayDeliveryResponse)
+	err := c.cc.Invoke(ctx, WalletService_ReplayDelivery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+// All implementations must embed UnimplementedWalletServiceServer
+// for forward compatibility
+type WalletServiceServer interface {
+	// Зачисление средств на счет пользователя.
+	Add(context.Context, *AddRequest) (*AddResponse, error)
+	// Баланс пользователя.
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	// Балансы нескольких пользователей.
+	GetBalances(context.Context, *GetBalancesRequest) (*GetBalancesResponse, error)
+	// Баланс пользователя на момент времени.
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error)
+	// Баланс пользователя на конец каждого дня периода.
+	GetDailyBalances(context.Context, *GetDailyBalancesRequest) (*GetDailyBalancesResponse, error)
+	// Резервирование средств под услугу.
+	Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error)
+	// Резервирование средств под заказ из нескольких услуг.
+	ReserveCart(context.Context, *ReserveCartRequest) (*ReserveCartResponse, error)
+	// Списание зарезервированных средств.
+	WriteOff(context.Context, *WriteOffRequest) (*WriteOffResponse, error)
+	// Отмена резерва.
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	// Транзакции пользователя с сортировкой и пагинацией по смещению.
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	// Транзакции пользователя за период.
+	GetTransactionsByTime(context.Context, *GetTransactionsByTimeRequest) (*GetTransactionsByTimeResponse, error)
+	// Транзакция пользователя.
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	// История транзакций с пагинацией по токену.
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// Выписка за период. Файл отдается частями, при доставке ссылкой – одно сообщение со ссылкой.
+	ExportStatement(*ExportStatementRequest, WalletService_ExportStatementServer) error
+	// Ежемесячные выписки пользователя.
+	GetStatements(context.Context, *GetStatementsRequest) (*GetStatementsResponse, error)
+	// Ежемесячная выписка со ссылкой на файл.
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+	// Отчет по услугам за месяц.
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	// Поток изменений кошелька пользователя.
+	StreamEvents(*StreamEventsRequest, WalletService_StreamEventsServer) error
+	// Подписка на события webhook-ом.
+	AddWebhook(context.Context, *AddWebhookRequest) (*AddWebhookResponse, error)
+	// Доставки webhook-ов, попытки которых исчерпаны.
+	GetFailedDeliveries(context.Context, *GetFailedDeliveriesRequest) (*GetFailedDeliveriesResponse, error)
+	// Повторная доставка webhook-а.
+	ReplayDelivery(context.Context, *ReplayDeliveryRequest) (*ReplayDeliveryResponse, error)
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+// UnimplementedWalletServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWalletServiceServer struct {
+}
+
+func (UnimplementedWalletServiceServer) Add(context.Context, *AddRequest) (*AddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalances(context.Context, *GetBalancesRequest) (*GetBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalances not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalanceAt(context.Context, *GetBalanceAtRequest) (*GetBalanceAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAt not implemented")
+}
+func (UnimplementedWalletServiceServer) GetDailyBalances(context.Context, *GetDailyBalancesRequest) (*GetDailyBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyBalances not implemented")
+}
+func (UnimplementedWalletServiceServer) Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedWalletServiceServer) ReserveCart(context.Context, *ReserveCartRequest) (*ReserveCartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveCart not implemented")
+}
+func (UnimplementedWalletServiceServer) WriteOff(context.Context, *WriteOffRequest) (*WriteOffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteOff not implemented")
+}
+func (UnimplementedWalletServiceServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransactionsByTime(context.Context, *GetTransactionsByTimeRequest) (*GetTransactionsByTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionsByTime not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportStatement(*ExportStatementRequest, WalletService_ExportStatementServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStatement not implemented")
+}
+func (UnimplementedWalletServiceServer) GetStatements(context.Context, *GetStatementsRequest) (*GetStatementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatements not implemented")
+}
+func (UnimplementedWalletServiceServer) GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedWalletServiceServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedWalletServiceServer) StreamEvents(*StreamEventsRequest, WalletService_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedWalletServiceServer) AddWebhook(context.Context, *AddWebhookRequest) (*AddWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWebhook not implemented")
+}
+func (UnimplementedWalletServiceServer) GetFailedDeliveries(context.Context, *GetFailedDeliveriesRequest) (*GetFailedDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailedDeliveries not implemented")
+}
+func (UnimplementedWalletServiceServer) ReplayDelivery(context.Context, *ReplayDeliveryRequest) (*ReplayDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDelivery not implemented")
+}
+func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
+
+// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServiceServer will
+// result in compilation errors.
+type UnsafeWalletServiceServer interface {
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
+	s.RegisterService(&WalletService_ServiceDesc, srv)
+}
+
+func _WalletService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Add_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalances(ctx, req.(*GetBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalanceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalanceAt(ctx, req.(*GetBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetDailyBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetDailyBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetDailyBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetDailyBalances(ctx, req.(*GetDailyBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Reserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Reserve(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReserveCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveCartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReserveCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReserveCart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReserveCart(ctx, req.(*ReserveCartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WriteOff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteOffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WriteOff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_WriteOff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WriteOff(ctx, req.(*WriteOffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactionsByTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsByTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactionsByTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetTransactionsByTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactionsByTime(ctx, req.(*GetTransactionsByTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportStatement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStatementRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).ExportStatement(m, &walletServiceExportStatementServer{stream})
+}
+
+type WalletService_ExportStatementServer interface {
+	Send(*ExportStatementResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceExportStatementServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceExportStatementServer) Send(m *ExportStatementResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_GetStatements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetStatements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetStatements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetStatements(ctx, req.(*GetStatementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetStatement(ctx, req.(*GetStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).StreamEvents(m, &walletServiceStreamEventsServer{stream})
+}
+
+type WalletService_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type walletServiceStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AddWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_AddWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AddWebhook(ctx, req.(*AddWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetFailedDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailedDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetFailedDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetFailedDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetFailedDeliveries(ctx, req.(*GetFailedDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReplayDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReplayDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReplayDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReplayDelivery(ctx, req.(*ReplayDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wallet.v1.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _WalletService_Add_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _WalletService_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetBalances",
+			Handler:    _WalletService_GetBalances_Handler,
+		},
+		{
+			MethodName: "GetBalanceAt",
+			Handler:    _WalletService_GetBalanceAt_Handler,
+		},
+		{
+			MethodName: "GetDailyBalances",
+			Handler:    _WalletService_GetDailyBalances_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _WalletService_Reserve_Handler,
+		},
+		{
+			MethodName: "ReserveCart",
+			Handler:    _WalletService_ReserveCart_Handler,
+		},
+		{
+			MethodName: "WriteOff",
+			Handler:    _WalletService_WriteOff_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _WalletService_Cancel_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _WalletService_GetTransactions_Handler,
+		},
+		{
+			MethodName: "GetTransactionsByTime",
+			Handler:    _WalletService_GetTransactionsByTime_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _WalletService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _WalletService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GetStatements",
+			Handler:    _WalletService_GetStatements_Handler,
+		},
+		{
+			MethodName: "GetStatement",
+			Handler:    _WalletService_GetStatement_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _WalletService_GetReport_Handler,
+		},
+		{
+			MethodName: "AddWebhook",
+			Handler:    _WalletService_AddWebhook_Handler,
+		},
+		{
+			MethodName: "GetFailedDeliveries",
+			Handler:    _WalletService_GetFailedDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayDelivery",
+			Handler:    _WalletService_ReplayDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStatement",
+			Handler:       _WalletService_ExportStatement_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _WalletService_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wallet/v1/wallet.proto",
+}
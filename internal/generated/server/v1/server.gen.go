@@ -7,16 +7,45 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
 	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	ApiKeyScopes = "ApiKey.Scopes"
+)
+
+// Defines values for EventType.
+const (
+	WalletCancel           EventType = "wallet.cancel"
+	WalletIncomingTransfer EventType = "wallet.incoming_transfer"
+	WalletOutgoingTransfer EventType = "wallet.outgoing_transfer"
+	WalletReservation      EventType = "wallet.reservation"
+	WalletWriteOff         EventType = "wallet.write_off"
+)
+
+// Defines values for ExportStatementRequestDelivery.
+const (
+	Link   ExportStatementRequestDelivery = "link"
+	Stream ExportStatementRequestDelivery = "stream"
+)
+
+// Defines values for ExportStatementRequestFormat.
+const (
+	Csv    ExportStatementRequestFormat = "csv"
+	Ndjson ExportStatementRequestFormat = "ndjson"
+)
+
 // Defines values for GetTransactionsRequestDirection.
 const (
 	Asc  GetTransactionsRequestDirection = "asc"
@@ -29,6 +58,15 @@ const (
 	CreatedAt GetTransactionsRequestSortBy = "created_at"
 )
 
+// Defines values for TransactionType.
+const (
+	Cancel           TransactionType = "cancel"
+	IncomingTransfer TransactionType = "incoming_transfer"
+	OutgoingTransfer TransactionType = "outgoing_transfer"
+	Reservation      TransactionType = "reservation"
+	WriteOff         TransactionType = "write_off"
+)
+
 // AddData defines model for AddData.
 type AddData struct {
 	// Текущий баланс пользователя в копейках с учетом пополнения.
@@ -50,6 +88,75 @@ type AddResponse struct {
 	Error *Error   `json:"error,omitempty"`
 }
 
+// AddWebhookData defines model for AddWebhookData.
+type AddWebhookData struct {
+	// Секрет для проверки HMAC-подписи запросов (заголовок X-Wallet-Signature).
+	Secret string `json:"secret"`
+
+	// Идентификатор подписки.
+	SubscriptionID int64 `json:"subscriptionID"`
+}
+
+// AddWebhookRequest defines model for AddWebhookRequest.
+type AddWebhookRequest struct {
+	// Типы событий. Если не переданы, то подписка на все события.
+	EventTypes *[]EventType `json:"eventTypes,omitempty"`
+
+	// Адрес, на который отправляются события.
+	Url string `json:"url"`
+}
+
+// AddWebhookResponse defines model for AddWebhookResponse.
+type AddWebhookResponse struct {
+	Data  *AddWebhookData `json:"data,omitempty"`
+	Error *Error          `json:"error,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Идентификатор API-ключа. Отсутствует, если запрос не аутентифицирован.
+	ClientKeyID *int64 `json:"clientKeyID,omitempty"`
+
+	// Имя API-ключа. Пустое, если запрос не аутентифицирован.
+	ClientName string    `json:"clientName"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Endpoint   string    `json:"endpoint"`
+	Id         int64     `json:"id"`
+
+	// Операции по заказам, созданные запросом.
+	OrderTransactionIDs []int64 `json:"orderTransactionIDs"`
+	RemoteAddr          string  `json:"remoteAddr"`
+
+	// Тело запроса. Значения секретов, например, secret webhook-а, скрыты.
+	Request AuditEntry_Request `json:"request"`
+
+	// Идентификатор запроса из заголовка X-Request-ID.
+	RequestID string `json:"requestID"`
+
+	// ok или код ошибки.
+	ResultCode string `json:"resultCode"`
+
+	// Транзакции, созданные запросом.
+	TransactionIDs []int64 `json:"transactionIDs"`
+
+	// Пользователь, если он есть в запросе.
+	UserID *int64 `json:"userID,omitempty"`
+}
+
+// Тело запроса. Значения секретов, например, secret webhook-а, скрыты.
+type AuditEntry_Request struct {
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// Balance defines model for Balance.
+type Balance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+}
+
 // CancelData defines model for CancelData.
 type CancelData struct {
 	// Текущий баланс пользователя в копейках с учетом разрезервированных средств.
@@ -61,6 +168,9 @@ type CancelRequest struct {
 	// Идентификатор заказа
 	OrderID int64 `json:"orderID"`
 
+	// Идентификатор услуги. Обязателен, если заказ состоит из нескольких позиций (корзина).
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
 	// Идентификатор пользователя.
 	UserID int64 `json:"userID"`
 }
@@ -71,16 +181,180 @@ type CancelResponse struct {
 	Error *Error      `json:"error,omitempty"`
 }
 
+// CartItem defines model for CartItem.
+type CartItem struct {
+	// Стоимость позиции в копейках.
+	Price int64 `json:"price"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+}
+
+// DailyBalance defines model for DailyBalance.
+type DailyBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// День, на конец которого указан баланс.
+	Date openapi_types.Date `json:"date"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+}
+
+// DetailedBalance defines model for DetailedBalance.
+type DetailedBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
+// Тип события об изменении баланса.
+type EventType string
+
+// ExportStatementData defines model for ExportStatementData.
+type ExportStatementData struct {
+	// Время, до которого действует ссылка.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Ссылка на выписку в хранилище.
+	Url string `json:"url"`
+}
+
+// ExportStatementRequest defines model for ExportStatementRequest.
+type ExportStatementRequest struct {
+	// Способ получения выписки: в теле ответа или по ссылке на хранилище.
+	Delivery *ExportStatementRequestDelivery `json:"delivery,omitempty"`
+
+	// Временная точка в формате RFC3339, которой заканчивается выписка.
+	End time.Time `json:"end"`
+
+	// Формат выписки.
+	Format ExportStatementRequestFormat `json:"format"`
+
+	// Временная точка в формате RFC3339, от которой начинается выписка.
+	Start time.Time `json:"start"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// Способ получения выписки: в теле ответа или по ссылке на хранилище.
+type ExportStatementRequestDelivery string
+
+// Формат выписки.
+type ExportStatementRequestFormat string
+
+// ExportStatementResponse defines model for ExportStatementResponse.
+type ExportStatementResponse struct {
+	Data  *ExportStatementData `json:"data,omitempty"`
+	Error *Error               `json:"error,omitempty"`
+}
+
+// FailedDelivery defines model for FailedDelivery.
+type FailedDelivery struct {
+	// Количество сделанных попыток.
+	Attempts int `json:"attempts"`
+
+	// Идентификатор события.
+	EventID   int64  `json:"eventID"`
+	EventType string `json:"eventType"`
+
+	// Время последней попытки.
+	FailedAt time.Time `json:"failedAt"`
+
+	// Идентификатор доставки.
+	Id int64 `json:"id"`
+
+	// Ошибка последней попытки.
+	LastError      string `json:"lastError"`
+	SubscriptionID int64  `json:"subscriptionID"`
+	Url            string `json:"url"`
+}
+
+// GetAuditLogData defines model for GetAuditLogData.
+type GetAuditLogData struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// GetAuditLogRequest defines model for GetAuditLogRequest.
+type GetAuditLogRequest struct {
+	// Идентификатор последней записи предыдущей страницы.
+	AfterID *int64 `json:"afterID,omitempty"`
+
+	// Только запросы клиента с таким именем API-ключа.
+	ClientName *string `json:"clientName,omitempty"`
+
+	// Только запросы к методу: путь v1, метод и путь v2 или полный метод gRPC.
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Начало периода, включительно.
+	From *time.Time `json:"from,omitempty"`
+
+	// Количество записей на странице.
+	Limit *int `json:"limit,omitempty"`
+
+	// Конец периода, не включительно.
+	To *time.Time `json:"to,omitempty"`
+
+	// Только запрос, который создал транзакцию.
+	TransactionID *int64 `json:"transactionID,omitempty"`
+
+	// Только запросы по пользователю.
+	UserID *int64 `json:"userID,omitempty"`
+}
+
+// GetAuditLogResponse defines model for GetAuditLogResponse.
+type GetAuditLogResponse struct {
+	Data  *GetAuditLogData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
+// GetBalanceAtRequest defines model for GetBalanceAtRequest.
+type GetBalanceAtRequest struct {
+	// Момент времени в формате RFC3339.
+	At time.Time `json:"at"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetBalanceAtResponse defines model for GetBalanceAtResponse.
+type GetBalanceAtResponse struct {
+	Data  *Balance `json:"data,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
 // GetBalanceData defines model for GetBalanceData.
 type GetBalanceData struct {
-	// Текущий баланс пользователя в копейках.
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Текущий баланс пользователя в копейках. Совпадает с available, оставлен для совместимости.
 	Balance int64 `json:"balance"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
 }
 
 // GetBalanceRequest defines model for GetBalanceRequest.
@@ -95,6 +369,94 @@ type GetBalanceResponse struct {
 	Error *Error          `json:"error,omitempty"`
 }
 
+// GetBalancesData defines model for GetBalancesData.
+type GetBalancesData struct {
+	// Балансы в порядке запроса. Пользователи без кошелька в ответ не попадают.
+	Balances []UserBalance `json:"balances"`
+}
+
+// GetBalancesRequest defines model for GetBalancesRequest.
+type GetBalancesRequest struct {
+	// Идентификаторы пользователей.
+	UserIDs []int64 `json:"userIDs"`
+}
+
+// GetBalancesResponse defines model for GetBalancesResponse.
+type GetBalancesResponse struct {
+	Data  *GetBalancesData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
+// GetDailyBalancesData defines model for GetDailyBalancesData.
+type GetDailyBalancesData struct {
+	Balances []DailyBalance `json:"balances"`
+}
+
+// GetDailyBalancesRequest defines model for GetDailyBalancesRequest.
+type GetDailyBalancesRequest struct {
+	// Первый день промежутка.
+	From openapi_types.Date `json:"from"`
+
+	// Последний день промежутка.
+	To openapi_types.Date `json:"to"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetDailyBalancesResponse defines model for GetDailyBalancesResponse.
+type GetDailyBalancesResponse struct {
+	Data  *GetDailyBalancesData `json:"data,omitempty"`
+	Error *Error                `json:"error,omitempty"`
+}
+
+// GetFailedDeliveriesData defines model for GetFailedDeliveriesData.
+type GetFailedDeliveriesData struct {
+	Deliveries []FailedDelivery `json:"deliveries"`
+}
+
+// GetFailedDeliveriesRequest defines model for GetFailedDeliveriesRequest.
+type GetFailedDeliveriesRequest struct {
+	// Идентификатор последней доставки предыдущей страницы.
+	AfterID *int64 `json:"afterID,omitempty"`
+
+	// Количество доставок на странице.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// GetFailedDeliveriesResponse defines model for GetFailedDeliveriesResponse.
+type GetFailedDeliveriesResponse struct {
+	Data  *GetFailedDeliveriesData `json:"data,omitempty"`
+	Error *Error                   `json:"error,omitempty"`
+}
+
+// GetHistoryData defines model for GetHistoryData.
+type GetHistoryData struct {
+	// Токен следующей страницы. Отсутствует, если это последняя страница.
+	NextPageToken *string `json:"nextPageToken,omitempty"`
+
+	// Страница истории транзакций пользователя userID.
+	Transactions []Transaction `json:"transactions"`
+}
+
+// GetHistoryRequest defines model for GetHistoryRequest.
+type GetHistoryRequest struct {
+	// Количество записей на странице.
+	Limit int64 `json:"limit"`
+
+	// Токен страницы из поля nextPageToken предыдущего ответа. Для первой страницы не передается.
+	PageToken *string `json:"pageToken,omitempty"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetHistoryResponse defines model for GetHistoryResponse.
+type GetHistoryResponse struct {
+	Data  *GetHistoryData `json:"data,omitempty"`
+	Error *Error          `json:"error,omitempty"`
+}
+
 // GetReportData defines model for GetReportData.
 type GetReportData struct {
 	// Ссылка на CSV файл.
@@ -113,6 +475,63 @@ type GetReportResponse struct {
 	Error *Error         `json:"error,omitempty"`
 }
 
+// GetStatementData defines model for GetStatementData.
+type GetStatementData struct {
+	// Время, до которого действует ссылка.
+	ExpiresAt time.Time `json:"expiresAt"`
+	Statement Statement `json:"statement"`
+
+	// Ссылка на выписку в хранилище (JSON-документ с итогами по типам операций и списком операций).
+	Url string `json:"url"`
+}
+
+// GetStatementRequest defines model for GetStatementRequest.
+type GetStatementRequest struct {
+	// Месяц выписки в формате YYYY-MM.
+	Period string `json:"period"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetStatementResponse defines model for GetStatementResponse.
+type GetStatementResponse struct {
+	Data  *GetStatementData `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GetStatementsData defines model for GetStatementsData.
+type GetStatementsData struct {
+	Statements []Statement `json:"statements"`
+}
+
+// GetStatementsRequest defines model for GetStatementsRequest.
+type GetStatementsRequest struct {
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetStatementsResponse defines model for GetStatementsResponse.
+type GetStatementsResponse struct {
+	Data  *GetStatementsData `json:"data,omitempty"`
+	Error *Error             `json:"error,omitempty"`
+}
+
+// GetTransactionRequest defines model for GetTransactionRequest.
+type GetTransactionRequest struct {
+	// Идентификатор транзакции.
+	TransactionID int64 `json:"transactionID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetTransactionResponse defines model for GetTransactionResponse.
+type GetTransactionResponse struct {
+	Data  *Transaction `json:"data,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
 // GetTransactionsByTimeData defines model for GetTransactionsByTimeData.
 type GetTransactionsByTimeData struct {
 	// Список транзакций пользователя userID.
@@ -124,6 +543,9 @@ type GetTransactionsByTimeRequest struct {
 	// Временная точка в формате RFC3339, до которой происходит поиск транзакций.
 	End time.Time `json:"end"`
 
+	// Условия отбора транзакций. Все переданные условия объединяются через "И".
+	Filter *TransactionsFilter `json:"filter,omitempty"`
+
 	// Временная точка в формате RFC3339, от которой начинается поиск транзакций.
 	Start time.Time `json:"start"`
 
@@ -148,6 +570,9 @@ type GetTransactionsRequest struct {
 	// Направление сортировки (по возрастанию / убыванию).
 	Direction GetTransactionsRequestDirection `json:"direction"`
 
+	// Условия отбора транзакций. Все переданные условия объединяются через "И".
+	Filter *TransactionsFilter `json:"filter,omitempty"`
+
 	// Количество записей.
 	Limit int64 `json:"limit"`
 
@@ -173,6 +598,62 @@ type GetTransactionsResponse struct {
 	Error *Error               `json:"error,omitempty"`
 }
 
+// Hold defines model for Hold.
+type Hold struct {
+	// Зарезервированная сумма в копейках.
+	Amount int64 `json:"amount"`
+
+	// Время резервирования.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Идентификатор заказа.
+	OrderID int64 `json:"orderID"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+}
+
+// ReplayDeliveryData defines model for ReplayDeliveryData.
+type ReplayDeliveryData struct {
+	DeliveryID int64 `json:"deliveryID"`
+}
+
+// ReplayDeliveryRequest defines model for ReplayDeliveryRequest.
+type ReplayDeliveryRequest struct {
+	// Идентификатор доставки.
+	DeliveryID int64 `json:"deliveryID"`
+}
+
+// ReplayDeliveryResponse defines model for ReplayDeliveryResponse.
+type ReplayDeliveryResponse struct {
+	Data  *ReplayDeliveryData `json:"data,omitempty"`
+	Error *Error              `json:"error,omitempty"`
+}
+
+// ReserveCartData defines model for ReserveCartData.
+type ReserveCartData struct {
+	// Текущий баланс пользователя в копейках за вычетом зарезервированных средств.
+	Balance int64 `json:"balance"`
+}
+
+// ReserveCartRequest defines model for ReserveCartRequest.
+type ReserveCartRequest struct {
+	// Позиции корзины. Услуги в корзине не должны повторяться.
+	Items []CartItem `json:"items"`
+
+	// Идентификатор заказа
+	OrderID int64 `json:"orderID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// ReserveCartResponse defines model for ReserveCartResponse.
+type ReserveCartResponse struct {
+	Data  *ReserveCartData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
 // ReserveData defines model for ReserveData.
 type ReserveData struct {
 	// Текущий баланс пользователя в копейках за вычетом зарезервированных средств.
@@ -200,16 +681,90 @@ type ReserveResponse struct {
 	Error *Error       `json:"error,omitempty"`
 }
 
+// Statement defines model for Statement.
+type Statement struct {
+	// Средства на конец месяца (доступные и зарезервированные вместе) в копейках.
+	ClosingBalance int64 `json:"closingBalance"`
+
+	// Время формирования выписки.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Средства на начало месяца (доступные и зарезервированные вместе) в копейках.
+	OpeningBalance int64 `json:"openingBalance"`
+
+	// Месяц выписки в формате YYYY-MM.
+	Period string `json:"period"`
+}
+
+// Ответ отдается, только если ленту открыть не удалось.
+type StreamEventsResponse struct {
+	Error *Error `json:"error,omitempty"`
+}
+
 // Transaction defines model for Transaction.
 type Transaction struct {
 	// Количество денежных средств, задействованных в данной денежной операции.
 	Amount int64 `json:"amount"`
 
+	// Баланс пользователя в копейках после операции. Отсутствует у транзакций, проведенных до появления этой информации.
+	BalanceAfter *int64 `json:"balanceAfter,omitempty"`
+
 	// Время, когда была совершена операция.
 	CreatedAt time.Time `json:"createdAt"`
 
 	// Описание денежной операции.
 	Description string `json:"description"`
+
+	// Идентификатор транзакции.
+	Id int64 `json:"id"`
+
+	// Идентификатор заказа. Отсутствует у зачислений.
+	OrderID *int64 `json:"orderID,omitempty"`
+
+	// Идентификатор услуги. Отсутствует у зачислений.
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
+	// Тип транзакции: зачисление / резервирование / списание / отмена резервирования / списание без заказа.
+	Type TransactionType `json:"type"`
+}
+
+// Тип транзакции: зачисление / резервирование / списание / отмена резервирования / списание без заказа.
+type TransactionType string
+
+// Условия отбора транзакций. Все переданные условия объединяются через "И".
+type TransactionsFilter struct {
+	// Минимальная сумма транзакции в копейках (включительно).
+	AmountFrom *int64 `json:"amountFrom,omitempty"`
+
+	// Максимальная сумма транзакции в копейках (включительно).
+	AmountTo *int64 `json:"amountTo,omitempty"`
+
+	// Идентификатор заказа.
+	OrderID *int64 `json:"orderID,omitempty"`
+
+	// Идентификатор услуги.
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
+	// Типы транзакций: зачисление / резервирование / списание / отмена резервирования.
+	Types *[]TransactionType `json:"types,omitempty"`
+}
+
+// UserBalance defines model for UserBalance.
+type UserBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
 }
 
 // WriteOffData defines model for WriteOffData.
@@ -239,42 +794,159 @@ type WriteOffResponse struct {
 	Error *Error        `json:"error,omitempty"`
 }
 
+// AcceptLanguage defines model for AcceptLanguage.
+type AcceptLanguage = string
+
 // PostAddJSONBody defines parameters for PostAdd.
 type PostAddJSONBody = AddRequest
 
+// PostAdminAddWebhookJSONBody defines parameters for PostAdminAddWebhook.
+type PostAdminAddWebhookJSONBody = AddWebhookRequest
+
+// PostAdminGetAuditLogJSONBody defines parameters for PostAdminGetAuditLog.
+type PostAdminGetAuditLogJSONBody = GetAuditLogRequest
+
+// PostAdminGetFailedDeliveriesJSONBody defines parameters for PostAdminGetFailedDeliveries.
+type PostAdminGetFailedDeliveriesJSONBody = GetFailedDeliveriesRequest
+
+// PostAdminReplayDeliveryJSONBody defines parameters for PostAdminReplayDelivery.
+type PostAdminReplayDeliveryJSONBody = ReplayDeliveryRequest
+
 // PostCancelJSONBody defines parameters for PostCancel.
 type PostCancelJSONBody = CancelRequest
 
+// PostExportStatementJSONBody defines parameters for PostExportStatement.
+type PostExportStatementJSONBody = ExportStatementRequest
+
+// PostExportStatementParams defines parameters for PostExportStatement.
+type PostExportStatementParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
 // PostGetBalanceJSONBody defines parameters for PostGetBalance.
 type PostGetBalanceJSONBody = GetBalanceRequest
 
+// PostGetBalanceAtJSONBody defines parameters for PostGetBalanceAt.
+type PostGetBalanceAtJSONBody = GetBalanceAtRequest
+
+// PostGetBalancesJSONBody defines parameters for PostGetBalances.
+type PostGetBalancesJSONBody = GetBalancesRequest
+
+// PostGetDailyBalancesJSONBody defines parameters for PostGetDailyBalances.
+type PostGetDailyBalancesJSONBody = GetDailyBalancesRequest
+
+// PostGetHistoryJSONBody defines parameters for PostGetHistory.
+type PostGetHistoryJSONBody = GetHistoryRequest
+
+// PostGetHistoryParams defines parameters for PostGetHistory.
+type PostGetHistoryParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
 // PostGetReportJSONBody defines parameters for PostGetReport.
 type PostGetReportJSONBody = GetReportRequest
 
+// PostGetStatementJSONBody defines parameters for PostGetStatement.
+type PostGetStatementJSONBody = GetStatementRequest
+
+// PostGetStatementsJSONBody defines parameters for PostGetStatements.
+type PostGetStatementsJSONBody = GetStatementsRequest
+
+// PostGetTransactionJSONBody defines parameters for PostGetTransaction.
+type PostGetTransactionJSONBody = GetTransactionRequest
+
+// PostGetTransactionParams defines parameters for PostGetTransaction.
+type PostGetTransactionParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
 // PostGetTransactionsJSONBody defines parameters for PostGetTransactions.
 type PostGetTransactionsJSONBody = GetTransactionsRequest
 
+// PostGetTransactionsParams defines parameters for PostGetTransactions.
+type PostGetTransactionsParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
 // PostGetTransactionsByTimeJSONBody defines parameters for PostGetTransactionsByTime.
 type PostGetTransactionsByTimeJSONBody = GetTransactionsByTimeRequest
 
+// PostGetTransactionsByTimeParams defines parameters for PostGetTransactionsByTime.
+type PostGetTransactionsByTimeParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
 // PostReserveJSONBody defines parameters for PostReserve.
 type PostReserveJSONBody = ReserveRequest
 
+// PostReserveCartJSONBody defines parameters for PostReserveCart.
+type PostReserveCartJSONBody = ReserveCartRequest
+
+// GetStreamEventsParams defines parameters for GetStreamEvents.
+type GetStreamEventsParams struct {
+	// Идентификатор пользователя.
+	UserID int64 `form:"userID" json:"userID"`
+
+	// Идентификатор последнего полученного события.
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
 // PostWriteOffJSONBody defines parameters for PostWriteOff.
 type PostWriteOffJSONBody = WriteOffRequest
 
 // PostAddJSONRequestBody defines body for PostAdd for application/json ContentType.
 type PostAddJSONRequestBody = PostAddJSONBody
 
+// PostAdminAddWebhookJSONRequestBody defines body for PostAdminAddWebhook for application/json ContentType.
+type PostAdminAddWebhookJSONRequestBody = PostAdminAddWebhookJSONBody
+
+// PostAdminGetAuditLogJSONRequestBody defines body for PostAdminGetAuditLog for application/json ContentType.
+type PostAdminGetAuditLogJSONRequestBody = PostAdminGetAuditLogJSONBody
+
+// PostAdminGetFailedDeliveriesJSONRequestBody defines body for PostAdminGetFailedDeliveries for application/json ContentType.
+type PostAdminGetFailedDeliveriesJSONRequestBody = PostAdminGetFailedDeliveriesJSONBody
+
+// PostAdminReplayDeliveryJSONRequestBody defines body for PostAdminReplayDelivery for application/json ContentType.
+type PostAdminReplayDeliveryJSONRequestBody = PostAdminReplayDeliveryJSONBody
+
 // PostCancelJSONRequestBody defines body for PostCancel for application/json ContentType.
 type PostCancelJSONRequestBody = PostCancelJSONBody
 
+// PostExportStatementJSONRequestBody defines body for PostExportStatement for application/json ContentType.
+type PostExportStatementJSONRequestBody = PostExportStatementJSONBody
+
 // PostGetBalanceJSONRequestBody defines body for PostGetBalance for application/json ContentType.
 type PostGetBalanceJSONRequestBody = PostGetBalanceJSONBody
 
+// PostGetBalanceAtJSONRequestBody defines body for PostGetBalanceAt for application/json ContentType.
+type PostGetBalanceAtJSONRequestBody = PostGetBalanceAtJSONBody
+
+// PostGetBalancesJSONRequestBody defines body for PostGetBalances for application/json ContentType.
+type PostGetBalancesJSONRequestBody = PostGetBalancesJSONBody
+
+// PostGetDailyBalancesJSONRequestBody defines body for PostGetDailyBalances for application/json ContentType.
+type PostGetDailyBalancesJSONRequestBody = PostGetDailyBalancesJSONBody
+
+// PostGetHistoryJSONRequestBody defines body for PostGetHistory for application/json ContentType.
+type PostGetHistoryJSONRequestBody = PostGetHistoryJSONBody
+
 // PostGetReportJSONRequestBody defines body for PostGetReport for application/json ContentType.
 type PostGetReportJSONRequestBody = PostGetReportJSONBody
 
+// PostGetStatementJSONRequestBody defines body for PostGetStatement for application/json ContentType.
+type PostGetStatementJSONRequestBody = PostGetStatementJSONBody
+
+// PostGetStatementsJSONRequestBody defines body for PostGetStatements for application/json ContentType.
+type PostGetStatementsJSONRequestBody = PostGetStatementsJSONBody
+
+// PostGetTransactionJSONRequestBody defines body for PostGetTransaction for application/json ContentType.
+type PostGetTransactionJSONRequestBody = PostGetTransactionJSONBody
+
 // PostGetTransactionsJSONRequestBody defines body for PostGetTransactions for application/json ContentType.
 type PostGetTransactionsJSONRequestBody = PostGetTransactionsJSONBody
 
@@ -284,33 +956,131 @@ type PostGetTransactionsByTimeJSONRequestBody = PostGetTransactionsByTimeJSONBod
 // PostReserveJSONRequestBody defines body for PostReserve for application/json ContentType.
 type PostReserveJSONRequestBody = PostReserveJSONBody
 
+// PostReserveCartJSONRequestBody defines body for PostReserveCart for application/json ContentType.
+type PostReserveCartJSONRequestBody = PostReserveCartJSONBody
+
 // PostWriteOffJSONRequestBody defines body for PostWriteOff for application/json ContentType.
 type PostWriteOffJSONRequestBody = PostWriteOffJSONBody
 
+// Getter for additional properties for AuditEntry_Request. Returns the specified
+// element and whether it was found
+func (a AuditEntry_Request) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for AuditEntry_Request
+func (a *AuditEntry_Request) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for AuditEntry_Request to handle AdditionalProperties
+func (a *AuditEntry_Request) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for AuditEntry_Request to handle AdditionalProperties
+func (a AuditEntry_Request) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
 	// (POST /add)
 	PostAdd(ctx echo.Context) error
 
+	// (POST /admin/addWebhook)
+	PostAdminAddWebhook(ctx echo.Context) error
+
+	// (POST /admin/getAuditLog)
+	PostAdminGetAuditLog(ctx echo.Context) error
+
+	// (POST /admin/getFailedDeliveries)
+	PostAdminGetFailedDeliveries(ctx echo.Context) error
+
+	// (POST /admin/replayDelivery)
+	PostAdminReplayDelivery(ctx echo.Context) error
+
 	// (POST /cancel)
 	PostCancel(ctx echo.Context) error
 
+	// (POST /exportStatement)
+	PostExportStatement(ctx echo.Context, params PostExportStatementParams) error
+
 	// (POST /getBalance)
 	PostGetBalance(ctx echo.Context) error
 
+	// (POST /getBalanceAt)
+	PostGetBalanceAt(ctx echo.Context) error
+
+	// (POST /getBalances)
+	PostGetBalances(ctx echo.Context) error
+
+	// (POST /getDailyBalances)
+	PostGetDailyBalances(ctx echo.Context) error
+
+	// (POST /getHistory)
+	PostGetHistory(ctx echo.Context, params PostGetHistoryParams) error
+
 	// (POST /getReport)
 	PostGetReport(ctx echo.Context) error
 
+	// (POST /getStatement)
+	PostGetStatement(ctx echo.Context) error
+
+	// (POST /getStatements)
+	PostGetStatements(ctx echo.Context) error
+
+	// (POST /getTransaction)
+	PostGetTransaction(ctx echo.Context, params PostGetTransactionParams) error
+
 	// (POST /getTransactions)
-	PostGetTransactions(ctx echo.Context) error
+	PostGetTransactions(ctx echo.Context, params PostGetTransactionsParams) error
 
 	// (POST /getTransactionsByTime)
-	PostGetTransactionsByTime(ctx echo.Context) error
+	PostGetTransactionsByTime(ctx echo.Context, params PostGetTransactionsByTimeParams) error
 
 	// (POST /reserve)
 	PostReserve(ctx echo.Context) error
 
+	// (POST /reserveCart)
+	PostReserveCart(ctx echo.Context) error
+
+	// (GET /streamEvents)
+	GetStreamEvents(ctx echo.Context, params GetStreamEventsParams) error
+
 	// (POST /writeOff)
 	PostWriteOff(ctx echo.Context) error
 }
@@ -324,44 +1094,266 @@ type ServerInterfaceWrapper struct {
 func (w *ServerInterfaceWrapper) PostAdd(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostAdd(ctx)
 	return err
 }
 
+// PostAdminAddWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminAddWebhook(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminAddWebhook(ctx)
+	return err
+}
+
+// PostAdminGetAuditLog converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminGetAuditLog(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminGetAuditLog(ctx)
+	return err
+}
+
+// PostAdminGetFailedDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminGetFailedDeliveries(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminGetFailedDeliveries(ctx)
+	return err
+}
+
+// PostAdminReplayDelivery converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminReplayDelivery(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminReplayDelivery(ctx)
+	return err
+}
+
 // PostCancel converts echo context to params.
 func (w *ServerInterfaceWrapper) PostCancel(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostCancel(ctx)
 	return err
 }
 
+// PostExportStatement converts echo context to params.
+func (w *ServerInterfaceWrapper) PostExportStatement(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostExportStatementParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostExportStatement(ctx, params)
+	return err
+}
+
 // PostGetBalance converts echo context to params.
 func (w *ServerInterfaceWrapper) PostGetBalance(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostGetBalance(ctx)
 	return err
 }
 
+// PostGetBalanceAt converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetBalanceAt(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetBalanceAt(ctx)
+	return err
+}
+
+// PostGetBalances converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetBalances(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetBalances(ctx)
+	return err
+}
+
+// PostGetDailyBalances converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetDailyBalances(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetDailyBalances(ctx)
+	return err
+}
+
+// PostGetHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetHistory(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetHistoryParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetHistory(ctx, params)
+	return err
+}
+
 // PostGetReport converts echo context to params.
 func (w *ServerInterfaceWrapper) PostGetReport(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostGetReport(ctx)
 	return err
 }
 
+// PostGetStatement converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetStatement(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetStatement(ctx)
+	return err
+}
+
+// PostGetStatements converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetStatements(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetStatements(ctx)
+	return err
+}
+
+// PostGetTransaction converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetTransaction(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetTransactionParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostGetTransaction(ctx, params)
+	return err
+}
+
 // PostGetTransactions converts echo context to params.
 func (w *ServerInterfaceWrapper) PostGetTransactions(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetTransactionsParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.PostGetTransactions(ctx)
+	err = w.Handler.PostGetTransactions(ctx, params)
 	return err
 }
 
@@ -369,8 +1361,30 @@ func (w *ServerInterfaceWrapper) PostGetTransactions(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostGetTransactionsByTime(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetTransactionsByTimeParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.PostGetTransactionsByTime(ctx)
+	err = w.Handler.PostGetTransactionsByTime(ctx, params)
 	return err
 }
 
@@ -378,15 +1392,67 @@ func (w *ServerInterfaceWrapper) PostGetTransactionsByTime(ctx echo.Context) err
 func (w *ServerInterfaceWrapper) PostReserve(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostReserve(ctx)
 	return err
 }
 
+// PostReserveCart converts echo context to params.
+func (w *ServerInterfaceWrapper) PostReserveCart(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostReserveCart(ctx)
+	return err
+}
+
+// GetStreamEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStreamEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStreamEventsParams
+	// ------------- Required query parameter "userID" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "userID", ctx.QueryParams(), &params.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID int64
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Last-Event-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Last-Event-ID: %s", err))
+		}
+
+		params.LastEventID = &LastEventID
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetStreamEvents(ctx, params)
+	return err
+}
+
 // PostWriteOff converts echo context to params.
 func (w *ServerInterfaceWrapper) PostWriteOff(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostWriteOff(ctx)
 	return err
@@ -421,12 +1487,26 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.POST(baseURL+"/add", wrapper.PostAdd)
+	router.POST(baseURL+"/admin/addWebhook", wrapper.PostAdminAddWebhook)
+	router.POST(baseURL+"/admin/getAuditLog", wrapper.PostAdminGetAuditLog)
+	router.POST(baseURL+"/admin/getFailedDeliveries", wrapper.PostAdminGetFailedDeliveries)
+	router.POST(baseURL+"/admin/replayDelivery", wrapper.PostAdminReplayDelivery)
 	router.POST(baseURL+"/cancel", wrapper.PostCancel)
+	router.POST(baseURL+"/exportStatement", wrapper.PostExportStatement)
 	router.POST(baseURL+"/getBalance", wrapper.PostGetBalance)
+	router.POST(baseURL+"/getBalanceAt", wrapper.PostGetBalanceAt)
+	router.POST(baseURL+"/getBalances", wrapper.PostGetBalances)
+	router.POST(baseURL+"/getDailyBalances", wrapper.PostGetDailyBalances)
+	router.POST(baseURL+"/getHistory", wrapper.PostGetHistory)
 	router.POST(baseURL+"/getReport", wrapper.PostGetReport)
+	router.POST(baseURL+"/getStatement", wrapper.PostGetStatement)
+	router.POST(baseURL+"/getStatements", wrapper.PostGetStatements)
+	router.POST(baseURL+"/getTransaction", wrapper.PostGetTransaction)
 	router.POST(baseURL+"/getTransactions", wrapper.PostGetTransactions)
 	router.POST(baseURL+"/getTransactionsByTime", wrapper.PostGetTransactionsByTime)
 	router.POST(baseURL+"/reserve", wrapper.PostReserve)
+	router.POST(baseURL+"/reserveCart", wrapper.PostReserveCart)
+	router.GET(baseURL+"/streamEvents", wrapper.GetStreamEvents)
 	router.POST(baseURL+"/writeOff", wrapper.PostWriteOff)
 
 }
@@ -434,40 +1514,124 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xafW/Tzh1/K9ZtEpvkNk4LFPJfCwwhTRoq1ZCGqsnE19YofuB86YhQpSaBMVREtf3D",
-	"NGkP7BWYkFC3eeAtfO8dTXdnJ7bjJE3SpmVC+klt83N83/t8Pt9nXqOiY7mOjW3qocJr5BX3sKWLX9cN",
-	"475Odf6rSxwXE2pi8T+e6yXdLmL+q4G9IjFdajo2KiD4L7TgjNXZewjgVIEv4EMbfOiyqgLfoQdt9gFO",
-	"oAcN8FkNWtBmxwo0FDiDHnyHFpzCGfjsrcKqCquzd9BiNehBR35ZvAC60IIuBOx4GakIv9Itt4RRIa9p",
-	"mop2HGLpFBWQadPbN5GKaMXF8k+8iwk6OFARwS/LJsEGKjzrX2T7QOW33cQvy9ijwxcu6t5exm0/szp0",
-	"oAN+1h1mMU9FZQ+TR/czzvo7NPnFWQ0C9gYCcUYNeuxwJLBJA6YHJzRFlbfvQ+S5ju3hYYyMUCq/JHgH",
-	"FdAvcgNd5UJR5SJFHagIE+KQSc8/EA8dcMvucaJK10WP7BB8OGGH0IITaLFDaEDADuWLoAtddiS+JB5o",
-	"siqrQeOC5SrxGKlYhxjTC+kEfP4n/zmtdq6nciMU4oDNo9+YCGeQ8IPo+VRwcQxhjmXav8X2Lt0Tdw6v",
-	"6FFi2rv8NAt7nr47+ckUFtHXVHnOdv955/kLXKT8zQ8x3ZDKuiL3umDXGNxnpHtcP7GmDZ9HpilCZ5Dq",
-	"Q0w3sesQmq2IMillpsMqO4I2x0yBLvjKvSe/V9gb8OEU2hymPjRlYiJ1KhnzE7fjho2k1sXEdIwM8/4m",
-	"onFHSpC94aTy3M15VG5UKpXKkmXdSLCJVrSVlaW8xm3VX0W2riUsX5tkeWhPyvg56Y2RMxu7W0S3Pb3I",
-	"sfE2KlumNcL3aey5LMrhOwSsCj04U1hNZMWuTCPszzImjAoDUvYcbpNiy5tkfcxefuMQcp0QvTKEeMLm",
-	"7VH3HSkgbI9Rjyg+u+CzY0XEhXdS7UOC2vzNvdXV1buqAk3oiYAnwwj0BCjil4BV2VvoQRMCVpNIcSyz",
-	"kcwQpra2pN3cymsFjf/3h7iDGTrFS9S0MMrIJB7VCb3IK/a4+akr8he8g4D/5FUTq3K/m+mKKzNc8ToW",
-	"IxJ2VehrjCrnjAwjHHv+KDFTfPgXZ57DKwBPFMhwqrDqjxg+RgYOwyRYHjEMxD/BF17vQwPasoOFljKE",
-	"zRkEyq/4pRVoQE90GL5oH3zR835Ucgqrwxd2FOIYsI+/FgK1yxa3XfeKSBVn80pv4Ezy8yE3KZmWmRUJ",
-	"/iE67UA0PKJ54QZxXiRdrZSv5rMKNkt/ZVrcqjyv54b7BWdnx8M0M6d0oMXe90GScPRPZ8fQmeiTlmnL",
-	"wzOP9hxCNyoZR/9b3LulhmfGQ1qH1bPjdgaHfsRhMwyVOflCVg0HBq0EaUWCdYqNP+o8OuiWU7Zpkr3E",
-	"Az9ErJPC6pPch1yNOUmma11g9Js17m1iD5P9q+qFhNIVaLCj+PjrBPyrHDaEkFyjaYNLzEwWPgvEAuhA",
-	"jyPBPiTOubg5HcfDLOIp78zqrAptVoevECz/f4xYBkAMxi0RPQnpzOPZcZecwaPj2X/o/DDgnjMJCpyh",
-	"Bd8y3U6VauMPnUbfSXgp119T/i3L5Nj75AdCmyLt8wooqZJb5xRnmC/W6bguWJWe8JXbo4iSog2+yGbQ",
-	"4BawvwjT/JRJKemELXJ+Sbu9lV8taLcKN+8ur62uaXfPX6wnLBwuIcOiw4/KgakgQ/BJNiLc8WJ1V5y1",
-	"ZHsvQ8A0s4m4wf0EHmeB+8FTYlL8u52d65RW+vX3gtJIhMHPPPIzj0ybRwbamSeRJLxw6kzCjTftHUc0",
-	"viYV8eWJtFt5qpdKmCrrjx8hFe1j4klI9/Oi13GxrbsmKqDVZW15lV9Mp3vC6pxuiHGT63h0REcS7T0D",
-	"qcNzhQCJqxzDRm0HqytF3dvjlHHcdH7GIwMV0GPHo+uGgSQ12KMbjlGRqwmbYpkcddctmUXxndwLT0Zq",
-	"CdA5ln6Rwx8k6aekjMUHklKBx4qmXezJoVzE0Slw/5pGMrZhXkaS71xR7HzGUPSf0atAQVgM/mR0VYS6",
-	"FdFYTqCx3wKLAMTqSugi2VzKNdUl0ZlcPC6Y0dQSL4vUwVJ+wpLW71O829+XTPDEMPxP7YfZNA3WNJdE",
-	"1fAibMF0ZSy0zuWHIc4xL9yNdh4TGGqLNX0QOV64kOIuFi6klqKFlBol8cGIRxEl4jc5ju+xmiyVwtJJ",
-	"1pgB9KCpyJ1O6JYNMRHrxJOyL0dUmZSH17g0xpPrscUTntpwZbvo2EXhgPKt1HT5nK4591xZ7jTGTK4l",
-	"81ISrVhHJ6eEYljjizFmjR2y+kgtJO53aYrIGl4vXheZc75MdUwgb5RA5LZjkTLpB4D43iwxKuYffmN1",
-	"VuMxaKKq+rJKvTQIV2z85Q3ZpmVae6bIXQE7ZEdjIlAGaAvRXnLverUKTG3b5tIhkdOpMcr7NGp8e2EF",
-	"YlM2/ryEbYvXHiUb1rH1YjheuyQVpIbHC+Y9PX8cXzKOHbQPCsY/hW3kGMoj9VwTiqPG95I4Tk92Fkzy",
-	"0HBgQmMQn3uFrIazGEw8VHiWZvM+3sclx7WwTRX5FFLlP4JCe5S6hVyu5BT10p7j0cId7U4+x9v+7YP/",
-	"BQAA//+bIqcDXC0AAA==",
+	"H4sIAAAAAAAC/+xdCW8cyXX+K42OAZNAz0VKqxUDI+BKu7bitb2Q5GMjMUZruki1Ndd2N7WiNwRIjrXy",
+	"hoqYOE5sOI7X2uQHjCiONLz/QtVfyC8J3qvq7qru6pnumeEhYGHAK05fVe999erd9YVZbzc77RZpBb65",
+	"8IXZsT27SQLi4V+L9TrpBB/brZVVe4XALw7x657bCdx2y1ww6ddsg/bpHj2lJ+wZHbAt2qN9esS2ad9g",
+	"O/Qt26YHdGDQE3pKB2yT9ugxHdB9g22xDfzjLe3RA/Yl/jhDj2mPnrINOqBHtM82LOO+SVqln96xSOtv",
+	"P/tetXz9vjlbNujX9ITu0T24hb6hA7pLe+wF22KbbMfwVg06MEjLMmBQBuvSI3pCD9kz/m32wvBWy6Zl",
+	"kid2s9Mg5oJJWqZlujCdh8R2iGdaZstuwhU+/VI0f8v06w9J0wZCBGsduMUPPLe1Yq6vr4cXOeEc56Yd",
+	"4I0dr90hXuASvPDAbtituo6U39A+PWBd9hXSgr6iPXoIY2abOBN6yJ7Tt/QEJ7tF+/SQ7Rh016AHSNw+",
+	"3acHtMeeGmzTYF32jPbZFj2hR/xhfAE9pn2kwY5CgFq1WrXM5bbXtANzwXRbwXtXTCucoNsKyArxTJig",
+	"Rz5bdT3imAv3ooksrVsw29vks1XiB+kJ123/oWa2L5ExR7Snm8M4w7PMVZ94t25qvvVHgAo9Zlt0wH5D",
+	"B/iNLXrCNjIJqw6gOHHEUCw++4hEfqfd8kmaRo6Aync8smwumH9TiddkRYCqEiJq3TKJ57W9Ufd/iDch",
+	"Lhcd5+fkwcN2+5Eekz6peyTQMQkhuQFQMugeRxwsT6AVrD1Y2j/40eKNEtJxT6zxgYGrGm9km3CzMYO/",
+	"vEYQwsMn9MD4RenndqNBgtIdd6VlB6semS2bVnJdWaa/+iAa1DjsDYd1QAeTsjUxFCuk3JJC5MylQB6T",
+	"VnB3rUN8zSy+oQN6yrYNpNgrtg3Toftlg/4H26SHQNRj2ocZ9YXIBdGwbRkw1eREe3AzrCy2SfvKGzm0",
+	"3YA0/ZEACkdrrkeEsT3PXsO15jU0c/hXuodo2bTEAA7oCWcF2waZBn+couDfBTBFMjs9wlg6PwyCjr9Q",
+	"qXRsL2gRryyuVD7n4EGa+qbEy1XPTcMouT69RoppEy5OeYWNs0ZXHTf4sBV4axoR2nBJK/ghWSsI/8VP",
+	"bpXoAT1kL2D3Kxv0L0jvLv7/Ft1lXVjZlkH7IcSkdSvw1oPb5ffDXi1EQI8eK7yazyWm+Wx+jDusZjJH",
+	"bCc98K9ZF4d8QvvTG67Z9hzi+TqZU/eIHRBnERdx/MBcdW6uVKuVqrW7tbmFanWhWv0HGXqOHZBS4DaJ",
+	"7p2k5XTabivxysrjWsUjPvEeax9yHeX2Wi4K47zuenbLt+tcVOkEzl+ELOkBieiAq0tcH6M9/O+RxVfm",
+	"Wy5tQN4AjVXhfqQIlByjS4oSjzTbAVl0HE+lTK1ahv/V5nR08WIZazuOC3OyG59IqybwVomlU7EO6Yk6",
+	"BcDXH0BcsWehegTTjnY+wA6XZ6pqymW/8Tlf9yXaQ2odgKxjW2xb2svaD35F6oE07KLbmDJcgw7oWyOx",
+	"oYLM/0VJ7DylWzdVoF9drtbfs6ukNF+vkdKVB86V0nWnSkpVe365Wp97cI3Uanoq+6uN4Ebb0SzV9iMY",
+	"CC5D0N72QLr/lg7oq9RGa7Yf6V4ejALoNykTYXAugMzUI7/WKovPZYl0Qo/xL7bFnqNmK4+tP6n+4Tqm",
+	"LJtkPClyVVlUkuSJHjAV3qZ4oRchsGF+EJsv6h5lP7bdhv2goRPqv4fZsy3WpaeCZWyTKzF8H8phBLyf",
+	"0wYQotTRjOIPtIcffYtSb1feFsYd1dw4hlNMKWm4QNsbQNnGZTEbcfG9HUYxfEim2JStSk6PTG0aITqO",
+	"JBXbW9G1CNq+99itk4LfBNWFHrIufU0HoIPRV+AYCbkBDyVVGhwgSjqh9AzYlpD6x3jngeDrAR2wp5zN",
+	"b+kg8qKA1r0Bv8CmNVsuPtHLaEmH7JaRMYnKLq22MdT1G7YX3ApIM/3tjudq1+lLzkl6xLnKnnOShXwb",
+	"jOsHabott7naROKeCWQntZmjEViCNsDBm7bbWJM2E7vR+MmyuXBvOAPCB9YtDcf1Ow9M8LlkjuIK+lKy",
+	"TOkJfc39hKHae6xIUWX+3AKoXi/NV5N6/0ibE29aSimFSA0S2G6DOBe1u+Z2sT1sNxytLcG2Ys0XRiBt",
+	"G2x7IQX1WAyjcp0e8ClXKWX/AVf0sjYkcIXAzQa+o4djeWrQA+D8CQwioRcOg9kP2g1Hb6mcp3JRu5qT",
+	"KUE7sHXemN+xTd13Z+heCjGCJ8OnMDtaH7o6NYUonFaIOVgmH4ZCOuEeEfZJ0219TForwUNZFsb2RpP4",
+	"vr0y+s7E6MLHLP6dJY1VF3vKMtx6CQcXmAmvcEMHWzL0ytOBIndoD0nbAtF+z+TerrLbqrebbmvll6iw",
+	"L2OsQlzilLPxu9GPn3tuQH7ZXl6Of6rjvhf/3V4NVtrKK5dkgad5UYqyHz7ptL3gTmAHpElagV6DJU86",
+	"rkf8xUCL1A2MGu1Y4GY+SUtn3Kn2Za8V4HqTbdNDeiAolZDR76OXplbUS6N3bL6MvxW5VrdDdyvrwsJg",
+	"T4W5ikYx+4r2037MhUql0a7bjYdtP1i4Xq1WKwRJ51f8kHilWgnGX71WFf94v1orXX9Qc8iDK0657j/+",
+	"u1+UFpu/jp3m3yuXy7n8nZbEgqU01zL1bIc03MeE+yUdsmyvNgL+IWI3zZSL5SWGAjc5wrlO2JUcKzLd",
+	"6GABCSf0YO4ehsDCFndyHIZOKYnVfU7+DGKLxRKNreG2Hqlwji7p3HPZyMTxg4toB/3t7BlHwq7BfoMo",
+	"PeIKr3H7oxvz8/PXLRXB+/GGdwxRUhSqfeH7ViiSjeRqtSiSw7tSc/rfeMgJfshErPuPTctsOb/y2y2V",
+	"hvxK6nt+YHvBNEkIu3mSjtxNh4ZNAQpeG4+Cl9EM4lTmcI1eo13OkxhHOok+hpX0EWq1NyUBklBrg4A0",
+	"O4FOo/wT0JEO0CHBNReQA0j3Q8kBwYPbuLGe0APVZaTTlTBcVNQayo5OXZnLpaERWUEotrcuIwmH75qG",
+	"kLmHGBcEdWJfJkzKHztRGMN1ii0IoWxizC8Vgs2n4DZsP4h0v5TlETqde0XJsNoiTzqkHhDHgO131TdA",
+	"wzOuVufzhaGLOlW8hvJU7sjm8K0dfcKpwLTY8AXaZQRa8aqTKSshDaTJ90mAEcmP2ysZ6lwr8MQ/c9lU",
+	"UnwzZVklZhS+OjGOTAXFXg7GktNJqKCrPsxgOBVW0zbdQ+cq3IFGk9A72Jc8xBOxszp58PObyK2nhqjY",
+	"NuyDh3QgJtNDZ+0WqhQDyPAZRHbEUTJ2mjPgKQcni4zKgC+j8N1jXbDzMbr83Hhcs6RLBh1Il+Zk5Q6y",
+	"knhyQHz3yu1PbqgDHxElXfbaTc3I/xuVhR4P+fFg5wA+AKE6uhsRaRAGcsBVkCkpi6sPDbfpBorWXAPz",
+	"OMdGJ0GxL7SeBPiSsaSqZTbtJ8IRWK2OcgsG7YwtV3jHksTC2HoBis2NRzElDFUAilYy00SKEh5qcg3Z",
+	"i3F28Ux1cNgKOQ2zc9JK4YviSmFSIk6i4yVF/Bj63fdJIFyWi9n2o9YG+TM94TKL2yCxhTDINgmyPLF3",
+	"5+YXrl5/5zV7O9p5JZpOwuDYWz4BY8PdP5+DPunITjvqlUBmxyN1iGUPSdeYQmizbNCXeNcp7aFI4O4j",
+	"I/I7oq0Z6qgYkQuzHXn6Im5NcD2K3qT02IlindqYQMyCzJV1+VCcHPiE8knG4EQo9odG1XX257/FYAMx",
+	"vss1xg22Q/e4GyqZQqRNDuE+3T59yzeo34pdU3g+YodXlNx5EoIUciNzByx+6hNPWu1Dtetoziqv/BEo",
+	"84vATGx8Gnr06b4CtXs1a86aXyqWsNO0n9zit4eqTvjniLmHU0lNfTo49ScAqhwQzYHWXLCQ31kYF8qA",
+	"MsGRoXl/HYb+QG5zkDwPU8dhn3+DaZoZ3rrrpWptdHQ1Q4n9WrHrBuN9P09093IqEcgPJI2ejRMCPY3S",
+	"8dCuOAbdLMA70fXckE94HEeBXvrCkn5kZ+51SPjHzszzMIE1Kg/xhB5M3R5dzyL9hGjVomw8wP7A9YO2",
+	"t6bHaYs8CT6xV8jd9iPSyrAPD1CtDJnPuuxFFmtHp+mzf4mKPmI0sR22k3gZF2/DrGxfn6gkv8IANwTH",
+	"L8aotZV7meo4F0y5FRkp43Tk2lWmsaSwKXPRSovgbJww6fSsAl6ZTk4MqYARmYGcAzuGAkadNMF4uhRq",
+	"LRv096K0i+/aGGlLfyVVfiTib+V3ZW/kzE8iZUIZIwuG8UTLbQKxNr1kyZmNcOPOz8Bn0qP79LCcroUq",
+	"ku4SVkZFA8tcSx3iuW1neFwq5cr57tra2lqp2fyuzmtY5eslHOs1ZeTXRo1cjCcx+AnZKzFnPO5erqyY",
+	"2tx4WTFResqoyUfTnXIujTHz93d+8uMS0AFuinyH4AoCbzQQpEePoowR8NWAJX3ES8/jOqd9iEWwzfB7",
+	"9ERzy2yezJ2IJH5FRAlSSTylWq0M6RNjpezEJLe06Tsytoqv0T/jRrfDvlSor/W+fvrpp5+WfvQjbWDE",
+	"hE0rCIgHL/3H+/edL66sl2aq92ql60v/VLtXLc0tzX7nXdkfVAEypUyKlAiYUIxkmEoxGnObSspSHapp",
+	"SS9PEsd/95yT8tinxdlJTAtJ580k5qjAWDZNNep6wnc9d/WdrQlRybKkoeckDE4YI5Ox1v9g7a7bJPrV",
+	"O8oiE/IZbO9LbX6l55uJ6CmnW6b0o/3Q3QfW61MMZGMV1Sn/KYOSGcmDV+7Wiqdfuo2AeAUo7n/En7jQ",
+	"VMqxyDM3Bnkue27lEERPuGlkCIXJJcxYsgV9TRiz2UpVfOzHivI7JXqyU9ldj/BPaJOF4t4koiKjb6Ro",
+	"AzryDM/qAEcJFMj2hHOUd5eqQCnZK7Yt6DhgL2bltGrbr4ukeTWpmv8+VSkyvrOrqFtL04JiednPaCkE",
+	"wfSvIgLTU+XrbIceqV8fVvKo/bTf9oIP1rLK97GByGlytzgCu1O3X2j43wv5vyfEbCWqUuAtrfoKw0W5",
+	"/i9tkCx2s73aChLp9PIN75AjLWJyRHJLWmDaZTlFyTmuzMTavtQABF8K1vXxHXhz+p3MlPYzmd61zIGl",
+	"eihFaYxzYyR8T1pnP06q3ZTLlueKgz2ctaUUMAucyBwCoN8mnYa9FkYdhwYz15K542MkDkmvSn99ZB1X",
+	"UVZOnMBfbPSTyAgNH8YQEbd5ojGU+F9UMw5YN+iZk/s4jijQPet2HBJZMjEWqXqarVcq/5YaVGDA83/i",
+	"dRuSI7zOK/76CEN6SN/AE5yWuyLXaAeyysNAVC5dM2reUCiXyLqAjiOXuRFHSOwUNiZbweraG3/5frt0",
+	"U0v3ErXSKdAlRfrO9DSsC+iLckl9HpJ2E6/suFFLBJ0prOpxV/QdOfqYbI3Z9t3WygeZy/plsutGshHM",
+	"URgJm6Q1hiEliefok/H+1SlbAqHfL2EF6Mq7tZU684WNgg5pjUH3Y7ki6yIoDwIiJ+nPIXaaK6EhRWwr",
+	"ifqkOXIHmxxgVxLF6E67/sLsc/CESCk9vKtwXNMUdSc75GKKdfkjYcuf51xHY118ySFy8nnZtBKLtei6",
+	"l32C+U32jBRGXh/5RrvdWnyXkdIpErszwEq0nOTOc+l9/Ac1hUBdankb+Ygte3FZuPyySxIKqiRRomB6",
+	"nBnZhgbrav29ltQAXBBBUGgvzEjciR2obEfkKmLaBT2WVkeaSleuTlMm8pJA+hrYZqA/9hDz98Le5ViL",
+	"gTJJoUim5+S9u7X5herVhSvXy9fmr1Wv5xeVygi1XXjjgxH6BZHFHVTP8A2y01oGt5pUxTWkEa2SCtb4",
+	"Ty3GPLGraQia6dsUpfYvyDU1ySjzaZr8l9xBA97lXddYAF+kYjjTC5Z8YWYzLA1cFnQzB9d6tpOTX95M",
+	"rJ8K35mOxOoe5iPVPR5WayX9l8KZr+u/lWi8JTXziFptjeqxNawBiCa2kyYsOlBwbry9GNuir1DU9vRx",
+	"XEM0h1OPEwg71XUTb6Ov2D/jPQN6LHftfyYefmvcN+kf75vpHZ8j5SN9kdCf4X1o6/VELXnCn64DinaD",
+	"m8mqTZ/Np/wNT8vmk7jb1k4BBrZ5npO4Ot4kzt6Lf+GNSIcPIBh17oZmnVyEVFLLIyXZsFQ8kq4/vQME",
+	"vVwxOsXq7kuY9Kctrv45UPUny8uXyUmogOYcnIIhDb71Cn7rFSzqFYyxM4lbUFmFhf2CyK/6qucGa3fg",
+	"Gv/uYsf9IdFkf8htkXDl4dl0/OC4sCqKbUYieVPAKnnKRt9YXA0etj3316jwLRgfENsjnnF/tVqdr4cf",
+	"wL9I2aB/Cvdz/sk96Zw6XnuAi30PDb1606nYHfcRWfMtNAUA3m/w4muer8LfhVbsbpTDxxU9I8xdMmZs",
+	"x7EM0SzJMqL9wzK4OgrXbMcy4qRu+AWqXCzDdppuyzJsaAuDWgcSnZgL5gOcZowgqIngO4nbWkbNKHAD",
+	"VGbvcOgY/HAvaEdlWuZj4vmcD49rofvQ7rjmgjlfrpbneRnBQ2RgxXZ4gkbbDzIiieFhdgMuCnJJYQ7t",
+	"qIoPtTPWNeB8tjJ3sXnI0VuOuWB+0vaDRceJz8v4oO2s8VbDrUD4oe1Op+HW8ZkK9shc+EI6HXDEYVGh",
+	"zF1XVyB0QcEf+KpCesxVq9P9Mn83//QoD5N0bGBZrLkKgqRiRydejWBWeCpZL+x538N8qmPu0wgZMk57",
+	"ZCPX+XSjjqZL+j4Trk+5S0fUfRZ9WmxLeVkWippuKz4d7OwQlTh77vyBlTxHTYev/Cf1ofO/zxMwIx4n",
+	"ILgSN7AagUHeg3gQaw4RGqARxAaKUXAPwvFhPPFPdJPMSvKkg6yZIC5/p7w41ddFwjaa019x10Oic9gg",
+	"9Qu84IgbzWF5bvhRcdYfuqrRvbmJGuriJ7fgRdBQzzLQGEW16Q3txxsS7Ch0wGGOe6LaQrCP7gI4i6lP",
+	"XwlHVbjZ0B7fK4ZgX+oydkbg17SIPGf061qy6eCfqqzPRl8a6slmBkUgn+wxIc4ssxLtUZUuegAlgBl3",
+	"8pxGZyyMuyqGQiQ1uTODSlZ/j/OHTGa7i1zQgf2Th9t2oQMue8qVykSrjgSMPCU/bgSCRH5ViCHlg9i/",
+	"IvVBqN4V0FrAo9BoT0AFW/z12FdK126Dngh0gYn7HGt5T+gresy6wtkC/kgwjLmVDHgOK3ZTbZ8zsKVm",
+	"BJ4RrPQJmOeMqIw8Sh2Yfq+IgxSPRFwsyZ8IS8KtnY2ev2afHIZYkvRv1cNhoIWJxs8oPV49rJJ1DWGm",
+	"6rFwI/TEnwX71XPKzpntiaOw9LIj8kUPP9Mt3nWI2v99CK9/x7bpa7bBupjfyU89VMv5R3ESPTjJ1lzY",
+	"ZkjtC3oPy7Msg7ScJU2qBfTAEH2Ff3wTmgXgsbEbdGCECcfG9wx+/EPixIC0CZB9KsVpmLiyAbrpM7xL",
+	"pNpgF5ZXSOBtESZXvg2HURj/t/Hv/OucZm8SH031PbCMaClKRgi+W6lSSfVUAKupr18Mieb+pqUc+p/h",
+	"A45vqfBT8aND8deXzmZZZZxPcs7rK+tYBRiF/NYnpZaTfnM6wScgT4IKnKAx9L51S7fOJMRyoGfzXazj",
+	"lag34YjNXrhSCztUFox04paVK21rEB9PrqaKpQyVk6GHi+nOLdbjPm7UeHbqZaJl6/lrlcnWq7ncPNHJ",
+	"dxrgLAZnBJ0Jcv6SkJkV4D+SukrbQdlQZopqJBpXSrmzYoVlZj2xrbyHEe8mh2EZkSkWOpyjvlnDQLoY",
+	"nDlMF4MLB+pikB+qOh6nAeuPiVfR40x/vKq2k60xw0tTXvEbIKXMqFWrQqWRnDezo3jtnzmr/QvntJ+X",
+	"0UKmx1KJbctsVnqQTsDrPJEC9cRQJRyDLReNmZ/evTGr7Sxr3INerJYRtJdi37SQd3BzpDe+RrPrN8K2",
+	"3RVqa0oZFkVQh+j/w13emH/vPSNsJJqJMJVcZwYzbbvg88eavt1tPsAJntIjGW2iv18BnCU6J3an0UCT",
+	"t/DIaNTQZS/E+FWDiYcoxPGn7GnGhw+kE1PpkdwMMnl2X47mpQYMh6euKrllSJhO3JWSd6wc3ZoyC9Qh",
+	"Vy6p6ZLuRHr+KyHZ4DKXV3E0UuW1wZsjFnBEx/YK63IBe+POz0ph50orqv2M2jLwioM38DhfAc94/IH7",
+	"DOJDagxeKxEtg008CklKzBhmFIhpnBkU1D6a54+ERCtMPRCGdhSNWZ7HJTSa68jTflQA9CwUYuN4jqIq",
+	"IoGBTDbLDo8z4vRFOyu0bQvzeBNyeRLuKD0G8++GuuI0YSylcbAdhialUPAoHCT2uRgStI8SRd3hRuLD",
+	"PweA+JcAISMiTlLnJ4khclBpRWnJUgQUmhO5crr8+7Q/JM7IupnslUd6edUGTTPI80eIroOiDiLfpLi4",
+	"k4GNYhJjwp5jQ5XlbfF8qvQh6gKF3qceP44wL6L8dwNS/qXAVH65M0oBTbfSO0+caYyuY7WXmOQQYN2R",
+	"sIwPC5yKJZcHtoJo7wZ41Y6iFwvhRC/IiYAcHmyaDd3MLmRTC6cLB9UJPQWnCLiq1RT7odH129HJrGeT",
+	"XaE0Lzn3tAq1/8XwAPvQ2EUvyfEbthdMwnW1w0IONuMzPF0Cb90LY3D4V1aDJj0SDPrXITVQmc13+RV4",
+	"75dYeNWF2kExCHEq8DEv3gdv0H0Tk+jR8Rr5ucKBshec3lto7GNggHue4koapNRM5XNRaDAbfSOuxhqI",
+	"e3hWy2yYDBBV30H4Jpw83TWiyoihS+GGfWYOBU0brotZEkqzp6ksC1/qWwGDWNF2EP1POkBs70TtKBIZ",
+	"5Dl27hmoUiBe6Q5pBQb/4Kyu2A9bYQwp1rMSpX4wKBldbFt9PJlBggEmbsIgXMOeDKJVqeE6ybhDIlUe",
+	"80mE4zTTKrIMPP4db2Z4MoZlQH0Of5qHOHjW8Fby/TNsE5zCnUcrFcLJFKXVRAo0ShIpXzhM1se4RpfP",
+	"3UAKHaUOEEpW2MBm+bHtByVkSgmV+YHMaCFYwm5wUtr+ZnjtVHiT4yo6GRsouHrgf941alfD/PIulOGk",
+	"E2tEkc5R+Hm4TPdLwHJ6TF+nZQDa1xKKU5rV1Gu8XHjPZ6s8u7GFB8vHdVyqULCkBZ6jNGySg+1ex8de",
+	"hyGEYy2CU3XFOJ+HxHaIF09IgYRZbB5LZygKtZ12ojQfXDElLtWK5vv8V4Zoi4RluKcNUSBeKtvghauJ",
+	"YbnfGW2MyXrWc94VUyWRI1Ix5X1DbIFSRSPKirCW8d4SgBg3Xa0UuUkek0a7Az49g98lzgzSnF30fvX9",
+	"WgWK8JbW/38AvbFtlPunAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,877 @@
+// Package v2 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.11.0 DO NOT EDIT.
+package v2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ApiKeyScopes = "ApiKey.Scopes"
+)
+
+// Defines values for CreateWebhookRequestEventTypes.
+const (
+	WalletCancel           CreateWebhookRequestEventTypes = "wallet.cancel"
+	WalletIncomingTransfer CreateWebhookRequestEventTypes = "wallet.incoming_transfer"
+	WalletOutgoingTransfer CreateWebhookRequestEventTypes = "wallet.outgoing_transfer"
+	WalletReservation      CreateWebhookRequestEventTypes = "wallet.reservation"
+	WalletWriteOff         CreateWebhookRequestEventTypes = "wallet.write_off"
+)
+
+// Defines values for TransactionType.
+const (
+	Cancel           TransactionType = "cancel"
+	IncomingTransfer TransactionType = "incoming_transfer"
+	OutgoingTransfer TransactionType = "outgoing_transfer"
+	Reservation      TransactionType = "reservation"
+	WriteOff         TransactionType = "write_off"
+)
+
+// Balance defines model for Balance.
+type Balance struct {
+	Available int64 `json:"available"`
+	Reserved  int64 `json:"reserved"`
+}
+
+// BalanceChange defines model for BalanceChange.
+type BalanceChange struct {
+	// Доступный баланс после операции.
+	Balance int64 `json:"balance"`
+}
+
+// CancelRequest defines model for CancelRequest.
+type CancelRequest struct {
+	ServiceID *int64 `json:"serviceID,omitempty"`
+	UserID    int64  `json:"userID"`
+}
+
+// CaptureRequest defines model for CaptureRequest.
+type CaptureRequest struct {
+	Amount    int64 `json:"amount"`
+	ServiceID int64 `json:"serviceID"`
+	UserID    int64 `json:"userID"`
+}
+
+// CartItem defines model for CartItem.
+type CartItem struct {
+	Price     int64 `json:"price"`
+	ServiceID int64 `json:"serviceID"`
+}
+
+// CreateOrderRequest defines model for CreateOrderRequest.
+type CreateOrderRequest struct {
+	Items   []CartItem `json:"items"`
+	OrderID int64      `json:"orderID"`
+	UserID  int64      `json:"userID"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	EventTypes *[]CreateWebhookRequestEventTypes `json:"eventTypes,omitempty"`
+	Url        string                            `json:"url"`
+}
+
+// CreateWebhookRequestEventTypes defines model for CreateWebhookRequest.EventTypes.
+type CreateWebhookRequestEventTypes string
+
+// DailyBalance defines model for DailyBalance.
+type DailyBalance struct {
+	Available int64              `json:"available"`
+	Date      openapi_types.Date `json:"date"`
+	Reserved  int64              `json:"reserved"`
+}
+
+// DailyBalances defines model for DailyBalances.
+type DailyBalances struct {
+	Balances []DailyBalance `json:"balances"`
+}
+
+// DepositRequest defines model for DepositRequest.
+type DepositRequest struct {
+	Amount int64 `json:"amount"`
+}
+
+// FailedDeliveries defines model for FailedDeliveries.
+type FailedDeliveries struct {
+	Deliveries []FailedDelivery `json:"deliveries"`
+}
+
+// FailedDelivery defines model for FailedDelivery.
+type FailedDelivery struct {
+	Attempts       int       `json:"attempts"`
+	EventID        int64     `json:"eventID"`
+	EventType      string    `json:"eventType"`
+	FailedAt       time.Time `json:"failedAt"`
+	Id             int64     `json:"id"`
+	LastError      string    `json:"lastError"`
+	SubscriptionID int64     `json:"subscriptionID"`
+	Url            string    `json:"url"`
+}
+
+// Hold defines model for Hold.
+type Hold struct {
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+	OrderID   int64     `json:"orderID"`
+	ServiceID int64     `json:"serviceID"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	// Код ошибки из pkg/errcodes.
+	Code string `json:"code"`
+
+	// Описание ошибки.
+	Detail *string `json:"detail,omitempty"`
+
+	// Путь запроса.
+	Instance *string `json:"instance,omitempty"`
+	Status   int     `json:"status"`
+
+	// Описание статуса ответа.
+	Title string `json:"title"`
+
+	// Тип ошибки. about:blank – тип определяется полем code.
+	Type string `json:"type"`
+}
+
+// Report defines model for Report.
+type Report struct {
+	Url string `json:"url"`
+}
+
+// Statement defines model for Statement.
+type Statement struct {
+	ClosingBalance int64     `json:"closingBalance"`
+	CreatedAt      time.Time `json:"createdAt"`
+	OpeningBalance int64     `json:"openingBalance"`
+	Period         string    `json:"period"`
+}
+
+// StatementWithLink defines model for StatementWithLink.
+type StatementWithLink struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	Statement Statement `json:"statement"`
+	Url       string    `json:"url"`
+}
+
+// Statements defines model for Statements.
+type Statements struct {
+	Statements []Statement `json:"statements"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	Amount       int64           `json:"amount"`
+	BalanceAfter *int64          `json:"balanceAfter,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	Description  string          `json:"description"`
+	Id           int64           `json:"id"`
+	OrderID      *int64          `json:"orderID,omitempty"`
+	ServiceID    *int64          `json:"serviceID,omitempty"`
+	Type         TransactionType `json:"type"`
+}
+
+// TransactionType defines model for TransactionType.
+type TransactionType string
+
+// TransactionsPage defines model for TransactionsPage.
+type TransactionsPage struct {
+	NextPageToken *string       `json:"nextPageToken,omitempty"`
+	Transactions  []Transaction `json:"transactions"`
+}
+
+// Wallet defines model for Wallet.
+type Wallet struct {
+	Available int64  `json:"available"`
+	Holds     []Hold `json:"holds"`
+	Reserved  int64  `json:"reserved"`
+	Total     int64  `json:"total"`
+	UserID    int64  `json:"userID"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Id     int64  `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// AcceptLanguage defines model for AcceptLanguage.
+type AcceptLanguage = string
+
+// OrderID defines model for OrderID.
+type OrderID = int64
+
+// Period defines model for Period.
+type Period = string
+
+// UserID defines model for UserID.
+type UserID = int64
+
+// GetAdminFailedDeliveriesParams defines parameters for GetAdminFailedDeliveries.
+type GetAdminFailedDeliveriesParams struct {
+	AfterID *int64 `form:"afterID,omitempty" json:"afterID,omitempty"`
+	Limit   *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostAdminWebhooksJSONBody defines parameters for PostAdminWebhooks.
+type PostAdminWebhooksJSONBody = CreateWebhookRequest
+
+// PostOrdersJSONBody defines parameters for PostOrders.
+type PostOrdersJSONBody = CreateOrderRequest
+
+// PostOrdersOrderIDCancelJSONBody defines parameters for PostOrdersOrderIDCancel.
+type PostOrdersOrderIDCancelJSONBody = CancelRequest
+
+// PostOrdersOrderIDCaptureJSONBody defines parameters for PostOrdersOrderIDCapture.
+type PostOrdersOrderIDCaptureJSONBody = CaptureRequest
+
+// GetWalletsUserIDBalanceParams defines parameters for GetWalletsUserIDBalance.
+type GetWalletsUserIDBalanceParams struct {
+	At time.Time `form:"at" json:"at"`
+}
+
+// GetWalletsUserIDDailyBalancesParams defines parameters for GetWalletsUserIDDailyBalances.
+type GetWalletsUserIDDailyBalancesParams struct {
+	From openapi_types.Date `form:"from" json:"from"`
+	To   openapi_types.Date `form:"to" json:"to"`
+}
+
+// PostWalletsUserIDDepositsJSONBody defines parameters for PostWalletsUserIDDeposits.
+type PostWalletsUserIDDepositsJSONBody = DepositRequest
+
+// GetWalletsUserIDTransactionsParams defines parameters for GetWalletsUserIDTransactions.
+type GetWalletsUserIDTransactionsParams struct {
+	Limit     *int64  `form:"limit,omitempty" json:"limit,omitempty"`
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+
+	// Язык описаний транзакций: ru (по умолчанию) или en.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// GetWalletsUserIDTransactionsTransactionIDParams defines parameters for GetWalletsUserIDTransactionsTransactionID.
+type GetWalletsUserIDTransactionsTransactionIDParams struct {
+	// Язык описаний транзакций: ru (по умолчанию) или en.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostAdminWebhooksJSONRequestBody defines body for PostAdminWebhooks for application/json ContentType.
+type PostAdminWebhooksJSONRequestBody = PostAdminWebhooksJSONBody
+
+// PostOrdersJSONRequestBody defines body for PostOrders for application/json ContentType.
+type PostOrdersJSONRequestBody = PostOrdersJSONBody
+
+// PostOrdersOrderIDCancelJSONRequestBody defines body for PostOrdersOrderIDCancel for application/json ContentType.
+type PostOrdersOrderIDCancelJSONRequestBody = PostOrdersOrderIDCancelJSONBody
+
+// PostOrdersOrderIDCaptureJSONRequestBody defines body for PostOrdersOrderIDCapture for application/json ContentType.
+type PostOrdersOrderIDCaptureJSONRequestBody = PostOrdersOrderIDCaptureJSONBody
+
+// PostWalletsUserIDDepositsJSONRequestBody defines body for PostWalletsUserIDDeposits for application/json ContentType.
+type PostWalletsUserIDDepositsJSONRequestBody = PostWalletsUserIDDepositsJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /admin/failed-deliveries)
+	GetAdminFailedDeliveries(ctx echo.Context, params GetAdminFailedDeliveriesParams) error
+
+	// (POST /admin/failed-deliveries/{deliveryID}/replay)
+	PostAdminFailedDeliveriesDeliveryIDReplay(ctx echo.Context, deliveryID int64) error
+
+	// (POST /admin/webhooks)
+	PostAdminWebhooks(ctx echo.Context) error
+
+	// (POST /orders)
+	PostOrders(ctx echo.Context) error
+
+	// (POST /orders/{orderID}/cancel)
+	PostOrdersOrderIDCancel(ctx echo.Context, orderID OrderID) error
+
+	// (POST /orders/{orderID}/capture)
+	PostOrdersOrderIDCapture(ctx echo.Context, orderID OrderID) error
+
+	// (GET /reports/{period})
+	GetReportsPeriod(ctx echo.Context, period Period) error
+
+	// (GET /wallets/{userID})
+	GetWalletsUserID(ctx echo.Context, userID UserID) error
+
+	// (GET /wallets/{userID}/balance)
+	GetWalletsUserIDBalance(ctx echo.Context, userID UserID, params GetWalletsUserIDBalanceParams) error
+
+	// (GET /wallets/{userID}/daily-balances)
+	GetWalletsUserIDDailyBalances(ctx echo.Context, userID UserID, params GetWalletsUserIDDailyBalancesParams) error
+
+	// (POST /wallets/{userID}/deposits)
+	PostWalletsUserIDDeposits(ctx echo.Context, userID UserID) error
+
+	// (GET /wallets/{userID}/statements)
+	GetWalletsUserIDStatements(ctx echo.Context, userID UserID) error
+
+	// (GET /wallets/{userID}/statements/{period})
+	GetWalletsUserIDStatementsPeriod(ctx echo.Context, userID UserID, period Period) error
+
+	// (GET /wallets/{userID}/transactions)
+	GetWalletsUserIDTransactions(ctx echo.Context, userID UserID, params GetWalletsUserIDTransactionsParams) error
+
+	// (GET /wallets/{userID}/transactions/{transactionID})
+	GetWalletsUserIDTransactionsTransactionID(ctx echo.Context, userID UserID, transactionID int64, params GetWalletsUserIDTransactionsTransactionIDParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAdminFailedDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAdminFailedDeliveries(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminFailedDeliveriesParams
+	// ------------- Optional query parameter "afterID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "afterID", ctx.QueryParams(), &params.AfterID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter afterID: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAdminFailedDeliveries(ctx, params)
+	return err
+}
+
+// PostAdminFailedDeliveriesDeliveryIDReplay converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminFailedDeliveriesDeliveryIDReplay(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "deliveryID" -------------
+	var deliveryID int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "deliveryID", runtime.ParamLocationPath, ctx.Param("deliveryID"), &deliveryID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter deliveryID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminFailedDeliveriesDeliveryIDReplay(ctx, deliveryID)
+	return err
+}
+
+// PostAdminWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAdminWebhooks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostAdminWebhooks(ctx)
+	return err
+}
+
+// PostOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrders(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostOrders(ctx)
+	return err
+}
+
+// PostOrdersOrderIDCancel converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrdersOrderIDCancel(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderID" -------------
+	var orderID OrderID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "orderID", runtime.ParamLocationPath, ctx.Param("orderID"), &orderID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostOrdersOrderIDCancel(ctx, orderID)
+	return err
+}
+
+// PostOrdersOrderIDCapture converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrdersOrderIDCapture(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderID" -------------
+	var orderID OrderID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "orderID", runtime.ParamLocationPath, ctx.Param("orderID"), &orderID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostOrdersOrderIDCapture(ctx, orderID)
+	return err
+}
+
+// GetReportsPeriod converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReportsPeriod(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "period" -------------
+	var period Period
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "period", runtime.ParamLocationPath, ctx.Param("period"), &period)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter period: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetReportsPeriod(ctx, period)
+	return err
+}
+
+// GetWalletsUserID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserID(ctx, userID)
+	return err
+}
+
+// GetWalletsUserIDBalance converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDBalance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWalletsUserIDBalanceParams
+	// ------------- Required query parameter "at" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "at", ctx.QueryParams(), &params.At)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter at: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDBalance(ctx, userID, params)
+	return err
+}
+
+// GetWalletsUserIDDailyBalances converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDDailyBalances(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWalletsUserIDDailyBalancesParams
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDDailyBalances(ctx, userID, params)
+	return err
+}
+
+// PostWalletsUserIDDeposits converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWalletsUserIDDeposits(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PostWalletsUserIDDeposits(ctx, userID)
+	return err
+}
+
+// GetWalletsUserIDStatements converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDStatements(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDStatements(ctx, userID)
+	return err
+}
+
+// GetWalletsUserIDStatementsPeriod converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDStatementsPeriod(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	// ------------- Path parameter "period" -------------
+	var period Period
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "period", runtime.ParamLocationPath, ctx.Param("period"), &period)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter period: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDStatementsPeriod(ctx, userID, period)
+	return err
+}
+
+// GetWalletsUserIDTransactions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDTransactions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWalletsUserIDTransactionsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", ctx.QueryParams(), &params.PageToken)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pageToken: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDTransactions(ctx, userID, params)
+	return err
+}
+
+// GetWalletsUserIDTransactionsTransactionID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWalletsUserIDTransactionsTransactionID(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "userID" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "userID", runtime.ParamLocationPath, ctx.Param("userID"), &userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter userID: %s", err))
+	}
+
+	// ------------- Path parameter "transactionID" -------------
+	var transactionID int64
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "transactionID", runtime.ParamLocationPath, ctx.Param("transactionID"), &transactionID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter transactionID: %s", err))
+	}
+
+	ctx.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWalletsUserIDTransactionsTransactionIDParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept-Language" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept-Language")]; found {
+		var AcceptLanguage AcceptLanguage
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept-Language, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Accept-Language", runtime.ParamLocationHeader, valueList[0], &AcceptLanguage)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept-Language: %s", err))
+		}
+
+		params.AcceptLanguage = &AcceptLanguage
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetWalletsUserIDTransactionsTransactionID(ctx, userID, transactionID, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/admin/failed-deliveries", wrapper.GetAdminFailedDeliveries)
+	router.POST(baseURL+"/admin/failed-deliveries/:deliveryID/replay", wrapper.PostAdminFailedDeliveriesDeliveryIDReplay)
+	router.POST(baseURL+"/admin/webhooks", wrapper.PostAdminWebhooks)
+	router.POST(baseURL+"/orders", wrapper.PostOrders)
+	router.POST(baseURL+"/orders/:orderID/cancel", wrapper.PostOrdersOrderIDCancel)
+	router.POST(baseURL+"/orders/:orderID/capture", wrapper.PostOrdersOrderIDCapture)
+	router.GET(baseURL+"/reports/:period", wrapper.GetReportsPeriod)
+	router.GET(baseURL+"/wallets/:userID", wrapper.GetWalletsUserID)
+	router.GET(baseURL+"/wallets/:userID/balance", wrapper.GetWalletsUserIDBalance)
+	router.GET(baseURL+"/wallets/:userID/daily-balances", wrapper.GetWalletsUserIDDailyBalances)
+	router.POST(baseURL+"/wallets/:userID/deposits", wrapper.PostWalletsUserIDDeposits)
+	router.GET(baseURL+"/wallets/:userID/statements", wrapper.GetWalletsUserIDStatements)
+	router.GET(baseURL+"/wallets/:userID/statements/:period", wrapper.GetWalletsUserIDStatementsPeriod)
+	router.GET(baseURL+"/wallets/:userID/transactions", wrapper.GetWalletsUserIDTransactions)
+	router.GET(baseURL+"/wallets/:userID/transactions/:transactionID", wrapper.GetWalletsUserIDTransactionsTransactionID)
+
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/9xb3W4bxxV+lcE2Fwm6ImnHTVLeKVbTCg0QwXHgC9sNVuRI2ni5u9kdKmIFAiKZWAlk",
+	"2EiaoEBQJHFeoLQiWjQlUq9w5hX6JMWZ2Z9ZcvhnirLbK2n/Zs6c853/w32j5FV8z6UuC43ivuFbgVWh",
+	"jAbiarVUoj770HK3q9Y2xTtlGpYC22e25xpFA/4Np/wIegQGcAFd3oA29KELLwhv8gNxcQpt6PGHeLNI",
+	"gip5Ey5gQHgLzmEAZ/xQfsEfv0WgC2fQJdTNGaZh4/I71CrTwDAN16pQoxiRs5LQYxphaYdWLCSM1Xx8",
+	"JWSB7W4b9bppfBSUabC+hg/Far7FdtK1vOipaQT086od0LJRZEGVqmtueUHFYkbRsF32zg3DjDexXUa3",
+	"aSB22aCB7ZU1rPkXdHiDP+EPCRwT/iUM+AGcQ5s3oUOuFwrvrBSuJQfNkubLJSdR5luM0QA//du9e+X9",
+	"G/UV/HO9/oZhajjxSTiBEdXwEvhQx89D33NDKoCzEXibDq3gvyXPZdRl+K/l+45dspBDeV++8fvPQmTX",
+	"vrLbGwHdMorG7/IpMvPyaZiP1xU7DjH8J/41dOEZ9KCtYfmtD26Sd98rvJsTHInWw+3etxzLLQlw+4Hn",
+	"04DZ8gzWrmU71qZDZ2KBYAANdml5VuSk/L6r7KWscz/5zNv8jJYYbhJRe3PHcrc1NG+mhxnizvcw4A3e",
+	"5C24gD4/ghcEnkEbzlD/eIOgVvIGnEFH6nIH1VeobRdROu95Yjp0R7iJT5xb9PMqDdnoEfDwdolKvM7A",
+	"9moC7nmJjL7U0+izakDHEmlVvKrLtJtWbNeuVCtG8ZqO2is/nbqlGdOtP3LA1hmtjB7WD+wSXfpZh8hX",
+	"qZYEaIkOqMWosPRjZWUzWsn+M8nCJGyoi+Oty2/S81lBYNXwoZe6l6uRY+qx5EHG8+MO3dzxvAdjOUJ3",
+	"qctu1/wh/lAXRXnX+MJyHMpytlvyKra7/SkLLDfcEo44eiRNlDDk6c0vApvRT72trfRWSah6eu1V2baX",
+	"WfL+iL8a5XQ1cPQePsOmwNFyZM2yndql2fiyxbKvihuaMyziDKI1Z/YJ6hHDsT5hdhXIsGxEHnpLr4fj",
+	"GvW90GaXbUaHned4o/aBZTu0vEYde5cGto475cyzmfiTWbQ2lUPKDlNJrGl4xBit+DI6HwWkUOWZrVCi",
+	"+Bp9Mo0tQckqGwH4CrMrWpTb5Rk3dqyQ/SkIvEC7cVjdTGKV2S3qLFbBLhsjy8tPU9apbDFTdqtEK7zR",
+	"ifAvnlOeC9yjpykJwz0X8+dzQAs549T1aIIJlXgdd5RcIMugklfWRao/wgBOCAyScL6LqeEp8R9s52kQ",
+	"4FdhTseQMmWW7WhW/CmTnHYya2tXst2QjYmjf+Yt3uSPiEhsL/iBCJvb2lVCZrHqGL1lNnPoDKRizI4Z",
+	"DG/hTaS8CcfQ4c0xe7Kar1v2V+jCRfbcxNr0qqy46VjuA/Kfg38Q3oxeEsfqwAl04Iw/wc0wkRUpAiYI",
+	"cE5QBjltqqmihkmNkkdN2GFKueuQcov6XqBxEwsFAB8zi9EKdTXrlhwvtN1tJTpYkqb61J17Hz8pLEw+",
+	"d1ItGNrFHD7eND1NGHXHZjsf2u6DUYbRPd8OaDjP2UOV/ZO8aiqnWY17unRi0hP6Jh5QEwaEmWczhQEZ",
+	"gidGAMriOrpuYzBslaSuLuRFomBsdYvRYHlwztiW/QWigmV6sNQUTpKhwnrh/7XxQ2THNO4uy4spso3j",
+	"rjjN0uVXQ4mVklElqdRsOZSyb7hh6UpFLt1j+OS294Dq5ciUNWbWCmXjqXqR2UDHvTsibVw8bdvxnPLs",
+	"RxDhnCYPnSunMw3mMcu5uvKANl2MqYhZoGWyrBaMcnlmPQ5pKZBimiUaly+PUiIXqgY2q32MkpBErPr2",
+	"X2ltNKRZ3VhfgR6c8cf8kMAxPxKdiGNo88f8G+jAb9hlaIg65rGMqLAoLKK230QcM4Bj6EGHrFbZjhfY",
+	"fxdKVyTvUyugAblXLRTeLsUbiCuaI/CjvIau3PJEbBeFRz0YYLUZ+nACA3hBSpVy3vLtB7QWmoS38IU2",
+	"PBcPkbh4bWgjncfiE+hDG55h3ZqIIKwNx+RNq1w2SSRRkyQ2wSTSJOAzq2yS1MngHQykTGKVK7b7Vi5u",
+	"lCC3N8X5UjHuMObLcrrtbnmjfIZfRB+jxQ94A8nDk2IAipdd/oSsbqznCHwnI9Mz6PLDOMRukt1rZiaA",
+	"zYSv4kKc8Tm08RYRUecpb8EZfyQ/KhL8TJSjv46r1skSyLYBRqn8AJ5DV95ow7lYJybZJNBW4165LQou",
+	"iWt5Q8oOBXNObuzt5f+wtzehg0DeHNfHeCtHZspeiAijJc1wnATWaVgtkwPjY+nxiDSDyGrDNHZpEErR",
+	"7F6Pg0vLt42i8XaukHvbMEV/R+hOXsg/L3PXlWylY5syjbCjLgECT5D+hbQNK6gtpiT0gh/xpjxXT/By",
+	"wA/4Ef+KoJbxQyGOC9FVOJJfEAHuU9FQkGuLhh+yBVOMPuYd/EvoonTkcjK3QVMkeLxeNorGnylbxdOM",
+	"VHTMTM/ybtTj+rxKg1ra5LK2WGQm5+ru6Rdz7IrNMkuV6ZZVdZhRvFYomEbF2otKV4VCYUol6/5Q4+x6",
+	"oTChaTZfs2yEVbqu2dMo4RxgF/dEEf8AejkZ7UVn02+WUK/25urmWOzl96P/a+tr9XxAfceSRS8v1AHy",
+	"O4Gnfpx0qwT2eIukawlFGkT468AJfzSKoQ0v1INoLVnmliRID6ps4zTde8Hm6SgGrk9VzXbUsYtuoD3p",
+	"Q1vHhLpp3CjcmEN8i4o8shnhBKn+LMxtVOmQkm3DibTbpBo4whFK+/5MmBv0NGnXInU40pd0ZP2iAR3o",
+	"iUWaiJQzUbPACo10WNJmXShbdydA5E58CilbGrL3vXLt0nRT26ip1+vDSKqPYOPapdEQ7a41C6qEerEs",
+	"ToXf7EM7QlXhSlAlssRJaHoak5YW5jDUOiVRfhk5WP4ITgUWhDsX8JDBM0FcnEJbxh9JyHgQv8wfYRQj",
+	"FaohQNeOcIRRYAOjHRlvipun0I365gR+0S0YFyElNNFpfiWYLaIB4S/xSRf6YpkL3iL3jHgfOSIjHOih",
+	"3PSeoQfxR5Jry0Rvpu16xdjNzkHoEPzPBAYqdk2NKMeKHmOYl4L6vAb3RuGPV6hK+f1IMer5qKowXrd+",
+	"4k04F86lKxVBYVSE1kTfeCvWuByBH8Q0STdJusQbCOE+GnnoRRrZG1abFyZBdw/PoS/nxOSHUg2TEtAk",
+	"zEdzXzfjgsmQJ9cxLH0lH31tSK+8BNXJTL/MpDWFK9SaX1TxDhLhd6B/JYpwicAWAzwTvYYaf2itQgaZ",
+	"/HEKPxXSbQ3oZamQSObxR/xrBP4EM4NYx6HJhpiLPMfMSWTGp0LvHorATlByjLf5N0r2KsM+ZZRrJtWQ",
+	"zHn9dCMzdfXaKcfTIYzwRoyh/ztPIStIYX5ftpfq40sGT3mDH8GZzEn6cV3nUEbhctYXtYK34DdRnZGK",
+	"dZ5Mx8oNtAm/7AeGG3GHaz60Rp8tNcWWFI4By1i+LJRXy1GqML8vg9cJkvl2aL5zQghcTFLrZEC0Y04x",
+	"WfiOxm52pd/oiapQU76krMGPtKKWJa7wk7iaPZ+oo8+WKmpJoVbU36rW9wq93jAU8sr478KQiEB7DoPI",
+	"jWGtUkgyigeJxaaLMm0+v5xEx5TgLDZbzWVCH3OpYImPPQUtU3n8auFUxkHAFXV+8LJQheE/JgIPR7oi",
+	"J9CXsyYdkQRjYtwmd7cCr2IS5t2fjrjsNOQl4w7pmB15ugkZ/brMW2jVZUI5y88pgOZHUVIoxAjnr3XS",
+	"MIp3Oa46rXIp2zVxRgzPZke/mhf3RI+oE2XBbZkYN02cv+qrJYso0NeH9Vnkx+Qv5D4vP6wfmgJ+7cL6",
+	"EfMVC/hls95LA2R2KklvfH+A59JfiHj6MIrLRHs6Lt92p+JyqlVVZqdex+BMIW9qkyvlTNziemUGJ5Xv",
+	"DInWd4pE29P9rDbNwvCcN4YzE/4ltOEFnM0Dg5dMyhT/+hqkb6Mjjzr0ZBj/agEzPJI1LiWPf30bFY+6",
+	"vBF11rsIAM1vc6fjCQc6RJ3qWHb7e/FoxwE/gvMcge/F6/JHhHDCW9EgjihrKfTwI7XAGwV6naR948cj",
+	"aXJsIp5FxmEb3kome4bnoCejVh2GWyJih34t/bLjA6M/hZljnmDMnglXJ/5mepmqNjKQqK+ZzA3c/60I",
+	"U9Xf/L5yNbGQ8+vwsfkTkvl2cf+uiue2uvQr0JfsmAUbouZyJy2WAnAttjVCvDpfosxWChnGU5V37yNP",
+	"xGhhJN0s1Wt0lzqejy6SyLeiWXs5OFjM5x2vZDk7XsiK7xXeu5bHmbT79f8OAIIzNhxWQgAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	var res = make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	var resolvePath = PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		var pathToFile = url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
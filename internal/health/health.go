@@ -0,0 +1,91 @@
+// Package health - проверки готовности сервиса к запросам. Зависимость, которая перестала отвечать, делает сервис
+// неготовым, но не останавливает его: когда она вернется, сервис снова станет готов.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+)
+
+// checkTimeout - сколько ждать ответа зависимости. Зависшая зависимость так же недоступна, как и упавшая.
+const checkTimeout = 2 * time.Second
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check - проверка одной зависимости, например, что база отвечает.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Report - результат проверки: общий статус и ok или unavailable по каждой зависимости. Отчет отдается без
+// API-ключа, поэтому ошибки зависимостей с адресами и именами бакетов в него не попадают, а пишутся в лог.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type logger interface {
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type Checker struct {
+	logger logger
+	checks []Check
+}
+
+func New(logger logger, checks ...Check) *Checker {
+	return &Checker{
+		logger: logger,
+		checks: checks,
+	}
+}
+
+// Ready выполняет все проверки одновременно. Сервис готов, если прошли все.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]string, len(c.checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, check := range c.checks {
+		check := check
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := StatusOK
+			if err := check.Check(ctx); err != nil {
+				c.logger.Error(ctx, "dependency unavailable", "check", check.Name, logfield.Error(err))
+				result = StatusUnavailable
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[check.Name] = result
+			if result != StatusOK {
+				report.Status = StatusUnavailable
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return report, report.Status == StatusOK
+}
@@ -0,0 +1,84 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/health"
+)
+
+type testLogger struct {
+	errors []string
+}
+
+func (l *testLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprint(append([]interface{}{msg}, args...)...))
+}
+
+func TestChecker_Ready(t *testing.T) {
+	ctx := context.Background()
+
+	ok := func(context.Context) error { return nil }
+
+	t.Run("all dependencies available", func(t *testing.T) {
+		log := &testLogger{}
+
+		report, ready := health.New(
+			log,
+			health.Check{Name: "postgres", Check: ok},
+			health.Check{Name: "minio", Check: ok},
+		).Ready(ctx)
+
+		assert.True(t, ready)
+		assert.Equal(t, health.Report{
+			Status: health.StatusOK,
+			Checks: map[string]string{"postgres": health.StatusOK, "minio": health.StatusOK},
+		}, report)
+		assert.Empty(t, log.errors)
+	})
+
+	t.Run("dependency unavailable", func(t *testing.T) {
+		log := &testLogger{}
+
+		report, ready := health.New(
+			log,
+			health.Check{Name: "postgres", Check: func(context.Context) error {
+				return errors.New("dial tcp postgres.internal:5432: connection refused")
+			}},
+			health.Check{Name: "minio", Check: ok},
+		).Ready(ctx)
+
+		assert.False(t, ready)
+		assert.Equal(t, health.Report{
+			Status: health.StatusUnavailable,
+			Checks: map[string]string{"postgres": health.StatusUnavailable, "minio": health.StatusOK},
+		}, report)
+
+		// Адрес базы попадает только в лог.
+		require.Len(t, log.errors, 1)
+		assert.Contains(t, log.errors[0], "postgres.internal:5432")
+	})
+
+	t.Run("hung dependency", func(t *testing.T) {
+		log := &testLogger{}
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, ready := health.New(
+			log,
+			health.Check{Name: "postgres", Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}},
+		).Ready(ctx)
+
+		assert.False(t, ready)
+	})
+}
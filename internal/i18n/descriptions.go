@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg"
+)
+
+// typeUnknown - ключ шаблона для транзакций неизвестного типа.
+const typeUnknown = "unknown"
+
+// descriptionTemplates - каталог шаблонов описаний транзакций map[язык]map[тип транзакции]шаблон.
+// В шаблоне доступны поля TxData.
+var descriptionTemplates = map[Locale]map[string]string{
+	Ru: {
+		transactions.TypeAdd:      `Зачисление средств`,
+		transactions.TypeReserve:  `Резервирование средств по заказу {{.OrderID}}{{with .ServiceName}}, услуга «{{.}}»{{end}}`,
+		transactions.TypeWriteOff: `Списание средств по заказу {{.OrderID}}{{with .ServiceName}}, услуга «{{.}}»{{end}}`,
+		transactions.TypeCancel:   `Отмена резервирования средств по заказу {{.OrderID}}{{with .ServiceName}}, услуга «{{.}}»{{end}}`,
+		transactions.TypeOutgoing: `Списание средств`,
+		typeUnknown:               `Неизвестный тип транзакции`,
+	},
+	En: {
+		transactions.TypeAdd:      `Incoming transfer`,
+		transactions.TypeReserve:  `Funds reserved for order {{.OrderID}}{{with .ServiceName}}, service "{{.}}"{{end}}`,
+		transactions.TypeWriteOff: `Payment for order {{.OrderID}}{{with .ServiceName}}, service "{{.}}"{{end}}`,
+		transactions.TypeCancel:   `Reservation cancelled for order {{.OrderID}}{{with .ServiceName}}, service "{{.}}"{{end}}`,
+		transactions.TypeOutgoing: `Outgoing transfer`,
+		typeUnknown:               `Unknown transaction type`,
+	},
+}
+
+// serviceNames - названия услуг на языках, отличных от языка pkg.Services (русского).
+var serviceNames = map[Locale]map[int64]string{
+	En: {
+		1: "More views",
+		2: "Color highlight",
+		3: "XL listing",
+	},
+}
+
+// Шаблоны разбираются при старте: ошибка в каталоге – ошибка программиста.
+var descriptions = mustParse(descriptionTemplates)
+
+// TxData - данные, доступные в шаблоне описания транзакции.
+type TxData struct {
+	OrderID     int64
+	ServiceID   int64
+	ServiceName string // Пустое, если услуга неизвестна.
+}
+
+// TxDescription создает описание транзакции типа txType на языке locale.
+// orderID и serviceID равны 0, если у транзакции нет заказа / услуги.
+func TxDescription(locale Locale, txType string, orderID, serviceID int64) string {
+	templates, ok := descriptions[locale]
+	if !ok {
+		templates = descriptions[DefaultLocale]
+	}
+
+	tmpl, ok := templates[txType]
+	if !ok {
+		// Сознательно не возвращаем ошибку, чтобы не блокировать показ всех остальных транзакций,
+		// если такое случится.
+		tmpl = templates[typeUnknown]
+	}
+
+	data := TxData{
+		OrderID:     orderID,
+		ServiceID:   serviceID,
+		ServiceName: ServiceName(locale, serviceID),
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return fmt.Sprintf("%s %d", txType, orderID)
+	}
+
+	return b.String()
+}
+
+// ServiceName отдает название услуги на языке locale. Если перевода нет, то отдает название из pkg.Services.
+// Для неизвестной услуги отдает пустую строку.
+func ServiceName(locale Locale, serviceID int64) string {
+	if name, ok := serviceNames[locale][serviceID]; ok {
+		return name
+	}
+
+	return pkg.Services[serviceID]
+}
+
+func mustParse(catalog map[Locale]map[string]string) map[Locale]map[string]*template.Template {
+	result := make(map[Locale]map[string]*template.Template, len(catalog))
+
+	for locale, templates := range catalog {
+		result[locale] = make(map[string]*template.Template, len(templates))
+
+		for txType, text := range templates {
+			name := fmt.Sprintf("%s/%s", locale, txType)
+			result[locale][txType] = template.Must(template.New(name).Parse(text))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,103 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+func TestParseLocale(t *testing.T) {
+	cases := []struct {
+		header string
+		locale i18n.Locale
+	}{
+		{header: "", locale: i18n.Ru},
+		{header: "en", locale: i18n.En},
+		{header: "en-US,en;q=0.9,ru;q=0.8", locale: i18n.En},
+		{header: "ru-RU,ru;q=0.9,en;q=0.8", locale: i18n.Ru},
+		{header: "de-DE,en;q=0.5", locale: i18n.En},
+		{header: "de-DE", locale: i18n.Ru},
+		{header: "*", locale: i18n.Ru},
+		{header: "not a header;;", locale: i18n.Ru},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.locale, i18n.ParseLocale(tc.header), tc.header)
+	}
+}
+
+func TestTxDescription(t *testing.T) {
+	cases := []struct {
+		name      string
+		locale    i18n.Locale
+		txType    string
+		orderID   int64
+		serviceID int64
+		expected  string
+	}{
+		{
+			name:     "ru enrollment",
+			locale:   i18n.Ru,
+			txType:   transactions.TypeAdd,
+			expected: "Зачисление средств",
+		},
+		{
+			name:      "ru write off with service",
+			locale:    i18n.Ru,
+			txType:    transactions.TypeWriteOff,
+			orderID:   42,
+			serviceID: 2,
+			expected:  "Списание средств по заказу 42, услуга «Выделение цветом»",
+		},
+		{
+			name:     "ru reservation without service",
+			locale:   i18n.Ru,
+			txType:   transactions.TypeReserve,
+			orderID:  42,
+			expected: "Резервирование средств по заказу 42",
+		},
+		{
+			name:      "en cancel with service",
+			locale:    i18n.En,
+			txType:    transactions.TypeCancel,
+			orderID:   42,
+			serviceID: 3,
+			expected:  `Reservation cancelled for order 42, service "XL listing"`,
+		},
+		{
+			name:      "en reservation with unknown service",
+			locale:    i18n.En,
+			txType:    transactions.TypeReserve,
+			orderID:   42,
+			serviceID: 100,
+			expected:  "Funds reserved for order 42",
+		},
+		{
+			name:     "en outgoing transfer",
+			locale:   i18n.En,
+			txType:   transactions.TypeOutgoing,
+			expected: "Outgoing transfer",
+		},
+		{
+			name:     "en unknown type",
+			locale:   i18n.En,
+			txType:   "refund",
+			expected: "Unknown transaction type",
+		},
+		{
+			name:     "unsupported locale falls back to default",
+			locale:   i18n.Locale("de"),
+			txType:   transactions.TypeAdd,
+			expected: "Зачисление средств",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.TxDescription(tc.locale, tc.txType, tc.orderID, tc.serviceID))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+// Locale - язык, на котором сервис отдает человекочитаемые тексты.
+type Locale string
+
+const (
+	Ru Locale = "ru"
+	En Locale = "en"
+
+	// DefaultLocale - язык по умолчанию, если клиент не передал поддерживаемый язык.
+	DefaultLocale = Ru
+)
+
+// Первый язык в списке используется, если ни один из языков клиента не подошел.
+var matcher = language.NewMatcher([]language.Tag{
+	language.Russian,
+	language.English,
+})
+
+// ParseLocale выбирает поддерживаемый язык по значению заголовка Accept-Language (например, "en-US,en;q=0.9").
+// Если заголовок пустой, некорректный или не содержит поддерживаемых языков, то отдает DefaultLocale.
+func ParseLocale(acceptLanguage string) Locale {
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, idx, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+
+	switch idx {
+	case 1:
+		return En
+	default:
+		return Ru
+	}
+}
@@ -0,0 +1,101 @@
+// Package logfield - поля структурного лога. Поля запроса (пользователь, кошелек, заказ, сумма) копятся в наборе,
+// который транспорт кладет в контекст в начале запроса, и попадают в каждую запись лога с этим контекстом.
+// Контекст при этом не меняется, поэтому сервисы добавляют поля по ходу операции, не передавая новый контекст
+// в репозитории.
+package logfield
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/exp/slog"
+)
+
+// Ключи полей.
+const (
+	KeyRequestID = "request_id"
+	KeyTraceID   = "trace_id"
+	KeyUserID    = "user_id"
+	KeyWalletID  = "wallet_id"
+	KeyOrderID   = "order_id"
+	KeyAmount    = "amount"
+	KeyError     = "error"
+)
+
+type ctxKey struct{}
+
+// Set - поля, накопленные за запрос.
+type Set struct {
+	mu     sync.Mutex
+	fields []slog.Attr
+}
+
+// Start кладет в контекст пустой набор полей.
+func Start(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &Set{})
+}
+
+// Add добавляет поля в набор из контекста. Поле с тем же ключом заменяется. Без набора в контексте ничего не делает,
+// например, в фоновых задачах: там поля передаются прямо в запись лога.
+func Add(ctx context.Context, fields ...slog.Attr) {
+	s, ok := ctx.Value(ctxKey{}).(*Set)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range fields {
+		replaced := false
+
+		for i := range s.fields {
+			if s.fields[i].Key == f.Key {
+				s.fields[i] = f
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			s.fields = append(s.fields, f)
+		}
+	}
+}
+
+// FromContext отдает копию полей из контекста.
+func FromContext(ctx context.Context) []slog.Attr {
+	s, ok := ctx.Value(ctxKey{}).(*Set)
+	if !ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]slog.Attr(nil), s.fields...)
+}
+
+// UserID - идентификатор пользователя.
+func UserID(id int64) slog.Attr {
+	return slog.Int64(KeyUserID, id)
+}
+
+// WalletID - идентификатор кошелька.
+func WalletID(id int64) slog.Attr {
+	return slog.Int64(KeyWalletID, id)
+}
+
+// OrderID - идентификатор заказа во внешней системе.
+func OrderID(id int64) slog.Attr {
+	return slog.Int64(KeyOrderID, id)
+}
+
+// Amount - сумма операции в копейках.
+func Amount(amount int64) slog.Attr {
+	return slog.Int64(KeyAmount, amount)
+}
+
+// Error - ошибка операции.
+func Error(err error) slog.Attr {
+	return slog.String(KeyError, err.Error())
+}
@@ -1,26 +1,97 @@
+// Package logger - структурный лог в формате JSON с уровнями. В каждую запись с контекстом запроса попадают
+// идентификатор запроса, идентификатор трассировки и поля из logfield.
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"io"
 	"os"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/requestid"
 )
 
 type Logger struct {
-	*log.Logger
+	l *slog.Logger
+}
+
+// New создает лог в stdout. level - минимальный уровень записей: debug, info, warn или error, по умолчанию info.
+func New(level string) (*Logger, error) {
+	return NewWithWriter(os.Stdout, level)
 }
 
-func New() *Logger {
+// NewWithWriter создает лог, который пишет записи в w.
+func NewWithWriter(w io.Writer, level string) (*Logger, error) {
+	var lvl slog.Level
+
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("parse level: %w", err)
+		}
+	}
+
+	h := slog.HandlerOptions{Level: lvl}.NewJSONHandler(w)
+
 	return &Logger{
-		Logger: log.New(os.Stdout, "", log.LstdFlags),
+		l: slog.New(contextHandler{Handler: h}),
+	}, nil
+}
+
+// Debug - пишет в лог подробности для отладки.
+func (l *Logger) Debug(ctx context.Context, msg string, args ...interface{}) {
+	l.l.DebugCtx(ctx, msg, args...)
+}
+
+// Info - пишет в лог успешно выполненные операции.
+func (l *Logger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.l.InfoCtx(ctx, msg, args...)
+}
+
+// Warn - пишет в лог то, что не помешало выполнить операцию, но требует внимания.
+func (l *Logger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.l.WarnCtx(ctx, msg, args...)
+}
+
+// Error - пишет в лог неудачно выполненные операции.
+func (l *Logger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.l.ErrorCtx(ctx, msg, args...)
+}
+
+// contextHandler добавляет в запись идентификаторы запроса и трассировки и поля запроса из контекста.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String(logfield.KeyRequestID, id))
 	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		r.AddAttrs(slog.String(logfield.KeyTraceID, sc.TraceID().String()))
+	}
+
+	// Поле, переданное в запись явно, важнее поля запроса с тем же ключом.
+	keys := make(map[string]bool, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) { keys[a.Key] = true })
+
+	for _, f := range logfield.FromContext(ctx) {
+		if !keys[f.Key] {
+			r.AddAttrs(f)
+		}
+	}
+
+	return h.Handler.Handle(ctx, r)
 }
 
-// Info - пишет в лог успешно выполненные операции
-func (l *Logger) Info(msg string) {
-	l.Println("INFO: ", msg)
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
 }
 
-// Error - пишет в лог неудачно выполненные операции
-func (l *Logger) Error(msg string) {
-	l.Println("ERROR: ", msg)
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
 }
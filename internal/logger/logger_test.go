@@ -0,0 +1,59 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/logger"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("unknown level", func(t *testing.T) {
+		_, err := logger.New("verbose")
+		assert.Error(t, err)
+	})
+
+	t.Run("request fields", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l, err := logger.NewWithWriter(&buf, "info")
+		require.NoError(t, err)
+
+		ctx := logfield.Start(requestid.WithID(context.Background(), "request"))
+		logfield.Add(ctx, logfield.UserID(1), logfield.OrderID(10), logfield.Amount(500))
+		logfield.Add(ctx, logfield.WalletID(2))
+
+		l.Error(ctx, "take", logfield.Error(errors.New("not enough cash")), logfield.Amount(100))
+
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+		assert.Equal(t, "ERROR", record["level"])
+		assert.Equal(t, "take", record["msg"])
+		assert.Equal(t, "request", record[logfield.KeyRequestID])
+		assert.Equal(t, float64(1), record[logfield.KeyUserID])
+		assert.Equal(t, float64(2), record[logfield.KeyWalletID])
+		assert.Equal(t, float64(10), record[logfield.KeyOrderID])
+		assert.Equal(t, "not enough cash", record[logfield.KeyError])
+		// Поле записи важнее поля запроса.
+		assert.Equal(t, float64(100), record[logfield.KeyAmount])
+	})
+
+	t.Run("level", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		l, err := logger.NewWithWriter(&buf, "warn")
+		require.NoError(t, err)
+
+		l.Info(context.Background(), "cash reserved")
+		assert.Empty(t, buf.String())
+	})
+}
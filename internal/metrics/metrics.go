@@ -0,0 +1,205 @@
+// Package metrics - метрики сервиса в формате Prometheus. Метрики собираются в собственный реестр и отдаются
+// обработчиком Handler, обычно по пути /metrics.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const namespace = "wallet"
+
+// Транспорты запросов, значения метки transport.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// Результаты повторов операций с тем же ключом идемпотентности, значения метки result.
+const (
+	RetryReplayed   = "replayed"    // Отдан сохраненный ответ.
+	RetryInProgress = "in_progress" // Первый запрос еще выполняется.
+	RetryKeyReused  = "key_reused"  // Ключ уже использован для другого запроса.
+)
+
+// ResultOK - значение метки code / result успешного запроса или операции.
+const ResultOK = "ok"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Запросы к API по транспорту, методу и коду результата: ok или код из pkg/errcodes.",
+	}, []string{"transport", "endpoint", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки запросов к API.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"transport", "endpoint"})
+
+	operationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operations_total",
+		Help:      "Исходы операций сервисов, например, reserve с not_enough_cash.",
+	}, []string{"operation", "result"})
+
+	operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "operation_duration_seconds",
+		Help:      "Время выполнения операций сервисов.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	transactionRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transaction_retries_total",
+		Help:      "Повторы денежных операций с тем же ключом идемпотентности.",
+	}, []string{"endpoint", "result"})
+
+	reportDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "report_generation_duration_seconds",
+		Help:      "Время формирования отчетов и выписок вместе с загрузкой в хранилище.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"kind"})
+
+	storageUploads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "storage_uploads_total",
+		Help:      "Загрузки файлов в MinIO по бакету и результату: ok или error.",
+	}, []string{"bucket", "result"})
+
+	auditFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "audit_failures_total",
+		Help:      "Запросы, которые не удалось записать в журнал аудита.",
+	}, []string{"transport", "endpoint"})
+
+	auditDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "audit_dropped_total",
+		Help:      "Отклоненные запросы, которые не записаны в журнал аудита сверх ограничения.",
+	}, []string{"transport", "endpoint"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		operationsTotal,
+		operationDuration,
+		transactionRetries,
+		reportDuration,
+		storageUploads,
+		auditFailures,
+		auditDropped,
+	)
+}
+
+// Handler отдает метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB добавляет статистику пула соединений db (sql.DB.Stats): открытые, занятые и простаивающие соединения,
+// ожидания свободного соединения. Повторная регистрация ничего не делает.
+func RegisterDB(db *sql.DB) {
+	err := registry.Register(collectors.NewDBStatsCollector(db, "wallet_service"))
+
+	var already prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &already) {
+		panic(err)
+	}
+}
+
+// ObserveRequest учитывает запрос к API, начатый в started.
+func ObserveRequest(transport, endpoint, code string, started time.Time) {
+	requestsTotal.WithLabelValues(transport, endpoint, code).Inc()
+	requestDuration.WithLabelValues(transport, endpoint).Observe(time.Since(started).Seconds())
+}
+
+// ObserveOperation учитывает операцию сервиса, начатую в started и завершенную с ошибкой err.
+func ObserveOperation(operation string, started time.Time, err error) {
+	operationsTotal.WithLabelValues(operation, operationResult(err)).Inc()
+	operationDuration.WithLabelValues(operation).Observe(time.Since(started).Seconds())
+}
+
+// ObserveTransactionRetry учитывает повтор операции endpoint с тем же ключом идемпотентности.
+func ObserveTransactionRetry(endpoint, result string) {
+	transactionRetries.WithLabelValues(endpoint, result).Inc()
+}
+
+// ObserveReport учитывает формирование отчета или выписки kind, начатое в started.
+func ObserveReport(kind string, started time.Time) {
+	reportDuration.WithLabelValues(kind).Observe(time.Since(started).Seconds())
+}
+
+// ObserveUpload учитывает загрузку файла в бакет MinIO.
+func ObserveUpload(bucket string, err error) {
+	result := ResultOK
+	if err != nil {
+		result = "error"
+	}
+
+	storageUploads.WithLabelValues(bucket, result).Inc()
+}
+
+// ObserveAuditFailure учитывает запрос к API, который не удалось записать в журнал аудита.
+func ObserveAuditFailure(transport, endpoint string) {
+	auditFailures.WithLabelValues(transport, endpoint).Inc()
+}
+
+// ObserveAuditDropped учитывает отклоненный запрос к API, который не записан в журнал аудита сверх ограничения.
+func ObserveAuditDropped(transport, endpoint string) {
+	auditDropped.WithLabelValues(transport, endpoint).Inc()
+}
+
+// operationResults сопоставляет ошибкам сервисов коды из pkg/errcodes, как и обработчики API.
+var operationResults = []struct {
+	err    error
+	result string
+}{
+	{servicesErrors.ErrWalletNotFound, errcodes.WalletNotFound},
+	{servicesErrors.ErrNotEnoughCash, errcodes.NotEnoughCash},
+	{servicesErrors.ErrOrderNotFound, errcodes.OrderNotFound},
+	{servicesErrors.ErrAmbiguousOrder, errcodes.AmbiguousOrder},
+	{servicesErrors.ErrDuplicateService, errcodes.DuplicateService},
+	{servicesErrors.ErrOrderExists, errcodes.OrderExists},
+	{servicesErrors.ErrInvalidPeriod, errcodes.InvalidPeriod},
+	{servicesErrors.ErrPeriodTooLong, errcodes.PeriodTooLong},
+	{servicesErrors.ErrStatementNotFound, errcodes.StatementNotFound},
+}
+
+func operationResult(err error) string {
+	if err == nil {
+		return ResultOK
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+
+	for _, r := range operationResults {
+		if errors.Is(err, r.err) {
+			return r.result
+		}
+	}
+
+	return errcodes.InternalError
+}
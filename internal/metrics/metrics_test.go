@@ -0,0 +1,71 @@
+package metrics_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestObserveOperation(t *testing.T) {
+	started := time.Now()
+
+	metrics.ObserveOperation("test_reserve", started, nil)
+	metrics.ObserveOperation("test_reserve", started, fmt.Errorf("reserve: %w", servicesErrors.ErrNotEnoughCash))
+	metrics.ObserveOperation("test_reserve", started, context.Canceled)
+	metrics.ObserveOperation("test_reserve", started, sql.ErrConnDone)
+
+	body := scrape(t)
+
+	assert.Contains(t, body, `wallet_operations_total{operation="test_reserve",result="ok"} 1`)
+	assert.Contains(t, body, `wallet_operations_total{operation="test_reserve",result="not_enough_cash"} 1`)
+	assert.Contains(t, body, `wallet_operations_total{operation="test_reserve",result="canceled"} 1`)
+	assert.Contains(t, body, `wallet_operations_total{operation="test_reserve",result="internal_error"} 1`)
+	assert.Contains(t, body, `wallet_operation_duration_seconds_count{operation="test_reserve"} 4`)
+}
+
+func TestRegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	metrics.RegisterDB(db)
+	// Повторная регистрация того же пула не паникует.
+	metrics.RegisterDB(db)
+
+	assert.Contains(t, scrape(t), `go_sql_open_connections{db_name="wallet_service"}`)
+}
+
+func TestObserveAuditFailure(t *testing.T) {
+	metrics.ObserveAuditFailure(metrics.TransportHTTP, "/v1/test_add")
+
+	assert.Contains(t, scrape(t), `wallet_audit_failures_total{endpoint="/v1/test_add",transport="http"} 1`)
+}
+
+func TestObserveAuditDropped(t *testing.T) {
+	metrics.ObserveAuditDropped(metrics.TransportHTTP, "/v1/test_add")
+
+	assert.Contains(t, scrape(t), `wallet_audit_dropped_total{endpoint="/v1/test_add",transport="http"} 1`)
+}
@@ -1,6 +1,7 @@
 package minio
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/minio/minio-go/v7"
@@ -18,6 +19,8 @@ func Must(
 		&minio.Options{
 			Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
 			Secure: false,
+			// Регион задан явно, чтобы подпись ссылок не требовала запроса к minio.
+			Region: "us-east-1",
 		},
 	)
 
@@ -27,3 +30,19 @@ func Must(
 
 	return client
 }
+
+// CheckBuckets проверяет, что minio доступен и в нем есть бакеты buckets.
+func CheckBuckets(ctx context.Context, client *minio.Client, buckets ...string) error {
+	for _, bucket := range buckets {
+		ok, err := client.BucketExists(ctx, bucket)
+		if err != nil {
+			return fmt.Errorf("bucket exists %s: %w", bucket, err)
+		}
+
+		if !ok {
+			return fmt.Errorf("bucket %s not found", bucket)
+		}
+	}
+
+	return nil
+}
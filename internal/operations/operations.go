@@ -0,0 +1,64 @@
+package operations
+
+// Операции API. Один и тот же запрос по http v1, v2 и gRPC – одна операция, поэтому, например, ограничения частоты
+// задаются и считаются по операции, а не по пути или методу транспорта. Имена совпадают с методами v1.
+const (
+	Add                   = "add"
+	Reserve               = "reserve"
+	ReserveCart           = "reserveCart"
+	WriteOff              = "writeOff"
+	Cancel                = "cancel"
+	GetBalance            = "getBalance"
+	GetBalances           = "getBalances"
+	GetBalanceAt          = "getBalanceAt"
+	GetDailyBalances      = "getDailyBalances"
+	GetTransactions       = "getTransactions"
+	GetTransactionsByTime = "getTransactionsByTime"
+	GetTransaction        = "getTransaction"
+	GetHistory            = "getHistory"
+	StreamEvents          = "streamEvents"
+	ExportStatement       = "exportStatement"
+	GetStatements         = "getStatements"
+	GetStatement          = "getStatement"
+	GetReport             = "getReport"
+	AddWebhook            = "addWebhook"
+	GetFailedDeliveries   = "getFailedDeliveries"
+	ReplayDelivery        = "replayDelivery"
+	GetAuditLog           = "getAuditLog"
+)
+
+// Operations - все известные операции.
+var Operations = []string{
+	Add,
+	Reserve,
+	ReserveCart,
+	WriteOff,
+	Cancel,
+	GetBalance,
+	GetBalances,
+	GetBalanceAt,
+	GetDailyBalances,
+	GetTransactions,
+	GetTransactionsByTime,
+	GetTransaction,
+	GetHistory,
+	StreamEvents,
+	ExportStatement,
+	GetStatements,
+	GetStatement,
+	GetReport,
+	AddWebhook,
+	GetFailedDeliveries,
+	ReplayDelivery,
+	GetAuditLog,
+}
+
+func IsOperation(operation string) bool {
+	for _, o := range Operations {
+		if o == operation {
+			return true
+		}
+	}
+
+	return false
+}
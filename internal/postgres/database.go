@@ -17,11 +17,21 @@ type Database interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
+// Open открывает пул соединений с базой, не подключаясь к ней: соединения устанавливаются при первых запросах.
+func Open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening pgx driver: %w", err)
+	}
+
+	return db, nil
+}
+
 // MustConnect устанавливает соединение с базой.
 func MustConnect(dsn string) *sql.DB {
-	db, err := sql.Open("pgx", dsn)
+	db, err := Open(dsn)
 	if err != nil {
-		panic(fmt.Errorf("opening pgx driver: %w", err))
+		panic(err)
 	}
 
 	if err := db.Ping(); err != nil {
@@ -33,7 +43,44 @@ func MustConnect(dsn string) *sql.DB {
 
 // MustMigrate применяет миграции из переданной директории
 func MustMigrate(db *sql.DB) {
+	if err := Migrate(db); err != nil {
+		panic(err)
+	}
+}
+
+// Migrate применяет миграции из директории миграций.
+func Migrate(db *sql.DB) error {
 	if err := goose.Up(db, pathMigration); err != nil {
-		panic(fmt.Errorf("apply migrations: %w", err))
+		return fmt.Errorf("apply migrations: %w", err)
 	}
+
+	return nil
+}
+
+// CheckMigrations проверяет, что к базе применены все миграции из директории миграций. Версия базы новее последней
+// миграции тоже подходит: ее уже обновила более новая реплика.
+func CheckMigrations(ctx context.Context, db *sql.DB) error {
+	migrations, err := goose.CollectMigrations(pathMigration, 0, goose.MaxVersion)
+	if err != nil {
+		return fmt.Errorf("collect migrations: %w", err)
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		return fmt.Errorf("last migration: %w", err)
+	}
+
+	const query = `select coalesce(max(version_id), 0) from goose_db_version where is_applied`
+
+	var version int64
+
+	if err := db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return fmt.Errorf("get db version: %w", err)
+	}
+
+	if version < last.Version {
+		return fmt.Errorf("db version %d is behind migration %d", version, last.Version)
+	}
+
+	return nil
 }
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+var (
+	// spaces схлопываются в запросе, чтобы многострочные запросы читались в спане.
+	spaces = regexp.MustCompile(`\s+`)
+
+	// closureSuffix - суффикс замыкания в имени функции, например, .func1.
+	closureSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+)
+
+type tracedDatabase struct {
+	db Database
+}
+
+// Traced оборачивает db так, что каждый запрос пишется в отдельный спан. Спан называется по методу
+// репозитория, который выполняет запрос, например, wallet.Reserve, а текст запроса лежит в атрибуте db.statement.
+// Спан QueryContext заканчивается, когда получен ответ, чтение строк в него не входит.
+func Traced(db Database) Database {
+	if _, ok := db.(*tracedDatabase); ok {
+		return db
+	}
+
+	return &tracedDatabase{db: db}
+}
+
+func (d *tracedDatabase) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+
+	row := d.db.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+
+	return row
+}
+
+func (d *tracedDatabase) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+
+	res, err := d.db.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+func (d *tracedDatabase) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+// Commit завершает транзакцию tx в отдельном спане: ожидание фиксации тоже бывает долгим.
+func Commit(ctx context.Context, tx *sql.Tx) error {
+	_, span := tracing.Start(ctx, "postgres.Commit", attribute.String("db.system", "postgresql"))
+
+	err := tx.Commit()
+	tracing.End(span, err)
+
+	return err
+}
+
+// startQuery начинает спан запроса query. Если спаны не выгружаются, то запрос не разбирается: startQuery
+// вызывается на каждый запрос к базе.
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	if !tracing.Enabled() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	statement := strings.TrimSpace(spaces.ReplaceAllString(query, " "))
+
+	operation := statement
+	if i := strings.IndexByte(statement, ' '); i > 0 {
+		operation = statement[:i]
+	}
+
+	return tracing.Start(
+		ctx,
+		statementName(),
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", strings.ToLower(operation)),
+		attribute.String("db.statement", statement),
+	)
+}
+
+// statementName - имя запроса по методу репозитория, который его выполняет: пакет и метод.
+func statementName() string {
+	// startQuery, метод tracedDatabase, метод репозитория.
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "postgres.Query"
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "postgres.Query"
+	}
+
+	// github.com/frutonanny/wallet-service/internal/repositories/wallet.(*Repository).Reserve
+	name := closureSuffix.ReplaceAllString(fn.Name(), "")
+	name = name[strings.LastIndexByte(name, '/')+1:]
+
+	parts := strings.Split(name, ".")
+
+	return fmt.Sprintf("%s.%s", parts[0], parts[len(parts)-1])
+}
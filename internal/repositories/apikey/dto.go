@@ -0,0 +1,11 @@
+package apikey
+
+import "time"
+
+type Key struct {
+	ID        int64
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
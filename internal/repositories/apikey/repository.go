@@ -0,0 +1,138 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// CreateKey - сохраняет ключ клиента name с правами scopes. Сам ключ не сохраняется, только его хеш.
+func (r *Repository) CreateKey(ctx context.Context, name, keyHash string, scopes []string) (int64, error) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	var id int64
+
+	query := `insert into api_keys(name, key_hash, scopes) values($1, $2, $3) returning id;`
+
+	if err := r.db.QueryRowContext(ctx, query, name, keyHash, scopes).Scan(&id); err != nil {
+		return 0, fmt.Errorf("query row: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetActiveKey - отдает неотозванный ключ по хешу.
+// Если такого ключа нет, то возвращаем ошибку ErrRepoAPIKeyNotFound.
+func (r *Repository) GetActiveKey(ctx context.Context, keyHash string) (Key, error) {
+	query := `select id, name, array_to_string(scopes, ' '), created_at, revoked_at
+				from api_keys
+				where key_hash = $1 and revoked_at is null;`
+
+	k, err := scanKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Key{}, repositories.ErrRepoAPIKeyNotFound
+		}
+
+		return Key{}, fmt.Errorf("scan: %w", err)
+	}
+
+	return k, nil
+}
+
+// GetKeys - отдает все ключи, включая отозванные, в порядке выдачи.
+func (r *Repository) GetKeys(ctx context.Context) ([]Key, error) {
+	query := `select id, name, array_to_string(scopes, ' '), created_at, revoked_at
+				from api_keys
+				order by id;`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Key
+
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// RevokeKey - отзывает ключ, после этого он не принимается.
+// Если такого неотозванного ключа нет, то возвращаем ошибку ErrRepoAPIKeyNotFound.
+func (r *Repository) RevokeKey(ctx context.Context, id int64) error {
+	query := `update api_keys set revoked_at = now() where id = $1 and revoked_at is null;`
+
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %v", err)
+	}
+
+	if n == 0 {
+		return repositories.ErrRepoAPIKeyNotFound
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanKey(row scanner) (Key, error) {
+	var (
+		k         Key
+		scopes    string
+		revokedAt sql.NullTime
+	)
+
+	if err := row.Scan(&k.ID, &k.Name, &scopes, &k.CreatedAt, &revokedAt); err != nil {
+		return Key{}, err
+	}
+
+	k.Scopes = strings.Fields(scopes)
+
+	if revokedAt.Valid {
+		t := revokedAt.Time
+		k.RevokedAt = &t
+	}
+
+	return k, nil
+}
@@ -0,0 +1,78 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoAPIKey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+)
+
+var config = serviceConfig.Must(fileConfig)
+
+func TestRepository_CreateKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("created key is found by hash", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoAPIKey.New(tx)
+
+		id, err := repo.CreateKey(ctx, "orders", "hash", []string{"reserve", "write_off"})
+		require.NoError(t, err)
+
+		k, err := repo.GetActiveKey(ctx, "hash")
+		require.NoError(t, err)
+		assert.Equal(t, id, k.ID)
+		assert.Equal(t, "orders", k.Name)
+		assert.Equal(t, []string{"reserve", "write_off"}, k.Scopes)
+		assert.Nil(t, k.RevokedAt)
+	})
+
+	t.Run("unknown hash, ErrRepoAPIKeyNotFound", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		_, err := repoAPIKey.New(tx).GetActiveKey(ctx, "unknown")
+		assert.ErrorIs(t, err, repositories.ErrRepoAPIKeyNotFound)
+	})
+}
+
+func TestRepository_RevokeKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("revoked key is not active", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoAPIKey.New(tx)
+
+		id, err := repo.CreateKey(ctx, "payments", "hash", []string{"add"})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.RevokeKey(ctx, id))
+
+		_, err = repo.GetActiveKey(ctx, "hash")
+		assert.ErrorIs(t, err, repositories.ErrRepoAPIKeyNotFound)
+
+		keys, err := repo.GetKeys(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, keys)
+		assert.Equal(t, id, keys[len(keys)-1].ID)
+		assert.NotNil(t, keys[len(keys)-1].RevokedAt)
+
+		// Повторно отозвать нельзя.
+		err = repo.RevokeKey(ctx, id)
+		assert.ErrorIs(t, err, repositories.ErrRepoAPIKeyNotFound)
+	})
+}
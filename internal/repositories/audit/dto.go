@@ -0,0 +1,28 @@
+package audit
+
+import "time"
+
+type Entry struct {
+	ID                  int64
+	CreatedAt           time.Time
+	RequestID           string
+	ClientKeyID         *int64
+	ClientName          string
+	RemoteAddr          string
+	Endpoint            string
+	UserID              *int64
+	Request             []byte
+	ResultCode          string
+	TransactionIDs      []int64
+	OrderTransactionIDs []int64
+}
+
+// Filter - условия выборки записей журнала. Нулевые поля не ограничивают выборку.
+type Filter struct {
+	UserID        int64
+	ClientName    string
+	Endpoint      string
+	TransactionID int64
+	From          time.Time
+	To            time.Time
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// where дописывает к запросу условия фильтра. Плейсхолдеры нумеруются после уже переданных аргументов args.
+func (f Filter) where(args []interface{}) (string, []interface{}) {
+	var conds []string
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.UserID != 0 {
+		conds = append(conds, "a.user_id = "+arg(f.UserID))
+	}
+
+	if f.ClientName != "" {
+		conds = append(conds, "a.client_name = "+arg(f.ClientName))
+	}
+
+	if f.Endpoint != "" {
+		conds = append(conds, "a.endpoint = "+arg(f.Endpoint))
+	}
+
+	if f.TransactionID != 0 {
+		conds = append(conds, `exists (select 1 from audit_log_transactions t
+								where t.audit_id = a.id and t.transaction_id = `+arg(f.TransactionID)+`)`)
+	}
+
+	if !f.From.IsZero() {
+		conds = append(conds, "a.created_at >= "+arg(f.From))
+	}
+
+	if !f.To.IsZero() {
+		conds = append(conds, "a.created_at < "+arg(f.To))
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+
+	return " and " + strings.Join(conds, " and "), args
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// AddEntry - добавляет запись в журнал аудита и отдает ее идентификатор. Связи с транзакциями добавляются
+// отдельно, методами AddTransactions и AddOrderTransactions.
+func (r *Repository) AddEntry(ctx context.Context, e Entry) (int64, error) {
+	var id int64
+
+	query := `insert into audit_log(request_id, client_key_id, client_name, remote_addr, endpoint, user_id, request,
+					result_code)
+				values($1, $2, $3, $4, $5, $6, $7, $8)
+				returning id;`
+
+	err := r.db.QueryRowContext(ctx, query,
+		e.RequestID, e.ClientKeyID, e.ClientName, e.RemoteAddr, e.Endpoint, e.UserID, e.Request, e.ResultCode,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("query row: %v", err)
+	}
+
+	return id, nil
+}
+
+// AddTransactions - связывает запись журнала с транзакциями, созданными запросом.
+func (r *Repository) AddTransactions(ctx context.Context, auditID int64, transactionIDs []int64) error {
+	query := `insert into audit_log_transactions(audit_id, transaction_id) values($1, $2) on conflict do nothing;`
+
+	for _, id := range transactionIDs {
+		if _, err := r.db.ExecContext(ctx, query, auditID, id); err != nil {
+			return fmt.Errorf("exec: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// AddOrderTransactions - связывает запись журнала с операциями по заказам, созданными запросом.
+func (r *Repository) AddOrderTransactions(ctx context.Context, auditID int64, orderTransactionIDs []int64) error {
+	query := `insert into audit_log_order_transactions(audit_id, order_transaction_id) values($1, $2)
+				on conflict do nothing;`
+
+	for _, id := range orderTransactionIDs {
+		if _, err := r.db.ExecContext(ctx, query, auditID, id); err != nil {
+			return fmt.Errorf("exec: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// GetEntries - отдает записи журнала после afterID в порядке добавления, ограниченные фильтром filter.
+func (r *Repository) GetEntries(ctx context.Context, filter Filter, afterID, limit int64) ([]Entry, error) {
+	conds, args := filter.where([]interface{}{afterID, limit})
+
+	query := `select a.id, a.created_at, a.request_id, a.client_key_id, a.client_name, a.remote_addr, a.endpoint,
+					a.user_id, a.request, a.result_code,
+					coalesce((select string_agg(t.transaction_id::text, ' ' order by t.transaction_id)
+								from audit_log_transactions t
+								where t.audit_id = a.id), ''),
+					coalesce((select string_agg(o.order_transaction_id::text, ' ' order by o.order_transaction_id)
+								from audit_log_order_transactions o
+								where o.audit_id = a.id), '')
+				from audit_log a
+				where a.id > $1` + conds + `
+				order by a.id
+				limit $2;`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Entry
+
+	for rows.Next() {
+		var (
+			e                 Entry
+			clientKeyID       sql.NullInt64
+			userID            sql.NullInt64
+			txIDs, orderTxIDs string
+		)
+
+		err := rows.Scan(&e.ID, &e.CreatedAt, &e.RequestID, &clientKeyID, &e.ClientName, &e.RemoteAddr, &e.Endpoint,
+			&userID, &e.Request, &e.ResultCode, &txIDs, &orderTxIDs)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %v", err)
+		}
+
+		if clientKeyID.Valid {
+			e.ClientKeyID = &clientKeyID.Int64
+		}
+
+		if userID.Valid {
+			e.UserID = &userID.Int64
+		}
+
+		if e.TransactionIDs, err = parseIDs(txIDs); err != nil {
+			return nil, fmt.Errorf("parse transaction ids: %v", err)
+		}
+
+		if e.OrderTransactionIDs, err = parseIDs(orderTxIDs); err != nil {
+			return nil, fmt.Errorf("parse order transaction ids: %v", err)
+		}
+
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func parseIDs(s string) ([]int64, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(fields))
+
+	for _, f := range fields {
+		id, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
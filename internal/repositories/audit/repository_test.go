@@ -0,0 +1,117 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+)
+
+var (
+	config = serviceConfig.Must(fileConfig)
+
+	txsQuery = []string{`insert into wallets(id, user_id, balance, reservation) values(61, 8, 1000, 0);`,
+		`insert into transactions(id, wallet_id, "type", payload, amount)
+					values(9001, 61, 'incoming_transfer', '{ "type": "enrollment" }', 1000);`,
+	}
+)
+
+func TestRepository_AddEntry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("entry is linked with transactions", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, txsQuery)
+		defer cancel()
+
+		repo := repoAudit.New(tx)
+		userID := int64(8)
+
+		id, err := repo.AddEntry(ctx, repoAudit.Entry{
+			RequestID:  "request-1",
+			ClientName: "payments",
+			RemoteAddr: "127.0.0.1",
+			Endpoint:   "/v1/add",
+			UserID:     &userID,
+			Request:    []byte(`{"userID": 8, "amount": 1000}`),
+			ResultCode: "ok",
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.AddTransactions(ctx, id, []int64{9001}))
+
+		entries, err := repo.GetEntries(ctx, repoAudit.Filter{TransactionID: 9001}, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, id, entries[0].ID)
+		assert.Equal(t, "request-1", entries[0].RequestID)
+		assert.Nil(t, entries[0].ClientKeyID)
+		assert.Equal(t, &userID, entries[0].UserID)
+		assert.JSONEq(t, `{"userID": 8, "amount": 1000}`, string(entries[0].Request))
+		assert.Equal(t, []int64{9001}, entries[0].TransactionIDs)
+		assert.Empty(t, entries[0].OrderTransactionIDs)
+	})
+
+	t.Run("entries are append-only", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		id, err := repoAudit.New(tx).AddEntry(ctx, repoAudit.Entry{
+			RequestID:  "request-2",
+			ClientName: "payments",
+			RemoteAddr: "127.0.0.1",
+			Endpoint:   "/v1/add",
+			Request:    []byte(`{}`),
+			ResultCode: "ok",
+		})
+		require.NoError(t, err)
+
+		_, err = tx.ExecContext(ctx, `update audit_log set result_code = 'internal_error' where id = $1;`, id)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_GetEntries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filter and keyset", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoAudit.New(tx)
+
+		var ids []int64
+
+		for _, client := range []string{"orders", "payments", "orders", "orders"} {
+			id, err := repo.AddEntry(ctx, repoAudit.Entry{
+				RequestID:  "request",
+				ClientName: client,
+				RemoteAddr: "127.0.0.1",
+				Endpoint:   "/v1/reserve",
+				Request:    []byte(`{}`),
+				ResultCode: "ok",
+			})
+			require.NoError(t, err)
+
+			ids = append(ids, id)
+		}
+
+		entries, err := repo.GetEntries(ctx, repoAudit.Filter{ClientName: "orders"}, ids[0]-1, 2)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, ids[0], entries[0].ID)
+		assert.Equal(t, ids[2], entries[1].ID)
+
+		entries, err = repo.GetEntries(ctx, repoAudit.Filter{ClientName: "orders"}, ids[2], 2)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, ids[3], entries[0].ID)
+	})
+}
@@ -0,0 +1,15 @@
+package balance
+
+import "time"
+
+// Balance - баланс кошелька: доступные и зарезервированные средства.
+type Balance struct {
+	Available int64
+	Reserved  int64
+}
+
+// DailyBalance - баланс кошелька на конец дня Day.
+type DailyBalance struct {
+	Day time.Time
+	Balance
+}
@@ -0,0 +1,247 @@
+package balance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+// reservedAmountExpr - сумма, зарезервированная под позицию заказа, которую закрывает списание t.
+//
+// Списание может быть меньше резерва – тогда разница возвращается в доступные средства. Поэтому резерв
+// уменьшается не на сумму списания, а на сумму резервирования той же позиции. В транзакциях, записанных
+// до появления service_id в payload, услуги нет – такой заказ состоит из одной позиции.
+const reservedAmountExpr = `(select r.amount
+		from transactions r
+		where r.wallet_id = t.wallet_id
+			and r."type" = $4
+			and (r.payload ->> 'order_id')::bigint = (t.payload ->> 'order_id')::bigint
+			and (r.payload ->> 'service_id' is null
+				or t.payload ->> 'service_id' is null
+				or r.payload ->> 'service_id' = t.payload ->> 'service_id')
+			and r.created_at <= t.created_at
+		order by r.created_at desc, r.id desc
+		limit 1)`
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// GetBalanceBefore отдает баланс кошелька на момент t, то есть с учетом транзакций, созданных до t.
+// Баланс считается по журналу транзакций от ближайшего снимка, сделанного не позже t. Если снимка нет,
+// то от начала истории кошелька.
+//
+// Как транзакции меняют баланс:
+// - зачисление увеличивает доступные средства, списание без заказа – уменьшает;
+// - резервирование перекладывает сумму из доступных средств в резерв, отмена – обратно;
+// - списание убирает из резерва сумму резервирования позиции, а разницу с суммой списания возвращает
+// в доступные средства.
+func (r *Repository) GetBalanceBefore(ctx context.Context, walletID int64, t time.Time) (Balance, error) {
+	query := `with snapshot as (
+			select taken_at, available, reserved
+			from balance_snapshots
+			where wallet_id = $1 and taken_at <= $2
+			order by taken_at desc
+			limit 1
+		)
+		select
+			coalesce((select available + reserved from snapshot), 0) + coalesce(sum(case t."type"
+				when $3 then t.amount
+				when $5 then -t.amount
+				when $7 then -t.amount
+				else 0 end), 0) as funds,
+			coalesce((select reserved from snapshot), 0) + coalesce(sum(case t."type"
+				when $4 then t.amount
+				when $6 then -t.amount
+				when $5 then -` + reservedAmountExpr + `
+				else 0 end), 0) as reserved
+		from transactions t
+		where t.wallet_id = $1
+			and t.created_at < $2
+			and t.created_at >= coalesce((select taken_at from snapshot), '-infinity'::timestamptz);`
+
+	var funds, reserved int64
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		walletID,
+		t,
+		transactions.TypeAdd,
+		transactions.TypeReserve,
+		transactions.TypeWriteOff,
+		transactions.TypeCancel,
+		transactions.TypeOutgoing,
+	).Scan(&funds, &reserved)
+	if err != nil {
+		return Balance{}, fmt.Errorf("query row: %w", err)
+	}
+
+	return Balance{
+		Available: funds - reserved,
+		Reserved:  reserved,
+	}, nil
+}
+
+// GetDailyBalances отдает балансы кошелька на конец каждого дня промежутка [from, to] одним запросом. from и to –
+// начала суток. Баланс считается, как в GetBalanceBefore: от ближайшего снимка, сделанного не позже конца первого
+// дня, по журналу транзакций. Изменения баланса суммируются по дням, а нарастающий итог по дням дает баланс
+// на конец каждого дня. Транзакции между снимком и from относятся к первому дню.
+func (r *Repository) GetDailyBalances(ctx context.Context, walletID int64, from, to time.Time) ([]DailyBalance, error) {
+	query := `with snapshot as (
+			select taken_at, available, reserved
+			from balance_snapshots
+			where wallet_id = $1 and taken_at <= $2::timestamptz + interval '24 hours'
+			order by taken_at desc
+			limit 1
+		),
+		days as (
+			select d as day, d + interval '24 hours' as day_end
+			from generate_series($2::timestamptz, $8::timestamptz, interval '24 hours') d
+		),
+		changes as (
+			select
+				t.created_at,
+				case t."type"
+					when $3 then t.amount
+					when $5 then -t.amount
+					when $7 then -t.amount
+					else 0 end as funds,
+				case t."type"
+					when $4 then t.amount
+					when $6 then -t.amount
+					when $5 then -` + reservedAmountExpr + `
+					else 0 end as reserved
+			from transactions t
+			where t.wallet_id = $1
+				and t.created_at < $8::timestamptz + interval '24 hours'
+				and t.created_at >= coalesce((select taken_at from snapshot), '-infinity'::timestamptz)
+		),
+		daily as (
+			select d.day, coalesce(sum(c.funds), 0) as funds, coalesce(sum(c.reserved), 0) as reserved
+			from days d
+				left join changes c on c.created_at < d.day_end
+					and (c.created_at >= d.day or d.day = $2::timestamptz)
+			group by d.day
+		)
+		select
+			day,
+			coalesce((select available + reserved from snapshot), 0) + sum(funds) over (order by day) as funds,
+			coalesce((select reserved from snapshot), 0) + sum(reserved) over (order by day) as reserved
+		from daily
+		order by day;`
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		query,
+		walletID,
+		from,
+		transactions.TypeAdd,
+		transactions.TypeReserve,
+		transactions.TypeWriteOff,
+		transactions.TypeCancel,
+		transactions.TypeOutgoing,
+		to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []DailyBalance
+
+	for rows.Next() {
+		var (
+			day             time.Time
+			funds, reserved int64
+		)
+
+		if err := rows.Scan(&day, &funds, &reserved); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, DailyBalance{
+			Day: day.UTC(),
+			Balance: Balance{
+				Available: funds - reserved,
+				Reserved:  reserved,
+			},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddSnapshot сохраняет снимок баланса кошелька на момент takenAt. Если снимок уже есть, то он не меняется.
+func (r *Repository) AddSnapshot(ctx context.Context, walletID int64, takenAt time.Time, b Balance) error {
+	query := `insert into balance_snapshots(wallet_id, taken_at, available, reserved)
+		values ($1, $2, $3, $4)
+		on conflict (wallet_id, taken_at) do nothing;`
+
+	_, err := r.db.ExecContext(ctx, query, walletID, takenAt, b.Available, b.Reserved)
+	if err != nil {
+		return fmt.Errorf("exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetWalletsWithoutSnapshot отдает до limit идентификаторов кошельков больше afterWalletID, для которых еще нет
+// снимка на момент takenAt. Кошельки, созданные после takenAt, не попадают в выборку. Кошельки отдаются
+// по возрастанию идентификатора.
+func (r *Repository) GetWalletsWithoutSnapshot(
+	ctx context.Context,
+	takenAt time.Time,
+	afterWalletID, limit int64,
+) ([]int64, error) {
+	query := `select w.id
+		from wallets w
+		where w.id > $1
+			and w.created_at < $2
+			and not exists(select 1 from balance_snapshots s where s.wallet_id = w.id and s.taken_at = $2)
+		order by w.id
+		limit $3;`
+
+	rows, err := r.db.QueryContext(ctx, query, afterWalletID, takenAt, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package balance_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig   = "../../../config/config.local.json"
+	testWalletID = int64(52)
+)
+
+var (
+	config = serviceConfig.Must(fileConfig)
+
+	// Заказ 10 записан до появления service_id в payload.
+	txsQuery = []string{`insert into wallets(id, user_id, balance, reservation) 
+							values(52, 7, 3000, 0);`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-01 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000, '2022-11-02 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'write_off', '{ "order_id": 10 }', 1500, '2022-11-03 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 11, "service_id": 2 }', 1000, '2022-11-04 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'cancel', '{ "order_id": 11, "service_id": 2 }', 1000, '2022-11-05 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'outgoing_transfer', '{ "type": "adjustment", "reason": "fix" }', 500, '2022-11-06 12:00');`,
+	}
+)
+
+func TestRepository_GetBalanceBefore(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		queries [][]string
+		t       string
+		balance repoBalance.Balance
+	}{
+		{
+			name:    "before first transaction",
+			queries: [][]string{txsQuery},
+			t:       "2022-11-01T12:00:00Z",
+			balance: repoBalance.Balance{},
+		},
+		{
+			name:    "after reservation",
+			queries: [][]string{txsQuery},
+			t:       "2022-11-02T13:00:00Z",
+			balance: repoBalance.Balance{Available: 3000, Reserved: 2000},
+		},
+		{
+			name:    "write-off returns difference to available",
+			queries: [][]string{txsQuery},
+			t:       "2022-11-03T13:00:00Z",
+			balance: repoBalance.Balance{Available: 3500, Reserved: 0},
+		},
+		{
+			name:    "after second reservation",
+			queries: [][]string{txsQuery},
+			t:       "2022-11-04T13:00:00Z",
+			balance: repoBalance.Balance{Available: 2500, Reserved: 1000},
+		},
+		{
+			name:    "cancel returns reserve to available",
+			queries: [][]string{txsQuery},
+			t:       "2022-11-06T00:00:00Z",
+			balance: repoBalance.Balance{Available: 3500, Reserved: 0},
+		},
+		{
+			name:    "outgoing transfer decreases available",
+			queries: [][]string{txsQuery},
+			t:       "2022-12-01T00:00:00Z",
+			balance: repoBalance.Balance{Available: 3000, Reserved: 0},
+		},
+		{
+			// Снимок намеренно отличается от журнала, чтобы убедиться, что счет идет от него.
+			name: "counted from snapshot",
+			queries: [][]string{txsQuery, {
+				`insert into balance_snapshots(wallet_id, taken_at, available, reserved)
+					values(52, '2022-11-03 00:00', 10000, 2000);`,
+			}},
+			t:       "2022-11-04T13:00:00Z",
+			balance: repoBalance.Balance{Available: 9500, Reserved: 1000},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, tt.queries...)
+			defer cancel()
+
+			repo := repoBalance.New(tx)
+
+			moment, err := time.Parse(time.RFC3339, tt.t)
+			require.NoError(t, err)
+
+			balance, err := repo.GetBalanceBefore(ctx, testWalletID, moment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.balance, balance)
+		})
+	}
+}
+
+func TestRepository_GetDailyBalances(t *testing.T) {
+	ctx := context.Background()
+
+	day := func(d int) time.Time {
+		return time.Date(2022, 11, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("balances at the end of each day", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, txsQuery)
+		defer cancel()
+
+		repo := repoBalance.New(tx)
+
+		balances, err := repo.GetDailyBalances(ctx, testWalletID, day(1), day(6))
+		require.NoError(t, err)
+		assert.Equal(t, []repoBalance.DailyBalance{
+			{Day: day(1), Balance: repoBalance.Balance{Available: 5000}},
+			{Day: day(2), Balance: repoBalance.Balance{Available: 3000, Reserved: 2000}},
+			{Day: day(3), Balance: repoBalance.Balance{Available: 3500}},
+			{Day: day(4), Balance: repoBalance.Balance{Available: 2500, Reserved: 1000}},
+			{Day: day(5), Balance: repoBalance.Balance{Available: 3500}},
+			{Day: day(6), Balance: repoBalance.Balance{Available: 3000}},
+		}, balances)
+	})
+
+	t.Run("counted from snapshot", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, txsQuery, []string{
+			`insert into balance_snapshots(wallet_id, taken_at, available, reserved)
+				values(52, '2022-11-03 00:00', 10000, 2000);`,
+		})
+		defer cancel()
+
+		repo := repoBalance.New(tx)
+
+		balances, err := repo.GetDailyBalances(ctx, testWalletID, day(2), day(4))
+		require.NoError(t, err)
+		assert.Equal(t, []repoBalance.DailyBalance{
+			{Day: day(2), Balance: repoBalance.Balance{Available: 10000, Reserved: 2000}},
+			{Day: day(3), Balance: repoBalance.Balance{Available: 10500}},
+			{Day: day(4), Balance: repoBalance.Balance{Available: 9500, Reserved: 1000}},
+		}, balances)
+	})
+}
+
+func TestRepository_AddSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	takenAt := time.Date(2022, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("add snapshot successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, txsQuery)
+		defer cancel()
+
+		repo := repoBalance.New(tx)
+
+		err := repo.AddSnapshot(ctx, testWalletID, takenAt, repoBalance.Balance{Available: 3000, Reserved: 2000})
+		require.NoError(t, err)
+
+		// Повторный снимок на тот же момент ничего не меняет.
+		err = repo.AddSnapshot(ctx, testWalletID, takenAt, repoBalance.Balance{Available: 1, Reserved: 1})
+		require.NoError(t, err)
+
+		balance, err := repo.GetBalanceBefore(ctx, testWalletID, takenAt)
+		require.NoError(t, err)
+		assert.Equal(t, repoBalance.Balance{Available: 3000, Reserved: 2000}, balance)
+
+		// Кошелек со снимком больше не попадает в выборку.
+		wallets, err := repo.GetWalletsWithoutSnapshot(ctx, takenAt, 0, 10)
+		require.NoError(t, err)
+		assert.NotContains(t, wallets, testWalletID)
+	})
+}
+
+func TestRepository_GetWalletsWithoutSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	takenAt := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	query := []string{
+		`insert into wallets(id, user_id, created_at) values(52, 7, '2022-10-01 12:00');`,
+		`insert into wallets(id, user_id, created_at) values(53, 8, '2022-10-01 12:00');`,
+		`insert into wallets(id, user_id, created_at) values(54, 9, '2022-12-15 12:00');`,
+		`insert into balance_snapshots(wallet_id, taken_at, available, reserved)
+					values(53, '2022-12-01 00:00', 0, 0);`,
+	}
+
+	t.Run("get wallets without snapshot", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoBalance.New(tx)
+
+		// Кошелек 53 уже со снимком, кошелек 54 создан после момента снимка.
+		wallets, err := repo.GetWalletsWithoutSnapshot(ctx, takenAt, 51, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{52}, wallets)
+	})
+}
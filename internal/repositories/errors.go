@@ -7,5 +7,11 @@ var (
 	ErrRepoNotEnoughCash         = errors.New("not enough cash")
 	ErrRepoWalletNotFound        = errors.New("wallet not found")
 	ErrRepoOrderNotFound         = errors.New("order not found")
+	ErrRepoOrderExists           = errors.New("order already exists")
 	ErrRepoNotEnoughReservedCash = errors.New("not enough reserved cash")
+	ErrRepoAmbiguousOrder        = errors.New("order has several items")
+	ErrRepoTransactionNotFound   = errors.New("transaction not found")
+	ErrRepoStatementNotFound     = errors.New("statement not found")
+	ErrRepoDeliveryNotFound      = errors.New("delivery not found")
+	ErrRepoAPIKeyNotFound        = errors.New("api key not found")
 )
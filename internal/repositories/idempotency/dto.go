@@ -0,0 +1,10 @@
+package idempotency
+
+// Key - ключ идемпотентности запроса. Completed = false, пока запрос с этим ключом выполняется.
+type Key struct {
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Response    []byte
+}
@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// Reserve - занимает ключ key клиента clientKeyID для запроса path с телом, хеш которого requestHash. Отдает true,
+// если ключ занят этим вызовом. Иначе отдает false и уже сохраненный ключ. Ключи с ответом старше ttl и ключи
+// без ответа старше lease считаются свободными: запрос, занявший такой ключ, уже не выполняется.
+func (r *Repository) Reserve(
+	ctx context.Context,
+	clientKeyID int64,
+	key, path, requestHash string,
+	ttl, lease time.Duration,
+) (bool, Key, error) {
+	query := `insert into idempotency_keys(key, path, request_hash, client_key_id) values($1, $2, $3, $5)
+				on conflict (client_key_id, key, path) do update
+					set request_hash = excluded.request_hash,
+						status_code  = null,
+						content_type = null,
+						response     = null,
+						created_at   = now()
+					where idempotency_keys.created_at < now() - make_interval(secs => $4)
+						or (idempotency_keys.status_code is null
+							and idempotency_keys.created_at < now() - make_interval(secs => $6))
+				returning key;`
+
+	var reserved string
+
+	err := r.db.QueryRowContext(ctx, query, key, path, requestHash, ttl.Seconds(), clientKeyID, lease.Seconds()).
+		Scan(&reserved)
+	if err == nil {
+		return true, Key{}, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return false, Key{}, fmt.Errorf("query row: %v", err)
+	}
+
+	var (
+		k           Key
+		statusCode  sql.NullInt32
+		contentType sql.NullString
+	)
+
+	query = `select request_hash, status_code, content_type, response
+				from idempotency_keys
+				where key = $1 and path = $2 and client_key_id = $3;`
+
+	if err := r.db.QueryRowContext(ctx, query, key, path, clientKeyID).
+		Scan(&k.RequestHash, &statusCode, &contentType, &k.Response); err != nil {
+		return false, Key{}, fmt.Errorf("query row: %v", err)
+	}
+
+	k.Completed = statusCode.Valid
+	k.StatusCode = int(statusCode.Int32)
+	k.ContentType = contentType.String
+
+	return false, k, nil
+}
+
+// Complete - сохраняет ответ на запрос с ключом key клиента clientKeyID.
+func (r *Repository) Complete(
+	ctx context.Context,
+	clientKeyID int64,
+	key, path string,
+	statusCode int,
+	contentType string,
+	response []byte,
+) error {
+	query := `update idempotency_keys
+				set status_code = $3, content_type = $4, response = $5
+				where key = $1 and path = $2 and client_key_id = $6;`
+
+	_, err := r.db.ExecContext(ctx, query, key, path, statusCode, contentType, response, clientKeyID)
+	if err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
+
+// Release - освобождает ключ запроса, который так и не получил ответа, чтобы запрос можно было повторить.
+func (r *Repository) Release(ctx context.Context, clientKeyID int64, key, path string) error {
+	query := `delete from idempotency_keys
+				where key = $1 and path = $2 and client_key_id = $3 and status_code is null;`
+
+	if _, err := r.db.ExecContext(ctx, query, key, path, clientKeyID); err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+
+	testClientKeyID  = int64(100001)
+	otherClientKeyID = int64(100002)
+	testKey          = "3f1c2a4e-key"
+	testPath         = "/v1/reserve"
+	testHash         = "hash"
+	testTTL          = 24 * time.Hour
+	testLease        = time.Minute
+)
+
+var (
+	config = serviceConfig.Must(fileConfig)
+
+	query = []string{
+		`insert into api_keys(id, name, key_hash) values(100001, 'orders', 'hash1'), (100002, 'billing', 'hash2');`,
+		`insert into idempotency_keys(client_key_id, key, path, request_hash, created_at)
+					values(100001, 'stale-key', '/v1/reserve', 'old-hash', now() - interval '2 days');`,
+		`insert into idempotency_keys(client_key_id, key, path, request_hash, created_at)
+					values(100001, 'abandoned-key', '/v1/reserve', 'old-hash', now() - interval '2 minutes');`,
+		`insert into idempotency_keys(client_key_id, key, path, request_hash, status_code, content_type, response,
+					created_at)
+					values(100001, 'completed-key', '/v1/reserve', 'old-hash', 200, 'application/json', '{}',
+					now() - interval '2 minutes');`,
+	}
+)
+
+func TestRepository_Reserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reserve, complete and replay", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, _, err := repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+
+		// Пока ответа нет, ключ занят незавершенным запросом.
+		reserved, key, err := repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.False(t, key.Completed)
+
+		err = repo.Complete(ctx, testClientKeyID, testKey, testPath, 200, "application/json", []byte(`{"data":{}}`))
+		require.NoError(t, err)
+
+		reserved, key, err = repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, repoIdempotency.Key{
+			RequestHash: testHash,
+			Completed:   true,
+			StatusCode:  200,
+			ContentType: "application/json",
+			Response:    []byte(`{"data":{}}`),
+		}, key)
+	})
+
+	t.Run("same key of another client is reserved separately", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, _, err := repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		err = repo.Complete(ctx, testClientKeyID, testKey, testPath, 200, "application/json", []byte(`{"data":{}}`))
+		require.NoError(t, err)
+
+		// Другой клиент с тем же ключом не получает чужой ответ, его запрос выполняется.
+		reserved, _, err = repo.Reserve(ctx, otherClientKeyID, testKey, testPath, "other-hash", testTTL, testLease)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("stale key is reserved again", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, _, err := repo.Reserve(ctx, testClientKeyID, "stale-key", testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("abandoned key is reserved again after lease", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, _, err := repo.Reserve(ctx, testClientKeyID, "abandoned-key", testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+
+	t.Run("completed key is kept after lease", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, key, err := repo.Reserve(ctx, testClientKeyID, "completed-key", testPath, testHash, testTTL,
+			testLease)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.True(t, key.Completed)
+	})
+}
+
+func TestRepository_Release(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("released key is reserved again", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoIdempotency.New(tx)
+
+		reserved, _, err := repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		require.NoError(t, repo.Release(ctx, testClientKeyID, testKey, testPath))
+
+		reserved, _, err = repo.Reserve(ctx, testClientKeyID, testKey, testPath, testHash, testTTL, testLease)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
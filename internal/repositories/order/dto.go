@@ -0,0 +1,22 @@
+package order
+
+import "time"
+
+// Hold - открытый резерв: позиция заказа, средства под которую зарезервированы, но еще не списаны и не возвращены.
+type Hold struct {
+	WalletID   int64
+	ExternalID int64
+	ServiceID  int64
+	Amount     int64
+	CreatedAt  time.Time
+}
+
+// Order - позиция заказа.
+type Order struct {
+	ExternalID int64
+	ServiceID  int64
+	Status     string
+	Amount     int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
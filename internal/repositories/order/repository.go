@@ -6,21 +6,28 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgconn"
+
+	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 )
 
+// externalServiceIndex - уникальный индекс позиций заказа по внешнему заказу и услуге.
+const externalServiceIndex = "orders_external_service_idx"
+
 type Repository struct {
 	db postgres.Database
 }
 
 func New(db postgres.Database) *Repository {
 	return &Repository{
-		db: db,
+		db: postgres.Traced(db),
 	}
 }
 
 // CreateOrder создает заказ.
+// Если у заказа externalID уже есть позиция с услугой serviceID, то возвращаем ошибку ErrRepoOrderExists.
 func (r *Repository) CreateOrder(
 	ctx context.Context,
 	walletID,
@@ -34,8 +41,13 @@ func (r *Repository) CreateOrder(
 	query := `insert into orders(wallet_id, external_id, service_id, status, amount) 
 				values($1, $2, $3, $4, $5) returning id;`
 
+	var pgErr *pgconn.PgError
 	err := r.db.QueryRowContext(ctx, query, walletID, externalID, serviceID, status, amount).Scan(&orderID)
 	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == externalServiceIndex {
+			return 0, repositories.ErrRepoOrderExists
+		}
+
 		return 0, fmt.Errorf("query row: %v", err)
 	}
 
@@ -68,13 +80,15 @@ func (r *Repository) GetOrderByServiceID(
 // GetOrder проверяет есть ли заказ с переданным идентификатором внешнего заказа и возращает
 // идентификатор, статус заказа и его стоимость.
 // Если заказа нет, то возвращаем ошибку ErrRepoOrderNotFound.
+// Если заказ состоит из нескольких позиций (услуг), то возвращаем ошибку ErrRepoAmbiguousOrder – позицию нужно
+// искать через GetOrderByServiceID.
 func (r *Repository) GetOrder(ctx context.Context, externalID int64) (int64, string, int64, error) {
-	var orderID, amount int64
+	var orderID, amount, items int64
 	var status string
 
-	query := `select id, status, amount from orders where external_id = $1;`
+	query := `select id, status, amount, count(*) over () from orders where external_id = $1 limit 1;`
 
-	err := r.db.QueryRowContext(ctx, query, externalID).Scan(&orderID, &status, &amount)
+	err := r.db.QueryRowContext(ctx, query, externalID).Scan(&orderID, &status, &amount, &items)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return 0, "", 0, repositories.ErrRepoOrderNotFound
@@ -82,6 +96,10 @@ func (r *Repository) GetOrder(ctx context.Context, externalID int64) (int64, str
 		return 0, "", 0, fmt.Errorf("query row: %v", err)
 	}
 
+	if items > 1 {
+		return 0, "", 0, repositories.ErrRepoAmbiguousOrder
+	}
+
 	return orderID, status, amount, nil
 }
 
@@ -140,3 +158,74 @@ func (r *Repository) AddOrderTransactions(ctx context.Context, orderID int64, na
 
 	return id, nil
 }
+
+// GetHolds отдает открытые резервы переданных кошельков от старых к новым.
+func (r *Repository) GetHolds(ctx context.Context, walletIDs []int64) ([]Hold, error) {
+	query := `select wallet_id, external_id, service_id, amount, created_at
+		from orders
+		where wallet_id = any($1) and status = $2
+		order by wallet_id, created_at, id;`
+
+	rows, err := r.db.QueryContext(ctx, query, walletIDs, orders.StatusReserved)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Hold
+
+	for rows.Next() {
+		h := Hold{}
+
+		if err := rows.Scan(&h.WalletID, &h.ExternalID, &h.ServiceID, &h.Amount, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, h)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetOrders отдает до limit последних позиций заказов кошелька от новых к старым.
+func (r *Repository) GetOrders(ctx context.Context, walletID, limit int64) ([]Order, error) {
+	query := `select external_id, service_id, status, amount, created_at, updated_at
+		from orders
+		where wallet_id = $1
+		order by created_at desc, id desc
+		limit $2;`
+
+	rows, err := r.db.QueryContext(ctx, query, walletID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Order
+
+	for rows.Next() {
+		o := Order{}
+
+		if err := rows.Scan(&o.ExternalID, &o.ServiceID, &o.Status, &o.Amount, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -10,6 +10,7 @@ import (
 	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
 	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
 	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
 	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
 	"github.com/frutonanny/wallet-service/internal/transactions"
@@ -66,6 +67,22 @@ func TestRepository_CreateOrder(t *testing.T) {
 		_, err := repo.CreateOrder(ctx, testFailed, testExternalID, testServiceID, testAmount, testStatusR)
 		assert.Error(t, err)
 	})
+
+	t.Run("create order failed, ErrRepoOrderExists", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoOrder.New(tx)
+
+		walletID := createWallet(ctx, t, tx, testUserID)
+
+		_, err := repo.CreateOrder(ctx, walletID, testExternalID, testServiceID, testAmount, testStatusR)
+		require.NoError(t, err)
+
+		// Вторая позиция того же заказа с той же услугой.
+		_, err = repo.CreateOrder(ctx, walletID, testExternalID, testServiceID, testAmount, testStatusR)
+		assert.ErrorIs(t, err, repositories.ErrRepoOrderExists)
+	})
 }
 
 func TestRepository_GetOrderByServiceID(t *testing.T) {
@@ -138,6 +155,34 @@ func TestRepository_GetOrder(t *testing.T) {
 		_, _, _, err := repo.GetOrder(ctx, testExternalID)
 		require.Error(t, err)
 	})
+
+	t.Run("get order failed, order has several items", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoOrder.New(tx)
+
+		// Создаем кошелек.
+		walletID := createWallet(ctx, t, tx, testUserID)
+
+		// Создаем заказ из двух позиций (корзина) с общим внешним идентификатором.
+		_, err := repo.CreateOrder(ctx, walletID, testExternalID, testServiceID, testAmount, testStatusR)
+		require.NoError(t, err)
+
+		_, err = repo.CreateOrder(ctx, walletID, testExternalID, testServiceID+1, testAmount, testStatusR)
+		require.NoError(t, err)
+
+		// Без услуги позицию заказа однозначно определить нельзя.
+		_, _, _, err = repo.GetOrder(ctx, testExternalID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, repositories.ErrRepoAmbiguousOrder)
+
+		// По услуге позиция находится.
+		_, status, amount, err := repo.GetOrderByServiceID(ctx, testExternalID, testServiceID+1)
+		require.NoError(t, err)
+		assert.EqualValues(t, testStatusR, status)
+		assert.EqualValues(t, testAmount, amount)
+	})
 }
 
 func TestRepository_UpdateOrder(t *testing.T) {
@@ -257,6 +302,84 @@ func TestRepository_AddOrderTransactions(t *testing.T) {
 	})
 }
 
+func TestRepository_GetHolds(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into wallets(id, user_id) values(52, 7);`,
+		`insert into wallets(id, user_id) values(53, 8);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 10, 1, 'reserved', 2000, '2022-11-02 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 11, 2, 'written_off', 1000, '2022-11-01 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 12, 3, 'reserved', 500, '2022-11-01 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(53, 13, 1, 'reserved', 700, '2022-11-03 12:00');`,
+	}
+
+	t.Run("get holds successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoOrder.New(tx)
+
+		// Списанный заказ 11 не попадает в ответ, резервы отдаются от старых к новым.
+		holds, err := repo.GetHolds(ctx, []int64{52})
+		require.NoError(t, err)
+		require.Len(t, holds, 2)
+		assert.EqualValues(t, 12, holds[0].ExternalID)
+		assert.EqualValues(t, 3, holds[0].ServiceID)
+		assert.EqualValues(t, 500, holds[0].Amount)
+		assert.EqualValues(t, 10, holds[1].ExternalID)
+	})
+
+	t.Run("get holds of several wallets", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoOrder.New(tx)
+
+		holds, err := repo.GetHolds(ctx, []int64{52, 53})
+		require.NoError(t, err)
+		assert.Len(t, holds, 3)
+	})
+}
+
+func TestRepository_GetOrders(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into wallets(id, user_id) values(52, 7);`,
+		`insert into wallets(id, user_id) values(53, 8);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 10, 1, 'reserved', 2000, '2022-11-02 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 11, 2, 'written_off', 1000, '2022-11-01 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(52, 12, 3, 'cancelled', 500, '2022-11-03 12:00');`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount, created_at)
+					values(53, 13, 1, 'reserved', 700, '2022-11-03 12:00');`,
+	}
+
+	t.Run("get orders successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoOrder.New(tx)
+
+		// Заказы отдаются от новых к старым, заказы другого кошелька не попадают в ответ.
+		result, err := repo.GetOrders(ctx, 52, 2)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.EqualValues(t, 12, result[0].ExternalID)
+		assert.Equal(t, orders.StatusCancelled, result[0].Status)
+		assert.EqualValues(t, 10, result[1].ExternalID)
+		assert.EqualValues(t, 1, result[1].ServiceID)
+		assert.EqualValues(t, 2000, result[1].Amount)
+	})
+}
+
 // createWallet создает кошелек.
 func createWallet(ctx context.Context, t *testing.T, db postgres.Database, userID int64) int64 {
 	t.Helper()
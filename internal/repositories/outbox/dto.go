@@ -0,0 +1,9 @@
+package outbox
+
+import "github.com/frutonanny/wallet-service/pkg/events"
+
+// UserEvent - событие вместе с пользователем, к которому оно относится.
+type UserEvent struct {
+	UserID int64
+	events.Event
+}
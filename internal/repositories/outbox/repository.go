@@ -0,0 +1,163 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// AddEvent - добавляет событие текущей версии схемы. Вызывается в транзакции операции, поэтому событие появляется
+// тогда и только тогда, когда операция зафиксирована.
+func (r *Repository) AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("marshal data: %v", err)
+	}
+
+	var id int64
+
+	query := `insert into outbox(event_type, version, payload, user_id) values($1, $2, $3, $4) returning id;`
+
+	err = r.db.QueryRowContext(ctx, query, eventType, events.Version, payload, data.UserID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("query row: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetUnpublished - отдает не больше limit неопубликованных событий в порядке их появления. Выбранные строки
+// блокируются до конца транзакции, а уже заблокированные пропускаются, так что несколько релеев не публикуют
+// одно и то же событие одновременно.
+func (r *Repository) GetUnpublished(ctx context.Context, limit int) ([]events.Event, error) {
+	query := `select id, event_type, version, payload, created_at
+				from outbox
+				where published_at is null
+				order by id
+				limit $1
+				for update skip locked;`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []events.Event
+
+	for rows.Next() {
+		e := events.Event{}
+
+		var payload []byte
+
+		if err := rows.Scan(&e.ID, &e.Type, &e.Version, &payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		e.Data = payload
+
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// MarkPublished - отмечает события опубликованными.
+func (r *Repository) MarkPublished(ctx context.Context, ids []int64) error {
+	query := `update outbox set published_at = now() where id = any($1);`
+
+	if _, err := r.db.ExecContext(ctx, query, ids); err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
+
+// GetLastID - отдает идентификатор последнего события, 0 – если событий нет.
+func (r *Repository) GetLastID(ctx context.Context) (int64, error) {
+	var id int64
+
+	query := `select coalesce(max(id), 0) from outbox;`
+
+	if err := r.db.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		return 0, fmt.Errorf("query row: %v", err)
+	}
+
+	return id, nil
+}
+
+// GetEventsAfter - отдает не больше limit событий всех пользователей с идентификатором больше afterID
+// по возрастанию идентификатора, независимо от того, опубликованы ли они.
+func (r *Repository) GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]UserEvent, error) {
+	query := `select user_id, id, event_type, version, payload, created_at
+				from outbox
+				where id > $1
+				order by id
+				limit $2;`
+
+	return r.getUserEvents(ctx, query, afterID, limit)
+}
+
+// GetUserEventsAfter - то же, что GetEventsAfter, но только события пользователя userID.
+func (r *Repository) GetUserEventsAfter(ctx context.Context, userID, afterID int64, limit int) ([]UserEvent, error) {
+	query := `select user_id, id, event_type, version, payload, created_at
+				from outbox
+				where user_id = $1 and id > $2
+				order by id
+				limit $3;`
+
+	return r.getUserEvents(ctx, query, userID, afterID, limit)
+}
+
+func (r *Repository) getUserEvents(ctx context.Context, query string, args ...interface{}) ([]UserEvent, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []UserEvent
+
+	for rows.Next() {
+		e := UserEvent{}
+
+		var payload []byte
+
+		if err := rows.Scan(&e.UserID, &e.ID, &e.Type, &e.Version, &payload, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		e.Data = payload
+
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+)
+
+var config = serviceConfig.Must(fileConfig)
+
+func TestRepository_AddEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("add event successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoOutbox.New(tx)
+
+		data := events.WalletData{UserID: 7, TransactionID: 1, Amount: 1000, Balance: 1000}
+
+		id, err := repo.AddEvent(ctx, events.TypeIncomingTransfer, data)
+		require.NoError(t, err)
+
+		unpublished, err := repo.GetUnpublished(ctx, 100)
+		require.NoError(t, err)
+		require.NotEmpty(t, unpublished)
+
+		e := unpublished[len(unpublished)-1]
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, events.TypeIncomingTransfer, e.Type)
+		assert.Equal(t, events.Version, e.Version)
+
+		var got events.WalletData
+		require.NoError(t, json.Unmarshal(e.Data, &got))
+		assert.Equal(t, data, got)
+	})
+}
+
+func TestRepository_MarkPublished(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into outbox(id, event_type, version, payload, user_id) 
+					values(100001, 'wallet.incoming_transfer', 1, '{"userID": 7}', 7);`,
+		`insert into outbox(id, event_type, version, payload, user_id) 
+					values(100002, 'wallet.reservation', 1, '{"userID": 7}', 7);`,
+		`insert into outbox(id, event_type, version, payload, user_id, published_at) 
+					values(100003, 'wallet.write_off', 1, '{"userID": 8}', 8, now());`,
+	}
+
+	t.Run("published events are not returned", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoOutbox.New(tx)
+
+		err := repo.MarkPublished(ctx, []int64{100001})
+		require.NoError(t, err)
+
+		unpublished, err := repo.GetUnpublished(ctx, 100)
+		require.NoError(t, err)
+
+		var ids []int64
+		for _, e := range unpublished {
+			ids = append(ids, e.ID)
+		}
+
+		assert.Contains(t, ids, int64(100002))
+		assert.NotContains(t, ids, int64(100001))
+		assert.NotContains(t, ids, int64(100003))
+	})
+}
+
+func TestRepository_GetUserEventsAfter(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into outbox(id, event_type, version, payload, user_id) 
+					values(100001, 'wallet.incoming_transfer', 1, '{"userID": 7}', 7);`,
+		`insert into outbox(id, event_type, version, payload, user_id) 
+					values(100002, 'wallet.incoming_transfer', 1, '{"userID": 8}', 8);`,
+		`insert into outbox(id, event_type, version, payload, user_id, published_at) 
+					values(100003, 'wallet.reservation', 1, '{"userID": 7}', 7, now());`,
+	}
+
+	t.Run("get user events after id", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoOutbox.New(tx)
+
+		// Опубликованные события тоже попадают в выборку.
+		userEvents, err := repo.GetUserEventsAfter(ctx, 7, 100001, 10)
+		require.NoError(t, err)
+		require.Len(t, userEvents, 1)
+		assert.Equal(t, int64(100003), userEvents[0].ID)
+		assert.Equal(t, int64(7), userEvents[0].UserID)
+		assert.Equal(t, events.TypeReservation, userEvents[0].Type)
+
+		all, err := repo.GetEventsAfter(ctx, 100000, 3)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, int64(100002), all[1].ID)
+		assert.Equal(t, int64(8), all[1].UserID)
+
+		lastID, err := repo.GetLastID(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, lastID, int64(100003))
+	})
+}
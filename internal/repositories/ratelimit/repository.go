@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// Take - берет токен из корзины key, которая пополняется на rate токенов в секунду и вмещает не больше burst.
+// Новая корзина полная. Если токен взят, то отдает true. Иначе отдает false и время, через которое появится токен.
+func (r *Repository) Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	query := `insert into rate_limit_buckets(key, tokens, updated_at) values($1, $3::float8 - 1, now())
+				on conflict (key) do update
+					set tokens     = least($3::float8, rate_limit_buckets.tokens +
+										extract(epoch from now() - rate_limit_buckets.updated_at) * $2::float8) - 1,
+						updated_at = now()
+					where least($3::float8, rate_limit_buckets.tokens +
+							extract(epoch from now() - rate_limit_buckets.updated_at) * $2::float8) >= 1
+				returning key;`
+
+	var taken string
+
+	err := r.db.QueryRowContext(ctx, query, key, rate, burst).Scan(&taken)
+	if err == nil {
+		return true, 0, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return false, 0, fmt.Errorf("query row: %v", err)
+	}
+
+	var tokens float64
+
+	query = `select least($3::float8, tokens + extract(epoch from now() - updated_at) * $2::float8)
+				from rate_limit_buckets
+				where key = $1;`
+
+	if err := r.db.QueryRowContext(ctx, query, key, rate, burst).Scan(&tokens); err != nil {
+		return false, 0, fmt.Errorf("query row: %v", err)
+	}
+
+	wait := time.Duration(math.Ceil((1 - tokens) / rate * float64(time.Second)))
+	if wait < 0 {
+		wait = 0
+	}
+
+	return false, wait, nil
+}
+
+// DeleteIdle - удаляет корзины, которые не использовались дольше idle, и отдает их количество.
+// Такие корзины уже полные, и новая корзина ничем от них не отличается.
+func (r *Repository) DeleteIdle(ctx context.Context, idle time.Duration) (int64, error) {
+	query := `delete from rate_limit_buckets where updated_at < now() - make_interval(secs => $1);`
+
+	res, err := r.db.ExecContext(ctx, query, idle.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("exec: %v", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %v", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,74 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	repoRateLimit "github.com/frutonanny/wallet-service/internal/repositories/ratelimit"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+)
+
+var config = serviceConfig.Must(fileConfig)
+
+func TestRepository_Take(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("bucket allows burst and then throttles", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoRateLimit.New(tx)
+
+		for i := 0; i < 3; i++ {
+			taken, _, err := repo.Take(ctx, "client:1:/v1/getTransactions", 1, 3)
+			require.NoError(t, err)
+			assert.True(t, taken, i)
+		}
+
+		taken, wait, err := repo.Take(ctx, "client:1:/v1/getTransactions", 1, 3)
+		require.NoError(t, err)
+		assert.False(t, taken)
+		// Внутри транзакции время не идет, поэтому до нового токена ровно секунда.
+		assert.Equal(t, time.Second, wait)
+
+		// Корзины независимы.
+		taken, _, err = repo.Take(ctx, "client:2:/v1/getTransactions", 1, 3)
+		require.NoError(t, err)
+		assert.True(t, taken)
+	})
+
+	t.Run("bucket is refilled over time", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, []string{
+			`insert into rate_limit_buckets(key, tokens, updated_at)
+					values('user:1:/v1/add', 0, now() - interval '2 seconds');`,
+		})
+		defer cancel()
+
+		taken, _, err := repoRateLimit.New(tx).Take(ctx, "user:1:/v1/add", 1, 3)
+		require.NoError(t, err)
+		assert.True(t, taken)
+	})
+}
+
+func TestRepository_DeleteIdle(t *testing.T) {
+	ctx := context.Background()
+
+	tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, []string{
+		`insert into rate_limit_buckets(key, tokens, updated_at) values('idle', 1, now() - interval '2 hours');`,
+		`insert into rate_limit_buckets(key, tokens, updated_at) values('active', 1, now());`,
+	})
+	defer cancel()
+
+	n, err := repoRateLimit.New(tx).DeleteIdle(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
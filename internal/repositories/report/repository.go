@@ -18,7 +18,7 @@ type Repository struct {
 
 func New(db postgres.Database) *Repository {
 	return &Repository{
-		db: db,
+		db: postgres.Traced(db),
 	}
 }
 
@@ -0,0 +1,18 @@
+package statement
+
+import "time"
+
+// Wallet - кошелек, для которого нужно сформировать выписку.
+type Wallet struct {
+	ID     int64
+	UserID int64
+}
+
+type Statement struct {
+	WalletID       int64
+	Period         string
+	ObjectName     string
+	OpeningBalance int64
+	ClosingBalance int64
+	CreatedAt      time.Time
+}
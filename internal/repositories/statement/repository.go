@@ -0,0 +1,153 @@
+package statement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+)
+
+const (
+	PeriodLayout = "2006-01"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// GetWalletsWithoutStatement отдает до limit кошельков с идентификатором больше afterWalletID, для которых еще нет
+// выписки за период period. Кошельки, созданные после createdBefore, не попадают в выборку – у них нет операций
+// за период. Кошельки отдаются по возрастанию идентификатора.
+func (r *Repository) GetWalletsWithoutStatement(
+	ctx context.Context,
+	period string,
+	createdBefore time.Time,
+	afterWalletID, limit int64,
+) ([]Wallet, error) {
+	query := `select w.id, w.user_id
+		from wallets w
+		where w.id > $1
+			and w.created_at < $2
+			and not exists(select 1 from statements s where s.wallet_id = w.id and s.period = $3)
+		order by w.id
+		limit $4;`
+
+	rows, err := r.db.QueryContext(ctx, query, afterWalletID, createdBefore, period, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Wallet
+
+	for rows.Next() {
+		w := Wallet{}
+
+		if err := rows.Scan(&w.ID, &w.UserID); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddStatement сохраняет информацию о выписке. Если выписка кошелька за этот период уже есть, то она заменяется.
+func (r *Repository) AddStatement(ctx context.Context, s Statement) error {
+	query := `insert into statements(wallet_id, "period", object_name, opening_balance, closing_balance)
+		values ($1, $2, $3, $4, $5)
+		on conflict (wallet_id, period) do update
+			set object_name     = excluded.object_name,
+				opening_balance = excluded.opening_balance,
+				closing_balance = excluded.closing_balance,
+				created_at      = now();`
+
+	_, err := r.db.ExecContext(ctx, query, s.WalletID, s.Period, s.ObjectName, s.OpeningBalance, s.ClosingBalance)
+	if err != nil {
+		return fmt.Errorf("exec query: %w", err)
+	}
+
+	return nil
+}
+
+// GetStatements отдает выписки кошелька от новых периодов к старым.
+func (r *Repository) GetStatements(ctx context.Context, walletID int64) ([]Statement, error) {
+	query := `select wallet_id, "period", object_name, opening_balance, closing_balance, created_at
+		from statements
+		where wallet_id = $1
+		order by period desc;`
+
+	rows, err := r.db.QueryContext(ctx, query, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Statement
+
+	for rows.Next() {
+		s, err := scanStatement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetStatement отдает выписку кошелька за период period.
+// Если выписки нет, то возвращаем ошибку ErrRepoStatementNotFound.
+func (r *Repository) GetStatement(ctx context.Context, walletID int64, period string) (Statement, error) {
+	query := `select wallet_id, "period", object_name, opening_balance, closing_balance, created_at
+		from statements
+		where wallet_id = $1 and period = $2;`
+
+	s, err := scanStatement(r.db.QueryRowContext(ctx, query, walletID, period))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Statement{}, repositories.ErrRepoStatementNotFound
+		}
+		return Statement{}, fmt.Errorf("query row: %w", err)
+	}
+
+	return s, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStatement(row scanner) (Statement, error) {
+	var s Statement
+
+	err := row.Scan(&s.WalletID, &s.Period, &s.ObjectName, &s.OpeningBalance, &s.ClosingBalance, &s.CreatedAt)
+
+	return s, err
+}
@@ -0,0 +1,147 @@
+package statement_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+)
+
+const (
+	fileConfig   = "../../../config/config.local.json"
+	testWalletID = int64(52)
+	testPeriod   = "2022-11"
+)
+
+var (
+	config = serviceConfig.Must(fileConfig)
+)
+
+func TestRepository_GetWalletsWithoutStatement(t *testing.T) {
+	ctx := context.Background()
+
+	createdBefore := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	query := []string{
+		`insert into wallets(id, user_id, created_at) values(52, 7, '2022-10-01 12:00');`,
+		`insert into wallets(id, user_id, created_at) values(53, 8, '2022-10-01 12:00');`,
+		`insert into wallets(id, user_id, created_at) values(54, 9, '2022-11-15 12:00');`,
+		`insert into wallets(id, user_id, created_at) values(55, 10, '2022-12-15 12:00');`,
+		`insert into statements(wallet_id, "period", object_name, opening_balance, closing_balance)
+					values(53, '2022-11', '2022-11/statement-8-2022-11.json', 0, 0);`,
+	}
+
+	t.Run("get wallets without statement", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		// Кошелек 53 уже с выпиской, кошелек 55 создан после окончания периода.
+		wallets, err := repo.GetWalletsWithoutStatement(ctx, testPeriod, createdBefore, 51, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []repoStatement.Wallet{{ID: 52, UserID: 7}, {ID: 54, UserID: 9}}, wallets)
+	})
+
+	t.Run("get wallets after cursor", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		wallets, err := repo.GetWalletsWithoutStatement(ctx, testPeriod, createdBefore, 52, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []repoStatement.Wallet{{ID: 54, UserID: 9}}, wallets)
+	})
+}
+
+func TestRepository_AddStatement(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{`insert into wallets(id, user_id) values(52, 7);`}
+
+	t.Run("add and replace statement", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		s := repoStatement.Statement{
+			WalletID:       testWalletID,
+			Period:         testPeriod,
+			ObjectName:     "2022-11/statement-7-2022-11.json",
+			OpeningBalance: 1000,
+			ClosingBalance: 2000,
+		}
+
+		err := repo.AddStatement(ctx, s)
+		require.NoError(t, err)
+
+		// Повторное формирование заменяет выписку.
+		s.ClosingBalance = 3000
+		err = repo.AddStatement(ctx, s)
+		require.NoError(t, err)
+
+		statements, err := repo.GetStatements(ctx, testWalletID)
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+		assert.Equal(t, s.ObjectName, statements[0].ObjectName)
+		assert.Equal(t, int64(1000), statements[0].OpeningBalance)
+		assert.Equal(t, int64(3000), statements[0].ClosingBalance)
+	})
+}
+
+func TestRepository_GetStatement(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{`insert into wallets(id, user_id) values(52, 7);`,
+		`insert into statements(wallet_id, "period", object_name, opening_balance, closing_balance)
+					values(52, '2022-10', '2022-10/statement-7-2022-10.json', 0, 1000);`,
+		`insert into statements(wallet_id, "period", object_name, opening_balance, closing_balance)
+					values(52, '2022-11', '2022-11/statement-7-2022-11.json', 1000, 2000);`,
+	}
+
+	t.Run("get statements successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		// Выписки отдаются от новых периодов к старым.
+		statements, err := repo.GetStatements(ctx, testWalletID)
+		require.NoError(t, err)
+		require.Len(t, statements, 2)
+		assert.Equal(t, "2022-11", statements[0].Period)
+		assert.Equal(t, "2022-10", statements[1].Period)
+	})
+
+	t.Run("get statement successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		s, err := repo.GetStatement(ctx, testWalletID, testPeriod)
+		require.NoError(t, err)
+		assert.Equal(t, "2022-11/statement-7-2022-11.json", s.ObjectName)
+		assert.Equal(t, int64(1000), s.OpeningBalance)
+		assert.Equal(t, int64(2000), s.ClosingBalance)
+	})
+
+	t.Run("get statement failed, not found", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoStatement.New(tx)
+
+		_, err := repo.GetStatement(ctx, testWalletID, "2022-12")
+		assert.ErrorIs(t, err, repositories.ErrRepoStatementNotFound)
+	})
+}
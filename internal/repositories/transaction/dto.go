@@ -14,8 +14,27 @@ type SortBy string
 type Direction string
 
 type Transaction struct {
+	ID        int64
 	Type      string
 	Payload   []byte
 	Amount    int64
+	ServiceID *int64 // nil для зачислений.
+	// BalanceAfter - баланс кошелька после операции. nil для транзакций, записанных до появления этой информации.
+	BalanceAfter *int64
+	CreatedAt    time.Time
+}
+
+// Cursor - позиция в истории транзакций кошелька, после которой начинается следующая страница.
+type Cursor struct {
 	CreatedAt time.Time
+	ID        int64
+}
+
+// Filter - условия отбора транзакций. Нулевое значение поля означает, что условие по нему не накладывается.
+type Filter struct {
+	Types      []string
+	AmountFrom int64
+	AmountTo   int64
+	OrderID    int64
+	ServiceID  int64
 }
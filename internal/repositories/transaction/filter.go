@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"fmt"
+	"strings"
+)
+
+// where дописывает к запросу условия фильтра. Плейсхолдеры нумеруются после уже переданных аргументов args.
+func (f Filter) where(args []interface{}) (string, []interface{}) {
+	var conds []string
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(f.Types) > 0 {
+		placeholders := make([]string, 0, len(f.Types))
+		for _, t := range f.Types {
+			placeholders = append(placeholders, arg(t))
+		}
+
+		conds = append(conds, `"type" in (`+strings.Join(placeholders, ", ")+`)`)
+	}
+
+	if f.AmountFrom != 0 {
+		conds = append(conds, "amount >= "+arg(f.AmountFrom))
+	}
+
+	if f.AmountTo != 0 {
+		conds = append(conds, "amount <= "+arg(f.AmountTo))
+	}
+
+	if f.OrderID != 0 {
+		conds = append(conds, "(payload ->> 'order_id')::bigint = "+arg(f.OrderID))
+	}
+
+	if f.ServiceID != 0 {
+		conds = append(conds, serviceIDExpr+" = "+arg(f.ServiceID))
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+
+	return " and " + strings.Join(conds, " and "), args
+}
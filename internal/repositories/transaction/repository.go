@@ -2,35 +2,57 @@ package transaction
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/internal/transactions"
 )
 
+// serviceIDExpr - услуга, к которой относится транзакция.
+//
+// Услуга берется из payload транзакции. В транзакциях, записанных до появления service_id в payload, а также
+// в отмене заказа без указания услуги, ее нет – тогда услугу берем из заказа. Такой заказ состоит из одной позиции,
+// поэтому услуга определяется однозначно. Для зачислений услуги нет (null).
+const serviceIDExpr = `coalesce(
+		(payload ->> 'service_id')::bigint,
+		(select o.service_id from orders o
+			where o.wallet_id = transactions.wallet_id
+			  and o.external_id = (transactions.payload ->> 'order_id')::bigint
+			limit 1)
+	)`
+
+// txColumns - колонки, из которых собирается Transaction (см. scanTxs).
+const txColumns = `id, "type", payload, amount, ` + serviceIDExpr + `, balance_after, created_at`
+
 type Repository struct {
 	db postgres.Database
 }
 
 func New(db postgres.Database) *Repository {
 	return &Repository{
-		db: db,
+		db: postgres.Traced(db),
 	}
 }
 
 // AddTransaction - добавляет информацию о проведенной денежной операции.
+// balanceAfter - баланс кошелька после этой операции.
 func (r *Repository) AddTransaction(
 	ctx context.Context,
 	walletID int64,
 	action string,
 	payload []byte,
-	amount int64,
+	amount, balanceAfter int64,
 ) (int64, error) {
 	var id int64
 
-	query := `insert into transactions(wallet_id, "type", payload, amount) values($1, $2, $3, $4) returning id;`
+	query := `insert into transactions(wallet_id, "type", payload, amount, balance_after)
+		values($1, $2, $3, $4, $5) returning id;`
 
-	err := r.db.QueryRowContext(ctx, query, walletID, action, payload, amount).Scan(&id)
+	err := r.db.QueryRowContext(ctx, query, walletID, action, payload, amount, balanceAfter).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("exec query: %v", err)
 	}
@@ -38,70 +60,180 @@ func (r *Repository) AddTransaction(
 	return id, nil
 }
 
+// GetTransaction отдает транзакцию кошелька по ее идентификатору.
+// Если у кошелька нет такой транзакции, то возвращаем ошибку ErrRepoTransactionNotFound.
+func (r *Repository) GetTransaction(ctx context.Context, walletID, txID int64) (Transaction, error) {
+	query := `select ` + txColumns + ` from transactions where id = $1 and wallet_id = $2;`
+
+	tx := Transaction{}
+
+	err := r.db.QueryRowContext(ctx, query, txID, walletID).Scan(
+		&tx.ID, &tx.Type, &tx.Payload, &tx.Amount, &tx.ServiceID, &tx.BalanceAfter, &tx.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Transaction{}, repositories.ErrRepoTransactionNotFound
+		}
+		return Transaction{}, fmt.Errorf("query row: %v", err)
+	}
+
+	return tx, nil
+}
+
 // GetTransactions отдает список транзакций пользователя, отсортированный по переданному параметру.
 //
 // Запрос с sortBy == "amount" потенциально тяжелый. Добавление индекса на колонку amount не имеет большого смысла
 // из-за невысокой селективности значений. БД придется выбрать все транзакции по кошельку и отсортировать их.
 // Как правило, банки предоставляют сортировку только по дате транзакций.
+//
+// Дополнительно список можно ограничить фильтром filter.
 func (r *Repository) GetTransactions(
 	ctx context.Context,
 	walletID, limit, offset int64,
 	sortBy SortBy,
 	direction Direction,
+	filter Filter,
+) ([]Transaction, error) {
+	conds, args := filter.where([]interface{}{walletID, limit, offset})
+
+	query := `select ` + txColumns + `
+		from transactions
+		where wallet_id = $1` + conds + ` order by ` + string(sortBy) + ` ` + string(direction) + ` limit $2 offset $3;`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return scanTxs(rows)
+}
+
+// GetTransactionsByTime - выводит список транзакций для пользователя в переданном промежутке времени, отсортированный
+// по переданному направлению. Дополнительно список можно ограничить фильтром filter.
+func (r *Repository) GetTransactionsByTime(
+	ctx context.Context,
+	walletID int64,
+	timeStart, timeEnd time.Time,
+	filter Filter,
 ) ([]Transaction, error) {
-	query := `select "type", payload, amount, created_at
+	conds, args := filter.where([]interface{}{walletID, timeStart, timeEnd})
+
+	query := `select ` + txColumns + `
 		from transactions
-		where wallet_id = $1 order by ` + string(sortBy) + ` ` + string(direction) + ` limit $2 offset $3;`
+		where wallet_id = $1 and (created_at >= $2 and created_at <= $3)` + conds + `
+		order by created_at  desc;`
 
-	rows, err := r.db.QueryContext(ctx, query, walletID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query: %w", err)
 	}
 
+	return scanTxs(rows)
+}
+
+// StreamTransactionsByTime вызывает fn для каждой транзакции кошелька в промежутке времени [timeStart, timeEnd]
+// в хронологическом порядке. Строки читаются из курсора БД по одной и не собираются в памяти, поэтому метод подходит
+// для выгрузки истории за большой период. Если fn возвращает ошибку, чтение прекращается и ошибка возвращается.
+func (r *Repository) StreamTransactionsByTime(
+	ctx context.Context,
+	walletID int64,
+	timeStart, timeEnd time.Time,
+	fn func(tx Transaction) error,
+) error {
+	query := `select ` + txColumns + `
+		from transactions
+		where wallet_id = $1 and (created_at >= $2 and created_at <= $3)
+		order by created_at, id;`
+
+	rows, err := r.db.QueryContext(ctx, query, walletID, timeStart, timeEnd)
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
 	defer func() {
 		_ = rows.Close()
 	}()
 
-	var result []Transaction
-
 	for rows.Next() {
-		tr := Transaction{}
-
-		err = rows.Scan(
-			&tr.Type, &tr.Payload, &tr.Amount, &tr.CreatedAt,
-		)
-
+		tx, err := scanTx(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scan: %w", err)
+			return fmt.Errorf("scan: %w", err)
 		}
 
-		result = append(result, tr)
+		if err := fn(tx); err != nil {
+			return err
+		}
 	}
 
-	if rows.Err() != nil {
-		return nil, fmt.Errorf("rows: %w", err)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows: %w", err)
 	}
 
-	return result, nil
+	return nil
 }
 
-// GetTransactionsByTime - выводит список транзакций для пользователя в переданном промежутке времени, отсортированный
-// по переданному направлению.
-func (r *Repository) GetTransactionsByTime(
+// GetFundsBefore отдает средства кошелька (доступные и зарезервированные вместе) на момент t, не включая его.
+// Резервирование и отмена лишь перекладывают средства между балансом и резервом, поэтому средства складываются
+// из зачислений за вычетом списаний по заказам и без них.
+func (r *Repository) GetFundsBefore(ctx context.Context, walletID int64, t time.Time) (int64, error) {
+	var funds int64
+
+	query := `select coalesce(sum(case when "type" = $3 then amount when "type" in ($4, $5) then -amount else 0 end), 0)
+		from transactions
+		where wallet_id = $1 and created_at < $2;`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		walletID,
+		t,
+		transactions.TypeAdd,
+		transactions.TypeWriteOff,
+		transactions.TypeOutgoing,
+	).Scan(&funds)
+	if err != nil {
+		return 0, fmt.Errorf("query row: %w", err)
+	}
+
+	return funds, nil
+}
+
+// GetTransactionsPage отдает страницу истории транзакций кошелька, отсортированную от новых к старым.
+// Если передан курсор after, то страница начинается с транзакции, следующей за ним.
+//
+// Пагинация по курсору (keyset) в отличие от limit-offset не зависит от глубины страницы: запрос использует индекс
+// transactions_wallet_created_id_idx и читает только limit строк.
+func (r *Repository) GetTransactionsPage(
 	ctx context.Context,
-	walletID int64,
-	timeStart, timeEnd time.Time,
+	walletID, limit int64,
+	after *Cursor,
 ) ([]Transaction, error) {
-	query := `select "type", payload, amount, created_at
+	query := `select ` + txColumns + `
 		from transactions
-		where wallet_id = $1 and (created_at >= $2 and created_at <= $3)
-		order by created_at  desc;`
+		where wallet_id = $1
+		order by created_at desc, id desc
+		limit $2;`
+	args := []interface{}{walletID, limit}
 
-	rows, err := r.db.QueryContext(ctx, query, walletID, timeStart, timeEnd)
+	if after != nil {
+		query = `select ` + txColumns + `
+			from transactions
+			where wallet_id = $1 and (created_at, id) < ($3, $4)
+			order by created_at desc, id desc
+			limit $2;`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query: %w", err)
 	}
 
+	return scanTxs(rows)
+}
+
+// scanTxs вычитывает транзакции, выбранные по колонкам txColumns, и закрывает rows.
+func scanTxs(rows *sql.Rows) ([]Transaction, error) {
 	defer func() {
 		_ = rows.Close()
 	}()
@@ -109,18 +241,26 @@ func (r *Repository) GetTransactionsByTime(
 	var result []Transaction
 
 	for rows.Next() {
-		tx := Transaction{}
-
-		if err := rows.Scan(&tx.Type, &tx.Payload, &tx.Amount, &tx.CreatedAt); err != nil {
+		tx, err := scanTx(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan: %w", err)
 		}
 
 		result = append(result, tx)
 	}
 
-	if rows.Err() != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows: %w", err)
 	}
 
 	return result, nil
 }
+
+// scanTx вычитывает текущую строку rows, выбранную по колонкам txColumns.
+func scanTx(rows *sql.Rows) (Transaction, error) {
+	tx := Transaction{}
+
+	err := rows.Scan(&tx.ID, &tx.Type, &tx.Payload, &tx.Amount, &tx.ServiceID, &tx.BalanceAfter, &tx.CreatedAt)
+
+	return tx, err
+}
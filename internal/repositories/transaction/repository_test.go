@@ -10,6 +10,7 @@ import (
 
 	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
 	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
 	"github.com/frutonanny/wallet-service/internal/transactions"
@@ -46,7 +47,7 @@ func TestRepository_AddTransaction(t *testing.T) {
 		walletID := createWallet(ctx, t, tx, testUserID)
 
 		// Добавляем транзакцию о пополнении баланса.
-		txID, err := repo.AddTransaction(ctx, walletID, transactions.TypeAdd, payloadAdd, testAmount)
+		txID, err := repo.AddTransaction(ctx, walletID, transactions.TypeAdd, payloadAdd, testAmount, testAmount)
 		require.NoError(t, err)
 
 		// Получаем данные транзакции для проверки
@@ -57,6 +58,7 @@ func TestRepository_AddTransaction(t *testing.T) {
 		assert.EqualValues(t, walletID, txAdd.WalletID)
 		assert.EqualValues(t, transactions.TypeAdd, txAdd.Type)
 		assert.EqualValues(t, testAmount, txAdd.Amount)
+		assert.EqualValues(t, testAmount, txAdd.BalanceAfter)
 	})
 
 	t.Run("add transactions failed", func(t *testing.T) {
@@ -66,11 +68,68 @@ func TestRepository_AddTransaction(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получаем ошибку, так как кошелька не существует.
-		_, err := repo.AddTransaction(ctx, testFailed, transactions.TypeAdd, payloadAdd, 3*testAmount)
+		_, err := repo.AddTransaction(ctx, testFailed, transactions.TypeAdd, payloadAdd, 3*testAmount, 3*testAmount)
 		assert.Error(t, err)
 	})
 }
 
+func TestRepository_GetTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{`insert into wallets(id, user_id, balance) 
+							values(52, 7, 5000);`,
+		`insert into wallets(id, user_id, balance) 
+							values(53, 8, 5000);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount)
+					values(52, 10, 3, 'reservation', 2000);`,
+		`insert into transactions(id, wallet_id, "type", payload, amount, balance_after, created_at)
+					values(1001, 52, 'reservation', '{ "order_id": 10 }', 2000, 3000, '2022-11-02 12:00');`,
+		`insert into transactions(id, wallet_id, "type", payload, amount, created_at)
+					values(1002, 52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-01 12:00');`,
+	}
+
+	t.Run("get transaction successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		result, err := repo.GetTransaction(ctx, testWalletID, 1001)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1001, result.ID)
+		assert.Equal(t, transactions.TypeReserve, result.Type)
+		assert.EqualValues(t, 2000, result.Amount)
+
+		// Услуга взята из заказа, так как в payload ее нет.
+		require.NotNil(t, result.ServiceID)
+		assert.EqualValues(t, 3, *result.ServiceID)
+		require.NotNil(t, result.BalanceAfter)
+		assert.EqualValues(t, 3000, *result.BalanceAfter)
+	})
+
+	t.Run("get transaction without service and balance successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		result, err := repo.GetTransaction(ctx, testWalletID, 1002)
+		require.NoError(t, err)
+		assert.Nil(t, result.ServiceID)
+		assert.Nil(t, result.BalanceAfter)
+	})
+
+	t.Run("get transaction failed, transaction of another wallet", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		_, err := repo.GetTransaction(ctx, 53, 1001)
+		assert.ErrorIs(t, err, repositories.ErrRepoTransactionNotFound)
+	})
+}
+
 func TestRepository_GetTransactions(t *testing.T) {
 	ctx := context.Background()
 
@@ -99,7 +158,7 @@ func TestRepository_GetTransactions(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получим список транзакций, отсортированный по убыванию даты с ограничениями: смещение = 1, лимит = 4.
-		result, err := repo.GetTransactions(ctx, testWalletID, testLimit, testOffset, repoTxs.CreatedAt, repoTxs.Desc)
+		result, err := repo.GetTransactions(ctx, testWalletID, testLimit, testOffset, repoTxs.CreatedAt, repoTxs.Desc, repoTxs.Filter{})
 		require.NoError(t, err)
 		assert.Len(t, result, 4)
 
@@ -133,7 +192,7 @@ func TestRepository_GetTransactions(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получим список транзакций, отсортированный по убыванию даты с ограничениями: смещение = 1, лимит = 4.
-		result, err := repo.GetTransactions(ctx, testWalletID, testLimit, testOffset, repoTxs.Amount, repoTxs.Asc)
+		result, err := repo.GetTransactions(ctx, testWalletID, testLimit, testOffset, repoTxs.Amount, repoTxs.Asc, repoTxs.Filter{})
 		require.NoError(t, err)
 		assert.Len(t, result, 4)
 
@@ -149,7 +208,7 @@ func TestRepository_GetTransactions(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получаем пустой результат, так как кошелька не существует.
-		result, err := repo.GetTransactions(ctx, testFailed, testLimit, testOffset, repoTxs.Amount, repoTxs.Asc)
+		result, err := repo.GetTransactions(ctx, testFailed, testLimit, testOffset, repoTxs.Amount, repoTxs.Asc, repoTxs.Filter{})
 		require.NoError(t, err)
 		require.Empty(t, result)
 	})
@@ -189,7 +248,7 @@ func TestRepository_GetTransactionsByTime(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получим список транзакций, отсортированный по убыванию даты, ограниченный датами start <= created_at <= end.
-		result, err := repo.GetTransactionsByTime(ctx, testWalletID, start, end)
+		result, err := repo.GetTransactionsByTime(ctx, testWalletID, start, end, repoTxs.Filter{})
 		require.NoError(t, err)
 		assert.Len(t, result, 5)
 		assert.EqualValues(t, 6000, result[0].Amount) // транзакция от 2022-11-06 12:00
@@ -203,7 +262,277 @@ func TestRepository_GetTransactionsByTime(t *testing.T) {
 		repo := repoTxs.New(tx)
 
 		// Получаем пустой результат, так как кошелька не существует.
-		result, err := repo.GetTransactionsByTime(ctx, testFailed, start, end)
+		result, err := repo.GetTransactionsByTime(ctx, testFailed, start, end, repoTxs.Filter{})
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestRepository_GetTransactionsFilter(t *testing.T) {
+	ctx := context.Background()
+
+	start, err := time.Parse(time.RFC3339, "2022-11-01T00:00:00Z")
+	require.NoError(t, err)
+
+	end, err := time.Parse(time.RFC3339, "2022-11-30T00:00:00Z")
+	require.NoError(t, err)
+
+	query := []string{`insert into wallets(id, user_id, balance) 
+							values(52, 7, 5000);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount)
+					values(52, 10, 1, 'written_off', 2000);`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-01 12:00');`,
+		// Транзакции по заказу 10 записаны без услуги в payload – услуга берется из заказа.
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000, '2022-11-02 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'write_off', '{ "order_id": 10 }', 1500, '2022-11-03 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 11, "service_id": 2 }', 700, '2022-11-04 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'cancel', '{ "order_id": 11, "service_id": 2 }', 700, '2022-11-05 12:00');`,
+	}
+
+	cases := []struct {
+		name    string
+		filter  repoTxs.Filter
+		amounts []int64
+	}{
+		{
+			name:    "by type",
+			filter:  repoTxs.Filter{Types: []string{transactions.TypeReserve, transactions.TypeCancel}},
+			amounts: []int64{700, 700, 2000},
+		},
+		{
+			name:    "by amount",
+			filter:  repoTxs.Filter{AmountFrom: 1000, AmountTo: 2000},
+			amounts: []int64{1500, 2000},
+		},
+		{
+			name:    "by order",
+			filter:  repoTxs.Filter{OrderID: 11},
+			amounts: []int64{700, 700},
+		},
+		{
+			name:    "by service from order",
+			filter:  repoTxs.Filter{ServiceID: 1},
+			amounts: []int64{1500, 2000},
+		},
+		{
+			name:    "by type and service",
+			filter:  repoTxs.Filter{Types: []string{transactions.TypeCancel}, ServiceID: 2},
+			amounts: []int64{700},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run("get transactions "+tc.name+" successfully", func(t *testing.T) {
+			tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+			defer cancel()
+
+			repo := repoTxs.New(tx)
+
+			result, err := repo.GetTransactions(ctx, testWalletID, testLimit, 0, repoTxs.CreatedAt, repoTxs.Desc, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.amounts, amounts(result))
+
+			result, err = repo.GetTransactionsByTime(ctx, testWalletID, start, end, tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.amounts, amounts(result))
+		})
+	}
+}
+
+func TestRepository_StreamTransactionsByTime(t *testing.T) {
+	ctx := context.Background()
+
+	start, err := time.Parse(time.RFC3339, "2022-11-02T12:00:00Z")
+	require.NoError(t, err)
+
+	end, err := time.Parse(time.RFC3339, "2022-11-04T12:00:00Z")
+	require.NoError(t, err)
+
+	query := []string{`insert into wallets(id, user_id, balance) 
+							values(52, 7, 5000);`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 1000, '2022-11-01 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000, '2022-11-02 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 3000, '2022-11-03 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 4000, '2022-11-04 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-05 12:00');`,
+	}
+
+	t.Run("stream transactions successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		// Транзакции отдаются от старых к новым в пределах промежутка.
+		var result []repoTxs.Transaction
+		err := repo.StreamTransactionsByTime(ctx, testWalletID, start, end, func(tx repoTxs.Transaction) error {
+			result = append(result, tx)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2000, 3000, 4000}, amounts(result))
+	})
+
+	t.Run("stream transactions stopped by callback", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		// Ошибка из fn прерывает чтение и возвращается как есть.
+		calls := 0
+		err := repo.StreamTransactionsByTime(ctx, testWalletID, start, end, func(tx repoTxs.Transaction) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRepository_GetFundsBefore(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{`insert into wallets(id, user_id, balance, reservation) 
+							values(52, 7, 3000, 0);`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-01 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000, '2022-11-02 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'write_off', '{ "order_id": 10 }', 1500, '2022-11-03 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 11 }', 1000, '2022-11-04 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'cancel', '{ "order_id": 11 }', 1000, '2022-11-05 12:00');`,
+		`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'outgoing_transfer', '{ "type": "adjustment", "reason": "fix" }', 500, '2022-11-06 12:00');`,
+	}
+
+	cases := []struct {
+		name  string
+		t     string
+		funds int64
+	}{
+		{
+			name:  "before first transaction",
+			t:     "2022-11-01T12:00:00Z",
+			funds: 0,
+		},
+		{
+			name:  "reservation does not change funds",
+			t:     "2022-11-03T00:00:00Z",
+			funds: 5000,
+		},
+		{
+			name:  "write-off decreases funds",
+			t:     "2022-11-04T00:00:00Z",
+			funds: 3500,
+		},
+		{
+			name:  "outgoing transfer decreases funds",
+			t:     "2022-11-07T00:00:00Z",
+			funds: 3000,
+		},
+		{
+			name:  "after all transactions",
+			t:     "2022-12-01T00:00:00Z",
+			funds: 3000,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+			defer cancel()
+
+			repo := repoTxs.New(tx)
+
+			moment, err := time.Parse(time.RFC3339, tt.t)
+			require.NoError(t, err)
+
+			funds, err := repo.GetFundsBefore(ctx, testWalletID, moment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.funds, funds)
+		})
+	}
+}
+
+func TestRepository_GetTransactionsPage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get transactions pages successfully", func(t *testing.T) {
+
+		query := []string{`insert into wallets(id, user_id, balance) 
+							values(52, 7, 5000);`,
+			`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 1000, '2022-11-01 12:00');`,
+			`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000, '2022-11-02 12:00');`,
+			// Две транзакции с одинаковым временем: порядок между ними определяется идентификатором.
+			`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 3000, '2022-11-03 12:00');`,
+			`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'reservation', '{ "order_id": 10 }', 4000, '2022-11-03 12:00');`,
+			`insert into transactions(wallet_id, "type", payload, amount, created_at)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 5000, '2022-11-05 12:00');`,
+		}
+
+		// Заполняем предварительно данными, для корректной выборки.
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		// Первая страница: 2 самые новые транзакции.
+		page1, err := repo.GetTransactionsPage(ctx, testWalletID, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, page1, 2)
+		assert.EqualValues(t, 5000, page1[0].Amount)
+		assert.EqualValues(t, 4000, page1[1].Amount)
+
+		// Вторая страница начинается сразу после последней транзакции первой страницы.
+		last := page1[len(page1)-1]
+		page2, err := repo.GetTransactionsPage(ctx, testWalletID, 2, &repoTxs.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, page2, 2)
+		assert.EqualValues(t, 3000, page2[0].Amount)
+		assert.EqualValues(t, 2000, page2[1].Amount)
+
+		// Последняя страница неполная.
+		last = page2[len(page2)-1]
+		page3, err := repo.GetTransactionsPage(ctx, testWalletID, 2, &repoTxs.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, page3, 1)
+		assert.EqualValues(t, 1000, page3[0].Amount)
+	})
+
+	t.Run("get transactions page failed", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		repo := repoTxs.New(tx)
+
+		// Получаем пустой результат, так как кошелька не существует.
+		result, err := repo.GetTransactionsPage(ctx, testFailed, testLimit, nil)
 		require.NoError(t, err)
 		assert.Empty(t, result)
 	})
@@ -224,10 +553,11 @@ func createWallet(ctx context.Context, t *testing.T, db postgres.Database, userI
 }
 
 type transaction struct {
-	ID       int64
-	WalletID int64
-	Type     string
-	Amount   int64
+	ID           int64
+	WalletID     int64
+	Type         string
+	Amount       int64
+	BalanceAfter int64
 }
 
 // getOrderTx отдает информацию о транзакции.
@@ -236,10 +566,21 @@ func getTx(ctx context.Context, t *testing.T, db postgres.Database, txID int64)
 
 	tx := transaction{}
 
-	query := `select id, wallet_id, "type", amount from transactions where id = $1;`
+	query := `select id, wallet_id, "type", amount, balance_after from transactions where id = $1;`
 
-	err := db.QueryRowContext(ctx, query, txID).Scan(&tx.ID, &tx.WalletID, &tx.Type, &tx.Amount)
+	err := db.QueryRowContext(ctx, query, txID).Scan(&tx.ID, &tx.WalletID, &tx.Type, &tx.Amount, &tx.BalanceAfter)
 	require.NoError(t, err)
 
 	return tx
 }
+
+// amounts отдает суммы транзакций в порядке выдачи.
+func amounts(txs []repoTxs.Transaction) []int64 {
+	result := make([]int64, 0, len(txs))
+
+	for _, tx := range txs {
+		result = append(result, tx.Amount)
+	}
+
+	return result
+}
@@ -0,0 +1,19 @@
+package wallet
+
+// Balance - баланс кошелька пользователя.
+type Balance struct {
+	WalletID    int64
+	UserID      int64
+	Balance     int64 // Доступные средства.
+	Reservation int64 // Зарезервированные средства.
+}
+
+// Ledger - остатки кошелька и то, какими они должны быть по журналу транзакций и заказам.
+type Ledger struct {
+	WalletID    int64
+	UserID      int64
+	Balance     int64 // Доступные средства.
+	Reservation int64 // Зарезервированные средства.
+	Funds       int64 // Средства по журналу: зачисления за вычетом списаний по заказам и без них.
+	Reserved    int64 // Сумма открытых резервов по заказам.
+}
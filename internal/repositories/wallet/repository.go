@@ -8,8 +8,10 @@ import (
 
 	"github.com/jackc/pgconn"
 
+	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/internal/transactions"
 )
 
 const constraintName = "wallets_balance_check"
@@ -20,7 +22,7 @@ type Repository struct {
 
 func New(db postgres.Database) *Repository {
 	return &Repository{
-		db: db,
+		db: postgres.Traced(db),
 	}
 }
 
@@ -97,6 +99,26 @@ func (r *Repository) Add(ctx context.Context, walletID int64, amount int64) (int
 	return balance, nil
 }
 
+// Withdraw - списывает переданную сумму с баланса без резервирования и возвращает текущий баланс.
+// Если средств не хватает, то возвращает ошибку ErrRepoNotEnoughCash.
+func (r *Repository) Withdraw(ctx context.Context, walletID, amount int64) (int64, error) {
+	var balance int64
+
+	query := `update wallets set balance = balance - $1 where id = $2 returning balance;`
+
+	var pgErr *pgconn.PgError
+	err := r.db.QueryRowContext(ctx, query, amount, walletID).Scan(&balance)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == constraintName {
+			return 0, repositories.ErrRepoNotEnoughCash
+		}
+
+		return 0, fmt.Errorf("query row: %w", err)
+	}
+
+	return balance, nil
+}
+
 // Reserve - резервирует переданную сумму денег.
 func (r *Repository) Reserve(ctx context.Context, walletID, cash int64) (int64, error) {
 	var balance int64
@@ -160,3 +182,87 @@ func (r *Repository) GetBalance(ctx context.Context, walletID int64) (int64, err
 
 	return balance, nil
 }
+
+// GetBalances - отдает балансы кошельков переданных пользователей. Пользователи без кошелька в ответ не попадают.
+func (r *Repository) GetBalances(ctx context.Context, userIDs []int64) ([]Balance, error) {
+	query := `select id, user_id, balance, reservation from wallets where user_id = any($1);`
+
+	rows, err := r.db.QueryContext(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Balance
+
+	for rows.Next() {
+		b := Balance{}
+
+		if err := rows.Scan(&b.WalletID, &b.UserID, &b.Balance, &b.Reservation); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetLedgers отдает до limit кошельков с идентификатором больше afterWalletID по возрастанию идентификатора. Вместе
+// с остатками кошелька отдаются средства, посчитанные по журналу транзакций, и сумма открытых резервов по заказам.
+func (r *Repository) GetLedgers(ctx context.Context, afterWalletID, limit int64) ([]Ledger, error) {
+	query := `select w.id, w.user_id, w.balance, w.reservation,
+			coalesce((select sum(case when t."type" = $3 then t.amount when t."type" in ($4, $5) then -t.amount end)
+				from transactions t
+				where t.wallet_id = w.id), 0) as funds,
+			coalesce((select sum(o.amount)
+				from orders o
+				where o.wallet_id = w.id and o.status = $6), 0) as reserved
+		from wallets w
+		where w.id > $1
+		order by w.id
+		limit $2;`
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		query,
+		afterWalletID,
+		limit,
+		transactions.TypeAdd,
+		transactions.TypeWriteOff,
+		transactions.TypeOutgoing,
+		orders.StatusReserved,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Ledger
+
+	for rows.Next() {
+		l := Ledger{}
+
+		if err := rows.Scan(&l.WalletID, &l.UserID, &l.Balance, &l.Reservation, &l.Funds, &l.Reserved); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		result = append(result, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
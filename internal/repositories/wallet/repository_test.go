@@ -141,6 +141,45 @@ func TestRepository_Add(t *testing.T) {
 	})
 }
 
+func TestRepository_Withdraw(t *testing.T) {
+	ctx := context.Background()
+	t.Run("withdraw amount successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		walletRepo := repoWallet.New(tx)
+
+		// Создаем кошелек и добавляем на баланс сумму testAmount.
+		walletID, err := walletRepo.CreateWallet(ctx, testUserID)
+		require.NoError(t, err)
+
+		_, err = walletRepo.Add(ctx, walletID, testAmount)
+		require.NoError(t, err)
+
+		// Списываем весь баланс. Резерв не меняется.
+		balance, err := walletRepo.Withdraw(ctx, walletID, testAmount)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, balance)
+		assert.EqualValues(t, 0, getReservation(ctx, t, tx, walletID))
+	})
+
+	t.Run("withdraw amount failed, not enough cash", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN)
+		defer cancel()
+
+		walletRepo := repoWallet.New(tx)
+
+		walletID, err := walletRepo.CreateWallet(ctx, testUserID)
+		require.NoError(t, err)
+
+		_, err = walletRepo.Add(ctx, walletID, testAmount)
+		require.NoError(t, err)
+
+		_, err = walletRepo.Withdraw(ctx, walletID, 2*testAmount)
+		assert.ErrorIs(t, err, repositories.ErrRepoNotEnoughCash)
+	})
+}
+
 func TestRepository_Reserve(t *testing.T) {
 	ctx := context.Background()
 	t.Run("reserve amount successfully", func(t *testing.T) {
@@ -347,6 +386,73 @@ func TestRepository_GetBalance(t *testing.T) {
 	})
 }
 
+func TestRepository_GetBalances(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into wallets(id, user_id, balance, reservation) values(52, 7, 3000, 2000);`,
+		`insert into wallets(id, user_id, balance, reservation) values(53, 8, 500, 0);`,
+		`insert into wallets(id, user_id, balance, reservation) values(54, 9, 100, 100);`,
+	}
+
+	t.Run("get balances successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		walletRepo := repoWallet.New(tx)
+
+		// У пользователя 100 кошелька нет, в ответ он не попадает.
+		balances, err := walletRepo.GetBalances(ctx, []int64{7, 8, 100})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []repoWallet.Balance{
+			{WalletID: 52, UserID: 7, Balance: 3000, Reservation: 2000},
+			{WalletID: 53, UserID: 8, Balance: 500, Reservation: 0},
+		}, balances)
+	})
+}
+
+func TestRepository_GetLedgers(t *testing.T) {
+	ctx := context.Background()
+
+	query := []string{
+		`insert into wallets(id, user_id, balance, reservation) values(52, 7, 3000, 2000);`,
+		`insert into wallets(id, user_id, balance, reservation) values(53, 8, 500, 0);`,
+		`insert into wallets(id, user_id, balance, reservation) values(54, 9, 0, 0);`,
+		`insert into transactions(wallet_id, "type", payload, amount)
+					values(52, 'incoming_transfer', '{ "type": "enrollment" }', 6000);`,
+		`insert into transactions(wallet_id, "type", payload, amount)
+					values(52, 'reservation', '{ "order_id": 10 }', 2000);`,
+		`insert into transactions(wallet_id, "type", payload, amount)
+					values(52, 'outgoing_transfer', '{ "type": "adjustment", "reason": "fix" }', 1000);`,
+		`insert into transactions(wallet_id, "type", payload, amount)
+					values(53, 'incoming_transfer', '{ "type": "enrollment" }', 700);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount)
+					values(52, 10, 1, 'reserved', 2000);`,
+		`insert into orders(wallet_id, external_id, service_id, status, amount)
+					values(52, 11, 2, 'written_off', 300);`,
+	}
+
+	t.Run("get ledgers successfully", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		walletRepo := repoWallet.New(tx)
+
+		// Кошелек 53 расходится с журналом: по транзакциям на нем 700, а не 500.
+		ledgers, err := walletRepo.GetLedgers(ctx, 51, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []repoWallet.Ledger{
+			{WalletID: 52, UserID: 7, Balance: 3000, Reservation: 2000, Funds: 5000, Reserved: 2000},
+			{WalletID: 53, UserID: 8, Balance: 500, Reservation: 0, Funds: 700, Reserved: 0},
+		}, ledgers)
+
+		// Следующая страница начинается после последнего кошелька предыдущей.
+		ledgers, err = walletRepo.GetLedgers(ctx, 53, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []repoWallet.Ledger{{WalletID: 54, UserID: 9}}, ledgers)
+	})
+}
+
 func getReservation(ctx context.Context, t *testing.T, db postgres.Database, walletID int64) int64 {
 	t.Helper()
 
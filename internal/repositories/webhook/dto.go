@@ -0,0 +1,23 @@
+package webhook
+
+import "time"
+
+// Статусы доставки.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	URL            string
+	Secret         string
+	EventID        int64
+	EventType      string
+	Payload        []byte
+	Attempts       int
+	LastError      string
+	UpdatedAt      time.Time
+}
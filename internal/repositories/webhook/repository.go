@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+type Repository struct {
+	db postgres.Database
+}
+
+func New(db postgres.Database) *Repository {
+	return &Repository{
+		db: postgres.Traced(db),
+	}
+}
+
+// AddSubscription - добавляет подписку на события переданных типов. Пустой eventTypes – подписка на все события.
+func (r *Repository) AddSubscription(ctx context.Context, url string, eventTypes []string, secret string) (int64, error) {
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	var id int64
+
+	query := `insert into webhook_subscriptions(url, event_types, secret) values($1, $2, $3) returning id;`
+
+	if err := r.db.QueryRowContext(ctx, query, url, eventTypes, secret).Scan(&id); err != nil {
+		return 0, fmt.Errorf("query row: %v", err)
+	}
+
+	return id, nil
+}
+
+// AddDeliveries - ставит событие в очередь доставки всем подходящим подпискам и отдает количество новых доставок.
+// Повторная постановка того же события ничего не меняет.
+func (r *Repository) AddDeliveries(ctx context.Context, e events.Event, payload []byte) (int64, error) {
+	query := `insert into webhook_deliveries(subscription_id, event_id, event_type, payload)
+				select id, $1, $2, $3
+				from webhook_subscriptions
+				where event_types = '{}' or $2 = any(event_types)
+				on conflict (subscription_id, event_id) do nothing;`
+
+	res, err := r.db.ExecContext(ctx, query, e.ID, e.Type, payload)
+	if err != nil {
+		return 0, fmt.Errorf("exec: %v", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %v", err)
+	}
+
+	return n, nil
+}
+
+// ClaimDueDeliveries - забирает не больше limit доставок, время очередной попытки которых наступило, и сдвигает
+// их следующую попытку на leaseUntil. Пока аренда не истекла, другие экземпляры эти доставки не берут, а если
+// экземпляр упадет, не записав результат, доставки снова станут доступны после leaseUntil. Строки, которые
+// забирает другой экземпляр, пропускаются.
+func (r *Repository) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]Delivery, error) {
+	query := `with due as (
+					select id
+					from webhook_deliveries
+					where status = $1 and next_attempt_at <= now()
+					order by next_attempt_at
+					limit $2
+					for update skip locked
+				)
+				update webhook_deliveries d
+				set next_attempt_at = $3
+				from due, webhook_subscriptions s
+				where d.id = due.id and s.id = d.subscription_id
+				returning d.id, d.subscription_id, s.url, s.secret, d.event_id, d.event_type, d.payload, d.attempts,
+					d.last_error, d.updated_at;`
+
+	return r.getDeliveries(ctx, query, StatusPending, limit, leaseUntil)
+}
+
+// GetDeadDeliveries - отдает доставки, попытки которых исчерпаны, по возрастанию идентификатора, начиная
+// со следующей после afterID.
+func (r *Repository) GetDeadDeliveries(ctx context.Context, afterID int64, limit int) ([]Delivery, error) {
+	query := `select d.id, d.subscription_id, s.url, '', d.event_id, d.event_type, d.payload, d.attempts,
+					d.last_error, d.updated_at
+				from webhook_deliveries d
+				join webhook_subscriptions s on s.id = d.subscription_id
+				where d.status = $1 and d.id > $2
+				order by d.id
+				limit $3;`
+
+	return r.getDeliveries(ctx, query, StatusDead, afterID, limit)
+}
+
+func (r *Repository) getDeliveries(ctx context.Context, query string, args ...interface{}) ([]Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %v", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var result []Delivery
+
+	for rows.Next() {
+		d := Delivery{}
+
+		var lastError sql.NullString
+
+		if err := rows.Scan(
+			&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventID, &d.EventType, &d.Payload, &d.Attempts,
+			&lastError, &d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+
+		d.LastError = lastError.String
+
+		result = append(result, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// AddAttempt - записывает попытку доставки в журнал. Нулевой statusCode – ответа не было.
+func (r *Repository) AddAttempt(
+	ctx context.Context,
+	deliveryID int64,
+	statusCode int,
+	attemptErr string,
+	duration time.Duration,
+) error {
+	query := `insert into webhook_delivery_attempts(delivery_id, status_code, error, duration_ms)
+				values($1, nullif($2, 0), nullif($3, ''), $4);`
+
+	_, err := r.db.ExecContext(ctx, query, deliveryID, statusCode, attemptErr, duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
+
+// MarkDelivered - отмечает доставку успешной.
+func (r *Repository) MarkDelivered(ctx context.Context, deliveryID int64) error {
+	query := `update webhook_deliveries
+				set status = $2, attempts = attempts + 1, last_error = null, updated_at = now()
+				where id = $1;`
+
+	if _, err := r.db.ExecContext(ctx, query, deliveryID, StatusDelivered); err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
+
+// MarkFailed - отмечает неудачную попытку: назначает следующую на nextAttemptAt или, если dead, переводит доставку
+// в статус dead.
+func (r *Repository) MarkFailed(
+	ctx context.Context,
+	deliveryID int64,
+	lastError string,
+	nextAttemptAt time.Time,
+	dead bool,
+) error {
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+
+	query := `update webhook_deliveries
+				set status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4, updated_at = now()
+				where id = $1;`
+
+	if _, err := r.db.ExecContext(ctx, query, deliveryID, status, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
+
+// Replay - возвращает доставку из статуса dead в очередь с обнуленным счетчиком попыток.
+// Если такой доставки в статусе dead нет, то возвращаем ошибку ErrRepoDeliveryNotFound.
+func (r *Repository) Replay(ctx context.Context, deliveryID int64) error {
+	query := `update webhook_deliveries
+				set status = $2, attempts = 0, next_attempt_at = now(), updated_at = now()
+				where id = $1 and status = $3;`
+
+	res, err := r.db.ExecContext(ctx, query, deliveryID, StatusPending, StatusDead)
+	if err != nil {
+		return fmt.Errorf("exec: %v", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %v", err)
+	}
+
+	if n == 0 {
+		return repositories.ErrRepoDeliveryNotFound
+	}
+
+	return nil
+}
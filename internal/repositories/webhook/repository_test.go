@@ -0,0 +1,146 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceConfig "github.com/frutonanny/wallet-service/internal/config"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	testingboilerplate "github.com/frutonanny/wallet-service/internal/testing_boilerplate"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	fileConfig = "../../../config/config.local.json"
+)
+
+var (
+	config = serviceConfig.Must(fileConfig)
+
+	query = []string{
+		`insert into webhook_subscriptions(id, url, event_types, secret)
+					values(100001, 'http://all.example', '{}', 'secret1');`,
+		`insert into webhook_subscriptions(id, url, event_types, secret)
+					values(100002, 'http://writeoffs.example', '{wallet.write_off}', 'secret2');`,
+		`insert into outbox(id, event_type, version, payload, user_id)
+					values(100001, 'wallet.incoming_transfer', 1, '{"userID": 7}', 7);`,
+	}
+
+	testEvent = events.Event{ID: 100001, Type: events.TypeIncomingTransfer, Version: events.Version}
+)
+
+func TestRepository_AddDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deliveries only for matching subscriptions", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoWebhook.New(tx)
+
+		n, err := repo.AddDeliveries(ctx, testEvent, []byte(`{"id": 100001}`))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		// Повторная постановка того же события ничего не добавляет.
+		n, err = repo.AddDeliveries(ctx, testEvent, []byte(`{"id": 100001}`))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		deliveries, err := repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, int64(100001), deliveries[0].SubscriptionID)
+		assert.Equal(t, "http://all.example", deliveries[0].URL)
+		assert.Equal(t, "secret1", deliveries[0].Secret)
+		assert.Equal(t, events.TypeIncomingTransfer, deliveries[0].EventType)
+	})
+}
+
+func TestRepository_ClaimDueDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("claimed delivery is not due until lease expires", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoWebhook.New(tx)
+
+		_, err := repo.AddDeliveries(ctx, testEvent, []byte(`{"id": 100001}`))
+		require.NoError(t, err)
+
+		deliveries, err := repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		// Пока аренда не истекла, доставку не забирает никто другой.
+		claimed, err := repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		// Экземпляр не записал результат, аренда истекла – доставку можно забрать снова.
+		_, err = tx.ExecContext(ctx, `update webhook_deliveries set next_attempt_at = now() - interval '1 second'
+				where id = $1;`, deliveries[0].ID)
+		require.NoError(t, err)
+
+		claimed, err = repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, deliveries[0].ID, claimed[0].ID)
+	})
+}
+
+func TestRepository_MarkFailed(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dead delivery can be replayed", func(t *testing.T) {
+		tx, cancel := testingboilerplate.InitDB(t, config.DB.DSN, query)
+		defer cancel()
+
+		repo := repoWebhook.New(tx)
+
+		_, err := repo.AddDeliveries(ctx, testEvent, []byte(`{"id": 100001}`))
+		require.NoError(t, err)
+
+		deliveries, err := repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+
+		id := deliveries[0].ID
+
+		err = repo.AddAttempt(ctx, id, 500, "unexpected status code 500", time.Second)
+		require.NoError(t, err)
+
+		// Следующая попытка в будущем – доставка пока не подходит.
+		err = repo.MarkFailed(ctx, id, "unexpected status code 500", time.Now().Add(time.Hour), false)
+		require.NoError(t, err)
+
+		deliveries, err = repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		// Повторить можно только доставку в статусе dead.
+		assert.ErrorIs(t, repo.Replay(ctx, id), repositories.ErrRepoDeliveryNotFound)
+
+		err = repo.MarkFailed(ctx, id, "timeout", time.Now(), true)
+		require.NoError(t, err)
+
+		dead, err := repo.GetDeadDeliveries(ctx, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, 2, dead[0].Attempts)
+		assert.Equal(t, "timeout", dead[0].LastError)
+
+		require.NoError(t, repo.Replay(ctx, id))
+
+		deliveries, err = repo.ClaimDueDeliveries(ctx, 100, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Zero(t, deliveries[0].Attempts)
+	})
+}
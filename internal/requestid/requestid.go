@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header - заголовок с идентификатором запроса. Если клиент его не передал, то идентификатор генерируется,
+// и в любом случае возвращается в ответе.
+const Header = "X-Request-ID"
+
+// maxLength - идентификатор клиента длиннее этого заменяется сгенерированным.
+const maxLength = 128
+
+type ctxKey struct{}
+
+// New отдает идентификатор клиента id, если он подходит, иначе генерирует новый.
+func New(id string) string {
+	if id == "" || len(id) > maxLength {
+		return uuid.NewString()
+	}
+
+	return id
+}
+
+// WithID кладет идентификатор запроса в контекст.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext отдает идентификатор запроса или пустую строку, если его нет.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
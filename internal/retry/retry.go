@@ -0,0 +1,50 @@
+// Package retry - повтор операции с растущей паузой, пока зависимость (база, MinIO) недоступна.
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff - пауза перед второй попыткой Base, дальше она удваивается, но не больше Max.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Do выполняет op, пока она не завершится успешно или не будет отменен ctx. notify, если задан, получает ошибку
+// каждой неудачной попытки и паузу перед следующей. Ошибку Do отдает, только если отменен ctx.
+func Do(
+	ctx context.Context,
+	b Backoff,
+	op func(ctx context.Context) error,
+	notify func(err error, wait time.Duration),
+) error {
+	wait := b.Base
+
+	for {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if notify != nil {
+			notify(err, wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+		if wait > b.Max {
+			wait = b.Max
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/frutonanny/wallet-service/internal/retry"
+)
+
+var errUnavailable = errors.New("connection refused")
+
+func TestDo(t *testing.T) {
+	b := retry.Backoff{Base: time.Millisecond, Max: 4 * time.Millisecond}
+
+	t.Run("retried until success", func(t *testing.T) {
+		var (
+			attempts int
+			waits    []time.Duration
+		)
+
+		err := retry.Do(context.Background(), b, func(context.Context) error {
+			attempts++
+			if attempts < 5 {
+				return errUnavailable
+			}
+
+			return nil
+		}, func(err error, wait time.Duration) {
+			assert.ErrorIs(t, err, errUnavailable)
+			waits = append(waits, wait)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, attempts)
+		assert.Equal(t, []time.Duration{
+			time.Millisecond,
+			2 * time.Millisecond,
+			4 * time.Millisecond,
+			4 * time.Millisecond,
+		}, waits)
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		err := retry.Do(ctx, b, func(context.Context) error {
+			cancel()
+			return errUnavailable
+		}, nil)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
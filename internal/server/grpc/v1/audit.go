@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// auditedMethods - изменяющие методы и методы администратора, которые записываются в журнал аудита.
+var auditedMethods = map[string]bool{
+	v1.WalletService_Add_FullMethodName:                 true,
+	v1.WalletService_Reserve_FullMethodName:             true,
+	v1.WalletService_ReserveCart_FullMethodName:         true,
+	v1.WalletService_WriteOff_FullMethodName:            true,
+	v1.WalletService_Cancel_FullMethodName:              true,
+	v1.WalletService_AddWebhook_FullMethodName:          true,
+	v1.WalletService_GetFailedDeliveries_FullMethodName: true,
+	v1.WalletService_ReplayDelivery_FullMethodName:      true,
+}
+
+// unaryAudit записывает в журнал аудита вызовы методов из auditedMethods. Записываются и отклоненные вызовы,
+// например, без API-ключа, см. audit.Auditor.
+func unaryAudit(recorder audit.Recorder) grpc.UnaryServerInterceptor {
+	auditor := audit.NewAuditor(recorder, metrics.TransportGRPC)
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !auditedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		call := audit.Call{
+			Endpoint: info.FullMethod,
+			Route:    info.FullMethod,
+		}
+
+		if m, ok := req.(proto.Message); ok {
+			call.Body, _ = protojson.Marshal(m)
+		}
+
+		if r, ok := req.(userRequest); ok {
+			id := r.GetUserId()
+			call.UserID = &id
+		}
+
+		if p, ok := peer.FromContext(ctx); ok {
+			call.RemoteAddr = p.Addr.String()
+		}
+
+		var (
+			resp interface{}
+			err  error
+		)
+
+		auditor.Do(ctx, call, func(ctx context.Context) string {
+			resp, err = handler(ctx, req)
+			return resultCode(err)
+		})
+
+		return resp, err
+	}
+}
+
+// resultCode - код результата вызова для журнала: ok или reason из google.rpc.ErrorInfo статуса.
+func resultCode(err error) string {
+	if err == nil {
+		return audit.ResultOK
+	}
+
+	st := status.Convert(err)
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorDomain {
+			return info.GetReason()
+		}
+	}
+
+	if st.Code() == codes.InvalidArgument {
+		return errcodes.InvalidRequest
+	}
+
+	return errcodes.InternalError
+}
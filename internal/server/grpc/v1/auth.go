@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/auth"
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// errorDomain - domain в google.rpc.ErrorInfo, как и в ошибках обработчиков.
+const errorDomain = "wallet-service"
+
+// walletServicePrefix - методы кошелька требуют API-ключ, а служебные сервисы вроде reflection – нет.
+const walletServicePrefix = "/wallet.v1.WalletService/"
+
+// scopes - право, которое требует каждый метод. Метод кошелька, которого здесь нет, не доступен ни одному ключу.
+var scopes = map[string]string{
+	v1.WalletService_Add_FullMethodName:                   auth.ScopeAdd,
+	v1.WalletService_Reserve_FullMethodName:               auth.ScopeReserve,
+	v1.WalletService_ReserveCart_FullMethodName:           auth.ScopeReserve,
+	v1.WalletService_WriteOff_FullMethodName:              auth.ScopeWriteOff,
+	v1.WalletService_Cancel_FullMethodName:                auth.ScopeCancel,
+	v1.WalletService_GetBalance_FullMethodName:            auth.ScopeRead,
+	v1.WalletService_GetBalances_FullMethodName:           auth.ScopeRead,
+	v1.WalletService_GetBalanceAt_FullMethodName:          auth.ScopeRead,
+	v1.WalletService_GetDailyBalances_FullMethodName:      auth.ScopeRead,
+	v1.WalletService_GetTransactions_FullMethodName:       auth.ScopeRead,
+	v1.WalletService_GetTransactionsByTime_FullMethodName: auth.ScopeRead,
+	v1.WalletService_GetTransaction_FullMethodName:        auth.ScopeRead,
+	v1.WalletService_GetHistory_FullMethodName:            auth.ScopeRead,
+	v1.WalletService_StreamEvents_FullMethodName:          auth.ScopeRead,
+	v1.WalletService_ExportStatement_FullMethodName:       auth.ScopeStatements,
+	v1.WalletService_GetStatements_FullMethodName:         auth.ScopeStatements,
+	v1.WalletService_GetStatement_FullMethodName:          auth.ScopeStatements,
+	v1.WalletService_GetReport_FullMethodName:             auth.ScopeReport,
+	v1.WalletService_AddWebhook_FullMethodName:            auth.ScopeAdmin,
+	v1.WalletService_GetFailedDeliveries_FullMethodName:   auth.ScopeAdmin,
+	v1.WalletService_ReplayDelivery_FullMethodName:        auth.ScopeAdmin,
+}
+
+type authenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Client, error)
+}
+
+func unaryAuth(authenticator authenticator) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(authenticator authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate проверяет API-ключ из метаданных authorization (Bearer <ключ>) и право на метод method.
+// Отдает контекст с клиентом или статус Unauthenticated / PermissionDenied.
+func authenticate(ctx context.Context, authenticator authenticator, method string) (context.Context, error) {
+	if !strings.HasPrefix(method, walletServicePrefix) {
+		return ctx, nil
+	}
+
+	var key string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(auth.HeaderAuthorization); len(values) > 0 {
+			key = auth.BearerKey(values[0])
+		}
+	}
+
+	client, err := authenticator.Authenticate(ctx, key)
+	if err != nil {
+		if errors.Is(err, servicesErrors.ErrUnauthenticated) {
+			msg := "api key is missing, unknown or revoked"
+			return nil, newStatus(codes.Unauthenticated, errcodes.Unauthenticated, msg)
+		}
+
+		return nil, newStatus(codes.Internal, errcodes.InternalError, "internal server error")
+	}
+
+	audit.SetClient(ctx, client)
+
+	scope, ok := scopes[method]
+	if !ok || !client.Allowed(scope) {
+		return nil, newStatus(codes.PermissionDenied, errcodes.Forbidden, "api key has no access to this method")
+	}
+
+	return auth.WithClient(ctx, client), nil
+}
+
+// serverStream подменяет контекст потока на контекст с клиентом.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func newStatus(code codes.Code, errcode, msg string) error {
+	st := status.New(code, msg)
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: errcode,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// errorDomain - domain в google.rpc.ErrorInfo, по нему клиент понимает, что reason – код из pkg/errcodes.
+const errorDomain = "wallet-service"
+
+// serviceErrors сопоставляет ошибкам сервисов код gRPC и код из pkg/errcodes.
+var serviceErrors = []struct {
+	err     error
+	code    codes.Code
+	errcode string
+	msg     string
+}{
+	{servicesErrors.ErrWalletNotFound, codes.NotFound, errcodes.WalletNotFound, "wallet not found"},
+	{servicesErrors.ErrNotEnoughCash, codes.FailedPrecondition, errcodes.NotEnoughCash, "not enough cash"},
+	{servicesErrors.ErrOrderNotFound, codes.NotFound, errcodes.OrderNotFound, "order not found"},
+	{
+		servicesErrors.ErrAmbiguousOrder,
+		codes.InvalidArgument,
+		errcodes.AmbiguousOrder,
+		"order has several items, service must be specified",
+	},
+	{
+		servicesErrors.ErrDuplicateService,
+		codes.InvalidArgument,
+		errcodes.DuplicateService,
+		"cart contains duplicate services",
+	},
+	{
+		servicesErrors.ErrOrderExists,
+		codes.AlreadyExists,
+		errcodes.OrderExists,
+		"order with this service already exists",
+	},
+	{servicesErrors.ErrInvalidPageToken, codes.InvalidArgument, errcodes.InvalidPageToken, "invalid page token"},
+	{servicesErrors.ErrInvalidLimit, codes.InvalidArgument, errcodes.InvalidRequest, "limit must be positive"},
+	{servicesErrors.ErrTransactionNotFound, codes.NotFound, errcodes.TransactionNotFound, "transaction not found"},
+	{servicesErrors.ErrInvalidPeriod, codes.InvalidArgument, errcodes.InvalidPeriod, "invalid period"},
+	{servicesErrors.ErrPeriodTooLong, codes.InvalidArgument, errcodes.PeriodTooLong, "period is too long"},
+	{servicesErrors.ErrStatementNotFound, codes.NotFound, errcodes.StatementNotFound, "statement not found"},
+	{servicesErrors.ErrInvalidWebhookURL, codes.InvalidArgument, errcodes.InvalidWebhookURL, "invalid webhook url"},
+	{servicesErrors.ErrDeliveryNotFound, codes.NotFound, errcodes.DeliveryNotFound, "delivery not found"},
+}
+
+// adaptError преобразует ошибку сервиса в статус gRPC. Код из pkg/errcodes передается в google.rpc.ErrorInfo.
+func adaptError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	for _, e := range serviceErrors {
+		if errors.Is(err, e.err) {
+			return newStatus(e.code, e.errcode, e.msg)
+		}
+	}
+
+	return newStatus(codes.Internal, errcodes.InternalError, "internal server error")
+}
+
+// invalidArgument - ошибка разбора или проверки запроса. Как и ошибки сервисов, несет код invalid_request
+// в google.rpc.ErrorInfo.
+func invalidArgument(msg string) error {
+	return newStatus(codes.InvalidArgument, errcodes.InvalidRequest, msg)
+}
+
+func newStatus(code codes.Code, errcode, msg string) error {
+	st := status.New(code, msg)
+
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: errcode,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) Add(ctx context.Context, req *v1.AddRequest) (*v1.AddResponse, error) {
+	if err := validatePositive("cash", req.GetCash()); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.addService.Add(ctx, req.GetUserId(), req.GetCash())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.AddResponse{Balance: balance}, nil
+}
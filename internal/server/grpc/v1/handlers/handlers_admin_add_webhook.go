@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) AddWebhook(ctx context.Context, req *v1.AddWebhookRequest) (*v1.AddWebhookResponse, error) {
+	subscription, err := h.manageWebhooks.AddSubscription(ctx, req.GetUrl(), req.GetEventTypes())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.AddWebhookResponse{
+		SubscriptionId: subscription.ID,
+		Secret:         subscription.Secret,
+	}, nil
+}
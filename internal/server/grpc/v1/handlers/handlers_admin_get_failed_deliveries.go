@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+const defaultFailedDeliveriesLimit = 100
+
+func (h *Handlers) GetFailedDeliveries(
+	ctx context.Context,
+	req *v1.GetFailedDeliveriesRequest,
+) (*v1.GetFailedDeliveriesResponse, error) {
+	limit := int64(req.GetLimit())
+	if limit == 0 {
+		limit = defaultFailedDeliveriesLimit
+	}
+
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := h.manageWebhooks.GetFailedDeliveries(ctx, req.GetAfterId(), int(limit))
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	result := make([]*v1.FailedDelivery, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		result = append(result, &v1.FailedDelivery{
+			Id:             d.ID,
+			SubscriptionId: d.SubscriptionID,
+			Url:            d.URL,
+			EventId:        d.EventID,
+			EventType:      d.EventType,
+			Attempts:       int32(d.Attempts),
+			LastError:      d.LastError,
+			FailedAt:       timestamppb.New(d.FailedAt),
+		})
+	}
+
+	return &v1.GetFailedDeliveriesResponse{Deliveries: result}, nil
+}
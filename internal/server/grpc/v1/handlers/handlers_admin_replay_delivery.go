@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) ReplayDelivery(
+	ctx context.Context,
+	req *v1.ReplayDeliveryRequest,
+) (*v1.ReplayDeliveryResponse, error) {
+	if err := h.manageWebhooks.ReplayDelivery(ctx, req.GetDeliveryId()); err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.ReplayDeliveryResponse{DeliveryId: req.GetDeliveryId()}, nil
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) Cancel(ctx context.Context, req *v1.CancelRequest) (*v1.CancelResponse, error) {
+	balance, err := h.cancelService.Cancel(ctx, req.GetUserId(), req.GetServiceId(), req.GetOrderId())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.CancelResponse{Balance: balance}, nil
+}
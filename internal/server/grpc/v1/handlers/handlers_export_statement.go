@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"bufio"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+)
+
+// exportChunkSize - размер части выписки в одном сообщении потока.
+const exportChunkSize = 32 * 1024
+
+func (h *Handlers) ExportStatement(
+	req *v1.ExportStatementRequest,
+	stream v1.WalletService_ExportStatementServer,
+) error {
+	ctx := stream.Context()
+
+	if req.GetStart() == nil || req.GetEnd() == nil {
+		return invalidArgument("start and end are required")
+	}
+
+	start := req.GetStart().AsTime()
+	end := req.GetEnd().AsTime()
+	format := adaptStatementFormat(req.GetFormat())
+	locale := adaptLocale(req.GetLocale())
+
+	if req.GetDelivery() == v1.StatementDelivery_STATEMENT_DELIVERY_LINK {
+		link, err := h.exportStatement.ExportToStorage(ctx, req.GetUserId(), start, end, format, locale)
+		if err != nil {
+			return adaptError(err)
+		}
+
+		return stream.Send(&v1.ExportStatementResponse{
+			Result: &v1.ExportStatementResponse_Link{
+				Link: &v1.Link{
+					Url:       link.URL,
+					ExpiresAt: timestamppb.New(link.ExpiresAt),
+				},
+			},
+		})
+	}
+
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, exportChunkSize)
+
+	if err := h.exportStatement.Export(ctx, req.GetUserId(), start, end, format, locale, w); err != nil {
+		return adaptError(err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return adaptError(err)
+	}
+
+	return nil
+}
+
+func adaptStatementFormat(format v1.StatementFormat) export_statement.Format {
+	switch format {
+	case v1.StatementFormat_STATEMENT_FORMAT_NDJSON:
+		return export_statement.FormatNDJSON
+	default:
+		return export_statement.FormatCSV
+	}
+}
+
+// chunkWriter отправляет каждую запись отдельным сообщением потока.
+type chunkWriter struct {
+	stream v1.WalletService_ExportStatementServer
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	// Сообщение сериализуется при отправке, но буфер bufio переиспользуется, поэтому копируем.
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	if err := w.stream.Send(&v1.ExportStatementResponse{
+		Result: &v1.ExportStatementResponse_Chunk{Chunk: chunk},
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+)
+
+func (h *Handlers) GetBalance(ctx context.Context, req *v1.GetBalanceRequest) (*v1.GetBalanceResponse, error) {
+	balance, err := h.getBalanceService.GetBalance(ctx, req.GetUserId())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetBalanceResponse{
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+		Total:     balance.Total,
+		Holds:     adaptHolds(balance.Holds),
+	}, nil
+}
+
+func adaptHolds(holds []get_balance.Hold) []*v1.Hold {
+	result := make([]*v1.Hold, 0, len(holds))
+
+	for _, h := range holds {
+		result = append(result, &v1.Hold{
+			OrderId:   h.OrderID,
+			ServiceId: h.ServiceID,
+			Amount:    h.Amount,
+			CreatedAt: timestamppb.New(h.CreatedAt),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) GetBalanceAt(ctx context.Context, req *v1.GetBalanceAtRequest) (*v1.GetBalanceAtResponse, error) {
+	if req.GetAt() == nil {
+		return nil, invalidArgument("at is required")
+	}
+
+	balance, err := h.getBalanceAt.GetBalanceAt(ctx, req.GetUserId(), req.GetAt().AsTime())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetBalanceAtResponse{
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+	}, nil
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+)
+
+func (h *Handlers) GetBalances(ctx context.Context, req *v1.GetBalancesRequest) (*v1.GetBalancesResponse, error) {
+	balances, err := h.getBalanceService.GetBalances(ctx, req.GetUserIds())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetBalancesResponse{
+		Balances: adaptUserBalances(balances),
+	}, nil
+}
+
+func adaptUserBalances(balances []get_balance.Balance) []*v1.UserBalance {
+	result := make([]*v1.UserBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, &v1.UserBalance{
+			UserId:    b.UserID,
+			Available: b.Available,
+			Reserved:  b.Reserved,
+			Total:     b.Total,
+			Holds:     adaptHolds(b.Holds),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+)
+
+const dateLayout = "2006-01-02"
+
+func (h *Handlers) GetDailyBalances(
+	ctx context.Context,
+	req *v1.GetDailyBalancesRequest,
+) (*v1.GetDailyBalancesResponse, error) {
+	from, err := time.Parse(dateLayout, req.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from must be a date in format 2006-01-02")
+	}
+
+	to, err := time.Parse(dateLayout, req.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to must be a date in format 2006-01-02")
+	}
+
+	balances, err := h.getBalanceAt.GetDailyBalances(ctx, req.GetUserId(), from, to)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetDailyBalancesResponse{
+		Balances: adaptDailyBalances(balances),
+	}, nil
+}
+
+func adaptDailyBalances(balances []get_balance_at.DailyBalance) []*v1.DailyBalance {
+	result := make([]*v1.DailyBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, &v1.DailyBalance{
+			Date:      b.Date.Format(dateLayout),
+			Available: b.Available,
+			Reserved:  b.Reserved,
+		})
+	}
+
+	return result
+}
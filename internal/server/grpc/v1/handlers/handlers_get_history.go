@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) GetHistory(ctx context.Context, req *v1.GetHistoryRequest) (*v1.GetHistoryResponse, error) {
+	if err := validateLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	txs, next, err := h.getHistory.GetHistory(
+		ctx,
+		req.GetUserId(),
+		req.GetLimit(),
+		req.GetPageToken(),
+		adaptLocale(req.GetLocale()),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	result := make([]*v1.Transaction, 0, len(txs))
+
+	for i := range txs {
+		result = append(result, &v1.Transaction{
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderId:      txs[i].OrderID,
+			ServiceId:    txs[i].ServiceID,
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    timestamppb.New(txs[i].CreatedAt),
+			Description:  txs[i].Description,
+		})
+	}
+
+	return &v1.GetHistoryResponse{
+		Transactions:  result,
+		NextPageToken: next,
+	}, nil
+}
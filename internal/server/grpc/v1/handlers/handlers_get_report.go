@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) GetReport(ctx context.Context, req *v1.GetReportRequest) (*v1.GetReportResponse, error) {
+	url, err := h.getReport.GetReport(ctx, req.GetPeriod())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetReportResponse{Url: url}, nil
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) GetStatement(ctx context.Context, req *v1.GetStatementRequest) (*v1.GetStatementResponse, error) {
+	statement, link, err := h.getStatements.GetStatement(ctx, req.GetUserId(), req.GetPeriod())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetStatementResponse{
+		Statement: adaptStatement(statement),
+		Link: &v1.Link{
+			Url:       link.URL,
+			ExpiresAt: timestamppb.New(link.ExpiresAt),
+		},
+	}, nil
+}
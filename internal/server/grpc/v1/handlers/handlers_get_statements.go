@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+)
+
+func (h *Handlers) GetStatements(ctx context.Context, req *v1.GetStatementsRequest) (*v1.GetStatementsResponse, error) {
+	statements, err := h.getStatements.GetStatements(ctx, req.GetUserId())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	result := make([]*v1.Statement, 0, len(statements))
+
+	for _, s := range statements {
+		result = append(result, adaptStatement(s))
+	}
+
+	return &v1.GetStatementsResponse{Statements: result}, nil
+}
+
+func adaptStatement(s get_statements.Statement) *v1.Statement {
+	return &v1.Statement{
+		Period:         s.Period,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		CreatedAt:      timestamppb.New(s.CreatedAt),
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) GetTransaction(
+	ctx context.Context,
+	req *v1.GetTransactionRequest,
+) (*v1.GetTransactionResponse, error) {
+	tx, err := h.getTransaction.GetTransaction(
+		ctx,
+		req.GetUserId(),
+		req.GetTransactionId(),
+		adaptLocale(req.GetLocale()),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.GetTransactionResponse{
+		Transaction: &v1.Transaction{
+			Id:           tx.ID,
+			Type:         adaptTxType(tx.Type),
+			Amount:       tx.Amount,
+			OrderId:      tx.OrderID,
+			ServiceId:    tx.ServiceID,
+			BalanceAfter: tx.BalanceAfter,
+			CreatedAt:    timestamppb.New(tx.CreatedAt),
+			Description:  tx.Description,
+		},
+	}, nil
+}
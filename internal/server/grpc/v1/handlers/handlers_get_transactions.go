@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+func (h *Handlers) GetTransactions(
+	ctx context.Context,
+	req *v1.GetTransactionsRequest,
+) (*v1.GetTransactionsResponse, error) {
+	if err := validateLimit(req.GetLimit()); err != nil {
+		return nil, err
+	}
+
+	if req.GetOffset() < 0 {
+		return nil, invalidArgument("offset must not be negative")
+	}
+
+	f := req.GetFilter()
+	if err := validateFilter(f); err != nil {
+		return nil, err
+	}
+
+	txs, err := h.getTransactions.GetTransactions(
+		ctx,
+		req.GetUserId(),
+		req.GetLimit(),
+		req.GetOffset(),
+		adaptSortBy(req.GetSortBy()),
+		adaptDirection(req.GetDirection()),
+		get_transactions.Filter{
+			Types:      adaptFilterTypes(f.GetTypes()),
+			AmountFrom: f.GetAmountFrom(),
+			AmountTo:   f.GetAmountTo(),
+			OrderID:    f.GetOrderId(),
+			ServiceID:  f.GetServiceId(),
+		},
+		adaptLocale(req.GetLocale()),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	result := make([]*v1.Transaction, 0, len(txs))
+
+	for i := range txs {
+		result = append(result, &v1.Transaction{
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderId:      txs[i].OrderID,
+			ServiceId:    txs[i].ServiceID,
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    timestamppb.New(txs[i].CreatedAt),
+			Description:  txs[i].Description,
+		})
+	}
+
+	return &v1.GetTransactionsResponse{Transactions: result}, nil
+}
+
+func adaptSortBy(sortBy v1.SortBy) get_transactions.SortBy {
+	switch sortBy {
+	case v1.SortBy_SORT_BY_AMOUNT:
+		return get_transactions.Amount
+	default:
+		return get_transactions.CreatedAt
+	}
+}
+
+func adaptDirection(direction v1.Direction) get_transactions.Direction {
+	switch direction {
+	case v1.Direction_DIRECTION_ASC:
+		return get_transactions.Asc
+	default:
+		return get_transactions.Desc
+	}
+}
+
+// validateFilter проверяет, что суммы и идентификаторы фильтра не отрицательные. 0 – поле фильтра не задано.
+func validateFilter(f *v1.TransactionsFilter) error {
+	fields := []struct {
+		name  string
+		value int64
+	}{
+		{"filter.amount_from", f.GetAmountFrom()},
+		{"filter.amount_to", f.GetAmountTo()},
+		{"filter.order_id", f.GetOrderId()},
+		{"filter.service_id", f.GetServiceId()},
+	}
+
+	for _, field := range fields {
+		if field.value < 0 {
+			return invalidArgument(fmt.Sprintf("%s must not be negative", field.name))
+		}
+	}
+
+	return nil
+}
+
+// adaptFilterTypes преобразует типы транзакций из запроса в типы транзакций сервиса.
+func adaptFilterTypes(types []v1.TransactionType) []string {
+	if len(types) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(types))
+
+	for _, t := range types {
+		switch t {
+		case v1.TransactionType_TRANSACTION_TYPE_INCOMING_TRANSFER:
+			result = append(result, transactions.TypeAdd)
+		case v1.TransactionType_TRANSACTION_TYPE_RESERVATION:
+			result = append(result, transactions.TypeReserve)
+		case v1.TransactionType_TRANSACTION_TYPE_WRITE_OFF:
+			result = append(result, transactions.TypeWriteOff)
+		case v1.TransactionType_TRANSACTION_TYPE_CANCEL:
+			result = append(result, transactions.TypeCancel)
+		case v1.TransactionType_TRANSACTION_TYPE_OUTGOING_TRANSFER:
+			result = append(result, transactions.TypeOutgoing)
+		}
+	}
+
+	return result
+}
+
+// adaptTxType преобразует тип транзакции сервиса в тип транзакции ответа.
+func adaptTxType(txType string) v1.TransactionType {
+	switch txType {
+	case transactions.TypeAdd:
+		return v1.TransactionType_TRANSACTION_TYPE_INCOMING_TRANSFER
+	case transactions.TypeReserve:
+		return v1.TransactionType_TRANSACTION_TYPE_RESERVATION
+	case transactions.TypeWriteOff:
+		return v1.TransactionType_TRANSACTION_TYPE_WRITE_OFF
+	case transactions.TypeOutgoing:
+		return v1.TransactionType_TRANSACTION_TYPE_OUTGOING_TRANSFER
+	default:
+		return v1.TransactionType_TRANSACTION_TYPE_CANCEL
+	}
+}
+
+// adaptLocale выбирает язык описаний транзакций. Значение разбирается так же, как заголовок Accept-Language.
+func adaptLocale(locale string) i18n.Locale {
+	if locale == "" {
+		return i18n.DefaultLocale
+	}
+
+	return i18n.ParseLocale(locale)
+}
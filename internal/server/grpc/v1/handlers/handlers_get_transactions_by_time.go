@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+)
+
+func (h *Handlers) GetTransactionsByTime(
+	ctx context.Context,
+	req *v1.GetTransactionsByTimeRequest,
+) (*v1.GetTransactionsByTimeResponse, error) {
+	if req.GetStart() == nil || req.GetEnd() == nil {
+		return nil, invalidArgument("start and end are required")
+	}
+
+	f := req.GetFilter()
+	if err := validateFilter(f); err != nil {
+		return nil, err
+	}
+
+	txs, err := h.getTransactionsByTime.GetTransactionsByTime(
+		ctx,
+		req.GetUserId(),
+		req.GetStart().AsTime(),
+		req.GetEnd().AsTime(),
+		get_transactions_by_time.Filter{
+			Types:      adaptFilterTypes(f.GetTypes()),
+			AmountFrom: f.GetAmountFrom(),
+			AmountTo:   f.GetAmountTo(),
+			OrderID:    f.GetOrderId(),
+			ServiceID:  f.GetServiceId(),
+		},
+		adaptLocale(req.GetLocale()),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	result := make([]*v1.Transaction, 0, len(txs))
+
+	for i := range txs {
+		result = append(result, &v1.Transaction{
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderId:      txs[i].OrderID,
+			ServiceId:    txs[i].ServiceID,
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    timestamppb.New(txs[i].CreatedAt),
+			Description:  txs[i].Description,
+		})
+	}
+
+	return &v1.GetTransactionsByTimeResponse{Transactions: result}, nil
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) Reserve(ctx context.Context, req *v1.ReserveRequest) (*v1.ReserveResponse, error) {
+	if err := validatePositive("price", req.GetPrice()); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.reserveService.Reserve(ctx, req.GetUserId(), req.GetServiceId(), req.GetOrderId(), req.GetPrice())
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.ReserveResponse{Balance: balance}, nil
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+)
+
+func (h *Handlers) ReserveCart(ctx context.Context, req *v1.ReserveCartRequest) (*v1.ReserveCartResponse, error) {
+	if err := validateCartItems(req.GetItems()); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.reserveCartService.ReserveCart(
+		ctx,
+		req.GetUserId(),
+		req.GetOrderId(),
+		adaptCartItems(req.GetItems()),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.ReserveCartResponse{Balance: balance}, nil
+}
+
+// validateCartItems проверяет, что в корзине от 1 до maxCartItems позиций и цена каждой больше нуля.
+func validateCartItems(items []*v1.CartItem) error {
+	if len(items) == 0 || len(items) > maxCartItems {
+		return invalidArgument(fmt.Sprintf("items must contain between 1 and %d items", maxCartItems))
+	}
+
+	for _, item := range items {
+		if err := validatePositive("price", item.GetPrice()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func adaptCartItems(items []*v1.CartItem) []reserve_cart.Item {
+	result := make([]reserve_cart.Item, 0, len(items))
+
+	for _, item := range items {
+		result = append(result, reserve_cart.Item{
+			ServiceID: item.GetServiceId(),
+			Price:     item.GetPrice(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) StreamEvents(req *v1.StreamEventsRequest, stream v1.WalletService_StreamEventsServer) error {
+	ctx := stream.Context()
+
+	sub, err := h.streamEvents.Subscribe(ctx, req.GetUserId(), req.GetLastEventId())
+	if err != nil {
+		return adaptError(err)
+	}
+	defer sub.Close()
+
+	for {
+		e, ok := sub.Next(ctx)
+		if !ok {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			// Сервис остановился или клиент не успевал читать – пусть переподпишется с last_event_id.
+			return status.Error(codes.Unavailable, "stream closed, resubscribe with last_event_id")
+		}
+
+		if err := stream.Send(&v1.Event{
+			Id:         e.ID,
+			Type:       e.Type,
+			Version:    int32(e.Version),
+			OccurredAt: timestamppb.New(e.OccurredAt),
+			Data:       e.Data,
+		}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"context"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+func (h *Handlers) WriteOff(ctx context.Context, req *v1.WriteOffRequest) (*v1.WriteOffResponse, error) {
+	if err := validatePositive("price", req.GetPrice()); err != nil {
+		return nil, err
+	}
+
+	balance, err := h.writeOffService.WriteOff(
+		ctx,
+		req.GetUserId(),
+		req.GetServiceId(),
+		req.GetOrderId(),
+		req.GetPrice(),
+	)
+	if err != nil {
+		return nil, adaptError(err)
+	}
+
+	return &v1.WriteOffResponse{Balance: balance}, nil
+}
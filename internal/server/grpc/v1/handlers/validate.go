@@ -0,0 +1,28 @@
+package handlers
+
+import "fmt"
+
+// Границы те же, что объявлены в api/schema.yaml: http-серверы проверяют их валидатором OpenAPI, а у gRPC такой
+// проверки нет, поэтому обработчики проверяют запросы сами.
+const (
+	maxPageLimit = 100
+	maxCartItems = 100
+)
+
+// validatePositive проверяет, что сумма в копейках больше нуля.
+func validatePositive(field string, amount int64) error {
+	if amount <= 0 {
+		return invalidArgument(fmt.Sprintf("%s must be positive", field))
+	}
+
+	return nil
+}
+
+// validateLimit проверяет размер страницы: от 1 до maxPageLimit.
+func validateLimit(limit int64) error {
+	if limit < 1 || limit > maxPageLimit {
+		return invalidArgument(fmt.Sprintf("limit must be between 1 and %d", maxPageLimit))
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/server/grpc/v1/handlers"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// Запросы за границами схемы отклоняются до вызова сервисов, поэтому сервисы в обработчиках не заданы.
+func TestHandlers_InvalidArgument(t *testing.T) {
+	ctx := context.Background()
+	h := &handlers.Handlers{}
+
+	cart := make([]*v1.CartItem, 101)
+	for i := range cart {
+		cart[i] = &v1.CartItem{ServiceId: int64(i + 1), Price: 100}
+	}
+
+	cases := []struct {
+		name string
+		call func() error
+	}{
+		{"get history with zero limit", func() error {
+			_, err := h.GetHistory(ctx, &v1.GetHistoryRequest{UserId: 1})
+			return err
+		}},
+		{"get history with limit above maximum", func() error {
+			_, err := h.GetHistory(ctx, &v1.GetHistoryRequest{UserId: 1, Limit: 101})
+			return err
+		}},
+		{"add zero cash", func() error {
+			_, err := h.Add(ctx, &v1.AddRequest{UserId: 1})
+			return err
+		}},
+		{"add negative cash", func() error {
+			_, err := h.Add(ctx, &v1.AddRequest{UserId: 1, Cash: -100})
+			return err
+		}},
+		{"reserve negative price", func() error {
+			_, err := h.Reserve(ctx, &v1.ReserveRequest{UserId: 1, ServiceId: 1, OrderId: 1, Price: -100})
+			return err
+		}},
+		{"write off zero price", func() error {
+			_, err := h.WriteOff(ctx, &v1.WriteOffRequest{UserId: 1, ServiceId: 1, OrderId: 1})
+			return err
+		}},
+		{"reserve empty cart", func() error {
+			_, err := h.ReserveCart(ctx, &v1.ReserveCartRequest{UserId: 1, OrderId: 1})
+			return err
+		}},
+		{"reserve cart with too many items", func() error {
+			_, err := h.ReserveCart(ctx, &v1.ReserveCartRequest{UserId: 1, OrderId: 1, Items: cart})
+			return err
+		}},
+		{"reserve cart with zero price", func() error {
+			_, err := h.ReserveCart(ctx, &v1.ReserveCartRequest{
+				UserId:  1,
+				OrderId: 1,
+				Items:   []*v1.CartItem{{ServiceId: 1, Price: 100}, {ServiceId: 2}},
+			})
+			return err
+		}},
+		{"get transactions with zero limit", func() error {
+			_, err := h.GetTransactions(ctx, &v1.GetTransactionsRequest{UserId: 1})
+			return err
+		}},
+		{"get transactions with limit above maximum", func() error {
+			_, err := h.GetTransactions(ctx, &v1.GetTransactionsRequest{UserId: 1, Limit: 1000})
+			return err
+		}},
+		{"get transactions with negative offset", func() error {
+			_, err := h.GetTransactions(ctx, &v1.GetTransactionsRequest{UserId: 1, Limit: 10, Offset: -1})
+			return err
+		}},
+		{"get transactions with negative filter", func() error {
+			_, err := h.GetTransactions(ctx, &v1.GetTransactionsRequest{
+				UserId: 1,
+				Limit:  10,
+				Filter: &v1.TransactionsFilter{AmountFrom: -1},
+			})
+			return err
+		}},
+		{"get failed deliveries with negative limit", func() error {
+			_, err := h.GetFailedDeliveries(ctx, &v1.GetFailedDeliveriesRequest{Limit: -1})
+			return err
+		}},
+		{"get failed deliveries with limit above maximum", func() error {
+			_, err := h.GetFailedDeliveries(ctx, &v1.GetFailedDeliveriesRequest{Limit: 101})
+			return err
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			require.Error(t, err)
+
+			st := status.Convert(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			assert.Equal(t, errcodes.InvalidRequest, info.GetReason())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/frutonanny/wallet-service/internal/metrics"
+)
+
+// unaryMetrics считает вызовы и время их обработки по методу и коду результата, как и для http.
+func unaryMetrics() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		started := time.Now()
+
+		resp, err := handler(ctx, req)
+		metrics.ObserveRequest(metrics.TransportGRPC, info.FullMethod, resultCode(err), started)
+
+		return resp, err
+	}
+}
+
+// streamMetrics учитывает поток целиком: время - от открытия до закрытия.
+func streamMetrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+
+		err := handler(srv, ss)
+		metrics.ObserveRequest(metrics.TransportGRPC, info.FullMethod, resultCode(err), started)
+
+		return err
+	}
+}
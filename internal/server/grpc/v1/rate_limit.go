@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+	"github.com/frutonanny/wallet-service/internal/operations"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// methodOperations - операция каждого метода кошелька. Ограничения частоты задаются по операциям, общим для всех
+// транспортов, а служебные сервисы вроде reflection не ограничиваются.
+var methodOperations = map[string]string{
+	v1.WalletService_Add_FullMethodName:                   operations.Add,
+	v1.WalletService_Reserve_FullMethodName:               operations.Reserve,
+	v1.WalletService_ReserveCart_FullMethodName:           operations.ReserveCart,
+	v1.WalletService_WriteOff_FullMethodName:              operations.WriteOff,
+	v1.WalletService_Cancel_FullMethodName:                operations.Cancel,
+	v1.WalletService_GetBalance_FullMethodName:            operations.GetBalance,
+	v1.WalletService_GetBalances_FullMethodName:           operations.GetBalances,
+	v1.WalletService_GetBalanceAt_FullMethodName:          operations.GetBalanceAt,
+	v1.WalletService_GetDailyBalances_FullMethodName:      operations.GetDailyBalances,
+	v1.WalletService_GetTransactions_FullMethodName:       operations.GetTransactions,
+	v1.WalletService_GetTransactionsByTime_FullMethodName: operations.GetTransactionsByTime,
+	v1.WalletService_GetTransaction_FullMethodName:        operations.GetTransaction,
+	v1.WalletService_GetHistory_FullMethodName:            operations.GetHistory,
+	v1.WalletService_StreamEvents_FullMethodName:          operations.StreamEvents,
+	v1.WalletService_ExportStatement_FullMethodName:       operations.ExportStatement,
+	v1.WalletService_GetStatements_FullMethodName:         operations.GetStatements,
+	v1.WalletService_GetStatement_FullMethodName:          operations.GetStatement,
+	v1.WalletService_GetReport_FullMethodName:             operations.GetReport,
+	v1.WalletService_AddWebhook_FullMethodName:            operations.AddWebhook,
+	v1.WalletService_GetFailedDeliveries_FullMethodName:   operations.GetFailedDeliveries,
+	v1.WalletService_ReplayDelivery_FullMethodName:        operations.ReplayDelivery,
+}
+
+type rateLimiter interface {
+	Allow(ctx context.Context, operation string, clientID int64, userID *int64) (time.Duration, error)
+}
+
+// userRequest - запрос, который относится к одному пользователю.
+type userRequest interface {
+	GetUserId() int64
+}
+
+func unaryRateLimit(limiter rateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := allow(ctx, limiter, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamRateLimit проверяет ограничения, когда обработчик читает запрос из потока: только в нем есть userID.
+func streamRateLimit(limiter rateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &rateLimitedStream{ServerStream: ss, limiter: limiter, method: info.FullMethod})
+	}
+}
+
+// allow расходует запрос из ограничений клиента и пользователя запроса req. Если ограничение превышено, то отдает
+// статус ResourceExhausted с кодом rate_limited и временем, через которое запрос можно повторить
+// (google.rpc.RetryInfo).
+func allow(ctx context.Context, limiter rateLimiter, method string, req interface{}) error {
+	operation, ok := methodOperations[method]
+	if !ok {
+		return nil
+	}
+
+	client, _ := auth.ClientFrom(ctx)
+
+	var userID *int64
+
+	if r, ok := req.(userRequest); ok {
+		id := r.GetUserId()
+		userID = &id
+	}
+
+	wait, err := limiter.Allow(ctx, operation, client.KeyID, userID)
+	if !errors.Is(err, servicesErrors.ErrRateLimited) {
+		return nil
+	}
+
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: errcodes.RateLimited,
+			Domain: errorDomain,
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(wait),
+		},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return st.Err()
+}
+
+// rateLimitedStream проверяет ограничения на первом запросе из потока.
+type rateLimitedStream struct {
+	grpc.ServerStream
+	limiter rateLimiter
+	method  string
+	once    sync.Once
+}
+
+func (s *rateLimitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	var err error
+
+	s.once.Do(func() {
+		err = allow(s.Context(), s.limiter, s.method, m)
+	})
+
+	return err
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+)
+
+type logger interface {
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+// unaryRecovery превращает панику в обработчике в ошибку Internal. gRPC, в отличие от net/http, панику не
+// перехватывает, и без этого один вызов останавливает весь процесс вместе с http-сервером.
+func unaryRecovery(logger logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (_ interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func streamRecovery(logger logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// recovered логирует панику со стеком, а клиенту отдает только внутреннюю ошибку.
+func recovered(ctx context.Context, logger logger, method string, r interface{}) error {
+	logger.Error(ctx, "panic in grpc handler",
+		slog.String("method", method),
+		logfield.Error(fmt.Errorf("%v", r)),
+		slog.String("stack", string(debug.Stack())),
+	)
+
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testLogger struct {
+	errors []string
+}
+
+func (l *testLogger) Error(_ context.Context, msg string, _ ...interface{}) {
+	l.errors = append(l.errors, msg)
+}
+
+func TestUnaryRecovery(t *testing.T) {
+	t.Run("panic becomes internal error", func(t *testing.T) {
+		log := &testLogger{}
+
+		_, err := unaryRecovery(log)(
+			context.Background(),
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: "/wallet.v1.WalletService/GetHistory"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				var txs []int
+				return txs[len(txs)-1], nil
+			},
+		)
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Len(t, log.errors, 1)
+	})
+
+	t.Run("response passes through", func(t *testing.T) {
+		log := &testLogger{}
+
+		resp, err := unaryRecovery(log)(
+			context.Background(),
+			nil,
+			&grpc.UnaryServerInfo{},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+		assert.Empty(t, log.errors)
+	})
+}
+
+type testStream struct {
+	grpc.ServerStream
+}
+
+func (s testStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamRecovery(t *testing.T) {
+	log := &testLogger{}
+
+	err := streamRecovery(log)(
+		nil,
+		testStream{},
+		&grpc.StreamServerInfo{FullMethod: "/wallet.v1.WalletService/StreamEvents"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			panic("boom")
+		},
+	)
+	require.Error(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Len(t, log.errors, 1)
+}
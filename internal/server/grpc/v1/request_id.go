@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+)
+
+// requestIDKey - идентификатор запроса в метаданных, как и заголовок X-Request-ID в http.
+var requestIDKey = strings.ToLower(requestid.Header)
+
+// unaryRequestID берет идентификатор запроса из метаданных x-request-id или генерирует новый, кладет его в контекст
+// и возвращает в заголовках ответа.
+func unaryRequestID() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, id := withRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+		return handler(ctx, req)
+	}
+}
+
+func streamRequestID() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(requestIDKey, id))
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+
+	id = requestid.New(id)
+
+	return logfield.Start(requestid.WithID(ctx, id)), id
+}
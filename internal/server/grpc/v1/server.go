@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	v1 "github.com/frutonanny/wallet-service/internal/generated/grpc/v1"
+)
+
+const defaultTimeout = 3 * time.Second
+
+type Server struct {
+	addr            string
+	srv             *grpc.Server
+	shutdownTimeout time.Duration
+}
+
+// New создает gRPC-сервер. Методы кошелька требуют API-ключ с нужным правом в метаданных authorization, частота
+// запросов ограничивается limiter, изменяющие вызовы записываются в журнал аудита recorder.
+// Метрики вызовов отдает http-сервер вместе с остальными, вызовы ведутся в спанах OpenTelemetry. Паника
+// в обработчике логируется в logger и завершает только свой вызов с ошибкой Internal.
+func New(
+	logger logger,
+	addr string,
+	handlers v1.WalletServiceServer,
+	authenticator authenticator,
+	limiter rateLimiter,
+	recorder audit.Recorder,
+) *Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			unaryMetrics(),
+			unaryRequestID(),
+			unaryTracing(),
+			unaryRecovery(logger),
+			unaryAudit(recorder),
+			unaryAuth(authenticator),
+			unaryRateLimit(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			streamMetrics(),
+			streamRequestID(),
+			streamTracing(),
+			streamRecovery(logger),
+			streamAuth(authenticator),
+			streamRateLimit(limiter),
+		),
+	)
+
+	v1.RegisterWalletServiceServer(srv, handlers)
+	// Описание сервиса для grpcurl и подобных клиентов.
+	reflection.Register(srv)
+
+	return &Server{
+		addr:            addr,
+		srv:             srv,
+		shutdownTimeout: defaultTimeout,
+	}
+}
+
+// WithShutdownTimeout задает, сколько при остановке ждать завершения открытых вызовов.
+func (s *Server) WithShutdownTimeout(timeout time.Duration) *Server {
+	s.shutdownTimeout = timeout
+	return s
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		<-ctx.Done()
+
+		stopped := make(chan struct{})
+
+		go func() {
+			s.srv.GracefulStop()
+			close(stopped)
+		}()
+
+		// Открытые потоки событий сами не завершатся, поэтому ждем не дольше shutdownTimeout.
+		select {
+		case <-stopped:
+		case <-time.After(s.shutdownTimeout):
+			s.srv.Stop()
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		if err := s.srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("serve: %w", err)
+		}
+
+		return nil
+	})
+
+	return eg.Wait()
+}
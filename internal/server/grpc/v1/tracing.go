@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+// unaryTracing ведет спан каждого вызова, продолжая трассировку вызывающего из метаданных traceparent.
+func unaryTracing() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, span := startSpan(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+		endSpan(span, err)
+
+		return resp, err
+	}
+}
+
+func streamTracing() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startSpan(ss.Context(), info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return tracing.StartServer(
+		ctx,
+		method,
+		metadataCarrier(md),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", method),
+		attribute.String("request.id", requestid.FromContext(ctx)),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	span.SetAttributes(
+		attribute.String("rpc.grpc.status_code", status.Code(err).String()),
+		attribute.String("wallet.result_code", resultCode(err)),
+	)
+	tracing.End(span, err)
+}
+
+// metadataCarrier читает контекст трассировки из метаданных вызова.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// adminPrefix - методы администратора записываются в журнал аудита, даже если ничего не меняют.
+const adminPrefix = "/v1/admin/"
+
+// audited записывает в журнал аудита изменяющие запросы и запросы администратора к известным методам.
+// Записываются и отклоненные запросы, например, без API-ключа, см. audit.Auditor.
+func audited(recorder audit.Recorder) echo.MiddlewareFunc {
+	auditor := audit.NewAuditor(recorder, metrics.TransportHTTP)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+			endpoint := eCtx.Path()
+
+			if !isAudited(endpoint) {
+				return next(eCtx)
+			}
+
+			body, err := audit.ReadBody(req)
+			if err != nil {
+				// Тело длиннее ограничения сервера, echo ответит 413.
+				if errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+					return err
+				}
+
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			userID, err := requestUserID(req)
+			if err != nil {
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			call := audit.Call{
+				Endpoint:   endpoint,
+				Route:      endpoint,
+				RemoteAddr: eCtx.RealIP(),
+				UserID:     userID,
+				Body:       body,
+			}
+
+			auditor.Do(req.Context(), call, func(ctx context.Context) string {
+				eCtx.SetRequest(req.WithContext(ctx))
+
+				resp := eCtx.Response()
+				rec := &responseRecorder{ResponseWriter: resp.Writer}
+				resp.Writer = rec
+
+				err = next(eCtx)
+
+				resp.Writer = rec.ResponseWriter
+
+				return resultCode(err, resp.Status, rec.body.Bytes())
+			})
+
+			return err
+		}
+	}
+}
+
+// isAudited - записывается ли в журнал метод с путем endpoint: известный изменяющий метод или метод администратора.
+// Неизвестные пути, в том числе под /v1/admin/, не записываются.
+func isAudited(endpoint string) bool {
+	if _, ok := scopes[endpoint]; !ok {
+		return false
+	}
+
+	return idempotentPaths[endpoint] || strings.HasPrefix(endpoint, adminPrefix)
+}
+
+// resultCode - код результата запроса для журнала: ok или код ошибки из ответа. Ошибку, которую вернул
+// обработчик или валидатор, ответом отдаст echo.
+func resultCode(err error, status int, body []byte) string {
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+			return errcodes.InvalidRequest
+		}
+
+		return errcodes.InternalError
+	}
+
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Error != nil {
+		return resp.Error.Code
+	}
+
+	if status >= http.StatusInternalServerError {
+		return errcodes.InternalError
+	}
+
+	return audit.ResultOK
+}
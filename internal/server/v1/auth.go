@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/auth"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// scopes - право, которое требует каждый метод. Метод, которого здесь нет, не доступен ни одному ключу.
+var scopes = map[string]string{
+	"/v1/add":                       auth.ScopeAdd,
+	"/v1/reserve":                   auth.ScopeReserve,
+	"/v1/reserveCart":               auth.ScopeReserve,
+	"/v1/writeOff":                  auth.ScopeWriteOff,
+	"/v1/cancel":                    auth.ScopeCancel,
+	"/v1/getBalance":                auth.ScopeRead,
+	"/v1/getBalances":               auth.ScopeRead,
+	"/v1/getBalanceAt":              auth.ScopeRead,
+	"/v1/getDailyBalances":          auth.ScopeRead,
+	"/v1/getTransactions":           auth.ScopeRead,
+	"/v1/getTransactionsByTime":     auth.ScopeRead,
+	"/v1/getTransaction":            auth.ScopeRead,
+	"/v1/getHistory":                auth.ScopeRead,
+	"/v1/streamEvents":              auth.ScopeRead,
+	"/v1/exportStatement":           auth.ScopeStatements,
+	"/v1/getStatements":             auth.ScopeStatements,
+	"/v1/getStatement":              auth.ScopeStatements,
+	"/v1/getReport":                 auth.ScopeReport,
+	"/v1/admin/addWebhook":          auth.ScopeAdmin,
+	"/v1/admin/getFailedDeliveries": auth.ScopeAdmin,
+	"/v1/admin/replayDelivery":      auth.ScopeAdmin,
+	"/v1/admin/getAuditLog":         auth.ScopeAudit,
+}
+
+type authenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Client, error)
+}
+
+// authenticated пропускает запрос, только если API-ключ из заголовка Authorization действителен и у него есть право
+// на вызываемый метод. Клиент кладется в контекст запроса.
+func authenticated(authenticator authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			key := auth.BearerKey(req.Header.Get(auth.HeaderAuthorization))
+
+			client, err := authenticator.Authenticate(req.Context(), key)
+			if err != nil {
+				if errors.Is(err, servicesErrors.ErrUnauthenticated) {
+					return writeError(eCtx, errcodes.Unauthenticated, "api key is missing, unknown or revoked")
+				}
+
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			audit.SetClient(req.Context(), client)
+
+			scope, ok := scopes[req.URL.Path]
+			if !ok || !client.Allowed(scope) {
+				return writeError(eCtx, errcodes.Forbidden, "api key has no access to this method")
+			}
+
+			eCtx.SetRequest(req.WithContext(auth.WithClient(req.Context(), client)))
+
+			return next(eCtx)
+		}
+	}
+}
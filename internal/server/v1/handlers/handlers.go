@@ -3,14 +3,32 @@ package handlers
 import (
 	"context"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+	"io"
 	"time"
 
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
 )
 
 type getBalanceService interface {
-	GetBalance(ctx context.Context, userID int64) (int64, error)
+	GetBalance(ctx context.Context, userID int64) (get_balance.Balance, error)
+	GetBalances(ctx context.Context, userIDs []int64) ([]get_balance.Balance, error)
 }
+type getBalanceAt interface {
+	GetBalanceAt(ctx context.Context, userID int64, at time.Time) (get_balance_at.Balance, error)
+	GetDailyBalances(ctx context.Context, userID int64, from, to time.Time) ([]get_balance_at.DailyBalance, error)
+}
+
 type addService interface {
 	Add(ctx context.Context, userID, amount int64) (int64, error)
 }
@@ -19,12 +37,16 @@ type reserveService interface {
 	Reserve(ctx context.Context, userID, serviceID, externalID, price int64) (int64, error)
 }
 
+type reserveCartService interface {
+	ReserveCart(ctx context.Context, userID, externalID int64, items []reserve_cart.Item) (int64, error)
+}
+
 type writeOffService interface {
 	WriteOff(ctx context.Context, userID, serviceID, externalID, price int64) (int64, error)
 }
 
 type cancelService interface {
-	Cancel(ctx context.Context, userID, orderID int64) (int64, error)
+	Cancel(ctx context.Context, userID, serviceID, orderID int64) (int64, error)
 }
 
 type getTransactions interface {
@@ -33,45 +55,138 @@ type getTransactions interface {
 		userID, limit, offset int64,
 		sortBy get_transactions.SortBy,
 		direction get_transactions.Direction,
+		filter get_transactions.Filter,
+		locale i18n.Locale,
 	) ([]get_transactions.Transaction, error)
 }
 type getTransactionsByTime interface {
-	GetTransactionsByTime(ctx context.Context, userID int64, start, end time.Time) ([]get_transactions_by_time.Transaction, error)
+	GetTransactionsByTime(
+		ctx context.Context,
+		userID int64,
+		start, end time.Time,
+		filter get_transactions_by_time.Filter,
+		locale i18n.Locale,
+	) ([]get_transactions_by_time.Transaction, error)
+}
+
+type getTransaction interface {
+	GetTransaction(
+		ctx context.Context,
+		userID, txID int64,
+		locale i18n.Locale,
+	) (get_transaction.Transaction, error)
+}
+
+type getHistory interface {
+	GetHistory(
+		ctx context.Context,
+		userID, limit int64,
+		token string,
+		locale i18n.Locale,
+	) ([]get_history.Transaction, string, error)
+}
+
+type exportStatement interface {
+	Export(
+		ctx context.Context,
+		userID int64,
+		start, end time.Time,
+		format export_statement.Format,
+		locale i18n.Locale,
+		w io.Writer,
+	) error
+	ExportToStorage(
+		ctx context.Context,
+		userID int64,
+		start, end time.Time,
+		format export_statement.Format,
+		locale i18n.Locale,
+	) (export_statement.Link, error)
+}
+
+type getStatements interface {
+	GetStatements(ctx context.Context, userID int64) ([]get_statements.Statement, error)
+	GetStatement(
+		ctx context.Context,
+		userID int64,
+		period string,
+	) (get_statements.Statement, get_statements.Link, error)
 }
 
 type getReport interface {
 	GetReport(ctx context.Context, period string) (string, error)
 }
 
+type streamEvents interface {
+	Subscribe(ctx context.Context, userID, lastEventID int64) (*stream_events.Subscription, error)
+}
+
+type manageWebhooks interface {
+	AddSubscription(ctx context.Context, url string, eventTypes []string) (manage_webhooks.Subscription, error)
+	GetFailedDeliveries(ctx context.Context, afterID int64, limit int) ([]manage_webhooks.Delivery, error)
+	ReplayDelivery(ctx context.Context, deliveryID int64) error
+}
+
+type auditLog interface {
+	GetEntries(ctx context.Context, filter audit_log.Filter, afterID, limit int64) ([]audit_log.Entry, error)
+}
+
 type Handlers struct {
 	getBalanceService     getBalanceService
+	getBalanceAt          getBalanceAt
 	addService            addService
 	reserveService        reserveService
+	reserveCartService    reserveCartService
 	writeOffService       writeOffService
 	cancelService         cancelService
 	getTransactions       getTransactions
 	getTransactionsByTime getTransactionsByTime
+	getTransaction        getTransaction
+	getHistory            getHistory
+	exportStatement       exportStatement
+	getStatements         getStatements
 	getReport             getReport
+	streamEvents          streamEvents
+	manageWebhooks        manageWebhooks
+	auditLog              auditLog
 }
 
 func NewHandlers(
 	getBalanceService getBalanceService,
+	getBalanceAt getBalanceAt,
 	addService addService,
 	reserveService reserveService,
+	reserveCartService reserveCartService,
 	writeOffService writeOffService,
 	cancelService cancelService,
 	getTransactions getTransactions,
 	getTransactionsByTime getTransactionsByTime,
+	getTransaction getTransaction,
+	getHistory getHistory,
+	exportStatement exportStatement,
+	getStatements getStatements,
 	getReport getReport,
+	streamEvents streamEvents,
+	manageWebhooks manageWebhooks,
+	auditLog auditLog,
 ) *Handlers {
 	return &Handlers{
 		getBalanceService:     getBalanceService,
+		getBalanceAt:          getBalanceAt,
 		addService:            addService,
 		reserveService:        reserveService,
+		reserveCartService:    reserveCartService,
 		writeOffService:       writeOffService,
 		cancelService:         cancelService,
 		getTransactions:       getTransactions,
 		getTransactionsByTime: getTransactionsByTime,
+		getTransaction:        getTransaction,
+		getHistory:            getHistory,
+		exportStatement:       exportStatement,
+		getStatements:         getStatements,
 		getReport:             getReport,
+		streamEvents:          streamEvents,
+		manageWebhooks:        manageWebhooks,
+		auditLog:              auditLog,
 	}
 }
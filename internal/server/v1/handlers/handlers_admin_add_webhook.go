@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostAdminAddWebhook(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.AddWebhookRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.AddWebhookResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	var eventTypes []string
+	if req.EventTypes != nil {
+		for _, t := range *req.EventTypes {
+			eventTypes = append(eventTypes, string(t))
+		}
+	}
+
+	subscription, err := h.manageWebhooks.AddSubscription(ctx, req.Url, eventTypes)
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrInvalidWebhookURL) {
+			code = errcodes.InvalidWebhookURL
+			msg = "invalid webhook url"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.AddWebhookResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.AddWebhookResponse{
+		Data: &v1.AddWebhookData{
+			SubscriptionID: subscription.ID,
+			Secret:         subscription.Secret,
+		},
+	})
+}
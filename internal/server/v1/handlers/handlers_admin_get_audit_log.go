@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const defaultAuditLogLimit = 100
+
+func (h *Handlers) PostAdminGetAuditLog(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetAuditLogRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetAuditLogResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	var afterID int64
+	if req.AfterID != nil {
+		afterID = *req.AfterID
+	}
+
+	limit := int64(defaultAuditLogLimit)
+	if req.Limit != nil {
+		limit = int64(*req.Limit)
+	}
+
+	entries, err := h.auditLog.GetEntries(ctx, adaptAuditFilter(req), afterID, limit)
+	if err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetAuditLogResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetAuditLogResponse{
+		Data: &v1.GetAuditLogData{
+			Entries: adaptAuditEntries(entries),
+		},
+	})
+}
+
+func adaptAuditFilter(req v1.GetAuditLogRequest) audit_log.Filter {
+	var filter audit_log.Filter
+
+	if req.UserID != nil {
+		filter.UserID = *req.UserID
+	}
+
+	if req.ClientName != nil {
+		filter.ClientName = *req.ClientName
+	}
+
+	if req.Endpoint != nil {
+		filter.Endpoint = *req.Endpoint
+	}
+
+	if req.TransactionID != nil {
+		filter.TransactionID = *req.TransactionID
+	}
+
+	if req.From != nil {
+		filter.From = *req.From
+	}
+
+	if req.To != nil {
+		filter.To = *req.To
+	}
+
+	return filter
+}
+
+func adaptAuditEntries(entries []audit_log.Entry) []v1.AuditEntry {
+	result := make([]v1.AuditEntry, 0, len(entries))
+
+	for _, e := range entries {
+		var request v1.AuditEntry_Request
+		// Тело в журнале – всегда JSON-объект, его записывает сам сервис.
+		_ = json.Unmarshal(e.Request, &request)
+
+		result = append(result, v1.AuditEntry{
+			Id:                  e.ID,
+			CreatedAt:           e.CreatedAt,
+			RequestID:           e.RequestID,
+			ClientKeyID:         e.ClientKeyID,
+			ClientName:          e.ClientName,
+			RemoteAddr:          e.RemoteAddr,
+			Endpoint:            e.Endpoint,
+			UserID:              e.UserID,
+			Request:             request,
+			ResultCode:          e.ResultCode,
+			TransactionIDs:      nonNilIDs(e.TransactionIDs),
+			OrderTransactionIDs: nonNilIDs(e.OrderTransactionIDs),
+		})
+	}
+
+	return result
+}
+
+// nonNilIDs - пустой список отдается как [], а не null.
+func nonNilIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+
+	return ids
+}
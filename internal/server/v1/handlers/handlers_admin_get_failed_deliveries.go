@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const defaultFailedDeliveriesLimit = 100
+
+func (h *Handlers) PostAdminGetFailedDeliveries(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetFailedDeliveriesRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetFailedDeliveriesResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	var afterID int64
+	if req.AfterID != nil {
+		afterID = *req.AfterID
+	}
+
+	limit := defaultFailedDeliveriesLimit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	deliveries, err := h.manageWebhooks.GetFailedDeliveries(ctx, afterID, limit)
+	if err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetFailedDeliveriesResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetFailedDeliveriesResponse{
+		Data: &v1.GetFailedDeliveriesData{
+			Deliveries: adaptFailedDeliveries(deliveries),
+		},
+	})
+}
+
+func adaptFailedDeliveries(deliveries []manage_webhooks.Delivery) []v1.FailedDelivery {
+	result := make([]v1.FailedDelivery, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		result = append(result, v1.FailedDelivery{
+			Id:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			Url:            d.URL,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Attempts:       d.Attempts,
+			LastError:      d.LastError,
+			FailedAt:       d.FailedAt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostAdminReplayDelivery(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.ReplayDeliveryRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.ReplayDeliveryResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	if err := h.manageWebhooks.ReplayDelivery(ctx, req.DeliveryID); err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrDeliveryNotFound) {
+			code = errcodes.DeliveryNotFound
+			msg = "delivery not found"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.ReplayDeliveryResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.ReplayDeliveryResponse{
+		Data: &v1.ReplayDeliveryData{
+			DeliveryID: req.DeliveryID,
+		},
+	})
+}
@@ -24,7 +24,12 @@ func (h *Handlers) PostCancel(eCtx echo.Context) error {
 		})
 	}
 
-	balance, err := h.cancelService.Cancel(ctx, req.UserID, req.OrderID)
+	var serviceID int64
+	if req.ServiceID != nil {
+		serviceID = *req.ServiceID
+	}
+
+	balance, err := h.cancelService.Cancel(ctx, req.UserID, serviceID, req.OrderID)
 	if err != nil {
 		code := errcodes.InternalError
 		msg := "internal server error"
@@ -39,6 +44,11 @@ func (h *Handlers) PostCancel(eCtx echo.Context) error {
 			msg = "order not found"
 		}
 
+		if errors.Is(err, servicesErrors.ErrAmbiguousOrder) {
+			code = errcodes.AmbiguousOrder
+			msg = "order has several items, serviceID is required"
+		}
+
 		return eCtx.JSON(http.StatusOK, v1.CancelResponse{
 			Error: &v1.Error{
 				Code:    code,
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostExportStatement(eCtx echo.Context, params v1.PostExportStatementParams) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.ExportStatementRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.ExportStatementResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	format := export_statement.Format(req.Format)
+	locale := adaptLocale(params.AcceptLanguage)
+
+	if req.Delivery != nil && *req.Delivery == v1.Link {
+		link, err := h.exportStatement.ExportToStorage(ctx, req.UserID, req.Start, req.End, format, locale)
+		if err != nil {
+			return eCtx.JSON(http.StatusOK, v1.ExportStatementResponse{
+				Error: adaptExportError(err),
+			})
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.ExportStatementResponse{
+			Data: &v1.ExportStatementData{
+				Url:       link.URL,
+				ExpiresAt: link.ExpiresAt,
+			},
+		})
+	}
+
+	w := &streamWriter{
+		resp:        eCtx.Response(),
+		contentType: format.ContentType(),
+		filename:    fmt.Sprintf("statement-%d.%s", req.UserID, format.Extension()),
+	}
+
+	if err := h.exportStatement.Export(ctx, req.UserID, req.Start, req.End, format, locale, w); err != nil {
+		// Часть выписки уже отправлена клиенту, ответить ошибкой в json не получится.
+		if w.started {
+			return nil
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.ExportStatementResponse{
+			Error: adaptExportError(err),
+		})
+	}
+
+	// Выписка без транзакций – только заголовки ответа.
+	w.start()
+
+	return nil
+}
+
+func adaptExportError(err error) *v1.Error {
+	code := errcodes.InternalError
+	msg := "internal server error"
+
+	if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+		code = errcodes.WalletNotFound
+		msg = "wallet not found"
+	}
+
+	if errors.Is(err, servicesErrors.ErrInvalidPeriod) {
+		code = errcodes.InvalidPeriod
+		msg = "invalid period"
+	}
+
+	return &v1.Error{
+		Code:    code,
+		Message: msg,
+	}
+}
+
+// streamWriter откладывает отправку заголовков ответа до первой записи выписки. Так, пока ничего не записано,
+// на ошибку еще можно ответить json.
+type streamWriter struct {
+	resp        *echo.Response
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.start()
+
+	n, err := w.resp.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	w.resp.Flush()
+
+	return n, nil
+}
+
+func (w *streamWriter) start() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+
+	w.resp.Header().Set(echo.HeaderContentType, w.contentType)
+	w.resp.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.resp.WriteHeader(http.StatusOK)
+}
@@ -8,6 +8,7 @@ import (
 
 	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
 	"github.com/frutonanny/wallet-service/pkg/errcodes"
 )
 
@@ -44,7 +45,26 @@ func (h *Handlers) PostGetBalance(eCtx echo.Context) error {
 
 	return eCtx.JSON(http.StatusOK, v1.GetBalanceResponse{
 		Data: &v1.GetBalanceData{
-			Balance: balance,
+			Balance:   balance.Available,
+			Available: balance.Available,
+			Reserved:  balance.Reserved,
+			Total:     balance.Total,
+			Holds:     adaptHolds(balance.Holds),
 		},
 	})
 }
+
+func adaptHolds(holds []get_balance.Hold) []v1.Hold {
+	result := make([]v1.Hold, 0, len(holds))
+
+	for _, h := range holds {
+		result = append(result, v1.Hold{
+			OrderID:   h.OrderID,
+			ServiceID: h.ServiceID,
+			Amount:    h.Amount,
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
+	return result
+}
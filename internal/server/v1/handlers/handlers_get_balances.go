@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetBalances(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetBalancesRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetBalancesResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	balances, err := h.getBalanceService.GetBalances(ctx, req.UserIDs)
+	if err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetBalancesResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetBalancesResponse{
+		Data: &v1.GetBalancesData{
+			Balances: adaptUserBalances(balances),
+		},
+	})
+}
+
+func adaptUserBalances(balances []get_balance.Balance) []v1.UserBalance {
+	result := make([]v1.UserBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, v1.UserBalance{
+			UserID:    b.UserID,
+			Available: b.Available,
+			Reserved:  b.Reserved,
+			Total:     b.Total,
+			Holds:     adaptHolds(b.Holds),
+		})
+	}
+
+	return result
+}
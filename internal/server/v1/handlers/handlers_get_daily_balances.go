@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetDailyBalances(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetDailyBalancesRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetDailyBalancesResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	balances, err := h.getBalanceAt.GetDailyBalances(ctx, req.UserID, req.From.Time, req.To.Time)
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		if errors.Is(err, servicesErrors.ErrInvalidPeriod) {
+			code = errcodes.InvalidPeriod
+			msg = "invalid period"
+		}
+
+		if errors.Is(err, servicesErrors.ErrPeriodTooLong) {
+			code = errcodes.PeriodTooLong
+			msg = "period is too long"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.GetDailyBalancesResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetDailyBalancesResponse{
+		Data: &v1.GetDailyBalancesData{
+			Balances: adaptDailyBalances(balances),
+		},
+	})
+}
+
+func adaptDailyBalances(balances []get_balance_at.DailyBalance) []v1.DailyBalance {
+	result := make([]v1.DailyBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, v1.DailyBalance{
+			Date:      openapi_types.Date{Time: b.Date},
+			Available: b.Available,
+			Reserved:  b.Reserved,
+		})
+	}
+
+	return result
+}
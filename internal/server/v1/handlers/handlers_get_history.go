@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetHistory(eCtx echo.Context, params v1.PostGetHistoryParams) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetHistoryRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetHistoryResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	var token string
+	if req.PageToken != nil {
+		token = *req.PageToken
+	}
+
+	txs, next, err := h.getHistory.GetHistory(ctx, req.UserID, req.Limit, token, adaptLocale(params.AcceptLanguage))
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		if errors.Is(err, servicesErrors.ErrInvalidPageToken) {
+			code = errcodes.InvalidPageToken
+			msg = "invalid page token"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.GetHistoryResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	data := &v1.GetHistoryData{
+		Transactions: adaptHistoryTxs(txs),
+	}
+
+	if next != "" {
+		data.NextPageToken = &next
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetHistoryResponse{
+		Data: data,
+	})
+}
+
+func adaptHistoryTxs(txs []get_history.Transaction) []v1.Transaction {
+	result := make([]v1.Transaction, 0, len(txs))
+
+	for i := range txs {
+		tx := v1.Transaction{
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderID:      int64Ptr(txs[i].OrderID),
+			ServiceID:    int64Ptr(txs[i].ServiceID),
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    txs[i].CreatedAt,
+			Description:  txs[i].Description,
+		}
+
+		result = append(result, tx)
+	}
+
+	return result
+}
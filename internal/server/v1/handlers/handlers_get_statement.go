@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetStatement(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetStatementRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetStatementResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	statement, link, err := h.getStatements.GetStatement(ctx, req.UserID, req.Period)
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		if errors.Is(err, servicesErrors.ErrStatementNotFound) {
+			code = errcodes.StatementNotFound
+			msg = "statement not found"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.GetStatementResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetStatementResponse{
+		Data: &v1.GetStatementData{
+			Statement: adaptStatement(statement),
+			Url:       link.URL,
+			ExpiresAt: link.ExpiresAt,
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetStatements(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetStatementsRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetStatementsResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	statements, err := h.getStatements.GetStatements(ctx, req.UserID)
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.GetStatementsResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetStatementsResponse{
+		Data: &v1.GetStatementsData{
+			Statements: adaptStatements(statements),
+		},
+	})
+}
+
+func adaptStatements(statements []get_statements.Statement) []v1.Statement {
+	result := make([]v1.Statement, 0, len(statements))
+
+	for _, s := range statements {
+		result = append(result, adaptStatement(s))
+	}
+
+	return result
+}
+
+func adaptStatement(s get_statements.Statement) v1.Statement {
+	return v1.Statement{
+		Period:         s.Period,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		CreatedAt:      s.CreatedAt,
+	}
+}
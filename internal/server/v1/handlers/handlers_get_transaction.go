@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostGetTransaction(eCtx echo.Context, params v1.PostGetTransactionParams) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.GetTransactionRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.GetTransactionResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	tx, err := h.getTransaction.GetTransaction(ctx, req.UserID, req.TransactionID, adaptLocale(params.AcceptLanguage))
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		if errors.Is(err, servicesErrors.ErrTransactionNotFound) {
+			code = errcodes.TransactionNotFound
+			msg = "transaction not found"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.GetTransactionResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.GetTransactionResponse{
+		Data: adaptTx(tx),
+	})
+}
+
+func adaptTx(tx get_transaction.Transaction) *v1.Transaction {
+	return &v1.Transaction{
+		Id:           tx.ID,
+		Type:         adaptTxType(tx.Type),
+		Amount:       tx.Amount,
+		OrderID:      int64Ptr(tx.OrderID),
+		ServiceID:    int64Ptr(tx.ServiceID),
+		BalanceAfter: tx.BalanceAfter,
+		CreatedAt:    tx.CreatedAt,
+		Description:  tx.Description,
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/labstack/echo/v4"
 
 	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/i18n"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
+	"github.com/frutonanny/wallet-service/internal/transactions"
 	"github.com/frutonanny/wallet-service/pkg/errcodes"
 )
 
-func (h *Handlers) PostGetTransactions(eCtx echo.Context) error {
+func (h *Handlers) PostGetTransactions(eCtx echo.Context, params v1.PostGetTransactionsParams) error {
 	ctx := eCtx.Request().Context()
 
 	var req v1.GetTransactionsRequest
@@ -33,6 +35,8 @@ func (h *Handlers) PostGetTransactions(eCtx echo.Context) error {
 			req.Offset,
 			adaptSortBy(req.SortBy),
 			adaptDirection(req.Direction),
+			adaptTxsFilter(req.Filter),
+			adaptLocale(params.AcceptLanguage),
 		)
 
 	if err != nil {
@@ -64,9 +68,14 @@ func adaptTxs(txs []get_transactions.Transaction) []v1.Transaction {
 
 	for i := range txs {
 		tx := v1.Transaction{
-			Amount:      txs[i].Amount,
-			CreatedAt:   txs[i].CreatedAt,
-			Description: txs[i].Description,
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderID:      int64Ptr(txs[i].OrderID),
+			ServiceID:    int64Ptr(txs[i].ServiceID),
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    txs[i].CreatedAt,
+			Description:  txs[i].Description,
 		}
 
 		result = append(result, tx)
@@ -92,3 +101,85 @@ func adaptDirection(direction v1.GetTransactionsRequestDirection) get_transactio
 		return get_transactions.Desc
 	}
 }
+
+func adaptTxsFilter(filter *v1.TransactionsFilter) get_transactions.Filter {
+	if filter == nil {
+		return get_transactions.Filter{}
+	}
+
+	return get_transactions.Filter{
+		Types:      adaptFilterTypes(filter.Types),
+		AmountFrom: int64Value(filter.AmountFrom),
+		AmountTo:   int64Value(filter.AmountTo),
+		OrderID:    int64Value(filter.OrderID),
+		ServiceID:  int64Value(filter.ServiceID),
+	}
+}
+
+// adaptFilterTypes преобразует типы транзакций из запроса в типы транзакций сервиса.
+func adaptFilterTypes(types *[]v1.TransactionType) []string {
+	if types == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(*types))
+
+	for _, t := range *types {
+		switch t {
+		case v1.IncomingTransfer:
+			result = append(result, transactions.TypeAdd)
+		case v1.Reservation:
+			result = append(result, transactions.TypeReserve)
+		case v1.WriteOff:
+			result = append(result, transactions.TypeWriteOff)
+		case v1.Cancel:
+			result = append(result, transactions.TypeCancel)
+		case v1.OutgoingTransfer:
+			result = append(result, transactions.TypeOutgoing)
+		}
+	}
+
+	return result
+}
+
+// adaptTxType преобразует тип транзакции сервиса в тип транзакции ответа.
+func adaptTxType(txType string) v1.TransactionType {
+	switch txType {
+	case transactions.TypeAdd:
+		return v1.IncomingTransfer
+	case transactions.TypeReserve:
+		return v1.Reservation
+	case transactions.TypeWriteOff:
+		return v1.WriteOff
+	case transactions.TypeOutgoing:
+		return v1.OutgoingTransfer
+	default:
+		return v1.Cancel
+	}
+}
+
+// adaptLocale выбирает язык описаний транзакций по заголовку Accept-Language.
+func adaptLocale(acceptLanguage *v1.AcceptLanguage) i18n.Locale {
+	if acceptLanguage == nil {
+		return i18n.DefaultLocale
+	}
+
+	return i18n.ParseLocale(*acceptLanguage)
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
+
+// int64Ptr отдает nil для нулевого значения – поле не выводится в ответе.
+func int64Ptr(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/frutonanny/wallet-service/pkg/errcodes"
 )
 
-func (h *Handlers) PostGetTransactionsByTime(eCtx echo.Context) error {
+func (h *Handlers) PostGetTransactionsByTime(eCtx echo.Context, params v1.PostGetTransactionsByTimeParams) error {
 	ctx := eCtx.Request().Context()
 
 	var req v1.GetTransactionsByTimeRequest
@@ -25,7 +25,14 @@ func (h *Handlers) PostGetTransactionsByTime(eCtx echo.Context) error {
 		})
 	}
 
-	txs, err := h.getTransactionsByTime.GetTransactionsByTime(ctx, req.UserID, req.Start, req.End)
+	txs, err := h.getTransactionsByTime.GetTransactionsByTime(
+		ctx,
+		req.UserID,
+		req.Start,
+		req.End,
+		adaptTxsByTimeFilter(req.Filter),
+		adaptLocale(params.AcceptLanguage),
+	)
 	if err != nil {
 		code := errcodes.InternalError
 		msg := "internal server error"
@@ -55,9 +62,14 @@ func adaptTxsByTime(txs []get_transactions_by_time.Transaction) []v1.Transaction
 
 	for i := range txs {
 		tx := v1.Transaction{
-			Amount:      txs[i].Amount,
-			CreatedAt:   txs[i].CreatedAt,
-			Description: txs[i].Description,
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderID:      int64Ptr(txs[i].OrderID),
+			ServiceID:    int64Ptr(txs[i].ServiceID),
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    txs[i].CreatedAt,
+			Description:  txs[i].Description,
 		}
 
 		result = append(result, tx)
@@ -65,3 +77,17 @@ func adaptTxsByTime(txs []get_transactions_by_time.Transaction) []v1.Transaction
 
 	return result
 }
+
+func adaptTxsByTimeFilter(filter *v1.TransactionsFilter) get_transactions_by_time.Filter {
+	if filter == nil {
+		return get_transactions_by_time.Filter{}
+	}
+
+	return get_transactions_by_time.Filter{
+		Types:      adaptFilterTypes(filter.Types),
+		AmountFrom: int64Value(filter.AmountFrom),
+		AmountTo:   int64Value(filter.AmountTo),
+		OrderID:    int64Value(filter.OrderID),
+		ServiceID:  int64Value(filter.ServiceID),
+	}
+}
@@ -39,6 +39,11 @@ func (h *Handlers) PostReserve(eCtx echo.Context) error {
 			msg = "not enough cash"
 		}
 
+		if errors.Is(err, servicesErrors.ErrOrderExists) {
+			code = errcodes.OrderExists
+			msg = "order with this service already exists"
+		}
+
 		return eCtx.JSON(http.StatusOK, v1.ReserveResponse{Error: &v1.Error{
 			Code:    code,
 			Message: msg,
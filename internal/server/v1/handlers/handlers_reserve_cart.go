@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+func (h *Handlers) PostReserveCart(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v1.ReserveCartRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return eCtx.JSON(http.StatusOK, v1.ReserveCartResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+
+	balance, err := h.reserveCartService.ReserveCart(ctx, req.UserID, req.OrderID, adaptCartItems(req.Items))
+	if err != nil {
+		code := errcodes.InternalError
+		msg := "internal server error"
+
+		if errors.Is(err, servicesErrors.ErrWalletNotFound) {
+			code = errcodes.WalletNotFound
+			msg = "wallet not found"
+		}
+
+		if errors.Is(err, servicesErrors.ErrNotEnoughCash) {
+			code = errcodes.NotEnoughCash
+			msg = "not enough cash"
+		}
+
+		if errors.Is(err, servicesErrors.ErrOrderExists) {
+			code = errcodes.OrderExists
+			msg = "order with this service already exists"
+		}
+
+		if errors.Is(err, servicesErrors.ErrDuplicateService) {
+			code = errcodes.DuplicateService
+			msg = "cart contains duplicate services"
+		}
+
+		return eCtx.JSON(http.StatusOK, v1.ReserveCartResponse{
+			Error: &v1.Error{
+				Code:    code,
+				Message: msg,
+			},
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v1.ReserveCartResponse{
+		Data: &v1.ReserveCartData{
+			Balance: balance,
+		},
+	})
+}
+
+func adaptCartItems(items []v1.CartItem) []reserve_cart.Item {
+	result := make([]reserve_cart.Item, 0, len(items))
+
+	for i := range items {
+		result = append(result, reserve_cart.Item{
+			ServiceID: items[i].ServiceID,
+			Price:     items[i].Price,
+		})
+	}
+
+	return result
+}
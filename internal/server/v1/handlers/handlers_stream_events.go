@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+// streamPingInterval - как часто отправлять пинг, чтобы прокси не закрывали простаивающее соединение.
+const streamPingInterval = 15 * time.Second
+
+func (h *Handlers) GetStreamEvents(eCtx echo.Context, params v1.GetStreamEventsParams) error {
+	ctx := eCtx.Request().Context()
+
+	var lastEventID int64
+	if params.LastEventID != nil {
+		lastEventID = *params.LastEventID
+	}
+
+	sub, err := h.streamEvents.Subscribe(ctx, params.UserID, lastEventID)
+	if err != nil {
+		return eCtx.JSON(http.StatusOK, v1.StreamEventsResponse{
+			Error: &v1.Error{
+				Code:    errcodes.InternalError,
+				Message: "internal server error",
+			},
+		})
+	}
+	defer sub.Close()
+
+	resp := eCtx.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Отключаем буферизацию ответа в nginx.
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	received := make(chan events.Event)
+
+	go func() {
+		defer close(received)
+
+		for {
+			e, ok := sub.Next(ctx)
+			if !ok {
+				return
+			}
+
+			select {
+			case received <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case e, ok := <-received:
+			if !ok {
+				return nil
+			}
+
+			// json.Marshal отдает конверт одной строкой, как того требует формат SSE.
+			data, err := json.Marshal(e)
+			if err != nil {
+				return nil
+			}
+
+			if _, err := fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+				return nil
+			}
+
+		case <-ping.C:
+			if _, err := fmt.Fprint(resp, ": ping\n\n"); err != nil {
+				return nil
+			}
+		}
+
+		resp.Flush()
+	}
+}
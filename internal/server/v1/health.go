@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/health"
+)
+
+const (
+	// healthzPath - процесс жив и обслуживает запросы. Зависимости не проверяются: перезапуск процесса не вернет
+	// упавшую базу.
+	healthzPath = "/healthz"
+
+	// readyzPath - сервис готов к запросам: база и MinIO доступны, миграции применены.
+	readyzPath = "/readyz"
+)
+
+type readinessChecker interface {
+	Ready(ctx context.Context) (health.Report, bool)
+}
+
+func healthz(eCtx echo.Context) error {
+	return eCtx.JSON(http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// readyz отвечает 503, пока хотя бы одна зависимость недоступна.
+func readyz(checker readinessChecker) echo.HandlerFunc {
+	return func(eCtx echo.Context) error {
+		report, ready := checker.Ready(eCtx.Request().Context())
+		if !ready {
+			return eCtx.JSON(http.StatusServiceUnavailable, report)
+		}
+
+		return eCtx.JSON(http.StatusOK, report)
+	}
+}
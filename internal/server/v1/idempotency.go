@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const (
+	// HeaderIdempotencyKey - ключ идемпотентности изменяющего запроса. Повтор запроса с тем же ключом не выполняет
+	// операцию еще раз, а получает ответ на первый запрос.
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed - выставляется в ответе, если он взят из сохраненных.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// idempotentPaths - методы, которые меняют баланс и принимают ключ идемпотентности.
+var idempotentPaths = map[string]bool{
+	"/v1/add":         true,
+	"/v1/reserve":     true,
+	"/v1/reserveCart": true,
+	"/v1/writeOff":    true,
+	"/v1/cancel":      true,
+}
+
+type idempotencyService interface {
+	Begin(ctx context.Context, clientKeyID int64, key, path, requestHash string) (*idempotency.Response, error)
+	Complete(ctx context.Context, clientKeyID int64, key, path string, resp idempotency.Response) error
+	Release(ctx context.Context, clientKeyID int64, key, path string) error
+}
+
+// errorResponse - общий вид ответа v1 с ошибкой.
+type errorResponse struct {
+	Error *v1.Error `json:"error,omitempty"`
+}
+
+// idempotent сохраняет ответы на изменяющие запросы с заголовком Idempotency-Key и отдает их на повторы.
+// Ответ с internal_error не сохраняется: операция не выполнена, и повтор должен выполнить ее заново.
+// Ключи хранятся отдельно для каждого клиента, поэтому idempotent стоит после authenticated.
+func idempotent(service idempotencyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || !idempotentPaths[req.URL.Path] {
+				return next(eCtx)
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			client, ok := auth.ClientFrom(req.Context())
+			if !ok {
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			hash := sha256.Sum256(body)
+			path := req.URL.Path
+
+			saved, err := service.Begin(req.Context(), client.KeyID, key, path, hex.EncodeToString(hash[:]))
+			if err != nil {
+				switch {
+				case errors.Is(err, servicesErrors.ErrIdempotencyKeyReused):
+					metrics.ObserveTransactionRetry(path, metrics.RetryKeyReused)
+					return writeError(eCtx, errcodes.IdempotencyKeyReused, "idempotency key reused with another request")
+				case errors.Is(err, servicesErrors.ErrRequestInProgress):
+					metrics.ObserveTransactionRetry(path, metrics.RetryInProgress)
+					return writeError(eCtx, errcodes.RequestInProgress, "request is in progress")
+				default:
+					return writeError(eCtx, errcodes.InternalError, "internal server error")
+				}
+			}
+
+			if saved != nil {
+				metrics.ObserveTransactionRetry(path, metrics.RetryReplayed)
+				eCtx.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return eCtx.Blob(saved.StatusCode, saved.ContentType, saved.Body)
+			}
+
+			resp := eCtx.Response()
+			rec := &responseRecorder{ResponseWriter: resp.Writer}
+			resp.Writer = rec
+
+			err = next(eCtx)
+
+			resp.Writer = rec.ResponseWriter
+
+			// Запрос мог быть отменен клиентом, а ключ нужно сохранить или освободить в любом случае.
+			ctx, cancel := context.WithTimeout(context.Background(), idempotency.WriteTimeout)
+			defer cancel()
+
+			if err != nil || resp.Status >= http.StatusInternalServerError || isInternalError(rec.body.Bytes()) {
+				_ = service.Release(ctx, client.KeyID, key, path)
+				return err
+			}
+
+			_ = service.Complete(ctx, client.KeyID, key, path, idempotency.Response{
+				StatusCode:  resp.Status,
+				ContentType: resp.Header().Get(echo.HeaderContentType),
+				Body:        rec.body.Bytes(),
+			})
+
+			return nil
+		}
+	}
+}
+
+func writeError(eCtx echo.Context, code, msg string) error {
+	return eCtx.JSON(http.StatusOK, errorResponse{
+		Error: &v1.Error{
+			Code:    code,
+			Message: msg,
+		},
+	})
+}
+
+func isInternalError(body []byte) bool {
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false
+	}
+
+	return resp.Error != nil && resp.Error.Code == errcodes.InternalError
+}
+
+// responseRecorder копирует тело ответа, чтобы его можно было сохранить.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const (
+	// metricsPath - метрики в формате Prometheus, отдаются без API-ключа.
+	metricsPath = "/metrics"
+
+	// maxMeasuredBody - сколько байт ответа хватает, чтобы достать код ошибки. Ленты событий не копируются целиком.
+	maxMeasuredBody = 4 << 10
+)
+
+// measured считает запросы ко всем версиям API и время их обработки. Метод определяется шаблоном пути,
+// например, GET /v2/users/:userId/balance, а код - ok или код ошибки из ответа v1 или v2.
+func measured() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			started := time.Now()
+
+			resp := eCtx.Response()
+			rec := &prefixRecorder{ResponseWriter: resp.Writer}
+			resp.Writer = rec
+
+			err := next(eCtx)
+
+			resp.Writer = rec.ResponseWriter
+
+			endpoint := eCtx.Path()
+			if endpoint == "" {
+				endpoint = "unmatched"
+			}
+
+			if endpoint == metricsPath {
+				return err
+			}
+
+			metrics.ObserveRequest(
+				metrics.TransportHTTP,
+				eCtx.Request().Method+" "+endpoint,
+				responseCode(err, resp.Status, rec.body.Bytes()),
+				started,
+			)
+
+			return err
+		}
+	}
+}
+
+// responseCode - код результата запроса: ok, код из ответа v1 ({"error": {"code": ...}}) или из problem-ответа v2.
+// Ошибку, которую вернул обработчик или валидатор, ответом отдаст echo.
+func responseCode(err error, status int, body []byte) string {
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+			return errcodes.InvalidRequest
+		}
+
+		return errcodes.InternalError
+	}
+
+	var resp struct {
+		Error *struct {
+			Code string `json:"code"`
+		} `json:"error"`
+		Code string `json:"code"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err == nil {
+		switch {
+		case resp.Error != nil && resp.Error.Code != "":
+			return resp.Error.Code
+		case status >= http.StatusBadRequest && resp.Code != "":
+			return resp.Code
+		}
+	}
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		return errcodes.InternalError
+	case status >= http.StatusBadRequest:
+		return errcodes.InvalidRequest
+	}
+
+	return metrics.ResultOK
+}
+
+// prefixRecorder копирует только начало тела ответа.
+type prefixRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *prefixRecorder) Write(p []byte) (int, error) {
+	if rest := maxMeasuredBody - r.body.Len(); rest > 0 {
+		if len(p) > rest {
+			r.body.Write(p[:rest])
+		} else {
+			r.body.Write(p)
+		}
+	}
+
+	return r.ResponseWriter.Write(p)
+}
+
+// Flush нужен лентам событий, которые отдают события по мере появления.
+func (r *prefixRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/operations"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// pathOperations - операция каждого метода. Ограничения частоты задаются по операциям, общим для всех транспортов.
+var pathOperations = map[string]string{
+	"/v1/add":                       operations.Add,
+	"/v1/reserve":                   operations.Reserve,
+	"/v1/reserveCart":               operations.ReserveCart,
+	"/v1/writeOff":                  operations.WriteOff,
+	"/v1/cancel":                    operations.Cancel,
+	"/v1/getBalance":                operations.GetBalance,
+	"/v1/getBalances":               operations.GetBalances,
+	"/v1/getBalanceAt":              operations.GetBalanceAt,
+	"/v1/getDailyBalances":          operations.GetDailyBalances,
+	"/v1/getTransactions":           operations.GetTransactions,
+	"/v1/getTransactionsByTime":     operations.GetTransactionsByTime,
+	"/v1/getTransaction":            operations.GetTransaction,
+	"/v1/getHistory":                operations.GetHistory,
+	"/v1/streamEvents":              operations.StreamEvents,
+	"/v1/exportStatement":           operations.ExportStatement,
+	"/v1/getStatements":             operations.GetStatements,
+	"/v1/getStatement":              operations.GetStatement,
+	"/v1/getReport":                 operations.GetReport,
+	"/v1/admin/addWebhook":          operations.AddWebhook,
+	"/v1/admin/getFailedDeliveries": operations.GetFailedDeliveries,
+	"/v1/admin/replayDelivery":      operations.ReplayDelivery,
+	"/v1/admin/getAuditLog":         operations.GetAuditLog,
+}
+
+type rateLimiter interface {
+	Allow(ctx context.Context, operation string, clientID int64, userID *int64) (time.Duration, error)
+}
+
+// rateLimited ограничивает частоту запросов клиента и запросов по пользователю userID из тела или query-параметра.
+// Ограниченный запрос получает 429 с кодом rate_limited и заголовком Retry-After. Запросы к неизвестным методам
+// сюда не доходят, их отклоняет authenticated.
+func rateLimited(limiter rateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			client, _ := auth.ClientFrom(req.Context())
+
+			userID, err := requestUserID(req)
+			if err != nil {
+				return writeError(eCtx, errcodes.InternalError, "internal server error")
+			}
+
+			wait, err := limiter.Allow(req.Context(), pathOperations[req.URL.Path], client.KeyID, userID)
+			if errors.Is(err, servicesErrors.ErrRateLimited) {
+				eCtx.Response().Header().Set(echo.HeaderRetryAfter, retryAfter(wait))
+				return eCtx.JSON(http.StatusTooManyRequests, errorResponse{
+					Error: &v1.Error{
+						Code:    errcodes.RateLimited,
+						Message: "rate limit exceeded",
+					},
+				})
+			}
+
+			return next(eCtx)
+		}
+	}
+}
+
+// requestUserID достает userID из query-параметра или из тела запроса. Тело остается доступным обработчику.
+func requestUserID(req *http.Request) (*int64, error) {
+	if v := req.URL.Query().Get("userID"); v != "" {
+		if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return &id, nil
+		}
+
+		return nil, nil
+	}
+
+	if req.Body == nil || req.Method != http.MethodPost {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		UserID *int64 `json:"userID"`
+	}
+
+	// Запрос уже проверен валидатором, а у методов без userID ограничение по пользователю не действует.
+	_ = json.Unmarshal(body, &payload)
+
+	return payload.UserID, nil
+}
+
+// retryAfter - значение заголовка Retry-After: целое число секунд, не меньше одной.
+func retryAfter(wait time.Duration) string {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.FormatInt(seconds, 10)
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/requestid"
+)
+
+// withRequestID берет идентификатор запроса из заголовка X-Request-ID или генерирует новый, кладет его в контекст
+// запроса и возвращает в ответе. Там же запрос получает набор полей лога. Действует на все версии API сервера.
+func withRequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			id := requestid.New(req.Header.Get(requestid.Header))
+
+			eCtx.Response().Header().Set(requestid.Header, id)
+			eCtx.SetRequest(req.WithContext(logfield.Start(requestid.WithID(req.Context(), id))))
+
+			return next(eCtx)
+		}
+	}
+}
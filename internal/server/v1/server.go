@@ -10,46 +10,100 @@ import (
 
 	mdlwr "github.com/deepmap/oapi-codegen/pkg/middleware"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/frutonanny/wallet-service/internal/audit"
 	"github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 )
 
 const (
 	readHeaderTimeout = 10 * time.Second
 	defaultTimeout    = 3 * time.Second
+
+	// maxRequestBody - запрос с телом длиннее получает 413 до журнала аудита и обработчиков.
+	maxRequestBody = "1M"
 )
 
 type Server struct {
-	srv *http.Server
+	srv             *http.Server
+	shutdownTimeout time.Duration
 }
 
+// New создает http-сервер с API v1. routes регистрируют на том же сервере другие версии API. Каждый ответ сервера
+// несет идентификатор запроса в заголовке X-Request-ID, изменяющие запросы записываются в журнал аудита recorder.
+// Метрики запросов ко всем версиям API отдаются по пути /metrics, запросы ведутся в спанах OpenTelemetry.
+// Живость и готовность сервиса отдаются по путям /healthz и /readyz, готовность проверяет readiness.
 func New(
 	addr string,
 	handlers v1.ServerInterface,
 	swagger *openapi3.T,
+	authenticator authenticator,
+	limiter rateLimiter,
+	idempotency idempotencyService,
+	recorder audit.Recorder,
+	readiness readinessChecker,
+	routes ...func(e *echo.Echo),
 ) *Server {
 	e := echo.New()
+	e.Use(withRequestID(), traced(), measured(), middleware.BodyLimit(maxRequestBody))
+	e.GET(metricsPath, echo.WrapHandler(metrics.Handler()))
+	e.GET(healthzPath, healthz)
+	e.GET(readyzPath, readyz(readiness))
+
+	// Адрес из servers в схеме привязан к хосту localhost:8081, а валидатору нужен только префикс пути.
+	swagger.Servers = openapi3.Servers{{URL: "/v1"}}
 
-	group := e.Group("v1", mdlwr.OapiRequestValidator(swagger))
+	// Ключ проверяет authenticated, валидатору достаточно знать, что схема безопасности описана.
+	validator := mdlwr.OapiRequestValidatorWithOptions(swagger, &mdlwr.Options{
+		Options: openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	})
+
+	group := e.Group(
+		"v1",
+		audited(recorder),
+		validator,
+		authenticated(authenticator),
+		rateLimited(limiter),
+		idempotent(idempotency),
+	)
 	v1.RegisterHandlers(group, handlers)
 
+	for _, r := range routes {
+		r(e)
+	}
+
 	return &Server{
 		srv: &http.Server{
 			Addr:              addr,
 			Handler:           e,
 			ReadHeaderTimeout: readHeaderTimeout,
 		},
+		shutdownTimeout: defaultTimeout,
 	}
 }
 
+// WithTimeouts задает, сколько ждать заголовков запроса и сколько при остановке ждать завершения открытых запросов.
+func (s *Server) WithTimeouts(readHeader, shutdown time.Duration) *Server {
+	s.srv.ReadHeaderTimeout = readHeader
+	s.shutdownTimeout = shutdown
+	return s
+}
+
+// Handler отдает обработчик запросов сервера, например, для httptest.Server.
+func (s *Server) Handler() http.Handler {
+	return s.srv.Handler
+}
+
 func (s *Server) Run(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
 		<-ctx.Done()
 
-		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 		defer cancel()
 
 		if err := s.srv.Shutdown(ctx); err != nil {
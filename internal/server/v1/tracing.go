@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/frutonanny/wallet-service/internal/requestid"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+// traced ведет спан каждого запроса ко всем версиям API, продолжая трассировку вызывающего из заголовка
+// traceparent. Спан называется по методу и шаблону пути, например, POST /v1/writeOff.
+func traced() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			if eCtx.Path() == metricsPath {
+				return next(eCtx)
+			}
+
+			ctx, span := tracing.StartServer(
+				req.Context(),
+				req.Method+" "+eCtx.Path(),
+				propagation.HeaderCarrier(req.Header),
+				attribute.String("http.method", req.Method),
+				attribute.String("http.route", eCtx.Path()),
+				attribute.String("http.target", req.URL.Path),
+				attribute.String("request.id", requestid.FromContext(req.Context())),
+			)
+			eCtx.SetRequest(req.WithContext(ctx))
+
+			err := next(eCtx)
+
+			// Ответ с ошибкой сервера тоже отмечается в спане, даже если обработчик отдал его сам.
+			status := eCtx.Response().Status
+			spanErr := err
+			if spanErr == nil && status >= http.StatusInternalServerError {
+				spanErr = echo.NewHTTPError(status)
+			}
+
+			span.SetAttributes(attribute.Int("http.status_code", status))
+			tracing.End(span, spanErr)
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/server/v2/handlers"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// adminPrefix - операции администратора записываются в журнал аудита, даже если ничего не меняют.
+const adminPrefix = prefix + "/admin/"
+
+// audited записывает в журнал аудита изменяющие запросы (все, кроме GET) и запросы администратора к известным
+// операциям. Операция записывается вместе с путем, потому что часть параметров, например, заказ, есть только в нем,
+// а в метриках – с шаблоном пути. Записываются и отклоненные запросы, см. audit.Auditor.
+func audited(recorder audit.Recorder) echo.MiddlewareFunc {
+	auditor := audit.NewAuditor(recorder, metrics.TransportHTTP)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+			if !isAudited(req.Method, eCtx.Path()) {
+				return next(eCtx)
+			}
+
+			body, err := audit.ReadBody(req)
+			if err != nil {
+				// Тело длиннее ограничения сервера, echo ответит 413.
+				if errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+					return err
+				}
+
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusInternalServerError,
+					errcodes.InternalError,
+					"internal server error",
+				)
+			}
+
+			userID, err := requestUserID(eCtx)
+			if err != nil {
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusInternalServerError,
+					errcodes.InternalError,
+					"internal server error",
+				)
+			}
+
+			call := audit.Call{
+				Endpoint:   req.Method + " " + req.URL.Path,
+				Route:      req.Method + " " + eCtx.Path(),
+				RemoteAddr: eCtx.RealIP(),
+				UserID:     userID,
+				Body:       body,
+			}
+
+			auditor.Do(req.Context(), call, func(ctx context.Context) string {
+				eCtx.SetRequest(req.WithContext(ctx))
+
+				resp := eCtx.Response()
+				rec := &responseRecorder{ResponseWriter: resp.Writer}
+				resp.Writer = rec
+
+				err = next(eCtx)
+
+				resp.Writer = rec.ResponseWriter
+
+				return resultCode(err, resp.Status, rec.body.Bytes())
+			})
+
+			return err
+		}
+	}
+}
+
+// isAudited - записывается ли в журнал операция method с шаблоном пути path: известная изменяющая операция
+// или операция администратора. Неизвестные пути не записываются.
+func isAudited(method, path string) bool {
+	if _, ok := scopes[method+" "+path]; !ok {
+		return false
+	}
+
+	return method != http.MethodGet || strings.HasPrefix(path, adminPrefix)
+}
+
+// resultCode - код результата запроса для журнала: ok или код из problem-ответа. Ошибку, которую вернул
+// обработчик, ответом отдаст echo.
+func resultCode(err error, status int, body []byte) string {
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Code < http.StatusInternalServerError {
+			return errcodes.InvalidRequest
+		}
+
+		return errcodes.InternalError
+	}
+
+	if status < http.StatusBadRequest {
+		return audit.ResultOK
+	}
+
+	var problem v2.Problem
+	if err := json.Unmarshal(body, &problem); err == nil && problem.Code != "" {
+		return problem.Code
+	}
+
+	if status >= http.StatusInternalServerError {
+		return errcodes.InternalError
+	}
+
+	return errcodes.InvalidRequest
+}
+
+// responseRecorder копирует тело ответа, чтобы достать из него код ошибки.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
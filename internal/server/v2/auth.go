@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/server/v2/handlers"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// scopes - право, которое требует каждая операция (метод и путь). Операция, которой здесь нет, не доступна
+// ни одному ключу.
+var scopes = map[string]string{
+	http.MethodGet + " " + prefix + "/wallets/:userID":                             auth.ScopeRead,
+	http.MethodGet + " " + prefix + "/wallets/:userID/balance":                     auth.ScopeRead,
+	http.MethodGet + " " + prefix + "/wallets/:userID/daily-balances":              auth.ScopeRead,
+	http.MethodPost + " " + prefix + "/wallets/:userID/deposits":                   auth.ScopeAdd,
+	http.MethodGet + " " + prefix + "/wallets/:userID/transactions":                auth.ScopeRead,
+	http.MethodGet + " " + prefix + "/wallets/:userID/transactions/:transactionID": auth.ScopeRead,
+	http.MethodGet + " " + prefix + "/wallets/:userID/statements":                  auth.ScopeStatements,
+	http.MethodGet + " " + prefix + "/wallets/:userID/statements/:period":          auth.ScopeStatements,
+	http.MethodPost + " " + prefix + "/orders":                                     auth.ScopeReserve,
+	http.MethodPost + " " + prefix + "/orders/:orderID/capture":                    auth.ScopeWriteOff,
+	http.MethodPost + " " + prefix + "/orders/:orderID/cancel":                     auth.ScopeCancel,
+	http.MethodGet + " " + prefix + "/reports/:period":                             auth.ScopeReport,
+	http.MethodPost + " " + prefix + "/admin/webhooks":                             auth.ScopeAdmin,
+	http.MethodGet + " " + prefix + "/admin/failed-deliveries":                     auth.ScopeAdmin,
+	http.MethodPost + " " + prefix + "/admin/failed-deliveries/:deliveryID/replay": auth.ScopeAdmin,
+}
+
+type authenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Client, error)
+}
+
+// authenticated пропускает запрос, только если API-ключ из заголовка Authorization действителен (иначе 401) и у него
+// есть право на операцию (иначе 403). Клиент кладется в контекст запроса.
+func authenticated(authenticator authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			key := auth.BearerKey(req.Header.Get(auth.HeaderAuthorization))
+
+			client, err := authenticator.Authenticate(req.Context(), key)
+			if err != nil {
+				if errors.Is(err, servicesErrors.ErrUnauthenticated) {
+					eCtx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+					return handlers.WriteProblem(
+						eCtx,
+						http.StatusUnauthorized,
+						errcodes.Unauthenticated,
+						"api key is missing, unknown or revoked",
+					)
+				}
+
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusInternalServerError,
+					errcodes.InternalError,
+					"internal server error",
+				)
+			}
+
+			audit.SetClient(req.Context(), client)
+
+			scope, ok := scopes[req.Method+" "+eCtx.Path()]
+			if !ok || !client.Allowed(scope) {
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusForbidden,
+					errcodes.Forbidden,
+					"api key has no access to this operation",
+				)
+			}
+
+			eCtx.SetRequest(req.WithContext(auth.WithClient(req.Context(), client)))
+
+			return next(eCtx)
+		}
+	}
+}
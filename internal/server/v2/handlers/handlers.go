@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+)
+
+type getBalanceService interface {
+	GetBalance(ctx context.Context, userID int64) (get_balance.Balance, error)
+}
+
+type getBalanceAt interface {
+	GetBalanceAt(ctx context.Context, userID int64, at time.Time) (get_balance_at.Balance, error)
+	GetDailyBalances(ctx context.Context, userID int64, from, to time.Time) ([]get_balance_at.DailyBalance, error)
+}
+
+type addService interface {
+	Add(ctx context.Context, userID, amount int64) (int64, error)
+}
+
+type reserveCartService interface {
+	ReserveCart(ctx context.Context, userID, externalID int64, items []reserve_cart.Item) (int64, error)
+}
+
+type writeOffService interface {
+	WriteOff(ctx context.Context, userID, serviceID, externalID, price int64) (int64, error)
+}
+
+type cancelService interface {
+	Cancel(ctx context.Context, userID, serviceID, orderID int64) (int64, error)
+}
+
+type getTransaction interface {
+	GetTransaction(
+		ctx context.Context,
+		userID, txID int64,
+		locale i18n.Locale,
+	) (get_transaction.Transaction, error)
+}
+
+type getHistory interface {
+	GetHistory(
+		ctx context.Context,
+		userID, limit int64,
+		token string,
+		locale i18n.Locale,
+	) ([]get_history.Transaction, string, error)
+}
+
+type getStatements interface {
+	GetStatements(ctx context.Context, userID int64) ([]get_statements.Statement, error)
+	GetStatement(
+		ctx context.Context,
+		userID int64,
+		period string,
+	) (get_statements.Statement, get_statements.Link, error)
+}
+
+type getReport interface {
+	GetReport(ctx context.Context, period string) (string, error)
+}
+
+type manageWebhooks interface {
+	AddSubscription(ctx context.Context, url string, eventTypes []string) (manage_webhooks.Subscription, error)
+	GetFailedDeliveries(ctx context.Context, afterID int64, limit int) ([]manage_webhooks.Delivery, error)
+	ReplayDelivery(ctx context.Context, deliveryID int64) error
+}
+
+// Handlers реализует ресурсное API v2 поверх тех же сервисов, что и v1.
+type Handlers struct {
+	getBalanceService  getBalanceService
+	getBalanceAt       getBalanceAt
+	addService         addService
+	reserveCartService reserveCartService
+	writeOffService    writeOffService
+	cancelService      cancelService
+	getTransaction     getTransaction
+	getHistory         getHistory
+	getStatements      getStatements
+	getReport          getReport
+	manageWebhooks     manageWebhooks
+}
+
+func NewHandlers(
+	getBalanceService getBalanceService,
+	getBalanceAt getBalanceAt,
+	addService addService,
+	reserveCartService reserveCartService,
+	writeOffService writeOffService,
+	cancelService cancelService,
+	getTransaction getTransaction,
+	getHistory getHistory,
+	getStatements getStatements,
+	getReport getReport,
+	manageWebhooks manageWebhooks,
+) *Handlers {
+	return &Handlers{
+		getBalanceService:  getBalanceService,
+		getBalanceAt:       getBalanceAt,
+		addService:         addService,
+		reserveCartService: reserveCartService,
+		writeOffService:    writeOffService,
+		cancelService:      cancelService,
+		getTransaction:     getTransaction,
+		getHistory:         getHistory,
+		getStatements:      getStatements,
+		getReport:          getReport,
+		manageWebhooks:     manageWebhooks,
+	}
+}
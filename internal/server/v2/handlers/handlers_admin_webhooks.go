@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+)
+
+const defaultFailedDeliveriesLimit = 100
+
+func (h *Handlers) PostAdminWebhooks(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v2.CreateWebhookRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return invalidRequest(eCtx, err)
+	}
+
+	var eventTypes []string
+	if req.EventTypes != nil {
+		for _, t := range *req.EventTypes {
+			eventTypes = append(eventTypes, string(t))
+		}
+	}
+
+	subscription, err := h.manageWebhooks.AddSubscription(ctx, req.Url, eventTypes)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusCreated, v2.Webhook{
+		Id:     subscription.ID,
+		Secret: subscription.Secret,
+	})
+}
+
+func (h *Handlers) GetAdminFailedDeliveries(eCtx echo.Context, params v2.GetAdminFailedDeliveriesParams) error {
+	ctx := eCtx.Request().Context()
+
+	var afterID int64
+	if params.AfterID != nil {
+		afterID = *params.AfterID
+	}
+
+	limit := defaultFailedDeliveriesLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	deliveries, err := h.manageWebhooks.GetFailedDeliveries(ctx, afterID, limit)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	result := make([]v2.FailedDelivery, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		result = append(result, v2.FailedDelivery{
+			Id:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			Url:            d.URL,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Attempts:       d.Attempts,
+			LastError:      d.LastError,
+			FailedAt:       d.FailedAt,
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.FailedDeliveries{Deliveries: result})
+}
+
+func (h *Handlers) PostAdminFailedDeliveriesDeliveryIDReplay(eCtx echo.Context, deliveryID int64) error {
+	ctx := eCtx.Request().Context()
+
+	if err := h.manageWebhooks.ReplayDelivery(ctx, deliveryID); err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.NoContent(http.StatusAccepted)
+}
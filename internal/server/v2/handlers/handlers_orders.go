@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+)
+
+func (h *Handlers) PostOrders(eCtx echo.Context) error {
+	ctx := eCtx.Request().Context()
+
+	var req v2.CreateOrderRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return invalidRequest(eCtx, err)
+	}
+
+	items := make([]reserve_cart.Item, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, reserve_cart.Item{
+			ServiceID: item.ServiceID,
+			Price:     item.Price,
+		})
+	}
+
+	balance, err := h.reserveCartService.ReserveCart(ctx, req.UserID, req.OrderID, items)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusCreated, v2.BalanceChange{Balance: balance})
+}
+
+func (h *Handlers) PostOrdersOrderIDCapture(eCtx echo.Context, orderID v2.OrderID) error {
+	ctx := eCtx.Request().Context()
+
+	var req v2.CaptureRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return invalidRequest(eCtx, err)
+	}
+
+	balance, err := h.writeOffService.WriteOff(ctx, req.UserID, req.ServiceID, orderID, req.Amount)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.BalanceChange{Balance: balance})
+}
+
+func (h *Handlers) PostOrdersOrderIDCancel(eCtx echo.Context, orderID v2.OrderID) error {
+	ctx := eCtx.Request().Context()
+
+	var req v2.CancelRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return invalidRequest(eCtx, err)
+	}
+
+	var serviceID int64
+	if req.ServiceID != nil {
+		serviceID = *req.ServiceID
+	}
+
+	balance, err := h.cancelService.Cancel(ctx, req.UserID, serviceID, orderID)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.BalanceChange{Balance: balance})
+}
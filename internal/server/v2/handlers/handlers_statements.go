@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+)
+
+func (h *Handlers) GetWalletsUserIDStatements(eCtx echo.Context, userID v2.UserID) error {
+	ctx := eCtx.Request().Context()
+
+	statements, err := h.getStatements.GetStatements(ctx, userID)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	result := make([]v2.Statement, 0, len(statements))
+
+	for _, s := range statements {
+		result = append(result, adaptStatement(s))
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.Statements{Statements: result})
+}
+
+func (h *Handlers) GetWalletsUserIDStatementsPeriod(eCtx echo.Context, userID v2.UserID, period v2.Period) error {
+	ctx := eCtx.Request().Context()
+
+	statement, link, err := h.getStatements.GetStatement(ctx, userID, period)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.StatementWithLink{
+		Statement: adaptStatement(statement),
+		Url:       link.URL,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
+
+func (h *Handlers) GetReportsPeriod(eCtx echo.Context, period v2.Period) error {
+	ctx := eCtx.Request().Context()
+
+	url, err := h.getReport.GetReport(ctx, period)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.Report{Url: url})
+}
+
+func adaptStatement(s get_statements.Statement) v2.Statement {
+	return v2.Statement{
+		Period:         s.Period,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		CreatedAt:      s.CreatedAt,
+	}
+}
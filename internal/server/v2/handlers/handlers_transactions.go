@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+const defaultTransactionsLimit = 100
+
+func (h *Handlers) GetWalletsUserIDTransactions(
+	eCtx echo.Context,
+	userID v2.UserID,
+	params v2.GetWalletsUserIDTransactionsParams,
+) error {
+	ctx := eCtx.Request().Context()
+
+	limit := int64(defaultTransactionsLimit)
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	var token string
+	if params.PageToken != nil {
+		token = *params.PageToken
+	}
+
+	txs, next, err := h.getHistory.GetHistory(ctx, userID, limit, token, adaptLocale(params.AcceptLanguage))
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	page := v2.TransactionsPage{
+		Transactions: make([]v2.Transaction, 0, len(txs)),
+	}
+
+	for i := range txs {
+		page.Transactions = append(page.Transactions, v2.Transaction{
+			Id:           txs[i].ID,
+			Type:         adaptTxType(txs[i].Type),
+			Amount:       txs[i].Amount,
+			OrderID:      int64Ptr(txs[i].OrderID),
+			ServiceID:    int64Ptr(txs[i].ServiceID),
+			BalanceAfter: txs[i].BalanceAfter,
+			CreatedAt:    txs[i].CreatedAt,
+			Description:  txs[i].Description,
+		})
+	}
+
+	if next != "" {
+		page.NextPageToken = &next
+	}
+
+	return eCtx.JSON(http.StatusOK, page)
+}
+
+func (h *Handlers) GetWalletsUserIDTransactionsTransactionID(
+	eCtx echo.Context,
+	userID v2.UserID,
+	transactionID int64,
+	params v2.GetWalletsUserIDTransactionsTransactionIDParams,
+) error {
+	ctx := eCtx.Request().Context()
+
+	tx, err := h.getTransaction.GetTransaction(ctx, userID, transactionID, adaptLocale(params.AcceptLanguage))
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.Transaction{
+		Id:           tx.ID,
+		Type:         adaptTxType(tx.Type),
+		Amount:       tx.Amount,
+		OrderID:      int64Ptr(tx.OrderID),
+		ServiceID:    int64Ptr(tx.ServiceID),
+		BalanceAfter: tx.BalanceAfter,
+		CreatedAt:    tx.CreatedAt,
+		Description:  tx.Description,
+	})
+}
+
+// adaptTxType преобразует тип транзакции сервиса в тип транзакции ответа.
+func adaptTxType(txType string) v2.TransactionType {
+	switch txType {
+	case transactions.TypeAdd:
+		return v2.IncomingTransfer
+	case transactions.TypeReserve:
+		return v2.Reservation
+	case transactions.TypeWriteOff:
+		return v2.WriteOff
+	case transactions.TypeOutgoing:
+		return v2.OutgoingTransfer
+	default:
+		return v2.Cancel
+	}
+}
+
+// adaptLocale выбирает язык описаний транзакций по заголовку Accept-Language.
+func adaptLocale(acceptLanguage *v2.AcceptLanguage) i18n.Locale {
+	if acceptLanguage == nil {
+		return i18n.DefaultLocale
+	}
+
+	return i18n.ParseLocale(*acceptLanguage)
+}
+
+// int64Ptr отдает nil для нулевого значения – поле не выводится в ответе.
+func int64Ptr(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
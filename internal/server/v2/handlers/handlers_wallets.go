@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+)
+
+func (h *Handlers) GetWalletsUserID(eCtx echo.Context, userID v2.UserID) error {
+	ctx := eCtx.Request().Context()
+
+	balance, err := h.getBalanceService.GetBalance(ctx, userID)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.Wallet{
+		UserID:    userID,
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+		Total:     balance.Total,
+		Holds:     adaptHolds(balance.Holds),
+	})
+}
+
+func (h *Handlers) GetWalletsUserIDBalance(
+	eCtx echo.Context,
+	userID v2.UserID,
+	params v2.GetWalletsUserIDBalanceParams,
+) error {
+	ctx := eCtx.Request().Context()
+
+	balance, err := h.getBalanceAt.GetBalanceAt(ctx, userID, params.At)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.Balance{
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+	})
+}
+
+func (h *Handlers) GetWalletsUserIDDailyBalances(
+	eCtx echo.Context,
+	userID v2.UserID,
+	params v2.GetWalletsUserIDDailyBalancesParams,
+) error {
+	ctx := eCtx.Request().Context()
+
+	balances, err := h.getBalanceAt.GetDailyBalances(ctx, userID, params.From.Time, params.To.Time)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	result := make([]v2.DailyBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, v2.DailyBalance{
+			Date:      openapi_types.Date{Time: b.Date},
+			Available: b.Available,
+			Reserved:  b.Reserved,
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.DailyBalances{Balances: result})
+}
+
+func (h *Handlers) PostWalletsUserIDDeposits(eCtx echo.Context, userID v2.UserID) error {
+	ctx := eCtx.Request().Context()
+
+	var req v2.DepositRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return invalidRequest(eCtx, err)
+	}
+
+	balance, err := h.addService.Add(ctx, userID, req.Amount)
+	if err != nil {
+		return serviceProblem(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, v2.BalanceChange{Balance: balance})
+}
+
+func adaptHolds(holds []get_balance.Hold) []v2.Hold {
+	result := make([]v2.Hold, 0, len(holds))
+
+	for _, h := range holds {
+		result = append(result, v2.Hold{
+			OrderID:   h.OrderID,
+			ServiceID: h.ServiceID,
+			Amount:    h.Amount,
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	v2 "github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// ContentTypeProblem - тип содержимого ответа с ошибкой по RFC 7807.
+const ContentTypeProblem = "application/problem+json"
+
+// problemTypeBlank - тип ошибки по умолчанию из RFC 7807: смысл ошибки передает статус, а у нас еще и поле code.
+const problemTypeBlank = "about:blank"
+
+// serviceErrors сопоставляет ошибкам сервисов статус ответа и код из pkg/errcodes.
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+	detail string
+}{
+	{servicesErrors.ErrWalletNotFound, http.StatusNotFound, errcodes.WalletNotFound, "wallet not found"},
+	{servicesErrors.ErrNotEnoughCash, http.StatusConflict, errcodes.NotEnoughCash, "not enough cash"},
+	{servicesErrors.ErrOrderNotFound, http.StatusNotFound, errcodes.OrderNotFound, "order not found"},
+	{
+		servicesErrors.ErrAmbiguousOrder,
+		http.StatusBadRequest,
+		errcodes.AmbiguousOrder,
+		"order has several items, service must be specified",
+	},
+	{
+		servicesErrors.ErrDuplicateService,
+		http.StatusBadRequest,
+		errcodes.DuplicateService,
+		"cart contains duplicate services",
+	},
+	{
+		servicesErrors.ErrOrderExists,
+		http.StatusConflict,
+		errcodes.OrderExists,
+		"order with this service already exists",
+	},
+	{servicesErrors.ErrInvalidPageToken, http.StatusBadRequest, errcodes.InvalidPageToken, "invalid page token"},
+	{servicesErrors.ErrTransactionNotFound, http.StatusNotFound, errcodes.TransactionNotFound, "transaction not found"},
+	{servicesErrors.ErrInvalidPeriod, http.StatusBadRequest, errcodes.InvalidPeriod, "invalid period"},
+	{servicesErrors.ErrPeriodTooLong, http.StatusBadRequest, errcodes.PeriodTooLong, "period is too long"},
+	{servicesErrors.ErrStatementNotFound, http.StatusNotFound, errcodes.StatementNotFound, "statement not found"},
+	{servicesErrors.ErrInvalidWebhookURL, http.StatusBadRequest, errcodes.InvalidWebhookURL, "invalid webhook url"},
+	{servicesErrors.ErrDeliveryNotFound, http.StatusNotFound, errcodes.DeliveryNotFound, "delivery not found"},
+}
+
+// serviceProblem отвечает ошибкой сервиса. Неизвестные ошибки – 500 с кодом internal_error.
+func serviceProblem(eCtx echo.Context, err error) error {
+	for _, e := range serviceErrors {
+		if errors.Is(err, e.err) {
+			return WriteProblem(eCtx, e.status, e.code, e.detail)
+		}
+	}
+
+	return WriteProblem(eCtx, http.StatusInternalServerError, errcodes.InternalError, "internal server error")
+}
+
+// invalidRequest отвечает 400 на запрос, который не удалось разобрать.
+func invalidRequest(eCtx echo.Context, err error) error {
+	return WriteProblem(eCtx, http.StatusBadRequest, errcodes.InvalidRequest, err.Error())
+}
+
+// WriteProblem отвечает ошибкой в формате RFC 7807.
+func WriteProblem(eCtx echo.Context, status int, code, detail string) error {
+	problem := v2.Problem{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+	}
+
+	if detail != "" {
+		problem.Detail = &detail
+	}
+
+	if path := eCtx.Request().URL.Path; path != "" {
+		problem.Instance = &path
+	}
+
+	eCtx.Response().Header().Set(echo.HeaderContentType, ContentTypeProblem)
+
+	return eCtx.JSON(status, problem)
+}
@@ -0,0 +1,125 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/operations"
+	"github.com/frutonanny/wallet-service/internal/server/v2/handlers"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// routeOperations - операция каждой операции v2 (метода и пути). Ограничения частоты задаются по операциям, общим
+// для всех транспортов.
+var routeOperations = map[string]string{
+	http.MethodGet + " " + prefix + "/wallets/:userID":                             operations.GetBalance,
+	http.MethodGet + " " + prefix + "/wallets/:userID/balance":                     operations.GetBalanceAt,
+	http.MethodGet + " " + prefix + "/wallets/:userID/daily-balances":              operations.GetDailyBalances,
+	http.MethodPost + " " + prefix + "/wallets/:userID/deposits":                   operations.Add,
+	http.MethodGet + " " + prefix + "/wallets/:userID/transactions":                operations.GetTransactions,
+	http.MethodGet + " " + prefix + "/wallets/:userID/transactions/:transactionID": operations.GetTransaction,
+	http.MethodGet + " " + prefix + "/wallets/:userID/statements":                  operations.GetStatements,
+	http.MethodGet + " " + prefix + "/wallets/:userID/statements/:period":          operations.GetStatement,
+	http.MethodPost + " " + prefix + "/orders":                                     operations.ReserveCart,
+	http.MethodPost + " " + prefix + "/orders/:orderID/capture":                    operations.WriteOff,
+	http.MethodPost + " " + prefix + "/orders/:orderID/cancel":                     operations.Cancel,
+	http.MethodGet + " " + prefix + "/reports/:period":                             operations.GetReport,
+	http.MethodPost + " " + prefix + "/admin/webhooks":                             operations.AddWebhook,
+	http.MethodGet + " " + prefix + "/admin/failed-deliveries":                     operations.GetFailedDeliveries,
+	http.MethodPost + " " + prefix + "/admin/failed-deliveries/:deliveryID/replay": operations.ReplayDelivery,
+}
+
+type rateLimiter interface {
+	Allow(ctx context.Context, operation string, clientID int64, userID *int64) (time.Duration, error)
+}
+
+// rateLimited ограничивает частоту запросов клиента и запросов по пользователю userID из пути или тела запроса.
+// Ограниченный запрос получает 429 с кодом rate_limited и заголовком Retry-After. Запросы к неизвестным операциям
+// сюда не доходят, их отклоняет authenticated.
+func rateLimited(limiter rateLimiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(eCtx echo.Context) error {
+			req := eCtx.Request()
+
+			client, _ := auth.ClientFrom(req.Context())
+
+			userID, err := requestUserID(eCtx)
+			if err != nil {
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusInternalServerError,
+					errcodes.InternalError,
+					"internal server error",
+				)
+			}
+
+			wait, err := limiter.Allow(req.Context(), routeOperations[req.Method+" "+eCtx.Path()], client.KeyID, userID)
+			if errors.Is(err, servicesErrors.ErrRateLimited) {
+				eCtx.Response().Header().Set(echo.HeaderRetryAfter, retryAfter(wait))
+				return handlers.WriteProblem(
+					eCtx,
+					http.StatusTooManyRequests,
+					errcodes.RateLimited,
+					"rate limit exceeded",
+				)
+			}
+
+			return next(eCtx)
+		}
+	}
+}
+
+// requestUserID достает userID из пути (/wallets/{userID}/...) или из тела запроса. Тело остается доступным
+// обработчику.
+func requestUserID(eCtx echo.Context) (*int64, error) {
+	if v := eCtx.Param("userID"); v != "" {
+		if id, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return &id, nil
+		}
+
+		return nil, nil
+	}
+
+	req := eCtx.Request()
+
+	if req.Body == nil || req.Method != http.MethodPost {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		UserID *int64 `json:"userID"`
+	}
+
+	// Запрос уже проверен валидатором, а у операций без userID ограничение по пользователю не действует.
+	_ = json.Unmarshal(body, &payload)
+
+	return payload.UserID, nil
+}
+
+// retryAfter - значение заголовка Retry-After: целое число секунд, не меньше одной.
+func retryAfter(wait time.Duration) string {
+	seconds := int64(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.FormatInt(seconds, 10)
+}
@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	mdlwr "github.com/deepmap/oapi-codegen/pkg/middleware"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/generated/server/v2"
+	"github.com/frutonanny/wallet-service/internal/server/v2/handlers"
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const prefix = "/v2"
+
+// Routes регистрирует API v2 на сервере рядом с v1. Ошибки разбора и валидации запроса, а также неизвестные пути
+// под /v2 отдаются в формате RFC 7807. Каждая операция требует API-ключ с нужным правом, частота запросов
+// ограничивается limiter, изменяющие запросы записываются в журнал аудита recorder.
+func Routes(
+	h v2.ServerInterface,
+	swagger *openapi3.T,
+	authenticator authenticator,
+	limiter rateLimiter,
+	recorder audit.Recorder,
+) func(e *echo.Echo) {
+	// Адрес из servers в схеме привязан к хосту, а валидатору нужен только префикс пути.
+	swagger.Servers = openapi3.Servers{{URL: prefix}}
+
+	return func(e *echo.Echo) {
+		validator := mdlwr.OapiRequestValidatorWithOptions(swagger, &mdlwr.Options{
+			// Ключ проверяет authenticated, валидатору достаточно знать, что схема безопасности описана.
+			Options: openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			ErrorHandler: func(eCtx echo.Context, err *echo.HTTPError) error {
+				// Валидатор отвечает 400 и на неизвестный путь или метод.
+				switch err.Message {
+				case routers.ErrPathNotFound.Error():
+					err.Code = http.StatusNotFound
+				case routers.ErrMethodNotAllowed.Error():
+					err.Code = http.StatusMethodNotAllowed
+				}
+
+				return writeHTTPError(eCtx, err)
+			},
+		})
+
+		group := e.Group(prefix, audited(recorder), validator, authenticated(authenticator), rateLimited(limiter))
+		v2.RegisterHandlers(group, h)
+
+		defaultHandler := e.HTTPErrorHandler
+
+		e.HTTPErrorHandler = func(err error, eCtx echo.Context) {
+			if !strings.HasPrefix(eCtx.Request().URL.Path, prefix+"/") || eCtx.Response().Committed {
+				defaultHandler(err, eCtx)
+				return
+			}
+
+			var httpErr *echo.HTTPError
+			if !errors.As(err, &httpErr) {
+				httpErr = echo.NewHTTPError(http.StatusInternalServerError)
+			}
+
+			if err := writeHTTPError(eCtx, httpErr); err != nil {
+				e.Logger.Error(err)
+			}
+		}
+	}
+}
+
+func writeHTTPError(eCtx echo.Context, err *echo.HTTPError) error {
+	code := errcodes.InvalidRequest
+	if err.Code >= http.StatusInternalServerError {
+		code = errcodes.InternalError
+	}
+
+	detail, ok := err.Message.(string)
+	if !ok {
+		detail = http.StatusText(err.Code)
+	}
+
+	return handlers.WriteProblem(eCtx, err.Code, code, detail)
+}
@@ -2,6 +2,7 @@ package add
 
 import (
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
 	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 )
@@ -15,3 +16,7 @@ func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepos
 func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) TransactionRepository {
 	return repoTxs.New(db)
 }
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
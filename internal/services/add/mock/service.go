@@ -10,6 +10,7 @@ import (
 
 	postgres "github.com/frutonanny/wallet-service/internal/postgres"
 	add "github.com/frutonanny/wallet-service/internal/services/add"
+	events "github.com/frutonanny/wallet-service/pkg/events"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -37,27 +38,37 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 }
 
 // Error mocks base method.
-func (m *Mocklogger) Error(msg string) {
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
 }
 
 // MockWalletRepository is a mock of WalletRepository interface.
@@ -137,18 +148,56 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 }
 
 // AddTransaction mocks base method.
-func (m *MockTransactionRepository) AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount int64) (int64, error) {
+func (m *MockTransactionRepository) AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount, balanceAfter int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTransaction", ctx, walletID, action, payload, amount)
+	ret := m.ctrl.Call(m, "AddTransaction", ctx, walletID, action, payload, amount, balanceAfter)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddTransaction indicates an expected call of AddTransaction.
-func (mr *MockTransactionRepositoryMockRecorder) AddTransaction(ctx, walletID, action, payload, amount interface{}) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) AddTransaction(ctx, walletID, action, payload, amount, balanceAfter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).AddTransaction), ctx, walletID, action, payload, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).AddTransaction), ctx, walletID, action, payload, amount, balanceAfter)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockOutboxRepository) AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", ctx, eventType, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockOutboxRepositoryMockRecorder) AddEvent(ctx, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockOutboxRepository)(nil).AddEvent), ctx, eventType, data)
 }
 
 // Mockdependencies is a mock of dependencies interface.
@@ -174,6 +223,20 @@ func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
 	return m.recorder
 }
 
+// NewOutboxRepository mocks base method.
+func (m *Mockdependencies) NewOutboxRepository(db postgres.Database) add.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewOutboxRepository", db)
+	ret0, _ := ret[0].(add.OutboxRepository)
+	return ret0
+}
+
+// NewOutboxRepository indicates an expected call of NewOutboxRepository.
+func (mr *MockdependenciesMockRecorder) NewOutboxRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOutboxRepository", reflect.TypeOf((*Mockdependencies)(nil).NewOutboxRepository), db)
+}
+
 // NewTransactionRepository mocks base method.
 func (m *Mockdependencies) NewTransactionRepository(db postgres.Database) add.TransactionRepository {
 	m.ctrl.T.Helper()
@@ -6,14 +6,20 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type WalletRepository interface {
@@ -22,7 +28,17 @@ type WalletRepository interface {
 }
 
 type TransactionRepository interface {
-	AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount int64) (int64, error)
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
+}
+
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
 }
 
 // dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
@@ -30,8 +46,12 @@ type TransactionRepository interface {
 type dependencies interface {
 	NewWalletRepository(db postgres.Database) WalletRepository
 	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
 }
 
+// operation - имя операции в метриках.
+const operation = "add"
+
 type Service struct {
 	db     *sql.DB
 	logger logger
@@ -55,12 +75,21 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 // - проверяем есть ли кошелек у пользователя, если нет, то создаем;
 // - зачисляем переданную сумму на кошелек пользователя;
 // - добавляем транзакцию о внесенных средствах;
+// - добавляем событие о зачислении в outbox;
 // - в ответ отдаем текущий баланс пользователя в копейках с учетом пополнения.
-func (s *Service) Add(ctx context.Context, userID, amount int64) (int64, error) {
+func (s *Service) Add(ctx context.Context, userID, amount int64) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "add.Add")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.Amount(amount))
+
 	// Стартуем транзакцию.
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("begin tx: %s", err))
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
 		return 0, fmt.Errorf("begin tx: %v", err)
 	}
 
@@ -70,7 +99,7 @@ func (s *Service) Add(ctx context.Context, userID, amount int64) (int64, error)
 				return
 			}
 
-			s.logger.Error(fmt.Sprintf("rollback: %s", err))
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
 		}
 	}()
 
@@ -79,39 +108,57 @@ func (s *Service) Add(ctx context.Context, userID, amount int64) (int64, error)
 	// Создаем кошелек пользователю, если еще не создан.
 	walletID, err := walletRepo.CreateIfNotExist(ctx, userID)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("create if not exist: %s", err))
+		s.logger.Error(ctx, "create if not exist", logfield.Error(err))
 		return 0, fmt.Errorf("create if not exist: %v", err)
 	}
 
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
 	// Зачисляем переданную сумму на кошелек пользователя.
 	balance, err := walletRepo.Add(ctx, walletID, amount)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("add amount: %s", err))
+		s.logger.Error(ctx, "add amount", logfield.Error(err))
 		return 0, fmt.Errorf("add amount: %v", err)
 	}
 
 	// Генерируем payload.
 	payload, err := transactions.EnrollmentPayload()
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("generated payload: %s", err))
+		s.logger.Error(ctx, "generated payload", logfield.Error(err))
 		return 0, fmt.Errorf("generated payload: %v", err)
 	}
 
 	txsRepo := s.deps.NewTransactionRepository(tx)
 
 	// Добавляем транзакцию о проведенной денежной операции.
-	if _, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeAdd, payload, amount); err != nil {
-		s.logger.Error(fmt.Sprintf("add transaction: %s", err))
+	txID, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeAdd, payload, amount, balance)
+	if err != nil {
+		s.logger.Error(ctx, "add transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add transaction: %v", err)
 	}
 
+	audit.AddTransaction(ctx, txID)
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Добавляем событие о зачислении, оно будет опубликовано только вместе с фиксацией транзакции.
+	if _, err := outboxRepo.AddEvent(ctx, events.TypeIncomingTransfer, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        amount,
+		Balance:       balance,
+	}); err != nil {
+		s.logger.Error(ctx, "add event", logfield.Error(err))
+		return 0, fmt.Errorf("add event: %v", err)
+	}
+
 	// Завершаем транзакцию.
-	if err := tx.Commit(); err != nil {
-		s.logger.Error(fmt.Sprintf("commit tx: %s", err))
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
 		return 0, fmt.Errorf("commit tx: %v", err)
 	}
 
-	s.logger.Info(fmt.Sprintf("amount added for wallet: %d", walletID))
+	s.logger.Info(ctx, "amount added")
 
 	return balance, nil
 }
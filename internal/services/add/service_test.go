@@ -12,6 +12,7 @@ import (
 
 	"github.com/frutonanny/wallet-service/internal/services/add"
 	mock_add "github.com/frutonanny/wallet-service/internal/services/add/mock"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 	testAmount   = int64(1_000)
 	testBalance  = int64(1_000)
 	testTxID     = int64(0)
+	testEventID  = int64(1)
 )
 
 var testError = errors.New("error")
@@ -40,17 +42,28 @@ func TestAdd(t *testing.T) {
 
 		txsRepo := mock_add.NewMockTransactionRepository(ctrl)
 		txsRepo.EXPECT().
-			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount).
+			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount, testBalance).
 			Return(testTxID, nil)
 
+		outboxRepo := mock_add.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().
+			AddEvent(context.Background(), events.TypeIncomingTransfer, events.WalletData{
+				UserID:        testUserID,
+				TransactionID: testTxID,
+				Amount:        testAmount,
+				Balance:       testBalance,
+			}).
+			Return(testEventID, nil)
+
 		mock.ExpectCommit()
 
 		deps := mock_add.NewMockdependencies(ctrl)
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
 
 		log := mock_add.NewMocklogger(ctrl)
-		log.EXPECT().Info(gomock.Any())
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := add.New(log, db).WithDependencies(deps)
 
@@ -77,7 +90,7 @@ func TestAdd(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_add.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := add.New(log, db).WithDependencies(deps)
 
@@ -104,7 +117,7 @@ func TestAdd(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_add.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := add.New(log, db).WithDependencies(deps)
 
@@ -128,7 +141,7 @@ func TestAdd(t *testing.T) {
 		txsRepo := mock_add.NewMockTransactionRepository(ctrl)
 
 		txsRepo.EXPECT().
-			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount).
+			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount, testBalance).
 			Return(testTxID, testError)
 
 		mock.ExpectRollback()
@@ -138,7 +151,46 @@ func TestAdd(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
 
 		log := mock_add.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := add.New(log, db).WithDependencies(deps)
+
+		_, err = service.Add(context.Background(), testUserID, testAmount)
+		assert.Error(t, err)
+	})
+
+	t.Run("add event failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_add.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().CreateIfNotExist(context.Background(), testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Add(context.Background(), testWalletID, testAmount).Return(testBalance, nil)
+
+		txsRepo := mock_add.NewMockTransactionRepository(ctrl)
+		txsRepo.EXPECT().
+			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount, testBalance).
+			Return(testTxID, nil)
+
+		outboxRepo := mock_add.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().
+			AddEvent(context.Background(), events.TypeIncomingTransfer, gomock.Any()).
+			Return(int64(0), testError)
+
+		mock.ExpectRollback()
+
+		deps := mock_add.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_add.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := add.New(log, db).WithDependencies(deps)
 
@@ -0,0 +1,22 @@
+package adjust
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
+
+func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) TransactionRepository {
+	return repoTxs.New(db)
+}
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
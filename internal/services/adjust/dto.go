@@ -0,0 +1,7 @@
+package adjust
+
+// Result - результат корректировки: созданная транзакция и доступный баланс после нее.
+type Result struct {
+	TransactionID int64 `json:"transactionID"`
+	Balance       int64 `json:"balance"`
+}
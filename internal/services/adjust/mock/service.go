@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_adjust is a generated GoMock package.
+package mock_adjust
+
+import (
+	context "context"
+	reflect "reflect"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	adjust "github.com/frutonanny/wallet-service/internal/services/adjust"
+	events "github.com/frutonanny/wallet-service/pkg/events"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockWalletRepository) Add(ctx context.Context, walletID, amount int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, walletID, amount)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockWalletRepositoryMockRecorder) Add(ctx, walletID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWalletRepository)(nil).Add), ctx, walletID, amount)
+}
+
+// ExistWallet mocks base method.
+func (m *MockWalletRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistWallet indicates an expected call of ExistWallet.
+func (mr *MockWalletRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockWalletRepository)(nil).ExistWallet), ctx, userID)
+}
+
+// Withdraw mocks base method.
+func (m *MockWalletRepository) Withdraw(ctx context.Context, walletID, amount int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, walletID, amount)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockWalletRepositoryMockRecorder) Withdraw(ctx, walletID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockWalletRepository)(nil).Withdraw), ctx, walletID, amount)
+}
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddTransaction mocks base method.
+func (m *MockTransactionRepository) AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount, balanceAfter int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransaction", ctx, walletID, action, payload, amount, balanceAfter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTransaction indicates an expected call of AddTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) AddTransaction(ctx, walletID, action, payload, amount, balanceAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).AddTransaction), ctx, walletID, action, payload, amount, balanceAfter)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEvent mocks base method.
+func (m *MockOutboxRepository) AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEvent", ctx, eventType, data)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEvent indicates an expected call of AddEvent.
+func (mr *MockOutboxRepositoryMockRecorder) AddEvent(ctx, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvent", reflect.TypeOf((*MockOutboxRepository)(nil).AddEvent), ctx, eventType, data)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewOutboxRepository mocks base method.
+func (m *Mockdependencies) NewOutboxRepository(db postgres.Database) adjust.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewOutboxRepository", db)
+	ret0, _ := ret[0].(adjust.OutboxRepository)
+	return ret0
+}
+
+// NewOutboxRepository indicates an expected call of NewOutboxRepository.
+func (mr *MockdependenciesMockRecorder) NewOutboxRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOutboxRepository", reflect.TypeOf((*Mockdependencies)(nil).NewOutboxRepository), db)
+}
+
+// NewTransactionRepository mocks base method.
+func (m *Mockdependencies) NewTransactionRepository(db postgres.Database) adjust.TransactionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransactionRepository", db)
+	ret0, _ := ret[0].(adjust.TransactionRepository)
+	return ret0
+}
+
+// NewTransactionRepository indicates an expected call of NewTransactionRepository.
+func (mr *MockdependenciesMockRecorder) NewTransactionRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransactionRepository", reflect.TypeOf((*Mockdependencies)(nil).NewTransactionRepository), db)
+}
+
+// NewWalletRepository mocks base method.
+func (m *Mockdependencies) NewWalletRepository(db postgres.Database) adjust.WalletRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWalletRepository", db)
+	ret0, _ := ret[0].(adjust.WalletRepository)
+	return ret0
+}
+
+// NewWalletRepository indicates an expected call of NewWalletRepository.
+func (mr *MockdependenciesMockRecorder) NewWalletRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWalletRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWalletRepository), db)
+}
@@ -0,0 +1,199 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package adjust
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+	Add(ctx context.Context, walletID int64, amount int64) (int64, error)
+	Withdraw(ctx context.Context, walletID, amount int64) (int64, error)
+}
+
+type TransactionRepository interface {
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
+}
+
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
+}
+
+// operation - имя операции в метриках.
+const operation = "adjust"
+
+type Service struct {
+	db     *sql.DB
+	logger logger
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		deps:   &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Adjust - вручную корректирует баланс пользователя: положительная сумма amount зачисляется, отрицательная
+// списывается с доступных средств. Причина reason обязательна, она и оператор operator попадают в payload транзакции.
+// - проверяем причину и сумму, пустая причина – ошибка ErrReasonRequired, нулевая сумма – ErrZeroAmount;
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound;
+// - зачисляем сумму на кошелек или списываем ее, если средств не хватает, то отдаем ошибку ErrNotEnoughCash;
+// - добавляем транзакцию о зачислении или о списании без заказа;
+// - добавляем событие о корректировке в outbox;
+// - в ответ отдаем транзакцию и баланс пользователя в копейках после корректировки.
+func (s *Service) Adjust(ctx context.Context, userID, amount int64, reason, operator string) (_ Result, err error) {
+	ctx, span := tracing.Start(ctx, "adjust.Adjust")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.Amount(amount))
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return Result{}, servicesErrors.ErrReasonRequired
+	}
+
+	if amount == 0 {
+		return Result{}, servicesErrors.ErrZeroAmount
+	}
+
+	// Стартуем транзакцию.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
+		return Result{}, fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	walletRepo := s.deps.NewWalletRepository(tx)
+
+	// Проверяем, есть ли кошелек у пользователя. Корректируется только существующий кошелек.
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			return Result{}, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "wallet not exist", logfield.Error(err))
+		return Result{}, fmt.Errorf("wallet not exist: %v", err)
+	}
+
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
+	// Сумма транзакции всегда положительная, направление задает тип транзакции.
+	txType, eventType, txAmount := transactions.TypeAdd, events.TypeIncomingTransfer, amount
+
+	var balance int64
+	if amount > 0 {
+		balance, err = walletRepo.Add(ctx, walletID, amount)
+	} else {
+		txType, eventType, txAmount = transactions.TypeOutgoing, events.TypeOutgoingTransfer, -amount
+		balance, err = walletRepo.Withdraw(ctx, walletID, txAmount)
+	}
+
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoNotEnoughCash) {
+			return Result{}, servicesErrors.ErrNotEnoughCash
+		}
+
+		s.logger.Error(ctx, "adjust balance", logfield.Error(err))
+		return Result{}, fmt.Errorf("adjust balance: %v", err)
+	}
+
+	// Генерируем payload.
+	payload, err := transactions.AdjustmentPayload(reason, operator)
+	if err != nil {
+		s.logger.Error(ctx, "generated payload", logfield.Error(err))
+		return Result{}, fmt.Errorf("generated payload: %v", err)
+	}
+
+	txsRepo := s.deps.NewTransactionRepository(tx)
+
+	// Добавляем транзакцию о проведенной денежной операции.
+	txID, err := txsRepo.AddTransaction(ctx, walletID, txType, payload, txAmount, balance)
+	if err != nil {
+		s.logger.Error(ctx, "add transaction", logfield.Error(err))
+		return Result{}, fmt.Errorf("add transaction: %v", err)
+	}
+
+	audit.AddTransaction(ctx, txID)
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Добавляем событие о корректировке, оно будет опубликовано только вместе с фиксацией транзакции.
+	if _, err := outboxRepo.AddEvent(ctx, eventType, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        txAmount,
+		Balance:       balance,
+	}); err != nil {
+		s.logger.Error(ctx, "add event", logfield.Error(err))
+		return Result{}, fmt.Errorf("add event: %v", err)
+	}
+
+	// Завершаем транзакцию.
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
+		return Result{}, fmt.Errorf("commit tx: %v", err)
+	}
+
+	s.logger.Info(ctx, "balance adjusted", "reason", reason, "operator", operator)
+
+	return Result{
+		TransactionID: txID,
+		Balance:       balance,
+	}, nil
+}
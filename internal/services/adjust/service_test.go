@@ -0,0 +1,273 @@
+package adjust_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/internal/services/adjust"
+	mock_adjust "github.com/frutonanny/wallet-service/internal/services/adjust/mock"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	testUserID   = int64(1)
+	testWalletID = int64(1)
+	testAmount   = int64(1_000)
+	testBalance  = int64(5_000)
+	testTxID     = int64(7)
+	testEventID  = int64(1)
+	testReason   = "double enrollment, ticket OPS-12"
+	testOperator = "cli:katya"
+)
+
+var testError = errors.New("error")
+
+// payloadMatcher проверяет, что в payload транзакции записаны причина и оператор корректировки.
+type payloadMatcher struct{}
+
+func (payloadMatcher) Matches(x interface{}) bool {
+	b, ok := x.([]byte)
+	if !ok {
+		return false
+	}
+
+	var p map[string]string
+	if err := json.Unmarshal(b, &p); err != nil {
+		return false
+	}
+
+	return p["type"] == "adjustment" && p["reason"] == testReason && p["operator"] == testOperator
+}
+
+func (payloadMatcher) String() string {
+	return "adjustment payload"
+}
+
+func TestAdjust(t *testing.T) {
+	t.Run("credit successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_adjust.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(context.Background(), testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Add(context.Background(), testWalletID, testAmount).Return(testBalance, nil)
+
+		txsRepo := mock_adjust.NewMockTransactionRepository(ctrl)
+		txsRepo.EXPECT().
+			AddTransaction(
+				context.Background(),
+				testWalletID,
+				transactions.TypeAdd,
+				payloadMatcher{},
+				testAmount,
+				testBalance,
+			).
+			Return(testTxID, nil)
+
+		outboxRepo := mock_adjust.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().
+			AddEvent(context.Background(), events.TypeIncomingTransfer, events.WalletData{
+				UserID:        testUserID,
+				TransactionID: testTxID,
+				Amount:        testAmount,
+				Balance:       testBalance,
+			}).
+			Return(testEventID, nil)
+
+		mock.ExpectCommit()
+
+		deps := mock_adjust.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_adjust.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := adjust.New(log, db).WithDependencies(deps)
+
+		result, err := service.Adjust(context.Background(), testUserID, testAmount, testReason, testOperator)
+		require.NoError(t, err)
+		assert.Equal(t, adjust.Result{TransactionID: testTxID, Balance: testBalance}, result)
+	})
+
+	t.Run("debit successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		// Сумма транзакции и события положительная, списание задает тип.
+		walletRepo := mock_adjust.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(context.Background(), testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Withdraw(context.Background(), testWalletID, testAmount).Return(testBalance, nil)
+
+		txsRepo := mock_adjust.NewMockTransactionRepository(ctrl)
+		txsRepo.EXPECT().
+			AddTransaction(
+				context.Background(),
+				testWalletID,
+				transactions.TypeOutgoing,
+				payloadMatcher{},
+				testAmount,
+				testBalance,
+			).
+			Return(testTxID, nil)
+
+		outboxRepo := mock_adjust.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().
+			AddEvent(context.Background(), events.TypeOutgoingTransfer, events.WalletData{
+				UserID:        testUserID,
+				TransactionID: testTxID,
+				Amount:        testAmount,
+				Balance:       testBalance,
+			}).
+			Return(testEventID, nil)
+
+		mock.ExpectCommit()
+
+		deps := mock_adjust.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_adjust.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := adjust.New(log, db).WithDependencies(deps)
+
+		result, err := service.Adjust(context.Background(), testUserID, -testAmount, testReason, testOperator)
+		require.NoError(t, err)
+		assert.Equal(t, adjust.Result{TransactionID: testTxID, Balance: testBalance}, result)
+	})
+
+	t.Run("reason is required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+
+		service := adjust.New(mock_adjust.NewMocklogger(ctrl), db).
+			WithDependencies(mock_adjust.NewMockdependencies(ctrl))
+
+		_, err = service.Adjust(context.Background(), testUserID, testAmount, "  ", testOperator)
+		assert.ErrorIs(t, err, servicesErrors.ErrReasonRequired)
+	})
+
+	t.Run("zero amount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+
+		service := adjust.New(mock_adjust.NewMocklogger(ctrl), db).
+			WithDependencies(mock_adjust.NewMockdependencies(ctrl))
+
+		_, err = service.Adjust(context.Background(), testUserID, 0, testReason, testOperator)
+		assert.ErrorIs(t, err, servicesErrors.ErrZeroAmount)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_adjust.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().
+			ExistWallet(context.Background(), testUserID).
+			Return(int64(0), repositories.ErrRepoWalletNotFound)
+
+		mock.ExpectRollback()
+
+		deps := mock_adjust.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		service := adjust.New(mock_adjust.NewMocklogger(ctrl), db).WithDependencies(deps)
+
+		_, err = service.Adjust(context.Background(), testUserID, testAmount, testReason, testOperator)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("not enough cash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_adjust.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(context.Background(), testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().
+			Withdraw(context.Background(), testWalletID, testAmount).
+			Return(int64(0), repositories.ErrRepoNotEnoughCash)
+
+		mock.ExpectRollback()
+
+		deps := mock_adjust.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		service := adjust.New(mock_adjust.NewMocklogger(ctrl), db).WithDependencies(deps)
+
+		_, err = service.Adjust(context.Background(), testUserID, -testAmount, testReason, testOperator)
+		assert.ErrorIs(t, err, servicesErrors.ErrNotEnoughCash)
+	})
+
+	t.Run("add transaction failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_adjust.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(context.Background(), testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Add(context.Background(), testWalletID, testAmount).Return(testBalance, nil)
+
+		txsRepo := mock_adjust.NewMockTransactionRepository(ctrl)
+		txsRepo.EXPECT().
+			AddTransaction(context.Background(), testWalletID, gomock.Any(), gomock.Any(), testAmount, testBalance).
+			Return(int64(0), testError)
+
+		mock.ExpectRollback()
+
+		deps := mock_adjust.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txsRepo)
+
+		log := mock_adjust.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := adjust.New(log, db).WithDependencies(deps)
+
+		_, err = service.Adjust(context.Background(), testUserID, testAmount, testReason, testOperator)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,12 @@
+package audit_log
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewAuditRepository(db postgres.Database) AuditRepository {
+	return repoAudit.New(db)
+}
@@ -0,0 +1,21 @@
+package audit_log
+
+import (
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+)
+
+// Entry - запись журнала аудита. Описана в internal/audit, чтобы запросы к API записывались без зависимости
+// от сервиса.
+type Entry = audit.Entry
+
+// Filter - условия выборки записей журнала. Нулевые поля не ограничивают выборку.
+type Filter struct {
+	UserID        int64
+	ClientName    string
+	Endpoint      string
+	TransactionID int64
+	From          time.Time
+	To            time.Time
+}
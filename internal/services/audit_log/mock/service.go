@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_audit_log is a generated GoMock package.
+package mock_audit_log
+
+import (
+	context "context"
+	reflect "reflect"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	audit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	audit_log "github.com/frutonanny/wallet-service/internal/services/audit_log"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddEntry mocks base method.
+func (m *MockAuditRepository) AddEntry(ctx context.Context, e audit.Entry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntry", ctx, e)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEntry indicates an expected call of AddEntry.
+func (mr *MockAuditRepositoryMockRecorder) AddEntry(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntry", reflect.TypeOf((*MockAuditRepository)(nil).AddEntry), ctx, e)
+}
+
+// AddOrderTransactions mocks base method.
+func (m *MockAuditRepository) AddOrderTransactions(ctx context.Context, auditID int64, orderTransactionIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrderTransactions", ctx, auditID, orderTransactionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrderTransactions indicates an expected call of AddOrderTransactions.
+func (mr *MockAuditRepositoryMockRecorder) AddOrderTransactions(ctx, auditID, orderTransactionIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrderTransactions", reflect.TypeOf((*MockAuditRepository)(nil).AddOrderTransactions), ctx, auditID, orderTransactionIDs)
+}
+
+// AddTransactions mocks base method.
+func (m *MockAuditRepository) AddTransactions(ctx context.Context, auditID int64, transactionIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransactions", ctx, auditID, transactionIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTransactions indicates an expected call of AddTransactions.
+func (mr *MockAuditRepositoryMockRecorder) AddTransactions(ctx, auditID, transactionIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransactions", reflect.TypeOf((*MockAuditRepository)(nil).AddTransactions), ctx, auditID, transactionIDs)
+}
+
+// GetEntries mocks base method.
+func (m *MockAuditRepository) GetEntries(ctx context.Context, filter audit.Filter, afterID, limit int64) ([]audit.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntries", ctx, filter, afterID, limit)
+	ret0, _ := ret[0].([]audit.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetEntries(ctx, filter, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetEntries), ctx, filter, afterID, limit)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewAuditRepository mocks base method.
+func (m *Mockdependencies) NewAuditRepository(db postgres.Database) audit_log.AuditRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAuditRepository", db)
+	ret0, _ := ret[0].(audit_log.AuditRepository)
+	return ret0
+}
+
+// NewAuditRepository indicates an expected call of NewAuditRepository.
+func (mr *MockdependenciesMockRecorder) NewAuditRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAuditRepository", reflect.TypeOf((*Mockdependencies)(nil).NewAuditRepository), db)
+}
@@ -0,0 +1,173 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package audit_log
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type AuditRepository interface {
+	AddEntry(ctx context.Context, e repoAudit.Entry) (int64, error)
+	AddTransactions(ctx context.Context, auditID int64, transactionIDs []int64) error
+	AddOrderTransactions(ctx context.Context, auditID int64, orderTransactionIDs []int64) error
+	GetEntries(ctx context.Context, filter repoAudit.Filter, afterID, limit int64) ([]repoAudit.Entry, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewAuditRepository(db postgres.Database) AuditRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Record - записывает запрос в журнал аудита вместе со связями с созданными им транзакциями.
+// Запись и связи добавляются в одной транзакции: в журнале не бывает записи без части своих транзакций.
+// Связи есть только у успешного запроса: транзакции неуспешного откатились вместе с ним.
+// Если записать не удалось, в лог попадает, какой запрос остался без записи в журнале.
+func (s *Service) Record(ctx context.Context, e Entry) error {
+	if err := s.record(ctx, e); err != nil {
+		s.logger.Error(ctx, "audit entry is not recorded",
+			"endpoint", e.Endpoint,
+			"request_id", e.RequestID,
+			"result_code", e.ResultCode,
+			logfield.Error(err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) record(ctx context.Context, e Entry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
+		return fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	repo := s.deps.NewAuditRepository(tx)
+
+	id, err := repo.AddEntry(ctx, repoAudit.Entry{
+		RequestID:   e.RequestID,
+		ClientKeyID: e.ClientKeyID,
+		ClientName:  e.ClientName,
+		RemoteAddr:  e.RemoteAddr,
+		Endpoint:    e.Endpoint,
+		UserID:      e.UserID,
+		Request:     e.Request,
+		ResultCode:  e.ResultCode,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "add entry", logfield.Error(err))
+		return fmt.Errorf("add entry: %v", err)
+	}
+
+	if e.ResultCode != audit.ResultOK {
+		return s.commit(ctx, tx)
+	}
+
+	if err := repo.AddTransactions(ctx, id, e.TransactionIDs); err != nil {
+		s.logger.Error(ctx, "add transactions", logfield.Error(err))
+		return fmt.Errorf("add transactions: %v", err)
+	}
+
+	if err := repo.AddOrderTransactions(ctx, id, e.OrderTransactionIDs); err != nil {
+		s.logger.Error(ctx, "add order transactions", logfield.Error(err))
+		return fmt.Errorf("add order transactions: %v", err)
+	}
+
+	return s.commit(ctx, tx)
+}
+
+func (s *Service) commit(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		s.logger.Error(ctx, "commit", logfield.Error(err))
+		return fmt.Errorf("commit: %v", err)
+	}
+
+	return nil
+}
+
+// GetEntries - отдает не больше limit записей журнала после afterID в порядке добавления,
+// ограниченные фильтром filter.
+func (s *Service) GetEntries(ctx context.Context, filter Filter, afterID, limit int64) (_ []Entry, err error) {
+	ctx, span := tracing.Start(ctx, "audit_log.GetEntries")
+	defer func() { tracing.End(span, err) }()
+
+	entries, err := s.deps.NewAuditRepository(s.db).GetEntries(ctx, repoAudit.Filter{
+		UserID:        filter.UserID,
+		ClientName:    filter.ClientName,
+		Endpoint:      filter.Endpoint,
+		TransactionID: filter.TransactionID,
+		From:          filter.From,
+		To:            filter.To,
+	}, afterID, limit)
+	if err != nil {
+		s.logger.Error(ctx, "get entries", logfield.Error(err))
+		return nil, fmt.Errorf("get entries: %v", err)
+	}
+
+	result := make([]Entry, 0, len(entries))
+
+	for _, e := range entries {
+		result = append(result, Entry{
+			ID:                  e.ID,
+			CreatedAt:           e.CreatedAt,
+			RequestID:           e.RequestID,
+			ClientKeyID:         e.ClientKeyID,
+			ClientName:          e.ClientName,
+			RemoteAddr:          e.RemoteAddr,
+			Endpoint:            e.Endpoint,
+			UserID:              e.UserID,
+			Request:             e.Request,
+			ResultCode:          e.ResultCode,
+			TransactionIDs:      e.TransactionIDs,
+			OrderTransactionIDs: e.OrderTransactionIDs,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,179 @@
+package audit_log_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	mock "github.com/frutonanny/wallet-service/internal/services/audit_log/mock"
+)
+
+var testError = errors.New("error")
+
+func TestService_Record(t *testing.T) {
+	userID := int64(1)
+
+	entry := audit_log.Entry{
+		RequestID:           "request",
+		ClientName:          "orders",
+		RemoteAddr:          "127.0.0.1",
+		Endpoint:            "/v1/reserve",
+		UserID:              &userID,
+		Request:             []byte(`{}`),
+		ResultCode:          "ok",
+		TransactionIDs:      []int64{10},
+		OrderTransactionIDs: []int64{20},
+	}
+
+	t.Run("record successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+
+		repo := mock.NewMockAuditRepository(ctrl)
+		repo.EXPECT().AddEntry(ctx, repoAudit.Entry{
+			RequestID:  "request",
+			ClientName: "orders",
+			RemoteAddr: "127.0.0.1",
+			Endpoint:   "/v1/reserve",
+			UserID:     &userID,
+			Request:    []byte(`{}`),
+			ResultCode: "ok",
+		}).Return(int64(5), nil)
+		repo.EXPECT().AddTransactions(ctx, int64(5), []int64{10}).Return(nil)
+		repo.EXPECT().AddOrderTransactions(ctx, int64(5), []int64{20}).Return(nil)
+
+		sqlMock.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAuditRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := audit_log.New(log, db).WithDependencies(deps)
+
+		require.NoError(t, service.Record(ctx, entry))
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("failed request is recorded without transactions", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+
+		failed := entry
+		failed.ResultCode = "not_enough_cash"
+
+		repo := mock.NewMockAuditRepository(ctrl)
+		repo.EXPECT().AddEntry(ctx, gomock.Any()).Return(int64(5), nil)
+
+		sqlMock.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAuditRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := audit_log.New(log, db).WithDependencies(deps)
+
+		require.NoError(t, service.Record(ctx, failed))
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("record failed, transaction is rolled back", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+
+		repo := mock.NewMockAuditRepository(ctrl)
+		repo.EXPECT().AddEntry(ctx, gomock.Any()).Return(int64(5), nil)
+		repo.EXPECT().AddTransactions(ctx, int64(5), []int64{10}).Return(testError)
+
+		sqlMock.ExpectRollback()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAuditRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), "add transactions", gomock.Any())
+		log.EXPECT().Error(gomock.Any(), "audit entry is not recorded", gomock.Any())
+
+		service := audit_log.New(log, db).WithDependencies(deps)
+
+		assert.Error(t, service.Record(ctx, entry))
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestService_GetEntries(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get entries successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAuditRepository(ctrl)
+		repo.EXPECT().GetEntries(ctx, repoAudit.Filter{ClientName: "orders"}, int64(0), int64(10)).
+			Return([]repoAudit.Entry{{ID: 1, ClientName: "orders", TransactionIDs: []int64{10}}}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAuditRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := audit_log.New(log, db).WithDependencies(deps)
+
+		entries, err := service.GetEntries(ctx, audit_log.Filter{ClientName: "orders"}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []audit_log.Entry{{ID: 1, ClientName: "orders", TransactionIDs: []int64{10}}}, entries)
+	})
+
+	t.Run("get entries failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAuditRepository(ctrl)
+		repo.EXPECT().GetEntries(ctx, repoAudit.Filter{}, int64(0), int64(10)).Return(nil, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAuditRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := audit_log.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetEntries(ctx, audit_log.Filter{}, 0, 10)
+		assert.Error(t, err)
+	})
+}
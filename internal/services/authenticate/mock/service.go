@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_authenticate is a generated GoMock package.
+package mock_authenticate
+
+import (
+	context "context"
+	reflect "reflect"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	apikey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+	authenticate "github.com/frutonanny/wallet-service/internal/services/authenticate"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetActiveKey mocks base method.
+func (m *MockAPIKeyRepository) GetActiveKey(ctx context.Context, keyHash string) (apikey.Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveKey", ctx, keyHash)
+	ret0, _ := ret[0].(apikey.Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveKey indicates an expected call of GetActiveKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetActiveKey(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetActiveKey), ctx, keyHash)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewAPIKeyRepository mocks base method.
+func (m *Mockdependencies) NewAPIKeyRepository(db postgres.Database) authenticate.APIKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAPIKeyRepository", db)
+	ret0, _ := ret[0].(authenticate.APIKeyRepository)
+	return ret0
+}
+
+// NewAPIKeyRepository indicates an expected call of NewAPIKeyRepository.
+func (mr *MockdependenciesMockRecorder) NewAPIKeyRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAPIKeyRepository", reflect.TypeOf((*Mockdependencies)(nil).NewAPIKeyRepository), db)
+}
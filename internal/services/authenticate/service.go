@@ -0,0 +1,75 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package authenticate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoAPIKey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type APIKeyRepository interface {
+	GetActiveKey(ctx context.Context, keyHash string) (repoAPIKey.Key, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewAPIKeyRepository(db postgres.Database) APIKeyRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Authenticate отдает клиента, которому выдан API-ключ key.
+// Если ключ пустой, неизвестный или отозван, то отдаем ошибку ErrUnauthenticated.
+func (s *Service) Authenticate(ctx context.Context, key string) (auth.Client, error) {
+	if key == "" {
+		return auth.Client{}, servicesErrors.ErrUnauthenticated
+	}
+
+	k, err := s.deps.NewAPIKeyRepository(s.db).GetActiveKey(ctx, auth.HashKey(key))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoAPIKeyNotFound) {
+			return auth.Client{}, servicesErrors.ErrUnauthenticated
+		}
+
+		s.logger.Error(ctx, "get active key", logfield.Error(err))
+		return auth.Client{}, fmt.Errorf("get active key: %v", err)
+	}
+
+	return auth.Client{
+		KeyID:  k.ID,
+		Name:   k.Name,
+		Scopes: k.Scopes,
+	}, nil
+}
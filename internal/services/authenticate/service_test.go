@@ -0,0 +1,114 @@
+package authenticate_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoAPIKey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+	"github.com/frutonanny/wallet-service/internal/services/authenticate"
+	mock "github.com/frutonanny/wallet-service/internal/services/authenticate/mock"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+const testKey = "wk_key"
+
+var testError = errors.New("error")
+
+func TestService_Authenticate(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("authenticate successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().GetActiveKey(ctx, auth.HashKey(testKey)).Return(repoAPIKey.Key{
+			ID:     1,
+			Name:   "orders",
+			Scopes: []string{auth.ScopeReserve, auth.ScopeWriteOff},
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := authenticate.New(log, db).WithDependencies(deps)
+
+		client, err := service.Authenticate(ctx, testKey)
+		require.NoError(t, err)
+		assert.Equal(t, auth.Client{
+			KeyID:  1,
+			Name:   "orders",
+			Scopes: []string{auth.ScopeReserve, auth.ScopeWriteOff},
+		}, client)
+		assert.True(t, client.Allowed(auth.ScopeReserve))
+		assert.False(t, client.Allowed(auth.ScopeAdd))
+	})
+
+	t.Run("authenticate failed, empty key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := authenticate.New(log, db).WithDependencies(deps)
+
+		_, err := service.Authenticate(context.Background(), "")
+		assert.ErrorIs(t, err, servicesErrors.ErrUnauthenticated)
+	})
+
+	t.Run("authenticate failed, unknown or revoked key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().GetActiveKey(ctx, auth.HashKey(testKey)).
+			Return(repoAPIKey.Key{}, repositories.ErrRepoAPIKeyNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := authenticate.New(log, db).WithDependencies(deps)
+
+		_, err := service.Authenticate(ctx, testKey)
+		assert.ErrorIs(t, err, servicesErrors.ErrUnauthenticated)
+	})
+
+	t.Run("authenticate failed, repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().GetActiveKey(ctx, auth.HashKey(testKey)).Return(repoAPIKey.Key{}, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := authenticate.New(log, db).WithDependencies(deps)
+
+		_, err := service.Authenticate(ctx, testKey)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, servicesErrors.ErrUnauthenticated)
+	})
+}
@@ -3,6 +3,7 @@ package cancel
 import (
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
 	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 )
@@ -20,3 +21,7 @@ func (b *dependenciesImpl) NewOrderRepository(db postgres.Database) OrderReposit
 func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) TransactionRepository {
 	return repoTxs.New(db)
 }
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
@@ -6,17 +6,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type WalletRepository interface {
@@ -26,12 +32,23 @@ type WalletRepository interface {
 
 type OrderRepository interface {
 	GetOrder(ctx context.Context, externalID int64) (int64, string, int64, error)
+	GetOrderByServiceID(ctx context.Context, externalID, serviceID int64) (int64, string, int64, error)
 	UpdateOrderStatus(ctx context.Context, orderID int64, status string) error
 	AddOrderTransactions(ctx context.Context, orderID int64, nameType string) (int64, error)
 }
 
 type TransactionRepository interface {
-	AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount int64) (int64, error)
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
+}
+
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
 }
 
 // dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
@@ -40,8 +57,12 @@ type dependencies interface {
 	NewWalletRepository(db postgres.Database) WalletRepository
 	NewOrderRepository(db postgres.Database) OrderRepository
 	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
 }
 
+// operation - имя операции в метриках.
+const operation = "cancel"
+
 type Service struct {
 	db     *sql.DB
 	logger logger
@@ -63,19 +84,30 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 
 // Cancel - разрезервирует переданную сумму средств у пользователя.
 // - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
-// - проверяем есть ли заказ с переданным идентификатором внешнего заказа.
+// - проверяем есть ли заказ с переданным идентификатором внешнего заказа. Если передан serviceID (не 0), то ищем
+// позицию заказа по этой услуге – так отменяется одна позиция корзины.
 // 		1. Если заказа нет, то возвращаем ошибку ErrOrderNotFound.
-// 		2. Заказ есть, то проверяем статус заказа. Должен быть reservation. Узнаем сумма резервирования.
+// 		2. Если serviceID не передан, а в заказе несколько позиций, то возвращаем ошибку ErrAmbiguousOrder.
+// 		3. Заказ есть, то проверяем статус заказа. Должен быть reservation. Узнаем сумма резервирования.
 // - списываем зарезервированную сумму с резерва пользователя. Добавляем эту сумму в баланс пользователя
 // - обновляем информацию о заказе.
 // - добавляем транзакцию об обновленном заказе;
 // - добавляем транзакцию об отмене резервирования средств;
+// - добавляем событие об отмене в outbox;
 // - в ответ отдаем обновленный баланс пользователя в копейках.
-func (s *Service) Cancel(ctx context.Context, userID, externalID int64) (int64, error) {
+func (s *Service) Cancel(ctx context.Context, userID, serviceID, externalID int64) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "cancel.Cancel")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.OrderID(externalID))
+
 	// Стартуем транзакцию.
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("begin tx: %s", err))
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
 		return 0, fmt.Errorf("begin tx: %v", err)
 	}
 
@@ -85,7 +117,7 @@ func (s *Service) Cancel(ctx context.Context, userID, externalID int64) (int64,
 				return
 			}
 
-			s.logger.Error(fmt.Sprintf("rollback: %s", err))
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
 		}
 	}()
 
@@ -98,70 +130,110 @@ func (s *Service) Cancel(ctx context.Context, userID, externalID int64) (int64,
 			return 0, servicesErrors.ErrWalletNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("wallet not exist: %s", err))
+		s.logger.Error(ctx, "wallet not exist", logfield.Error(err))
 		return 0, fmt.Errorf("wallet not exist: %v", err)
 	}
 
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
 	orderRepo := s.deps.NewOrderRepository(tx)
 
 	// Проверяем, есть ли заказ с переданным идентификатором внешнего заказа.
-	orderID, status, amount, err := orderRepo.GetOrder(ctx, externalID)
+	orderID, status, amount, err := getOrder(ctx, orderRepo, serviceID, externalID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrRepoOrderNotFound) {
 			return 0, servicesErrors.ErrOrderNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("order exist: %s", err))
+		if errors.Is(err, repositories.ErrRepoAmbiguousOrder) {
+			return 0, servicesErrors.ErrAmbiguousOrder
+		}
+
+		s.logger.Error(ctx, "order exist", logfield.Error(err))
 		return 0, fmt.Errorf("order exist: %v", err)
 	}
 
 	// Проверяем соответствие статуса. Отменить резерв можно только в том случае, если заказ в резерве.
 	if ok := orders.IsOrderReserved(status); !ok {
-		s.logger.Error(fmt.Sprintf("order has wrong status %v", status))
+		s.logger.Error(ctx, "order has wrong status", "status", status)
 		return 0, fmt.Errorf("order has wrong status %v", status)
 	}
 
 	//	Обновляем информацию в заказе.
 	if err := orderRepo.UpdateOrderStatus(ctx, orderID, orders.StatusCancelled); err != nil {
-		s.logger.Error(fmt.Sprintf("update order: %s", err))
+		s.logger.Error(ctx, "update order", logfield.Error(err))
 		return 0, fmt.Errorf("update order: %v", err)
 	}
 
 	// Добавляем транзакцию об изменении статуса заказа.
-	if _, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusCancelled); err != nil {
-		s.logger.Error(fmt.Sprintf("add order transaction: %s", err))
+	orderTxID, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusCancelled)
+	if err != nil {
+		s.logger.Error(ctx, "add order transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add order transaction: %v", err)
 	}
 
+	audit.AddOrderTransaction(ctx, orderTxID)
+
 	// Разрезервируем переданную сумму. Эту сумму возвращаем в баланс.
 	balance, err := walletRepo.Cancel(ctx, walletID, amount)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("cancel: %s", err))
+		s.logger.Error(ctx, "cancel", logfield.Error(err))
 		return 0, fmt.Errorf("cancel: %v", err)
 	}
 
 	// Генерируем payload.
-	payload, err := transactions.CancelPayload(externalID)
+	payload, err := transactions.CancelPayload(externalID, serviceID)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("generated payload: %s", err))
+		s.logger.Error(ctx, "generated payload", logfield.Error(err))
 		return 0, fmt.Errorf("generated payload: %v", err)
 	}
 
 	txsRepo := s.deps.NewTransactionRepository(tx)
 
 	// Добавляем транзакцию о разрезервированных средствах
-	if _, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeCancel, payload, amount); err != nil {
-		s.logger.Error(fmt.Sprintf("add transaction: %s", err))
+	txID, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeCancel, payload, amount, balance)
+	if err != nil {
+		s.logger.Error(ctx, "add transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add transaction: %v", err)
 	}
 
+	audit.AddTransaction(ctx, txID)
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Добавляем событие об отмене резерва.
+	if _, err := outboxRepo.AddEvent(ctx, events.TypeCancel, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        amount,
+		Balance:       balance,
+		OrderID:       externalID,
+		ServiceID:     serviceID,
+	}); err != nil {
+		s.logger.Error(ctx, "add event", logfield.Error(err))
+		return 0, fmt.Errorf("add event: %v", err)
+	}
+
 	// Завершаем транзакцию.
-	if err := tx.Commit(); err != nil {
-		s.logger.Error(fmt.Sprintf("commit tx: %s", err))
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
 		return 0, fmt.Errorf("commit tx: %v", err)
 	}
 
-	s.logger.Info(fmt.Sprintf("canceled cash reservation for wallet: %d", walletID))
+	s.logger.Info(ctx, "cash reservation canceled")
 
 	return balance, nil
 }
+
+// getOrder отдает позицию заказа: по услуге, если она передана, иначе – единственную позицию заказа.
+func getOrder(
+	ctx context.Context,
+	orderRepo OrderRepository,
+	serviceID, externalID int64,
+) (int64, string, int64, error) {
+	if serviceID != 0 {
+		return orderRepo.GetOrderByServiceID(ctx, externalID, serviceID)
+	}
+
+	return orderRepo.GetOrder(ctx, externalID)
+}
@@ -16,6 +16,7 @@ import (
 	mock_cancel "github.com/frutonanny/wallet-service/internal/services/cancel/mock"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 const (
@@ -23,10 +24,14 @@ const (
 	testWalletID   = int64(1)
 	testOrderID    = int64(1)
 	testTxID       = int64(0)
+	testEventID    = int64(1)
 	testExternalID = int64(1)
+	testServiceID  = int64(2)
 	testAmount     = int64(1_000)
 	testBalance    = int64(1_000)
 	testFailed     = int64(0)
+
+	testNoServiceID = int64(0)
 )
 
 var (
@@ -56,26 +61,116 @@ func TestService_Cancel(t *testing.T) {
 
 		txRepo := mock_cancel.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeCancel, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeCancel, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, nil)
 
+		outboxRepo := mock_cancel.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeCancel, gomock.Any()).Return(testEventID, nil)
+
 		mock.ExpectCommit()
 
 		deps := mock_cancel.NewMockdependencies(ctrl)
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Info(gomock.Any())
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		balance, err := service.Cancel(ctx, testUserID, testExternalID)
+		balance, err := service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		require.NoError(t, err)
 		assert.Equal(t, testBalance, balance)
 	})
 
+	t.Run("cancel cart item reservation successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_cancel.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Cancel(ctx, testWalletID, testAmount).Return(testBalance, nil)
+
+		// Позицию заказа ищем по услуге.
+		orderRepo := mock_cancel.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			GetOrderByServiceID(ctx, testExternalID, testServiceID).
+			Return(testOrderID, orders.StatusReserved, testAmount, nil)
+		orderRepo.EXPECT().UpdateOrderStatus(ctx, testOrderID, orders.StatusCancelled).Return(nil)
+		orderRepo.EXPECT().AddOrderTransactions(ctx, testOrderID, orders.StatusCancelled).Return(testTxID, nil)
+
+		txRepo := mock_cancel.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().AddTransaction(
+			ctx, testWalletID, transactions.TypeCancel, gomock.Any(), testAmount, testBalance).
+			Return(testTxID, nil)
+
+		outboxRepo := mock_cancel.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeCancel, gomock.Any()).Return(testEventID, nil)
+
+		mock.ExpectCommit()
+
+		deps := mock_cancel.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_cancel.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := cancel.New(log, db).WithDependencies(deps)
+
+		balance, err := service.Cancel(ctx, testUserID, testServiceID, testExternalID)
+		require.NoError(t, err)
+		assert.Equal(t, testBalance, balance)
+	})
+
+	t.Run("cancel reservation failed, ErrAmbiguousOrder", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_cancel.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		// В заказе несколько позиций, а услуга не передана.
+		orderRepo := mock_cancel.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			GetOrder(ctx, testExternalID).
+			Return(testFailed, "", testFailed, repositories.ErrRepoAmbiguousOrder)
+
+		mock.ExpectRollback()
+
+		deps := mock_cancel.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		log := mock_cancel.NewMocklogger(ctrl)
+
+		service := cancel.New(log, db).WithDependencies(deps)
+
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrAmbiguousOrder)
+	})
+
 	t.Run("cancel reservation failed, ErrWalletNotFound", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -99,7 +194,7 @@ func TestService_Cancel(t *testing.T) {
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
 	})
@@ -124,11 +219,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		require.Error(t, err)
 	})
 
@@ -162,7 +257,7 @@ func TestService_Cancel(t *testing.T) {
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, servicesErrors.ErrOrderNotFound)
 	})
@@ -194,11 +289,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		assert.Error(t, err)
 	})
 
@@ -227,11 +322,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		assert.Error(t, err)
 	})
 
@@ -261,11 +356,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		assert.Error(t, err)
 	})
 
@@ -296,11 +391,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		assert.Error(t, err)
 	})
 
@@ -326,7 +421,7 @@ func TestService_Cancel(t *testing.T) {
 
 		txRepo := mock_cancel.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeCancel, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeCancel, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, testError)
 
 		mock.ExpectRollback()
@@ -337,11 +432,11 @@ func TestService_Cancel(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
 
 		log := mock_cancel.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := cancel.New(log, db).WithDependencies(deps)
 
-		_, err = service.Cancel(ctx, testUserID, testExternalID)
+		_, err = service.Cancel(ctx, testUserID, testNoServiceID, testExternalID)
 		assert.Error(t, err)
 	})
 }
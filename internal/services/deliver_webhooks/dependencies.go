@@ -0,0 +1,12 @@
+package deliver_webhooks
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWebhookRepository(db postgres.Database) WebhookRepository {
+	return repoWebhook.New(db)
+}
@@ -0,0 +1,245 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_deliver_webhooks is a generated GoMock package.
+package mock_deliver_webhooks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	webhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	deliver_webhooks "github.com/frutonanny/wallet-service/internal/services/deliver_webhooks"
+	events "github.com/frutonanny/wallet-service/pkg/events"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAttempt mocks base method.
+func (m *MockWebhookRepository) AddAttempt(ctx context.Context, deliveryID int64, statusCode int, attemptErr string, duration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttempt", ctx, deliveryID, statusCode, attemptErr, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAttempt indicates an expected call of AddAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) AddAttempt(ctx, deliveryID, statusCode, attemptErr, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).AddAttempt), ctx, deliveryID, statusCode, attemptErr, duration)
+}
+
+// AddDeliveries mocks base method.
+func (m *MockWebhookRepository) AddDeliveries(ctx context.Context, e events.Event, payload []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveries", ctx, e, payload)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDeliveries indicates an expected call of AddDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) AddDeliveries(ctx, e, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).AddDeliveries), ctx, e, payload)
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", ctx, limit, leaseUntil)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDueDeliveries(ctx, limit, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDueDeliveries), ctx, limit, leaseUntil)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockWebhookRepository) MarkDelivered(ctx context.Context, deliveryID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, deliveryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockWebhookRepositoryMockRecorder) MarkDelivered(ctx, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockWebhookRepository)(nil).MarkDelivered), ctx, deliveryID)
+}
+
+// MarkFailed mocks base method.
+func (m *MockWebhookRepository) MarkFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, deliveryID, lastError, nextAttemptAt, dead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockWebhookRepositoryMockRecorder) MarkFailed(ctx, deliveryID, lastError, nextAttemptAt, dead interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookRepository)(nil).MarkFailed), ctx, deliveryID, lastError, nextAttemptAt, dead)
+}
+
+// MockhttpClient is a mock of httpClient interface.
+type MockhttpClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockhttpClientMockRecorder
+}
+
+// MockhttpClientMockRecorder is the mock recorder for MockhttpClient.
+type MockhttpClientMockRecorder struct {
+	mock *MockhttpClient
+}
+
+// NewMockhttpClient creates a new mock instance.
+func NewMockhttpClient(ctrl *gomock.Controller) *MockhttpClient {
+	mock := &MockhttpClient{ctrl: ctrl}
+	mock.recorder = &MockhttpClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhttpClient) EXPECT() *MockhttpClientMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockhttpClient) Do(req *http.Request) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", req)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockhttpClientMockRecorder) Do(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockhttpClient)(nil).Do), req)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewWebhookRepository mocks base method.
+func (m *Mockdependencies) NewWebhookRepository(db postgres.Database) deliver_webhooks.WebhookRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWebhookRepository", db)
+	ret0, _ := ret[0].(deliver_webhooks.WebhookRepository)
+	return ret0
+}
+
+// NewWebhookRepository indicates an expected call of NewWebhookRepository.
+func (mr *MockdependenciesMockRecorder) NewWebhookRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWebhookRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWebhookRepository), db)
+}
@@ -0,0 +1,353 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package deliver_webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	"github.com/frutonanny/wallet-service/pkg/events"
+	"github.com/frutonanny/wallet-service/pkg/webhooks"
+)
+
+const (
+	// MaxAttempts - после стольких неудачных попыток доставка переходит в статус dead.
+	MaxAttempts = 8
+
+	// deliveriesBatchSize - сколько доставок за раз отправляется.
+	deliveriesBatchSize = 20
+
+	// requestTimeout - сколько ждем ответа подписчика.
+	requestTimeout = 10 * time.Second
+
+	// leaseDuration - на сколько экземпляр забирает пачку доставок. С запасом больше requestTimeout, чтобы
+	// результат был записан раньше, чем доставку заберет другой экземпляр.
+	leaseDuration = 6 * requestTimeout
+
+	// Паузы между попытками растут вдвое: 10s, 20s, 40s, ... но не больше часа.
+	backoffBase = 10 * time.Second
+	backoffMax  = time.Hour
+
+	// maxErrorLength - сколько символов ошибки сохраняется в журнале.
+	maxErrorLength = 512
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WebhookRepository interface {
+	AddDeliveries(ctx context.Context, e events.Event, payload []byte) (int64, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]repoWebhook.Delivery, error)
+	AddAttempt(ctx context.Context, deliveryID int64, statusCode int, attemptErr string, duration time.Duration) error
+	MarkDelivered(ctx context.Context, deliveryID int64) error
+	MarkFailed(ctx context.Context, deliveryID int64, lastError string, nextAttemptAt time.Time, dead bool) error
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWebhookRepository(db postgres.Database) WebhookRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	client httpClient
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB, client httpClient) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		client: client,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Publish ставит события в очередь доставки подходящим подпискам. Позволяет использовать сервис как получателя
+// событий релея.
+func (s *Service) Publish(ctx context.Context, batch []events.Event) error {
+	webhookRepo := s.deps.NewWebhookRepository(s.db)
+
+	for _, e := range batch {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal event %d: %v", e.ID, err)
+		}
+
+		if _, err := webhookRepo.AddDeliveries(ctx, e, payload); err != nil {
+			return fmt.Errorf("add deliveries for event %d: %w", e.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Run отправляет доставки, пока не отменен ctx. Когда отправлять нечего, ждет pollInterval.
+func (s *Service) Run(ctx context.Context, pollInterval time.Duration) error {
+	s.logger.Info(ctx, "webhooks delivery started")
+
+	for {
+		delivered, err := s.DeliverBatch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "deliver batch", logfield.Error(err))
+		}
+
+		if err == nil && delivered == deliveriesBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info(ctx, "webhooks delivery stopped")
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// result - итог одной попытки доставки.
+type result struct {
+	statusCode int
+	err        error
+	duration   time.Duration
+}
+
+// DeliverBatch отправляет очередную пачку доставок, время которых наступило, и отдает их количество.
+// - в короткой транзакции забираем пачку доставок в аренду на leaseDuration, чтобы их не взяли другие экземпляры;
+// - вне транзакции параллельно отправляем подписанные запросы, успешным считается любой ответ 2xx;
+// - каждую попытку записываем в журнал в своей транзакции: успешные доставки отмечаем, а неудачным назначаем
+// следующую попытку с экспоненциальной паузой или, если попытки исчерпаны, переводим в статус dead.
+// Если результат записать не удалось, доставка будет отправлена снова после окончания аренды.
+func (s *Service) DeliverBatch(ctx context.Context) (int, error) {
+	deliveries, err := s.claim(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("claim: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	results := make([]result, len(deliveries))
+
+	var wg sync.WaitGroup
+
+	for i := range deliveries {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			results[i] = s.send(ctx, deliveries[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	var saveErr error
+
+	for i, d := range deliveries {
+		if err := s.saveResult(ctx, d, results[i]); err != nil {
+			s.logger.Error(ctx, "save result", "delivery_id", d.ID, logfield.Error(err))
+
+			if saveErr == nil {
+				saveErr = fmt.Errorf("save result of delivery %d: %w", d.ID, err)
+			}
+		}
+	}
+
+	if saveErr != nil {
+		return 0, saveErr
+	}
+
+	return len(deliveries), nil
+}
+
+// claim забирает пачку доставок в аренду.
+func (s *Service) claim(ctx context.Context) ([]repoWebhook.Delivery, error) {
+	// Стартуем транзакцию.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	webhookRepo := s.deps.NewWebhookRepository(tx)
+
+	deliveries, err := webhookRepo.ClaimDueDeliveries(ctx, deliveriesBatchSize, time.Now().Add(leaseDuration))
+	if err != nil {
+		return nil, fmt.Errorf("claim due deliveries: %w", err)
+	}
+
+	// Завершаем транзакцию.
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+// send отправляет подписанный запрос подписчику.
+func (s *Service) send(ctx context.Context, d repoWebhook.Delivery) result {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return result{err: fmt.Errorf("new request: %v", err)}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.HeaderEventType, d.EventType)
+	req.Header.Set(webhooks.HeaderDeliveryID, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(webhooks.HeaderSignature, webhooks.Sign(d.Secret, time.Now(), d.Payload))
+
+	start := time.Now()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return result{err: err, duration: time.Since(start)}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// Тело ответа не нужно, но его вычитываем, чтобы соединение вернулось в пул.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	r := result{statusCode: resp.StatusCode, duration: time.Since(start)}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		r.err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return r
+}
+
+// saveResult в своей транзакции записывает попытку в журнал и отмечает итог доставки.
+func (s *Service) saveResult(ctx context.Context, d repoWebhook.Delivery, r result) error {
+	// Стартуем транзакцию.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	webhookRepo := s.deps.NewWebhookRepository(tx)
+
+	var attemptErr string
+	if r.err != nil {
+		attemptErr = truncate(r.err.Error(), maxErrorLength)
+	}
+
+	if err := webhookRepo.AddAttempt(ctx, d.ID, r.statusCode, attemptErr, r.duration); err != nil {
+		return fmt.Errorf("add attempt: %w", err)
+	}
+
+	if r.err == nil {
+		if err := webhookRepo.MarkDelivered(ctx, d.ID); err != nil {
+			return fmt.Errorf("mark delivered: %w", err)
+		}
+
+		return s.commit(tx)
+	}
+
+	attempts := d.Attempts + 1
+	dead := attempts >= MaxAttempts
+
+	if err := webhookRepo.MarkFailed(ctx, d.ID, attemptErr, time.Now().Add(Backoff(attempts)), dead); err != nil {
+		return fmt.Errorf("mark failed: %w", err)
+	}
+
+	if err := s.commit(tx); err != nil {
+		return err
+	}
+
+	if dead {
+		s.logger.Error(ctx, "delivery is dead",
+			"delivery_id", d.ID,
+			"attempts", attempts,
+			logfield.KeyError, attemptErr,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) commit(tx *sql.Tx) error {
+	// Завершаем транзакцию.
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %v", err)
+	}
+
+	return nil
+}
+
+// Backoff отдает паузу перед следующей попыткой после attempts неудачных.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	d := backoffBase
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= backoffMax {
+			return backoffMax
+		}
+	}
+
+	return d
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n])
+}
@@ -0,0 +1,231 @@
+package deliver_webhooks_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	"github.com/frutonanny/wallet-service/internal/services/deliver_webhooks"
+	mock "github.com/frutonanny/wallet-service/internal/services/deliver_webhooks/mock"
+	"github.com/frutonanny/wallet-service/pkg/events"
+	"github.com/frutonanny/wallet-service/pkg/webhooks"
+)
+
+const (
+	testSecret = "secret"
+)
+
+var (
+	testError = errors.New("error")
+
+	testPayload = []byte(`{"id":1,"type":"wallet.incoming_transfer","version":1}`)
+)
+
+func TestService_DeliverBatch(t *testing.T) {
+	t.Run("deliver signed request successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, testPayload, body)
+			assert.Equal(t, events.TypeIncomingTransfer, r.Header.Get(webhooks.HeaderEventType))
+			assert.Equal(t, "10", r.Header.Get(webhooks.HeaderDeliveryID))
+			assert.NoError(t, webhooks.Verify(testSecret, r.Header.Get(webhooks.HeaderSignature), body, time.Minute))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		// Доставки забираются в аренду в отдельной транзакции, запрос отправляется уже после нее.
+		mockDB.ExpectBegin()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]repoWebhook.Delivery{{
+			ID:        10,
+			URL:       srv.URL,
+			Secret:    testSecret,
+			EventType: events.TypeIncomingTransfer,
+			Payload:   testPayload,
+		}}, nil)
+
+		mockDB.ExpectCommit()
+
+		// Результат записывается в своей транзакции.
+		mockDB.ExpectBegin()
+
+		webhookRepo.EXPECT().AddAttempt(ctx, int64(10), http.StatusNoContent, "", gomock.Any()).Return(nil)
+		webhookRepo.EXPECT().MarkDelivered(ctx, int64(10)).Return(nil)
+
+		mockDB.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo).Times(2)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := deliver_webhooks.New(log, db, srv.Client()).WithDependencies(deps)
+
+		delivered, err := service.DeliverBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("failed delivery is retried, last attempt makes it dead", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mockDB.ExpectBegin()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]repoWebhook.Delivery{
+			{ID: 10, URL: srv.URL, Secret: testSecret, Payload: testPayload, Attempts: 0},
+			{ID: 11, URL: srv.URL, Secret: testSecret, Payload: testPayload, Attempts: deliver_webhooks.MaxAttempts - 1},
+		}, nil)
+
+		mockDB.ExpectCommit()
+		mockDB.ExpectBegin()
+
+		webhookRepo.EXPECT().
+			AddAttempt(ctx, gomock.Any(), http.StatusInternalServerError, "unexpected status code 500", gomock.Any()).
+			Return(nil).
+			Times(2)
+		webhookRepo.EXPECT().MarkFailed(ctx, int64(10), "unexpected status code 500", gomock.Any(), false).Return(nil)
+
+		mockDB.ExpectCommit()
+		mockDB.ExpectBegin()
+
+		webhookRepo.EXPECT().MarkFailed(ctx, int64(11), "unexpected status code 500", gomock.Any(), true).Return(nil)
+
+		mockDB.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo).Times(3)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := deliver_webhooks.New(log, db, srv.Client()).WithDependencies(deps)
+
+		delivered, err := service.DeliverBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, delivered)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("failed result does not block other results", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mockDB.ExpectBegin()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any()).Return([]repoWebhook.Delivery{
+			{ID: 10, URL: srv.URL, Secret: testSecret, Payload: testPayload},
+			{ID: 11, URL: srv.URL, Secret: testSecret, Payload: testPayload},
+		}, nil)
+
+		mockDB.ExpectCommit()
+
+		// Результат первой доставки не записан, она будет отправлена снова после окончания аренды.
+		mockDB.ExpectBegin()
+
+		webhookRepo.EXPECT().AddAttempt(ctx, int64(10), http.StatusOK, "", gomock.Any()).Return(testError)
+
+		mockDB.ExpectRollback()
+		mockDB.ExpectBegin()
+
+		webhookRepo.EXPECT().AddAttempt(ctx, int64(11), http.StatusOK, "", gomock.Any()).Return(nil)
+		webhookRepo.EXPECT().MarkDelivered(ctx, int64(11)).Return(nil)
+
+		mockDB.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo).Times(3)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), "save result", gomock.Any())
+
+		service := deliver_webhooks.New(log, db, srv.Client()).WithDependencies(deps)
+
+		_, err = service.DeliverBatch(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, testError)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
+
+func TestService_Publish(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("enqueue events", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		batch := []events.Event{
+			{ID: 1, Type: events.TypeIncomingTransfer, Version: events.Version},
+			{ID: 2, Type: events.TypeWriteOff, Version: events.Version},
+		}
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().AddDeliveries(ctx, batch[0], gomock.Any()).Return(int64(1), nil)
+		webhookRepo.EXPECT().AddDeliveries(ctx, batch[1], gomock.Any()).Return(int64(0), nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := deliver_webhooks.New(log, db, http.DefaultClient).WithDependencies(deps)
+
+		require.NoError(t, service.Publish(ctx, batch))
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, deliver_webhooks.Backoff(1))
+	assert.Equal(t, 20*time.Second, deliver_webhooks.Backoff(2))
+	assert.Equal(t, 80*time.Second, deliver_webhooks.Backoff(4))
+	assert.Equal(t, time.Hour, deliver_webhooks.Backoff(20))
+}
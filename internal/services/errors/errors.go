@@ -3,7 +3,27 @@ package services_errors
 import "errors"
 
 var (
-	ErrNotEnoughCash  = errors.New("not enough cash")
-	ErrWalletNotFound = errors.New("wallet not found")
-	ErrOrderNotFound  = errors.New("order not found")
+	ErrNotEnoughCash        = errors.New("not enough cash")
+	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderExists          = errors.New("order with this service already exists")
+	ErrAmbiguousOrder       = errors.New("order has several items, service must be specified")
+	ErrDuplicateService     = errors.New("cart contains duplicate services")
+	ErrInvalidPageToken     = errors.New("invalid page token")
+	ErrTransactionNotFound  = errors.New("transaction not found")
+	ErrInvalidPeriod        = errors.New("period start is after its end")
+	ErrPeriodNotFinished    = errors.New("period is not finished yet")
+	ErrStatementNotFound    = errors.New("statement not found")
+	ErrPeriodTooLong        = errors.New("period is too long")
+	ErrInvalidWebhookURL    = errors.New("invalid webhook url")
+	ErrDeliveryNotFound     = errors.New("delivery not found")
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with another request")
+	ErrRequestInProgress    = errors.New("request with this idempotency key is in progress")
+	ErrUnauthenticated      = errors.New("api key is missing, unknown or revoked")
+	ErrUnknownScope         = errors.New("unknown scope")
+	ErrAPIKeyNotFound       = errors.New("api key not found")
+	ErrRateLimited          = errors.New("rate limit exceeded")
+	ErrReasonRequired       = errors.New("reason is required")
+	ErrZeroAmount           = errors.New("amount must not be zero")
+	ErrInvalidLimit         = errors.New("limit must be positive")
 )
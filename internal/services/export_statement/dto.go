@@ -0,0 +1,83 @@
+package export_statement
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Format - формат выписки.
+type Format string
+
+// ContentType отдает MIME-тип выписки.
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
+
+// Extension отдает расширение файла выписки.
+func (f Format) Extension() string {
+	if f == FormatNDJSON {
+		return "ndjson"
+	}
+
+	return "csv"
+}
+
+// Link - ссылка на выписку в хранилище.
+type Link struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Row - строка выписки.
+type Row struct {
+	ID           int64     `json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Type         string    `json:"type"`
+	Description  string    `json:"description"`
+	OrderID      int64     `json:"orderID,omitempty"`   // 0 для операций без заказа.
+	ServiceID    int64     `json:"serviceID,omitempty"` // 0 для операций без заказа.
+	Amount       int64     `json:"amount"`
+	BalanceAfter *int64    `json:"balanceAfter,omitempty"`
+}
+
+func adaptRow(tx repoTxs.Transaction, locale i18n.Locale) (Row, error) {
+	// У зачислений и списаний без заказа нет номера заказа.
+	var orderID int64
+	if transactions.HasOrder(tx.Type) {
+		id, err := transactions.GetOrderID(tx.Payload)
+		if err != nil {
+			return Row{}, fmt.Errorf("get order id: %v", err)
+		}
+
+		orderID = id
+	}
+
+	var serviceID int64
+	if tx.ServiceID != nil {
+		serviceID = *tx.ServiceID
+	}
+
+	return Row{
+		ID:           tx.ID,
+		CreatedAt:    tx.CreatedAt,
+		Type:         tx.Type,
+		Description:  i18n.TxDescription(locale, tx.Type, orderID, serviceID),
+		OrderID:      orderID,
+		ServiceID:    serviceID,
+		Amount:       tx.Amount,
+		BalanceAfter: tx.BalanceAfter,
+	}, nil
+}
@@ -0,0 +1,278 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_export_statement is a generated GoMock package.
+package mock_export_statement
+
+import (
+	context "context"
+	io "io"
+	url "net/url"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	transaction "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	export_statement "github.com/frutonanny/wallet-service/internal/services/export_statement"
+	gomock "github.com/golang/mock/gomock"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistWallet mocks base method.
+func (m *MockWalletRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistWallet indicates an expected call of ExistWallet.
+func (mr *MockWalletRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockWalletRepository)(nil).ExistWallet), ctx, userID)
+}
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// StreamTransactionsByTime mocks base method.
+func (m *MockTransactionRepository) StreamTransactionsByTime(ctx context.Context, walletID int64, timeStart, timeEnd time.Time, fn func(transaction.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTransactionsByTime", ctx, walletID, timeStart, timeEnd, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamTransactionsByTime indicates an expected call of StreamTransactionsByTime.
+func (mr *MockTransactionRepositoryMockRecorder) StreamTransactionsByTime(ctx, walletID, timeStart, timeEnd, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTransactionsByTime", reflect.TypeOf((*MockTransactionRepository)(nil).StreamTransactionsByTime), ctx, walletID, timeStart, timeEnd, fn)
+}
+
+// MockMinioClient is a mock of MinioClient interface.
+type MockMinioClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinioClientMockRecorder
+}
+
+// MockMinioClientMockRecorder is the mock recorder for MockMinioClient.
+type MockMinioClientMockRecorder struct {
+	mock *MockMinioClient
+}
+
+// NewMockMinioClient creates a new mock instance.
+func NewMockMinioClient(ctrl *gomock.Controller) *MockMinioClient {
+	mock := &MockMinioClient{ctrl: ctrl}
+	mock.recorder = &MockMinioClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinioClient) EXPECT() *MockMinioClientMockRecorder {
+	return m.recorder
+}
+
+// PutObject mocks base method.
+func (m *MockMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, bucketName, objectName, reader, objectSize, opts)
+	ret0, _ := ret[0].(minio.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockMinioClientMockRecorder) PutObject(ctx, bucketName, objectName, reader, objectSize, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockMinioClient)(nil).PutObject), ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+// MockPresigner is a mock of Presigner interface.
+type MockPresigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresignerMockRecorder
+}
+
+// MockPresignerMockRecorder is the mock recorder for MockPresigner.
+type MockPresignerMockRecorder struct {
+	mock *MockPresigner
+}
+
+// NewMockPresigner creates a new mock instance.
+func NewMockPresigner(ctrl *gomock.Controller) *MockPresigner {
+	mock := &MockPresigner{ctrl: ctrl}
+	mock.recorder = &MockPresignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresigner) EXPECT() *MockPresignerMockRecorder {
+	return m.recorder
+}
+
+// PresignedGetObject mocks base method.
+func (m *MockPresigner) PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedGetObject", ctx, bucketName, objectName, expires, reqParams)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedGetObject indicates an expected call of PresignedGetObject.
+func (mr *MockPresignerMockRecorder) PresignedGetObject(ctx, bucketName, objectName, expires, reqParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedGetObject", reflect.TypeOf((*MockPresigner)(nil).PresignedGetObject), ctx, bucketName, objectName, expires, reqParams)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewTransactionRepository mocks base method.
+func (m *Mockdependencies) NewTransactionRepository(db postgres.Database) export_statement.TransactionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransactionRepository", db)
+	ret0, _ := ret[0].(export_statement.TransactionRepository)
+	return ret0
+}
+
+// NewTransactionRepository indicates an expected call of NewTransactionRepository.
+func (mr *MockdependenciesMockRecorder) NewTransactionRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransactionRepository", reflect.TypeOf((*Mockdependencies)(nil).NewTransactionRepository), db)
+}
+
+// NewWalletRepository mocks base method.
+func (m *Mockdependencies) NewWalletRepository(db postgres.Database) export_statement.WalletRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWalletRepository", db)
+	ret0, _ := ret[0].(export_statement.WalletRepository)
+	return ret0
+}
+
+// NewWalletRepository indicates an expected call of NewWalletRepository.
+func (mr *MockdependenciesMockRecorder) NewWalletRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWalletRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWalletRepository), db)
+}
@@ -0,0 +1,283 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package export_statement
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+const (
+	ExportsBucketName = "exports"
+
+	// reportKind - вид выгрузки выписки в метриках.
+	reportKind = "statement_export"
+
+	// LinkTTL - время жизни ссылки на выписку в хранилище.
+	LinkTTL = time.Hour
+
+	// partSize - размер части при загрузке выписки в minio. Размер выписки заранее неизвестен, и без явного
+	// размера части клиент выделяет буфер под максимально возможный объект.
+	partSize = 16 << 20
+)
+
+var errUploadStopped = errors.New("upload stopped")
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type TransactionRepository interface {
+	StreamTransactionsByTime(
+		ctx context.Context,
+		walletID int64,
+		timeStart, timeEnd time.Time,
+		fn func(tx repoTxs.Transaction) error,
+	) error
+}
+
+type MinioClient interface {
+	PutObject(
+		ctx context.Context,
+		bucketName, objectName string,
+		reader io.Reader,
+		objectSize int64,
+		opts minio.PutObjectOptions,
+	) (info minio.UploadInfo, err error)
+}
+
+// Presigner подписывает ссылки на объекты хранилища. Ссылки отдаются наружу, поэтому подписываются
+// для публичного адреса minio.
+type Presigner interface {
+	PresignedGetObject(
+		ctx context.Context,
+		bucketName, objectName string,
+		expires time.Duration,
+		reqParams url.Values,
+	) (u *url.URL, err error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+}
+
+type Service struct {
+	logger      logger
+	db          *sql.DB
+	minioClient MinioClient
+	presigner   Presigner
+	deps        dependencies
+}
+
+func New(logger logger, db *sql.DB, minioClient MinioClient, presigner Presigner) *Service {
+	return &Service{
+		logger:      logger,
+		db:          db,
+		minioClient: minioClient,
+		presigner:   presigner,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Export пишет в w выписку пользователя за промежуток времени [start, end] в формате format.
+// - проверяем промежуток, если start позже end, то отдаем ошибку ErrInvalidPeriod.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - пишем транзакции в w по мере чтения из базы, от старых к новым. Описания транзакций – на языке locale.
+//
+// До первой записи в w метод успевает вернуть ошибки ErrInvalidPeriod и ErrWalletNotFound. Ошибка в процессе
+// записи означает, что в w попала только часть выписки.
+func (s *Service) Export(
+	ctx context.Context,
+	userID int64,
+	start, end time.Time,
+	format Format,
+	locale i18n.Locale,
+	w io.Writer,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "export_statement.Export")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletID, err := s.getWalletID(ctx, userID, start, end)
+	if err != nil {
+		return err
+	}
+
+	if err := s.writeStatement(ctx, walletID, start, end, format, locale, w); err != nil {
+		s.logger.Error(ctx, "write statement", logfield.Error(err))
+		return fmt.Errorf("write statement: %w", err)
+	}
+
+	return nil
+}
+
+// ExportToStorage выгружает выписку пользователя в хранилище minio и отдает ссылку на нее. Ссылка действует LinkTTL.
+// Подходит для больших выписок: выписка пишется в хранилище по мере чтения из базы и не собирается в памяти.
+// Проверки и ошибки те же, что и у Export.
+func (s *Service) ExportToStorage(
+	ctx context.Context,
+	userID int64,
+	start, end time.Time,
+	format Format,
+	locale i18n.Locale,
+) (_ Link, err error) {
+	ctx, span := tracing.Start(ctx, "export_statement.ExportToStorage")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	defer metrics.ObserveReport(reportKind, time.Now())
+
+	walletID, err := s.getWalletID(ctx, userID, start, end)
+	if err != nil {
+		return Link{}, err
+	}
+
+	objectName := fmt.Sprintf(
+		"statement-%d-%s-%s-%s.%s",
+		userID, start.Format("20060102"), end.Format("20060102"), uuid.New(), format.Extension(),
+	)
+
+	// Выписка пишется в pipe в отдельной горутине, а minio вычитывает ее оттуда частями.
+	pr, pw := io.Pipe()
+	writeErr := make(chan error, 1)
+
+	go func() {
+		err := s.writeStatement(ctx, walletID, start, end, format, locale, pw)
+		_ = pw.CloseWithError(err)
+		writeErr <- err
+	}()
+
+	_, err = s.minioClient.PutObject(
+		ctx,
+		ExportsBucketName,
+		objectName,
+		pr,
+		-1,
+		minio.PutObjectOptions{ContentType: format.ContentType(), PartSize: partSize},
+	)
+	metrics.ObserveUpload(ExportsBucketName, err)
+
+	// Если minio прекратил чтение раньше времени, то разблокируем запись в pipe.
+	_ = pr.CloseWithError(errUploadStopped)
+
+	// Ошибку записи, вызванную остановкой загрузки, не показываем – интересна причина остановки.
+	if err := <-writeErr; err != nil && !errors.Is(err, errUploadStopped) {
+		s.logger.Error(ctx, "write statement", logfield.Error(err))
+		return Link{}, fmt.Errorf("write statement: %w", err)
+	}
+
+	if err != nil {
+		s.logger.Error(ctx, "put object to minio", logfield.Error(err))
+		return Link{}, fmt.Errorf("put object to minio: %v", err)
+	}
+
+	expiresAt := time.Now().Add(LinkTTL)
+
+	u, err := s.presigner.PresignedGetObject(ctx, ExportsBucketName, objectName, LinkTTL, nil)
+	if err != nil {
+		s.logger.Error(ctx, "presign object", logfield.Error(err))
+		return Link{}, fmt.Errorf("presign object: %v", err)
+	}
+
+	s.logger.Info(ctx, "statement exported", "object", objectName)
+
+	return Link{
+		URL:       u.String(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// getWalletID проверяет промежуток времени и отдает кошелек пользователя.
+func (s *Service) getWalletID(ctx context.Context, userID int64, start, end time.Time) (int64, error) {
+	if start.After(end) {
+		return 0, servicesErrors.ErrInvalidPeriod
+	}
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	// Проверяем есть ли кошелек у пользователя.
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return 0, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return 0, fmt.Errorf("exist wallet: %w", err)
+	}
+
+	return walletID, nil
+}
+
+// writeStatement пишет транзакции кошелька в w по мере их чтения из базы.
+func (s *Service) writeStatement(
+	ctx context.Context,
+	walletID int64,
+	start, end time.Time,
+	format Format,
+	locale i18n.Locale,
+	w io.Writer,
+) error {
+	rw := newRowWriter(format, w)
+
+	if err := rw.header(); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	txsRepo := s.deps.NewTransactionRepository(s.db)
+
+	err := txsRepo.StreamTransactionsByTime(ctx, walletID, start, end, func(tx repoTxs.Transaction) error {
+		row, err := adaptRow(tx, locale)
+		if err != nil {
+			return fmt.Errorf("adapt row: %v", err)
+		}
+
+		if err := rw.write(row); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("stream transactions: %w", err)
+	}
+
+	if err := rw.flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package export_statement_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	mock "github.com/frutonanny/wallet-service/internal/services/export_statement/mock"
+)
+
+const (
+	testUserID   = int64(1)
+	testWalletID = int64(1)
+	testURL      = "http://localhost:9000/exports/statement.csv?X-Amz-Signature=signature"
+)
+
+var (
+	testError = errors.New("error")
+
+	testStart = time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	testServiceID = int64(2)
+	testBalance   = int64(500)
+	testTxs       = []repoTxs.Transaction{
+		{
+			ID:        1,
+			Type:      "incoming_transfer",
+			Payload:   []byte(`{"type": "enrollment"}`),
+			Amount:    1000,
+			CreatedAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           2,
+			Type:         "reservation",
+			Payload:      []byte(`{"order_id": 42, "service_id": 2}`),
+			Amount:       500,
+			ServiceID:    &testServiceID,
+			BalanceAfter: &testBalance,
+			CreatedAt:    time.Date(2022, 11, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+)
+
+// streamTxs имитирует чтение транзакций из курсора базы.
+func streamTxs(txs []repoTxs.Transaction) func(
+	ctx context.Context,
+	walletID int64,
+	start, end time.Time,
+	fn func(tx repoTxs.Transaction) error,
+) error {
+	return func(_ context.Context, _ int64, _, _ time.Time, fn func(tx repoTxs.Transaction) error) error {
+		for _, tx := range txs {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("export csv successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd, gomock.Any()).
+			DoAndReturn(streamTxs(testTxs))
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := export_statement.New(log, db, nil, nil).WithDependencies(deps)
+
+		var b bytes.Buffer
+		err := service.Export(ctx, testUserID, testStart, testEnd, export_statement.FormatCSV, i18n.En, &b)
+		require.NoError(t, err)
+		assert.Equal(t, "id,created_at,type,description,order_id,service_id,amount,balance_after\n"+
+			"1,2022-11-01T12:00:00Z,incoming_transfer,Incoming transfer,,,1000,\n"+
+			`2,2022-11-02T12:00:00Z,reservation,"Funds reserved for order 42, service ""Color highlight""",42,2,500,500`+"\n",
+			b.String())
+	})
+
+	t.Run("export ndjson successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd, gomock.Any()).
+			DoAndReturn(streamTxs(testTxs))
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := export_statement.New(log, db, nil, nil).WithDependencies(deps)
+
+		var b bytes.Buffer
+		err := service.Export(ctx, testUserID, testStart, testEnd, export_statement.FormatNDJSON, i18n.Ru, &b)
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":1,"createdAt":"2022-11-01T12:00:00Z","type":"incoming_transfer",`+
+			`"description":"Зачисление средств","amount":1000}`+"\n"+
+			`{"id":2,"createdAt":"2022-11-02T12:00:00Z","type":"reservation",`+
+			`"description":"Резервирование средств по заказу 42, услуга «Выделение цветом»",`+
+			`"orderID":42,"serviceID":2,"amount":500,"balanceAfter":500}`+"\n",
+			b.String())
+	})
+
+	t.Run("export failed, ErrInvalidPeriod", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := export_statement.New(log, db, nil, nil).WithDependencies(deps)
+
+		var b bytes.Buffer
+		err := service.Export(ctx, testUserID, testEnd, testStart, export_statement.FormatCSV, i18n.Ru, &b)
+		assert.ErrorIs(t, err, servicesErrors.ErrInvalidPeriod)
+		assert.Empty(t, b.String())
+	})
+
+	t.Run("export failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(int64(0), repositories.ErrRepoWalletNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := export_statement.New(log, db, nil, nil).WithDependencies(deps)
+
+		var b bytes.Buffer
+		err := service.Export(ctx, testUserID, testStart, testEnd, export_statement.FormatCSV, i18n.Ru, &b)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+		assert.Empty(t, b.String())
+	})
+
+	t.Run("export failed, stream error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd, gomock.Any()).
+			Return(testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := export_statement.New(log, db, nil, nil).WithDependencies(deps)
+
+		var b bytes.Buffer
+		err := service.Export(ctx, testUserID, testStart, testEnd, export_statement.FormatCSV, i18n.Ru, &b)
+		assert.ErrorIs(t, err, testError)
+	})
+}
+
+func TestService_ExportToStorage(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("export to storage successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd, gomock.Any()).
+			DoAndReturn(streamTxs(testTxs))
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+
+		// Выписка загружается в хранилище целиком.
+		var uploaded []byte
+		var objectName string
+		minioClient := mock.NewMockMinioClient(ctrl)
+		minioClient.EXPECT().
+			PutObject(ctx, export_statement.ExportsBucketName, gomock.Any(), gomock.Any(), int64(-1), gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_, name string,
+				reader io.Reader,
+				_ int64,
+				_ minio.PutObjectOptions,
+			) (minio.UploadInfo, error) {
+				objectName = name
+
+				var err error
+				uploaded, err = io.ReadAll(reader)
+
+				return minio.UploadInfo{}, err
+			})
+
+		u, err := url.Parse(testURL)
+		require.NoError(t, err)
+
+		presigner := mock.NewMockPresigner(ctrl)
+		presigner.EXPECT().
+			PresignedGetObject(ctx, export_statement.ExportsBucketName, gomock.Any(), export_statement.LinkTTL, nil).
+			DoAndReturn(func(_ context.Context, _, name string, _ time.Duration, _ url.Values) (*url.URL, error) {
+				assert.Equal(t, objectName, name)
+				return u, nil
+			})
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := export_statement.New(log, db, minioClient, presigner).WithDependencies(deps)
+
+		link, err := service.ExportToStorage(ctx, testUserID, testStart, testEnd, export_statement.FormatNDJSON, i18n.En)
+		require.NoError(t, err)
+		assert.Equal(t, testURL, link.URL)
+		assert.WithinDuration(t, time.Now().Add(export_statement.LinkTTL), link.ExpiresAt, time.Minute)
+		assert.Contains(t, objectName, ".ndjson")
+		assert.Equal(t, 2, bytes.Count(uploaded, []byte("\n")))
+	})
+
+	t.Run("export to storage failed, put object error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		// Запись выписки может не начаться, если загрузка прервалась сразу.
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd, gomock.Any()).
+			DoAndReturn(streamTxs(testTxs)).
+			AnyTimes()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo).AnyTimes()
+
+		// Хранилище отказывает, не дочитав выписку.
+		minioClient := mock.NewMockMinioClient(ctrl)
+		minioClient.EXPECT().
+			PutObject(ctx, export_statement.ExportsBucketName, gomock.Any(), gomock.Any(), int64(-1), gomock.Any()).
+			Return(minio.UploadInfo{}, testError)
+
+		presigner := mock.NewMockPresigner(ctrl)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := export_statement.New(log, db, minioClient, presigner).WithDependencies(deps)
+
+		_, err := service.ExportToStorage(ctx, testUserID, testStart, testEnd, export_statement.FormatCSV, i18n.Ru)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,86 @@
+package export_statement
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"id", "created_at", "type", "description", "order_id", "service_id", "amount", "balance_after"}
+
+// rowWriter пишет строки выписки в выбранном формате. Запись буферизуется, поэтому в конце нужно вызвать flush.
+type rowWriter interface {
+	header() error
+	write(row Row) error
+	flush() error
+}
+
+func newRowWriter(format Format, w io.Writer) rowWriter {
+	if format == FormatNDJSON {
+		bw := bufio.NewWriter(w)
+		return &ndjsonWriter{bw: bw, enc: json.NewEncoder(bw)}
+	}
+
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) header() error {
+	return c.w.Write(csvHeader)
+}
+
+func (c *csvWriter) write(row Row) error {
+	balanceAfter := ""
+	if row.BalanceAfter != nil {
+		balanceAfter = strconv.FormatInt(*row.BalanceAfter, 10)
+	}
+
+	return c.w.Write([]string{
+		strconv.FormatInt(row.ID, 10),
+		row.CreatedAt.Format(time.RFC3339),
+		row.Type,
+		row.Description,
+		formatID(row.OrderID),
+		formatID(row.ServiceID),
+		strconv.FormatInt(row.Amount, 10), // Сумма в копейках.
+		balanceAfter,
+	})
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonWriter пишет каждую строку выписки отдельным JSON-объектом на отдельной строке.
+type ndjsonWriter struct {
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+func (n *ndjsonWriter) header() error {
+	return nil
+}
+
+func (n *ndjsonWriter) write(row Row) error {
+	return n.enc.Encode(row)
+}
+
+func (n *ndjsonWriter) flush() error {
+	return n.bw.Flush()
+}
+
+// formatID отдает пустую строку для отсутствующего (нулевого) идентификатора.
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,22 @@
+package generate_statements
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
+
+func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) TransactionRepository {
+	return repoTxs.New(db)
+}
+
+func (b *dependenciesImpl) NewStatementRepository(db postgres.Database) StatementRepository {
+	return repoStatement.New(db)
+}
@@ -0,0 +1,76 @@
+package generate_statements
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+// txTypes - порядок итогов по типам операций в выписке.
+var txTypes = []string{
+	transactions.TypeAdd,
+	transactions.TypeReserve,
+	transactions.TypeWriteOff,
+	transactions.TypeCancel,
+	transactions.TypeOutgoing,
+}
+
+// Statement - ежемесячная выписка пользователя. Балансы – средства кошелька (доступные и зарезервированные вместе)
+// на начало и конец месяца.
+type Statement struct {
+	UserID         int64       `json:"userID"`
+	Period         string      `json:"period"`
+	OpeningBalance int64       `json:"openingBalance"`
+	ClosingBalance int64       `json:"closingBalance"`
+	Totals         []Total     `json:"totals"`
+	Operations     []Operation `json:"operations"`
+}
+
+// Total - итог по типу операций за месяц.
+type Total struct {
+	Type   string `json:"type"`
+	Count  int64  `json:"count"`
+	Amount int64  `json:"amount"`
+}
+
+// Operation - операция в выписке.
+type Operation struct {
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	OrderID     int64     `json:"orderID,omitempty"`   // 0 для операций без заказа.
+	ServiceID   int64     `json:"serviceID,omitempty"` // 0 для операций без заказа.
+	Amount      int64     `json:"amount"`
+}
+
+func adaptOperation(tx repoTxs.Transaction, locale i18n.Locale) (Operation, error) {
+	// У зачислений и списаний без заказа нет номера заказа.
+	var orderID int64
+	if transactions.HasOrder(tx.Type) {
+		id, err := transactions.GetOrderID(tx.Payload)
+		if err != nil {
+			return Operation{}, fmt.Errorf("get order id: %v", err)
+		}
+
+		orderID = id
+	}
+
+	var serviceID int64
+	if tx.ServiceID != nil {
+		serviceID = *tx.ServiceID
+	}
+
+	return Operation{
+		ID:          tx.ID,
+		CreatedAt:   tx.CreatedAt,
+		Type:        tx.Type,
+		Description: i18n.TxDescription(locale, tx.Type, orderID, serviceID),
+		OrderID:     orderID,
+		ServiceID:   serviceID,
+		Amount:      tx.Amount,
+	}, nil
+}
@@ -0,0 +1,321 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_generate_statements is a generated GoMock package.
+package mock_generate_statements
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	statement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	transaction "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	generate_statements "github.com/frutonanny/wallet-service/internal/services/generate_statements"
+	gomock "github.com/golang/mock/gomock"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistWallet mocks base method.
+func (m *MockWalletRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistWallet indicates an expected call of ExistWallet.
+func (mr *MockWalletRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockWalletRepository)(nil).ExistWallet), ctx, userID)
+}
+
+// MockTransactionRepository is a mock of TransactionRepository interface.
+type MockTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionRepositoryMockRecorder
+}
+
+// MockTransactionRepositoryMockRecorder is the mock recorder for MockTransactionRepository.
+type MockTransactionRepositoryMockRecorder struct {
+	mock *MockTransactionRepository
+}
+
+// NewMockTransactionRepository creates a new mock instance.
+func NewMockTransactionRepository(ctrl *gomock.Controller) *MockTransactionRepository {
+	mock := &MockTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetFundsBefore mocks base method.
+func (m *MockTransactionRepository) GetFundsBefore(ctx context.Context, walletID int64, t time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFundsBefore", ctx, walletID, t)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFundsBefore indicates an expected call of GetFundsBefore.
+func (mr *MockTransactionRepositoryMockRecorder) GetFundsBefore(ctx, walletID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFundsBefore", reflect.TypeOf((*MockTransactionRepository)(nil).GetFundsBefore), ctx, walletID, t)
+}
+
+// StreamTransactionsByTime mocks base method.
+func (m *MockTransactionRepository) StreamTransactionsByTime(ctx context.Context, walletID int64, timeStart, timeEnd time.Time, fn func(transaction.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamTransactionsByTime", ctx, walletID, timeStart, timeEnd, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamTransactionsByTime indicates an expected call of StreamTransactionsByTime.
+func (mr *MockTransactionRepositoryMockRecorder) StreamTransactionsByTime(ctx, walletID, timeStart, timeEnd, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTransactionsByTime", reflect.TypeOf((*MockTransactionRepository)(nil).StreamTransactionsByTime), ctx, walletID, timeStart, timeEnd, fn)
+}
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddStatement mocks base method.
+func (m *MockStatementRepository) AddStatement(ctx context.Context, s statement.Statement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStatement", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStatement indicates an expected call of AddStatement.
+func (mr *MockStatementRepositoryMockRecorder) AddStatement(ctx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStatement", reflect.TypeOf((*MockStatementRepository)(nil).AddStatement), ctx, s)
+}
+
+// GetWalletsWithoutStatement mocks base method.
+func (m *MockStatementRepository) GetWalletsWithoutStatement(ctx context.Context, period string, createdBefore time.Time, afterWalletID, limit int64) ([]statement.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletsWithoutStatement", ctx, period, createdBefore, afterWalletID, limit)
+	ret0, _ := ret[0].([]statement.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletsWithoutStatement indicates an expected call of GetWalletsWithoutStatement.
+func (mr *MockStatementRepositoryMockRecorder) GetWalletsWithoutStatement(ctx, period, createdBefore, afterWalletID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletsWithoutStatement", reflect.TypeOf((*MockStatementRepository)(nil).GetWalletsWithoutStatement), ctx, period, createdBefore, afterWalletID, limit)
+}
+
+// MockMinioClient is a mock of MinioClient interface.
+type MockMinioClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMinioClientMockRecorder
+}
+
+// MockMinioClientMockRecorder is the mock recorder for MockMinioClient.
+type MockMinioClientMockRecorder struct {
+	mock *MockMinioClient
+}
+
+// NewMockMinioClient creates a new mock instance.
+func NewMockMinioClient(ctrl *gomock.Controller) *MockMinioClient {
+	mock := &MockMinioClient{ctrl: ctrl}
+	mock.recorder = &MockMinioClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMinioClient) EXPECT() *MockMinioClientMockRecorder {
+	return m.recorder
+}
+
+// PutObject mocks base method.
+func (m *MockMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, bucketName, objectName, reader, objectSize, opts)
+	ret0, _ := ret[0].(minio.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockMinioClientMockRecorder) PutObject(ctx, bucketName, objectName, reader, objectSize, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockMinioClient)(nil).PutObject), ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewStatementRepository mocks base method.
+func (m *Mockdependencies) NewStatementRepository(db postgres.Database) generate_statements.StatementRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewStatementRepository", db)
+	ret0, _ := ret[0].(generate_statements.StatementRepository)
+	return ret0
+}
+
+// NewStatementRepository indicates an expected call of NewStatementRepository.
+func (mr *MockdependenciesMockRecorder) NewStatementRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStatementRepository", reflect.TypeOf((*Mockdependencies)(nil).NewStatementRepository), db)
+}
+
+// NewTransactionRepository mocks base method.
+func (m *Mockdependencies) NewTransactionRepository(db postgres.Database) generate_statements.TransactionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewTransactionRepository", db)
+	ret0, _ := ret[0].(generate_statements.TransactionRepository)
+	return ret0
+}
+
+// NewTransactionRepository indicates an expected call of NewTransactionRepository.
+func (mr *MockdependenciesMockRecorder) NewTransactionRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransactionRepository", reflect.TypeOf((*Mockdependencies)(nil).NewTransactionRepository), db)
+}
+
+// NewWalletRepository mocks base method.
+func (m *Mockdependencies) NewWalletRepository(db postgres.Database) generate_statements.WalletRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWalletRepository", db)
+	ret0, _ := ret[0].(generate_statements.WalletRepository)
+	return ret0
+}
+
+// NewWalletRepository indicates an expected call of NewWalletRepository.
+func (mr *MockdependenciesMockRecorder) NewWalletRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWalletRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWalletRepository), db)
+}
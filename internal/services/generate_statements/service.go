@@ -0,0 +1,297 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package generate_statements
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+const (
+	StatementsBucketName = "statements"
+
+	// reportKind - вид ежемесячной выписки в метриках.
+	reportKind = "monthly_statement"
+
+	// walletsBatchSize - сколько кошельков за раз берется из базы при формировании выписок по всем кошелькам.
+	walletsBatchSize = 100
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type TransactionRepository interface {
+	GetFundsBefore(ctx context.Context, walletID int64, t time.Time) (int64, error)
+	StreamTransactionsByTime(
+		ctx context.Context,
+		walletID int64,
+		timeStart, timeEnd time.Time,
+		fn func(tx repoTxs.Transaction) error,
+	) error
+}
+
+type StatementRepository interface {
+	GetWalletsWithoutStatement(
+		ctx context.Context,
+		period string,
+		createdBefore time.Time,
+		afterWalletID, limit int64,
+	) ([]repoStatement.Wallet, error)
+	AddStatement(ctx context.Context, s repoStatement.Statement) error
+}
+
+type MinioClient interface {
+	PutObject(
+		ctx context.Context,
+		bucketName, objectName string,
+		reader io.Reader,
+		objectSize int64,
+		opts minio.PutObjectOptions,
+	) (info minio.UploadInfo, err error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewStatementRepository(db postgres.Database) StatementRepository
+}
+
+type Service struct {
+	logger      logger
+	db          *sql.DB
+	minioClient MinioClient
+	deps        dependencies
+}
+
+func New(logger logger, db *sql.DB, minioClient MinioClient) *Service {
+	return &Service{
+		logger:      logger,
+		db:          db,
+		minioClient: minioClient,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// GenerateAll формирует выписки за месяц month по всем кошелькам, у которых их еще нет, и отдает количество
+// сформированных выписок.
+// - проверяем, что месяц закончился, иначе отдаем ошибку ErrPeriodNotFinished.
+// - берем из базы пачку кошельков без выписки за месяц, по возрастанию идентификатора.
+// - формируем выписку по каждому кошельку из пачки, и так пока кошельки не закончатся.
+//
+// Выписка кошелька сохраняется в базе сразу после загрузки в хранилище. Поэтому, если формирование прервалось,
+// повторный запуск продолжит с кошельков, по которым выписки еще нет.
+func (s *Service) GenerateAll(ctx context.Context, month time.Time) (int, error) {
+	start, end, err := monthBounds(month)
+	if err != nil {
+		return 0, err
+	}
+
+	period := start.Format(repoStatement.PeriodLayout)
+	statementRepo := s.deps.NewStatementRepository(s.db)
+
+	var generated int
+	var afterWalletID int64
+
+	for {
+		wallets, err := statementRepo.GetWalletsWithoutStatement(ctx, period, end, afterWalletID, walletsBatchSize)
+		if err != nil {
+			s.logger.Error(ctx, "get wallets without statement", logfield.Error(err))
+			return generated, fmt.Errorf("get wallets without statement: %w", err)
+		}
+
+		for _, w := range wallets {
+			if err := s.generate(ctx, w.ID, w.UserID, start, end); err != nil {
+				s.logger.Error(ctx, "generate statement", logfield.WalletID(w.ID), logfield.Error(err))
+				return generated, fmt.Errorf("generate statement for wallet %d: %w", w.ID, err)
+			}
+
+			generated++
+			afterWalletID = w.ID
+		}
+
+		if len(wallets) < walletsBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info(ctx, "statements generated", "count", generated, "period", period)
+
+	return generated, nil
+}
+
+// Generate формирует выписку пользователя за месяц month. Если выписка уже есть, то она формируется заново.
+// - проверяем, что месяц закончился, иначе отдаем ошибку ErrPeriodNotFinished.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+func (s *Service) Generate(ctx context.Context, userID int64, month time.Time) error {
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	start, end, err := monthBounds(month)
+	if err != nil {
+		return err
+	}
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return fmt.Errorf("exist wallet: %w", err)
+	}
+
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
+	if err := s.generate(ctx, walletID, userID, start, end); err != nil {
+		s.logger.Error(ctx, "generate statement", logfield.Error(err))
+		return fmt.Errorf("generate statement for wallet %d: %w", walletID, err)
+	}
+
+	return nil
+}
+
+// generate формирует выписку кошелька за промежуток [start, end), кладет ее в хранилище и сохраняет в базе.
+// Имя объекта в хранилище зависит только от пользователя и месяца, поэтому повторное формирование перезаписывает
+// выписку, а не плодит копии.
+func (s *Service) generate(ctx context.Context, walletID, userID int64, start, end time.Time) error {
+	defer metrics.ObserveReport(reportKind, time.Now())
+
+	period := start.Format(repoStatement.PeriodLayout)
+
+	txsRepo := s.deps.NewTransactionRepository(s.db)
+
+	opening, err := txsRepo.GetFundsBefore(ctx, walletID, start)
+	if err != nil {
+		return fmt.Errorf("get funds before: %w", err)
+	}
+
+	statement := Statement{
+		UserID:         userID,
+		Period:         period,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Operations:     []Operation{},
+	}
+
+	totals := make(map[string]Total, len(txTypes))
+
+	// Конец промежутка в базе включается, поэтому отступаем на минимальную единицу времени Postgres.
+	err = txsRepo.StreamTransactionsByTime(ctx, walletID, start, end.Add(-time.Microsecond),
+		func(tx repoTxs.Transaction) error {
+			op, err := adaptOperation(tx, i18n.DefaultLocale)
+			if err != nil {
+				return fmt.Errorf("adapt operation: %v", err)
+			}
+
+			statement.Operations = append(statement.Operations, op)
+
+			t := totals[tx.Type]
+			t.Count++
+			t.Amount += tx.Amount
+			totals[tx.Type] = t
+
+			switch tx.Type {
+			case transactions.TypeAdd:
+				statement.ClosingBalance += tx.Amount
+			case transactions.TypeWriteOff, transactions.TypeOutgoing:
+				statement.ClosingBalance -= tx.Amount
+			}
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("stream transactions: %w", err)
+	}
+
+	for _, txType := range txTypes {
+		t := totals[txType]
+		t.Type = txType
+		statement.Totals = append(statement.Totals, t)
+	}
+
+	b, err := json.Marshal(statement)
+	if err != nil {
+		return fmt.Errorf("marshal statement: %v", err)
+	}
+
+	objectName := fmt.Sprintf("%s/statement-%d-%s.json", period, userID, period)
+
+	_, err = s.minioClient.PutObject(
+		ctx,
+		StatementsBucketName,
+		objectName,
+		bytes.NewReader(b),
+		int64(len(b)),
+		minio.PutObjectOptions{ContentType: "application/json"},
+	)
+	metrics.ObserveUpload(StatementsBucketName, err)
+
+	if err != nil {
+		return fmt.Errorf("put object to minio: %v", err)
+	}
+
+	statementRepo := s.deps.NewStatementRepository(s.db)
+
+	if err := statementRepo.AddStatement(ctx, repoStatement.Statement{
+		WalletID:       walletID,
+		Period:         period,
+		ObjectName:     objectName,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+	}); err != nil {
+		return fmt.Errorf("add statement: %w", err)
+	}
+
+	s.logger.Info(ctx, "statement generated", logfield.WalletID(walletID), "object", objectName)
+
+	return nil
+}
+
+// monthBounds отдает границы месяца [start, end) в UTC. Выписку можно сформировать только за закончившийся месяц,
+// иначе отдаем ошибку ErrPeriodNotFinished.
+func monthBounds(month time.Time) (time.Time, time.Time, error) {
+	month = month.UTC()
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	if end.After(time.Now()) {
+		return time.Time{}, time.Time{}, servicesErrors.ErrPeriodNotFinished
+	}
+
+	return start, end, nil
+}
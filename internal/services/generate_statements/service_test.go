@@ -0,0 +1,313 @@
+package generate_statements_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/generate_statements"
+	mock "github.com/frutonanny/wallet-service/internal/services/generate_statements/mock"
+)
+
+const (
+	testUserID   = int64(1)
+	testWalletID = int64(1)
+	testPeriod   = "2022-11"
+	testOpening  = int64(1000)
+)
+
+var (
+	testError = errors.New("error")
+
+	testMonth = time.Date(2022, 11, 15, 10, 0, 0, 0, time.UTC)
+	testStart = time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	testServiceID = int64(2)
+	testTxs       = []repoTxs.Transaction{
+		{
+			ID:        1,
+			Type:      "incoming_transfer",
+			Payload:   []byte(`{"type": "enrollment"}`),
+			Amount:    5000,
+			CreatedAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        2,
+			Type:      "reservation",
+			Payload:   []byte(`{"order_id": 42, "service_id": 2}`),
+			Amount:    2000,
+			ServiceID: &testServiceID,
+			CreatedAt: time.Date(2022, 11, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        3,
+			Type:      "write_off",
+			Payload:   []byte(`{"order_id": 42, "service_id": 2}`),
+			Amount:    1500,
+			ServiceID: &testServiceID,
+			CreatedAt: time.Date(2022, 11, 3, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        4,
+			Type:      "outgoing_transfer",
+			Payload:   []byte(`{"type": "adjustment", "reason": "double enrollment", "operator": "ops"}`),
+			Amount:    500,
+			CreatedAt: time.Date(2022, 11, 4, 12, 0, 0, 0, time.UTC),
+		},
+	}
+)
+
+// streamTxs имитирует чтение транзакций из курсора базы.
+func streamTxs(txs []repoTxs.Transaction) func(
+	ctx context.Context,
+	walletID int64,
+	start, end time.Time,
+	fn func(tx repoTxs.Transaction) error,
+) error {
+	return func(_ context.Context, _ int64, _, _ time.Time, fn func(tx repoTxs.Transaction) error) error {
+		for _, tx := range txs {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// putObject сохраняет загруженную выписку в statement.
+func putObject(t *testing.T, statement *generate_statements.Statement) func(
+	ctx context.Context,
+	bucketName, objectName string,
+	reader io.Reader,
+	objectSize int64,
+	opts minio.PutObjectOptions,
+) (minio.UploadInfo, error) {
+	return func(_ context.Context, _, _ string, reader io.Reader, _ int64, _ minio.PutObjectOptions) (minio.UploadInfo, error) {
+		b, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, statement))
+
+		return minio.UploadInfo{}, nil
+	}
+}
+
+func TestService_Generate(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("generate successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().GetFundsBefore(ctx, testWalletID, testStart).Return(testOpening, nil)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, testWalletID, testStart, testEnd.Add(-time.Microsecond), gomock.Any()).
+			DoAndReturn(streamTxs(testTxs))
+
+		// Выписка перезаписывается по одному и тому же имени.
+		var statement generate_statements.Statement
+		minioClient := mock.NewMockMinioClient(ctrl)
+		minioClient.EXPECT().
+			PutObject(
+				ctx,
+				generate_statements.StatementsBucketName,
+				"2022-11/statement-1-2022-11.json",
+				gomock.Any(),
+				gomock.Any(),
+				gomock.Any(),
+			).
+			DoAndReturn(putObject(t, &statement))
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().AddStatement(ctx, repoStatement.Statement{
+			WalletID:       testWalletID,
+			Period:         testPeriod,
+			ObjectName:     "2022-11/statement-1-2022-11.json",
+			OpeningBalance: testOpening,
+			ClosingBalance: 4000,
+		}).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := generate_statements.New(log, db, minioClient).WithDependencies(deps)
+
+		err := service.Generate(ctx, testUserID, testMonth)
+		require.NoError(t, err)
+
+		// Средства: 1000 + 5000 зачислено - 1500 списано по заказу - 500 списано без заказа. Резервирование средства
+		// не меняет.
+		assert.Equal(t, testUserID, statement.UserID)
+		assert.Equal(t, testPeriod, statement.Period)
+		assert.Equal(t, testOpening, statement.OpeningBalance)
+		assert.Equal(t, int64(4000), statement.ClosingBalance)
+		assert.Equal(t, []generate_statements.Total{
+			{Type: "incoming_transfer", Count: 1, Amount: 5000},
+			{Type: "reservation", Count: 1, Amount: 2000},
+			{Type: "write_off", Count: 1, Amount: 1500},
+			{Type: "cancel", Count: 0, Amount: 0},
+			{Type: "outgoing_transfer", Count: 1, Amount: 500},
+		}, statement.Totals)
+		require.Len(t, statement.Operations, 4)
+		assert.Equal(t, int64(42), statement.Operations[1].OrderID)
+		assert.Equal(t, "Резервирование средств по заказу 42, услуга «Выделение цветом»",
+			statement.Operations[1].Description)
+	})
+
+	t.Run("generate failed, ErrPeriodNotFinished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+		minioClient := mock.NewMockMinioClient(ctrl)
+
+		service := generate_statements.New(log, db, minioClient).WithDependencies(deps)
+
+		err := service.Generate(ctx, testUserID, time.Now())
+		assert.ErrorIs(t, err, servicesErrors.ErrPeriodNotFinished)
+	})
+
+	t.Run("generate failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(int64(0), repositories.ErrRepoWalletNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		minioClient := mock.NewMockMinioClient(ctrl)
+
+		service := generate_statements.New(log, db, minioClient).WithDependencies(deps)
+
+		err := service.Generate(ctx, testUserID, testMonth)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+}
+
+func TestService_GenerateAll(t *testing.T) {
+	var db *sql.DB
+
+	wallets := []repoStatement.Wallet{
+		{ID: 1, UserID: 10},
+		{ID: 2, UserID: 20},
+	}
+
+	t.Run("generate all successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().GetFundsBefore(ctx, gomock.Any(), testStart).Return(int64(0), nil).Times(2)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, gomock.Any(), testStart, testEnd.Add(-time.Microsecond), gomock.Any()).
+			DoAndReturn(streamTxs(nil)).
+			Times(2)
+
+		minioClient := mock.NewMockMinioClient(ctrl)
+		minioClient.EXPECT().
+			PutObject(ctx, generate_statements.StatementsBucketName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(minio.UploadInfo{}, nil).
+			Times(2)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().
+			GetWalletsWithoutStatement(ctx, testPeriod, testEnd, int64(0), gomock.Any()).
+			Return(wallets, nil)
+		statementRepo.EXPECT().AddStatement(ctx, gomock.Any()).Return(nil).Times(2)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo).AnyTimes()
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).Times(3)
+
+		service := generate_statements.New(log, db, minioClient).WithDependencies(deps)
+
+		generated, err := service.GenerateAll(ctx, testMonth)
+		require.NoError(t, err)
+		assert.Equal(t, 2, generated)
+	})
+
+	t.Run("generate all stopped, put object error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		txRepo := mock.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().GetFundsBefore(ctx, gomock.Any(), testStart).Return(int64(0), nil).Times(2)
+		txRepo.EXPECT().
+			StreamTransactionsByTime(ctx, gomock.Any(), testStart, testEnd.Add(-time.Microsecond), gomock.Any()).
+			DoAndReturn(streamTxs(nil)).
+			Times(2)
+
+		// Первая выписка загружается, на второй хранилище отказывает.
+		minioClient := mock.NewMockMinioClient(ctrl)
+		gomock.InOrder(
+			minioClient.EXPECT().
+				PutObject(ctx, generate_statements.StatementsBucketName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(minio.UploadInfo{}, nil),
+			minioClient.EXPECT().
+				PutObject(ctx, generate_statements.StatementsBucketName, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(minio.UploadInfo{}, testError),
+		)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().
+			GetWalletsWithoutStatement(ctx, testPeriod, testEnd, int64(0), gomock.Any()).
+			Return(wallets, nil)
+		statementRepo.EXPECT().AddStatement(ctx, gomock.Any()).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo).AnyTimes()
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := generate_statements.New(log, db, minioClient).WithDependencies(deps)
+
+		generated, err := service.GenerateAll(ctx, testMonth)
+		assert.Error(t, err)
+		assert.Equal(t, 1, generated)
+	})
+}
@@ -2,6 +2,7 @@ package get_balance
 
 import (
 	"github.com/frutonanny/wallet-service/internal/postgres"
+	repositoryOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
 	repositoryWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 )
 
@@ -10,3 +11,7 @@ type dependenciesImpl struct{}
 func (b *dependenciesImpl) NewRepository(db postgres.Database) Repository {
 	return repositoryWallet.New(db)
 }
+
+func (b *dependenciesImpl) NewOrderRepository(db postgres.Database) OrderRepository {
+	return repositoryOrder.New(db)
+}
@@ -0,0 +1,46 @@
+package get_balance
+
+import (
+	"time"
+
+	repositoryOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repositoryWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+// Balance - баланс пользователя.
+type Balance struct {
+	UserID    int64
+	Available int64 // Доступные средства.
+	Reserved  int64 // Зарезервированные средства.
+	Total     int64 // Все средства: доступные и зарезервированные.
+	Holds     []Hold
+}
+
+// Hold - открытый резерв под позицию заказа.
+type Hold struct {
+	OrderID   int64
+	ServiceID int64
+	Amount    int64
+	CreatedAt time.Time
+}
+
+func adaptBalance(b repositoryWallet.Balance, holds []repositoryOrder.Hold) Balance {
+	result := Balance{
+		UserID:    b.UserID,
+		Available: b.Balance,
+		Reserved:  b.Reservation,
+		Total:     b.Balance + b.Reservation,
+		Holds:     make([]Hold, 0, len(holds)),
+	}
+
+	for _, h := range holds {
+		result.Holds = append(result.Holds, Hold{
+			OrderID:   h.ExternalID,
+			ServiceID: h.ServiceID,
+			Amount:    h.Amount,
+			CreatedAt: h.CreatedAt,
+		})
+	}
+
+	return result
+}
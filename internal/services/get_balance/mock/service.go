@@ -9,6 +9,8 @@ import (
 	reflect "reflect"
 
 	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	order "github.com/frutonanny/wallet-service/internal/repositories/order"
+	wallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 	get_balance "github.com/frutonanny/wallet-service/internal/services/get_balance"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -37,27 +39,37 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 }
 
 // Error mocks base method.
-func (m *Mocklogger) Error(msg string) {
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
 }
 
 // MockRepository is a mock of Repository interface.
@@ -83,34 +95,57 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// ExistWallet mocks base method.
-func (m *MockRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+// GetBalances mocks base method.
+func (m *MockRepository) GetBalances(ctx context.Context, userIDs []int64) ([]wallet.Balance, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userIDs)
+	ret0, _ := ret[0].([]wallet.Balance)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExistWallet indicates an expected call of ExistWallet.
-func (mr *MockRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockRepositoryMockRecorder) GetBalances(ctx, userIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockRepository)(nil).ExistWallet), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockRepository)(nil).GetBalances), ctx, userIDs)
 }
 
-// GetBalance mocks base method.
-func (m *MockRepository) GetBalance(ctx context.Context, walletID int64) (int64, error) {
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetHolds mocks base method.
+func (m *MockOrderRepository) GetHolds(ctx context.Context, walletIDs []int64) ([]order.Hold, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBalance", ctx, walletID)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetHolds", ctx, walletIDs)
+	ret0, _ := ret[0].([]order.Hold)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBalance indicates an expected call of GetBalance.
-func (mr *MockRepositoryMockRecorder) GetBalance(ctx, walletID interface{}) *gomock.Call {
+// GetHolds indicates an expected call of GetHolds.
+func (mr *MockOrderRepositoryMockRecorder) GetHolds(ctx, walletIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockRepository)(nil).GetBalance), ctx, walletID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolds", reflect.TypeOf((*MockOrderRepository)(nil).GetHolds), ctx, walletIDs)
 }
 
 // Mockdependencies is a mock of dependencies interface.
@@ -136,6 +171,20 @@ func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
 	return m.recorder
 }
 
+// NewOrderRepository mocks base method.
+func (m *Mockdependencies) NewOrderRepository(db postgres.Database) get_balance.OrderRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewOrderRepository", db)
+	ret0, _ := ret[0].(get_balance.OrderRepository)
+	return ret0
+}
+
+// NewOrderRepository indicates an expected call of NewOrderRepository.
+func (mr *MockdependenciesMockRecorder) NewOrderRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewOrderRepository", reflect.TypeOf((*Mockdependencies)(nil).NewOrderRepository), db)
+}
+
 // NewRepository mocks base method.
 func (m *Mockdependencies) NewRepository(db postgres.Database) get_balance.Repository {
 	m.ctrl.T.Helper()
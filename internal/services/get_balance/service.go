@@ -4,28 +4,34 @@ package get_balance
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 
+	"github.com/frutonanny/wallet-service/internal/logfield"
 	"github.com/frutonanny/wallet-service/internal/postgres"
-	"github.com/frutonanny/wallet-service/internal/repositories"
+	repositoryOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repositoryWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type Repository interface {
-	ExistWallet(ctx context.Context, userID int64) (int64, error)
-	GetBalance(ctx context.Context, walletID int64) (int64, error)
+	GetBalances(ctx context.Context, userIDs []int64) ([]repositoryWallet.Balance, error)
+}
+
+type OrderRepository interface {
+	GetHolds(ctx context.Context, walletIDs []int64) ([]repositoryOrder.Hold, error)
 }
 
 // dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
 // Нужен для написания юнит-тестов без подключения к базе.
 type dependencies interface {
 	NewRepository(db postgres.Database) Repository
+	NewOrderRepository(db postgres.Database) OrderRepository
 }
 
 type Service struct {
@@ -47,30 +53,87 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 	return s
 }
 
-// GetBalance - отдает баланс пользователя.
-// - проверяем есть ли кошелек у пользователя, если нет, то возвращаем ошибку - ErrWalletNotFound;
+// GetBalance - отдает баланс пользователя: доступные, зарезервированные и все средства, а также открытые резервы.
+// - если у пользователя нет кошелька, то возвращаем ошибку - ErrWalletNotFound;
 // - отдаем баланс пользователя.
-func (s *Service) GetBalance(ctx context.Context, userID int64) (int64, error) {
+func (s *Service) GetBalance(ctx context.Context, userID int64) (_ Balance, err error) {
+	ctx, span := tracing.Start(ctx, "get_balance.GetBalance")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	balances, err := s.getBalances(ctx, []int64{userID})
+	if err != nil {
+		return Balance{}, err
+	}
+
+	if len(balances) == 0 {
+		s.logger.Error(ctx, "wallet not found")
+		return Balance{}, servicesErrors.ErrWalletNotFound
+	}
+
+	return balances[0], nil
+}
+
+// GetBalances - отдает балансы нескольких пользователей в порядке userIDs. Пользователи без кошелька
+// в ответ не попадают.
+func (s *Service) GetBalances(ctx context.Context, userIDs []int64) (_ []Balance, err error) {
+	ctx, span := tracing.Start(ctx, "get_balance.GetBalances")
+	defer func() { tracing.End(span, err) }()
+
+	return s.getBalances(ctx, userIDs)
+}
+
+// getBalances - получает балансы кошельков и открытые резервы по ним двумя запросами на всех пользователей.
+func (s *Service) getBalances(ctx context.Context, userIDs []int64) ([]Balance, error) {
 	repo := s.deps.NewRepository(s.db)
 
-	// Проверяем есть ли кошелек у пользователя.
-	walletID, err := repo.ExistWallet(ctx, userID)
+	// Получаем текущие балансы пользователей.
+	balances, err := repo.GetBalances(ctx, userIDs)
 	if err != nil {
-		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
-			s.logger.Error(fmt.Sprintf("for user %d wallet not found", userID))
-			return 0, servicesErrors.ErrWalletNotFound
-		}
+		s.logger.Error(ctx, "get balances", logfield.Error(err))
+		return nil, fmt.Errorf("get balances: %w", err)
+	}
 
-		s.logger.Error(fmt.Sprintf("exist wallet: %s", err))
-		return 0, fmt.Errorf("exist wallet: %w", err)
+	if len(balances) == 0 {
+		return []Balance{}, nil
 	}
 
-	// Получаем текущий баланс пользователя.
-	balance, err := repo.GetBalance(ctx, walletID)
+	walletIDs := make([]int64, 0, len(balances))
+	byUser := make(map[int64]repositoryWallet.Balance, len(balances))
+
+	for _, b := range balances {
+		walletIDs = append(walletIDs, b.WalletID)
+		byUser[b.UserID] = b
+	}
+
+	orderRepo := s.deps.NewOrderRepository(s.db)
+
+	// Получаем открытые резервы по кошелькам.
+	holds, err := orderRepo.GetHolds(ctx, walletIDs)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("get balance: %s", err))
-		return 0, fmt.Errorf("get balance: %w", err)
+		s.logger.Error(ctx, "get holds", logfield.Error(err))
+		return nil, fmt.Errorf("get holds: %w", err)
+	}
+
+	holdsByWallet := make(map[int64][]repositoryOrder.Hold, len(balances))
+	for _, h := range holds {
+		holdsByWallet[h.WalletID] = append(holdsByWallet[h.WalletID], h)
+	}
+
+	result := make([]Balance, 0, len(balances))
+
+	for _, userID := range userIDs {
+		b, ok := byUser[userID]
+		if !ok {
+			continue
+		}
+
+		// Пользователь мог быть передан несколько раз.
+		delete(byUser, userID)
+
+		result = append(result, adaptBalance(b, holdsByWallet[b.WalletID]))
 	}
 
-	return balance, nil
+	return result, nil
 }
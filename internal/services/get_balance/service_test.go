@@ -5,25 +5,35 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/frutonanny/wallet-service/internal/repositories"
+	repositoryOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repositoryWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
 	"github.com/frutonanny/wallet-service/internal/services/get_balance"
 	mock "github.com/frutonanny/wallet-service/internal/services/get_balance/mock"
 )
 
 const (
-	testUserID   = int64(1)
-	testFailed   = int64(0)
-	testWalletID = int64(1)
-	testBalance  = int64(10_000)
+	testUserID      = int64(1)
+	testWalletID    = int64(1)
+	testBalance     = int64(10_000)
+	testReservation = int64(1_500)
 )
 
-var testError = errors.New("error")
+var (
+	testError     = errors.New("error")
+	testCreatedAt = time.Date(2022, 11, 2, 12, 0, 0, 0, time.UTC)
+
+	testHolds = []repositoryOrder.Hold{
+		{WalletID: testWalletID, ExternalID: 10, ServiceID: 1, Amount: 1_000, CreatedAt: testCreatedAt},
+		{WalletID: testWalletID, ExternalID: 11, ServiceID: 2, Amount: 500, CreatedAt: testCreatedAt},
+	}
+)
 
 func TestService_GetBalance(t *testing.T) {
 	var db *sql.DB
@@ -35,11 +45,16 @@ func TestService_GetBalance(t *testing.T) {
 		ctx := context.Background()
 
 		repo := mock.NewMockRepository(ctrl)
-		repo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
-		repo.EXPECT().GetBalance(ctx, testWalletID).Return(testBalance, nil)
+		repo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return([]repositoryWallet.Balance{
+			{WalletID: testWalletID, UserID: testUserID, Balance: testBalance, Reservation: testReservation},
+		}, nil)
+
+		orderRepo := mock.NewMockOrderRepository(ctrl)
+		orderRepo.EXPECT().GetHolds(ctx, []int64{testWalletID}).Return(testHolds, nil)
 
 		deps := mock.NewMockdependencies(ctrl)
 		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock.NewMocklogger(ctrl)
 
@@ -47,7 +62,16 @@ func TestService_GetBalance(t *testing.T) {
 
 		balance, err := service.GetBalance(ctx, testUserID)
 		require.NoError(t, err)
-		assert.EqualValues(t, testBalance, balance)
+		assert.Equal(t, get_balance.Balance{
+			UserID:    testUserID,
+			Available: testBalance,
+			Reserved:  testReservation,
+			Total:     testBalance + testReservation,
+			Holds: []get_balance.Hold{
+				{OrderID: 10, ServiceID: 1, Amount: 1_000, CreatedAt: testCreatedAt},
+				{OrderID: 11, ServiceID: 2, Amount: 500, CreatedAt: testCreatedAt},
+			},
+		}, balance)
 	})
 
 	t.Run("get balance failed, wallet not exist", func(t *testing.T) {
@@ -57,16 +81,13 @@ func TestService_GetBalance(t *testing.T) {
 		ctx := context.Background()
 
 		repo := mock.NewMockRepository(ctrl)
-		repo.
-			EXPECT().
-			ExistWallet(ctx, testUserID).
-			Return(testFailed, repositories.ErrRepoWalletNotFound)
+		repo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return(nil, nil)
 
 		deps := mock.NewMockdependencies(ctrl)
 		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
 
 		log := mock.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := get_balance.New(log, db).WithDependencies(deps)
 
@@ -82,18 +103,108 @@ func TestService_GetBalance(t *testing.T) {
 		ctx := context.Background()
 
 		repo := mock.NewMockRepository(ctrl)
-		repo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
-		repo.EXPECT().GetBalance(ctx, testWalletID).Return(testBalance, testError)
+		repo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return(nil, testError)
 
 		deps := mock.NewMockdependencies(ctrl)
 		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
 
 		log := mock.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := get_balance.New(log, db).WithDependencies(deps)
 
 		_, err := service.GetBalance(ctx, testUserID)
 		require.Error(t, err)
 	})
+
+	t.Run("get balance failed, holds error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRepository(ctrl)
+		repo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return([]repositoryWallet.Balance{
+			{WalletID: testWalletID, UserID: testUserID, Balance: testBalance},
+		}, nil)
+
+		orderRepo := mock.NewMockOrderRepository(ctrl)
+		orderRepo.EXPECT().GetHolds(ctx, []int64{testWalletID}).Return(nil, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_balance.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetBalance(ctx, testUserID)
+		assert.ErrorIs(t, err, testError)
+	})
+}
+
+func TestService_GetBalances(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get balances successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		// У пользователя 3 кошелька нет, пользователь 1 передан дважды.
+		userIDs := []int64{2, 3, 1, 1}
+
+		repo := mock.NewMockRepository(ctrl)
+		repo.EXPECT().GetBalances(ctx, userIDs).Return([]repositoryWallet.Balance{
+			{WalletID: 1, UserID: 1, Balance: 100, Reservation: 1_500},
+			{WalletID: 2, UserID: 2, Balance: 200},
+		}, nil)
+
+		orderRepo := mock.NewMockOrderRepository(ctrl)
+		orderRepo.EXPECT().GetHolds(ctx, []int64{1, 2}).Return(testHolds, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance.New(log, db).WithDependencies(deps)
+
+		balances, err := service.GetBalances(ctx, userIDs)
+		require.NoError(t, err)
+		require.Len(t, balances, 2)
+
+		// Балансы отдаются в порядке запроса.
+		assert.Equal(t, int64(2), balances[0].UserID)
+		assert.Equal(t, int64(200), balances[0].Total)
+		assert.Empty(t, balances[0].Holds)
+		assert.Equal(t, int64(1), balances[1].UserID)
+		assert.Equal(t, int64(1_600), balances[1].Total)
+		assert.Len(t, balances[1].Holds, 2)
+	})
+
+	t.Run("get balances, no wallets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRepository(ctrl)
+		repo.EXPECT().GetBalances(ctx, []int64{3}).Return(nil, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance.New(log, db).WithDependencies(deps)
+
+		balances, err := service.GetBalances(ctx, []int64{3})
+		require.NoError(t, err)
+		assert.Empty(t, balances)
+	})
 }
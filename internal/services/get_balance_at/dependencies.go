@@ -0,0 +1,17 @@
+package get_balance_at
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
+
+func (b *dependenciesImpl) NewBalanceRepository(db postgres.Database) BalanceRepository {
+	return repoBalance.New(db)
+}
@@ -0,0 +1,26 @@
+package get_balance_at
+
+import (
+	"time"
+
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+)
+
+// Balance - баланс кошелька: доступные и зарезервированные средства.
+type Balance struct {
+	Available int64
+	Reserved  int64
+}
+
+// DailyBalance - баланс кошелька на конец дня Date.
+type DailyBalance struct {
+	Date time.Time
+	Balance
+}
+
+func adaptBalance(b repoBalance.Balance) Balance {
+	return Balance{
+		Available: b.Available,
+		Reserved:  b.Reserved,
+	}
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_get_balance_at is a generated GoMock package.
+package mock_get_balance_at
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	balance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	get_balance_at "github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistWallet mocks base method.
+func (m *MockWalletRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistWallet indicates an expected call of ExistWallet.
+func (mr *MockWalletRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockWalletRepository)(nil).ExistWallet), ctx, userID)
+}
+
+// MockBalanceRepository is a mock of BalanceRepository interface.
+type MockBalanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepositoryMockRecorder
+}
+
+// MockBalanceRepositoryMockRecorder is the mock recorder for MockBalanceRepository.
+type MockBalanceRepositoryMockRecorder struct {
+	mock *MockBalanceRepository
+}
+
+// NewMockBalanceRepository creates a new mock instance.
+func NewMockBalanceRepository(ctrl *gomock.Controller) *MockBalanceRepository {
+	mock := &MockBalanceRepository{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepository) EXPECT() *MockBalanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBalanceBefore mocks base method.
+func (m *MockBalanceRepository) GetBalanceBefore(ctx context.Context, walletID int64, t time.Time) (balance.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceBefore", ctx, walletID, t)
+	ret0, _ := ret[0].(balance.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceBefore indicates an expected call of GetBalanceBefore.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceBefore(ctx, walletID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceBefore", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceBefore), ctx, walletID, t)
+}
+
+// GetDailyBalances mocks base method.
+func (m *MockBalanceRepository) GetDailyBalances(ctx context.Context, walletID int64, from, to time.Time) ([]balance.DailyBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyBalances", ctx, walletID, from, to)
+	ret0, _ := ret[0].([]balance.DailyBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyBalances indicates an expected call of GetDailyBalances.
+func (mr *MockBalanceRepositoryMockRecorder) GetDailyBalances(ctx, walletID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyBalances", reflect.TypeOf((*MockBalanceRepository)(nil).GetDailyBalances), ctx, walletID, from, to)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewBalanceRepository mocks base method.
+func (m *Mockdependencies) NewBalanceRepository(db postgres.Database) get_balance_at.BalanceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewBalanceRepository", db)
+	ret0, _ := ret[0].(get_balance_at.BalanceRepository)
+	return ret0
+}
+
+// NewBalanceRepository indicates an expected call of NewBalanceRepository.
+func (mr *MockdependenciesMockRecorder) NewBalanceRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBalanceRepository", reflect.TypeOf((*Mockdependencies)(nil).NewBalanceRepository), db)
+}
+
+// NewWalletRepository mocks base method.
+func (m *Mockdependencies) NewWalletRepository(db postgres.Database) get_balance_at.WalletRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWalletRepository", db)
+	ret0, _ := ret[0].(get_balance_at.WalletRepository)
+	return ret0
+}
+
+// NewWalletRepository indicates an expected call of NewWalletRepository.
+func (mr *MockdependenciesMockRecorder) NewWalletRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWalletRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWalletRepository), db)
+}
@@ -0,0 +1,165 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package get_balance_at
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+const (
+	// MaxSeriesDays - максимальное количество дней в ряде балансов.
+	MaxSeriesDays = 366
+
+	// precision - точность хранения времени в Postgres.
+	precision = time.Microsecond
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type BalanceRepository interface {
+	GetBalanceBefore(ctx context.Context, walletID int64, t time.Time) (repoBalance.Balance, error)
+	GetDailyBalances(ctx context.Context, walletID int64, from, to time.Time) ([]repoBalance.DailyBalance, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewBalanceRepository(db postgres.Database) BalanceRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// GetBalanceAt отдает баланс пользователя на момент at с учетом транзакций, созданных в этот момент.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - считаем баланс по журналу транзакций от ближайшего снимка баланса.
+func (s *Service) GetBalanceAt(ctx context.Context, userID int64, at time.Time) (_ Balance, err error) {
+	ctx, span := tracing.Start(ctx, "get_balance_at.GetBalanceAt")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletID, err := s.getWalletID(ctx, userID)
+	if err != nil {
+		return Balance{}, err
+	}
+
+	balanceRepo := s.deps.NewBalanceRepository(s.db)
+
+	balance, err := balanceRepo.GetBalanceBefore(ctx, walletID, at.Add(precision))
+	if err != nil {
+		s.logger.Error(ctx, "get balance before", logfield.Error(err))
+		return Balance{}, fmt.Errorf("get balance before: %w", err)
+	}
+
+	return adaptBalance(balance), nil
+}
+
+// GetDailyBalances отдает балансы пользователя на конец каждого дня (UTC) промежутка [from, to] для графиков.
+// - проверяем промежуток, если from позже to, то отдаем ошибку ErrInvalidPeriod, если он длиннее MaxSeriesDays
+// дней, то ErrPeriodTooLong.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - балансы на конец всех дней считаем одним запросом к журналу транзакций от ближайшего снимка.
+func (s *Service) GetDailyBalances(
+	ctx context.Context,
+	userID int64,
+	from, to time.Time,
+) (_ []DailyBalance, err error) {
+	ctx, span := tracing.Start(ctx, "get_balance_at.GetDailyBalances")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	from = truncateDay(from)
+	to = truncateDay(to)
+
+	if from.After(to) {
+		return nil, servicesErrors.ErrInvalidPeriod
+	}
+
+	days := int(to.Sub(from)/(24*time.Hour)) + 1
+	if days > MaxSeriesDays {
+		return nil, servicesErrors.ErrPeriodTooLong
+	}
+
+	walletID, err := s.getWalletID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.deps.NewBalanceRepository(s.db).GetDailyBalances(ctx, walletID, from, to)
+	if err != nil {
+		s.logger.Error(ctx, "get daily balances", logfield.Error(err))
+		return nil, fmt.Errorf("get daily balances: %w", err)
+	}
+
+	result := make([]DailyBalance, 0, len(balances))
+
+	for _, b := range balances {
+		result = append(result, DailyBalance{
+			Date:    b.Day,
+			Balance: adaptBalance(b.Balance),
+		})
+	}
+
+	return result, nil
+}
+
+// getWalletID отдает кошелек пользователя.
+func (s *Service) getWalletID(ctx context.Context, userID int64) (int64, error) {
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return 0, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return 0, fmt.Errorf("exist wallet: %w", err)
+	}
+
+	return walletID, nil
+}
+
+// truncateDay отдает начало суток (UTC), в которые попадает t.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,222 @@
+package get_balance_at_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	mock "github.com/frutonanny/wallet-service/internal/services/get_balance_at/mock"
+)
+
+const (
+	testUserID   = int64(1)
+	testWalletID = int64(1)
+)
+
+var (
+	testError = errors.New("error")
+
+	testAt      = time.Date(2022, 9, 30, 23, 59, 0, 0, time.UTC)
+	testBalance = repoBalance.Balance{Available: 3000, Reserved: 2000}
+)
+
+func TestService_GetBalanceAt(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get balance at successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		// Транзакции, созданные ровно в момент at, учитываются.
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().GetBalanceBefore(ctx, testWalletID, testAt.Add(time.Microsecond)).Return(testBalance, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		balance, err := service.GetBalanceAt(ctx, testUserID, testAt)
+		require.NoError(t, err)
+		assert.Equal(t, get_balance_at.Balance{Available: 3000, Reserved: 2000}, balance)
+	})
+
+	t.Run("get balance at failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(int64(0), repositories.ErrRepoWalletNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetBalanceAt(ctx, testUserID, testAt)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("get balance at failed, repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().GetBalanceBefore(ctx, testWalletID, gomock.Any()).Return(repoBalance.Balance{}, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetBalanceAt(ctx, testUserID, testAt)
+		assert.ErrorIs(t, err, testError)
+	})
+}
+
+func TestService_GetDailyBalances(t *testing.T) {
+	var db *sql.DB
+
+	from := time.Date(2022, 9, 29, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("get daily balances successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		// Промежуток приводится к началам суток.
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().
+			GetDailyBalances(
+				ctx,
+				testWalletID,
+				time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			).
+			Return([]repoBalance.DailyBalance{
+				{Day: time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC), Balance: repoBalance.Balance{Available: 1000}},
+				{
+					Day:     time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC),
+					Balance: repoBalance.Balance{Available: 800, Reserved: 200},
+				},
+				{Day: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), Balance: repoBalance.Balance{Available: 900}},
+			}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		balances, err := service.GetDailyBalances(ctx, testUserID, from, to)
+		require.NoError(t, err)
+		assert.Equal(t, []get_balance_at.DailyBalance{
+			{
+				Date:    time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+				Balance: get_balance_at.Balance{Available: 1000},
+			},
+			{
+				Date:    time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC),
+				Balance: get_balance_at.Balance{Available: 800, Reserved: 200},
+			},
+			{
+				Date:    time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+				Balance: get_balance_at.Balance{Available: 900},
+			},
+		}, balances)
+	})
+
+	t.Run("get daily balances failed, ErrInvalidPeriod", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetDailyBalances(ctx, testUserID, to, from)
+		assert.ErrorIs(t, err, servicesErrors.ErrInvalidPeriod)
+	})
+
+	t.Run("get daily balances failed, repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().GetDailyBalances(ctx, testWalletID, gomock.Any(), gomock.Any()).Return(nil, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetDailyBalances(ctx, testUserID, from, to)
+		assert.ErrorIs(t, err, testError)
+	})
+
+	t.Run("get daily balances failed, ErrPeriodTooLong", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_balance_at.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetDailyBalances(ctx, testUserID, from, from.AddDate(0, 0, get_balance_at.MaxSeriesDays))
+		assert.ErrorIs(t, err, servicesErrors.ErrPeriodTooLong)
+	})
+}
@@ -0,0 +1,127 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package get_history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type TransactionRepository interface {
+	GetTransactionsPage(ctx context.Context, walletID, limit int64, after *repoTxs.Cursor) ([]repoTxs.Transaction, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+}
+
+type Service struct {
+	db     *sql.DB
+	logger logger
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		deps:   &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// GetHistory - отдает страницу истории транзакций пользователя, отсортированную от новых к старым, и токен
+// следующей страницы.
+// - проверяем, что limit положительный, иначе отдаем ошибку ErrInvalidLimit.
+// - разбираем токен страницы, если он некорректный, то отдаем ошибку ErrInvalidPageToken.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - запрашиваем на одну транзакцию больше, чем limit, чтобы узнать, есть ли следующая страница.
+// - отдаем список транзакций с описаниями на языке locale и токен следующей страницы. Для последней страницы
+// токен пустой.
+func (s *Service) GetHistory(
+	ctx context.Context,
+	userID, limit int64,
+	token string,
+	locale i18n.Locale,
+) (_ []Transaction, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "get_history.GetHistory")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	// С limit <= 0 лишняя транзакция не отличит последнюю страницу от пустой.
+	if limit <= 0 {
+		return nil, "", servicesErrors.ErrInvalidLimit
+	}
+
+	cursor, err := decodeToken(token)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", servicesErrors.ErrInvalidPageToken, err)
+	}
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	// Проверяем есть ли кошелек у пользователя.
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return nil, "", servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return nil, "", fmt.Errorf("exist wallet: %w", err)
+	}
+
+	txsRepo := s.deps.NewTransactionRepository(s.db)
+
+	txs, err := txsRepo.GetTransactionsPage(ctx, walletID, limit+1, cursor)
+	if err != nil {
+		s.logger.Error(ctx, "get transactions page", logfield.Error(err))
+		return nil, "", fmt.Errorf("get transactions page: %w", err)
+	}
+
+	// Лишняя транзакция говорит о том, что есть следующая страница.
+	var next string
+	if int64(len(txs)) > limit {
+		txs = txs[:limit]
+
+		next, err = encodeToken(txs[len(txs)-1])
+		if err != nil {
+			s.logger.Error(ctx, "encode token", logfield.Error(err))
+			return nil, "", fmt.Errorf("encode token: %v", err)
+		}
+	}
+
+	result, err := adaptTxs(txs, locale)
+	if err != nil {
+		return nil, "", fmt.Errorf("adapt txs: %v", err)
+	}
+
+	return result, next, nil
+}
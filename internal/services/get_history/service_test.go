@@ -0,0 +1,171 @@
+package get_history_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	mock_get_history "github.com/frutonanny/wallet-service/internal/services/get_history/mock"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+const (
+	testUserID   = int64(1)
+	testWalletID = int64(1)
+	testLimit    = int64(2)
+)
+
+var (
+	testError = errors.New("error")
+
+	testTime = time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+	testTxs  = []repoTxs.Transaction{
+		{ID: 3, Type: transactions.TypeAdd, Amount: 3000, CreatedAt: testTime.Add(2 * time.Hour)},
+		{ID: 2, Type: transactions.TypeAdd, Amount: 2000, CreatedAt: testTime.Add(time.Hour)},
+		{ID: 1, Type: transactions.TypeAdd, Amount: 1000, CreatedAt: testTime},
+	}
+)
+
+func TestService_GetHistory(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get history pages successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_history.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil).Times(2)
+
+		// Первая страница: запрашиваем limit+1 транзакций, лишняя говорит о наличии следующей страницы.
+		repoTx := mock_get_history.NewMockTransactionRepository(ctrl)
+		repoTx.
+			EXPECT().
+			GetTransactionsPage(ctx, testWalletID, testLimit+1, nil).
+			Return(testTxs, nil)
+
+		// Вторая страница начинается после последней транзакции первой страницы.
+		repoTx.
+			EXPECT().
+			GetTransactionsPage(ctx, testWalletID, testLimit+1, &repoTxs.Cursor{
+				CreatedAt: testTxs[1].CreatedAt,
+				ID:        testTxs[1].ID,
+			}).
+			Return(testTxs[2:], nil)
+
+		deps := mock_get_history.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet).Times(2)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTx).Times(2)
+
+		log := mock_get_history.NewMocklogger(ctrl)
+
+		service := get_history.New(log, db).WithDependencies(deps)
+
+		page1, next, err := service.GetHistory(ctx, testUserID, testLimit, "", i18n.Ru)
+		require.NoError(t, err)
+		require.Len(t, page1, 2)
+		assert.EqualValues(t, 3000, page1[0].Amount)
+		assert.EqualValues(t, 2000, page1[1].Amount)
+		require.NotEmpty(t, next)
+
+		page2, next, err := service.GetHistory(ctx, testUserID, testLimit, next, i18n.Ru)
+		require.NoError(t, err)
+		require.Len(t, page2, 1)
+		assert.EqualValues(t, 1000, page2[0].Amount)
+		assert.Empty(t, next)
+	})
+
+	t.Run("get history failed, ErrInvalidPageToken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock_get_history.NewMockdependencies(ctrl)
+		log := mock_get_history.NewMocklogger(ctrl)
+
+		service := get_history.New(log, db).WithDependencies(deps)
+
+		_, _, err := service.GetHistory(ctx, testUserID, testLimit, "not a token", i18n.Ru)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrInvalidPageToken)
+	})
+
+	t.Run("get history failed, ErrInvalidLimit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock_get_history.NewMockdependencies(ctrl)
+		log := mock_get_history.NewMocklogger(ctrl)
+
+		service := get_history.New(log, db).WithDependencies(deps)
+
+		_, _, err := service.GetHistory(ctx, testUserID, 0, "", i18n.Ru)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrInvalidLimit)
+	})
+
+	t.Run("get history failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_history.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, repositories.ErrRepoWalletNotFound)
+
+		deps := mock_get_history.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+
+		log := mock_get_history.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_history.New(log, db).WithDependencies(deps)
+
+		_, _, err := service.GetHistory(ctx, testUserID, testLimit, "", i18n.Ru)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("get history failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_history.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		repoTx := mock_get_history.NewMockTransactionRepository(ctrl)
+		repoTx.
+			EXPECT().
+			GetTransactionsPage(ctx, testWalletID, testLimit+1, nil).
+			Return(nil, testError)
+
+		deps := mock_get_history.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTx)
+
+		log := mock_get_history.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_history.New(log, db).WithDependencies(deps)
+
+		_, _, err := service.GetHistory(ctx, testUserID, testLimit, "", i18n.Ru)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,55 @@
+package get_history
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+)
+
+// pageToken - содержимое токена следующей страницы. Для клиента токен непрозрачен: это base64 от JSON.
+type pageToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"id"`
+}
+
+// encodeToken собирает токен следующей страницы по последней транзакции текущей страницы.
+func encodeToken(tx repoTxs.Transaction) (string, error) {
+	b, err := json.Marshal(pageToken{
+		CreatedAt: tx.CreatedAt,
+		ID:        tx.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal token: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeToken разбирает токен страницы в курсор. Для пустого токена (первая страница) отдает nil.
+func decodeToken(token string) (*repoTxs.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("decode token: %v", err)
+	}
+
+	t := pageToken{}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("unmarshal token: %v", err)
+	}
+
+	if t.ID <= 0 || t.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("token has no position")
+	}
+
+	return &repoTxs.Cursor{
+		CreatedAt: t.CreatedAt,
+		ID:        t.ID,
+	}, nil
+}
@@ -40,27 +40,37 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 }
 
 // Error mocks base method.
-func (m *Mocklogger) Error(msg string) {
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
 }
 
 // MockRepository is a mock of Repository interface.
@@ -9,22 +9,30 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
+	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	repoReport "github.com/frutonanny/wallet-service/internal/repositories/report"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 	"github.com/frutonanny/wallet-service/pkg"
 )
 
 const (
 	ReportsBucketName = "reports"
+
+	// reportKind - вид отчета в метриках.
+	reportKind = "report"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type Repository interface {
@@ -78,35 +86,52 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 // - Преобразовываем полученный список в csv-файл в памяти.
 // - Кладем преобразованный файл в minio-бакет отчетов.
 // - Собираем ссылку на csv-файл.
-func (s *Service) GetReport(ctx context.Context, period string) (string, error) {
+func (s *Service) GetReport(ctx context.Context, period string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "get_report.GetReport")
+	defer func() { tracing.End(span, err) }()
+
+	defer metrics.ObserveReport(reportKind, time.Now())
+
 	reportRepo := s.deps.NewRepository(s.db)
 
 	// Получаем отчет из базы данных. В виде списка услуг за отчетный период period.
 	report, err := reportRepo.GetReport(ctx, period)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("get report: %v", err))
+		s.logger.Error(ctx, "get report", logfield.Error(err))
 		return "", fmt.Errorf("get report: %v", err)
 	}
 
 	// Преобразовываем полученный список в csv-файл в памяти.
 	var b bytes.Buffer
 	if err := writeToCsv(&b, report); err != nil {
-		s.logger.Error(fmt.Sprintf("write to csv: %v", err))
+		s.logger.Error(ctx, "write to csv", logfield.Error(err))
 		return "", fmt.Errorf("write to csv: %v", err)
 	}
 
 	reportName := fmt.Sprintf("report-%s-%s.csv", period, uuid.New())
 
 	// Кладем преобразованный файл в minio-бакет отчетов.
-	if _, err := s.minioClient.PutObject(
+	putCtx, putSpan := tracing.Start(
 		ctx,
+		"minio.PutObject",
+		attribute.String("minio.bucket", ReportsBucketName),
+		attribute.String("minio.object", reportName),
+		attribute.Int("minio.size", b.Len()),
+	)
+
+	_, err = s.minioClient.PutObject(
+		putCtx,
 		ReportsBucketName,
 		reportName,
 		&b,
 		int64(b.Len()),
 		minio.PutObjectOptions{ContentType: "text/csv"},
-	); err != nil {
-		s.logger.Error(fmt.Sprintf("put object to minio: %v", err))
+	)
+	tracing.End(putSpan, err)
+	metrics.ObserveUpload(ReportsBucketName, err)
+
+	if err != nil {
+		s.logger.Error(ctx, "put object to minio", logfield.Error(err))
 		return "", fmt.Errorf("put object to minio: %v", err)
 	}
 
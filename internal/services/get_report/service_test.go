@@ -68,7 +68,7 @@ func TestService_GetReport(t *testing.T) {
 		minioClient := mock.NewMockMinioClient(ctrl)
 
 		log := mock.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		deps := mock.NewMockdependencies(ctrl)
 		deps.EXPECT().NewRepository(gomock.Any()).Return(reportRepo)
@@ -97,7 +97,7 @@ func TestService_GetReport(t *testing.T) {
 			Return(minio.UploadInfo{}, testError)
 
 		log := mock.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		deps := mock.NewMockdependencies(ctrl)
 		deps.EXPECT().NewRepository(gomock.Any()).Return(reportRepo)
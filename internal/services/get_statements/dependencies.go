@@ -0,0 +1,17 @@
+package get_statements
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
+
+func (b *dependenciesImpl) NewStatementRepository(db postgres.Database) StatementRepository {
+	return repoStatement.New(db)
+}
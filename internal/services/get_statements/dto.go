@@ -0,0 +1,41 @@
+package get_statements
+
+import (
+	"time"
+
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+)
+
+// Statement - ежемесячная выписка пользователя. Балансы – средства кошелька (доступные и зарезервированные вместе)
+// на начало и конец месяца.
+type Statement struct {
+	Period         string
+	OpeningBalance int64
+	ClosingBalance int64
+	CreatedAt      time.Time
+}
+
+// Link - ссылка на выписку в хранилище.
+type Link struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+func adaptStatements(statements []repoStatement.Statement) []Statement {
+	result := make([]Statement, 0, len(statements))
+
+	for _, s := range statements {
+		result = append(result, adaptStatement(s))
+	}
+
+	return result
+}
+
+func adaptStatement(s repoStatement.Statement) Statement {
+	return Statement{
+		Period:         s.Period,
+		OpeningBalance: s.OpeningBalance,
+		ClosingBalance: s.ClosingBalance,
+		CreatedAt:      s.CreatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_get_statements is a generated GoMock package.
+package mock_get_statements
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	statement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	get_statements "github.com/frutonanny/wallet-service/internal/services/get_statements"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockWalletRepository is a mock of WalletRepository interface.
+type MockWalletRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRepositoryMockRecorder
+}
+
+// MockWalletRepositoryMockRecorder is the mock recorder for MockWalletRepository.
+type MockWalletRepositoryMockRecorder struct {
+	mock *MockWalletRepository
+}
+
+// NewMockWalletRepository creates a new mock instance.
+func NewMockWalletRepository(ctrl *gomock.Controller) *MockWalletRepository {
+	mock := &MockWalletRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExistWallet mocks base method.
+func (m *MockWalletRepository) ExistWallet(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistWallet", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistWallet indicates an expected call of ExistWallet.
+func (mr *MockWalletRepositoryMockRecorder) ExistWallet(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistWallet", reflect.TypeOf((*MockWalletRepository)(nil).ExistWallet), ctx, userID)
+}
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementRepository) GetStatement(ctx context.Context, walletID int64, period string) (statement.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, walletID, period)
+	ret0, _ := ret[0].(statement.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementRepositoryMockRecorder) GetStatement(ctx, walletID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementRepository)(nil).GetStatement), ctx, walletID, period)
+}
+
+// GetStatements mocks base method.
+func (m *MockStatementRepository) GetStatements(ctx context.Context, walletID int64) ([]statement.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatements", ctx, walletID)
+	ret0, _ := ret[0].([]statement.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatements indicates an expected call of GetStatements.
+func (mr *MockStatementRepositoryMockRecorder) GetStatements(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatements", reflect.TypeOf((*MockStatementRepository)(nil).GetStatements), ctx, walletID)
+}
+
+// MockPresigner is a mock of Presigner interface.
+type MockPresigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresignerMockRecorder
+}
+
+// MockPresignerMockRecorder is the mock recorder for MockPresigner.
+type MockPresignerMockRecorder struct {
+	mock *MockPresigner
+}
+
+// NewMockPresigner creates a new mock instance.
+func NewMockPresigner(ctrl *gomock.Controller) *MockPresigner {
+	mock := &MockPresigner{ctrl: ctrl}
+	mock.recorder = &MockPresignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresigner) EXPECT() *MockPresignerMockRecorder {
+	return m.recorder
+}
+
+// PresignedGetObject mocks base method.
+func (m *MockPresigner) PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignedGetObject", ctx, bucketName, objectName, expires, reqParams)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignedGetObject indicates an expected call of PresignedGetObject.
+func (mr *MockPresignerMockRecorder) PresignedGetObject(ctx, bucketName, objectName, expires, reqParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignedGetObject", reflect.TypeOf((*MockPresigner)(nil).PresignedGetObject), ctx, bucketName, objectName, expires, reqParams)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewStatementRepository mocks base method.
+func (m *Mockdependencies) NewStatementRepository(db postgres.Database) get_statements.StatementRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewStatementRepository", db)
+	ret0, _ := ret[0].(get_statements.StatementRepository)
+	return ret0
+}
+
+// NewStatementRepository indicates an expected call of NewStatementRepository.
+func (mr *MockdependenciesMockRecorder) NewStatementRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewStatementRepository", reflect.TypeOf((*Mockdependencies)(nil).NewStatementRepository), db)
+}
+
+// NewWalletRepository mocks base method.
+func (m *Mockdependencies) NewWalletRepository(db postgres.Database) get_statements.WalletRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWalletRepository", db)
+	ret0, _ := ret[0].(get_statements.WalletRepository)
+	return ret0
+}
+
+// NewWalletRepository indicates an expected call of NewWalletRepository.
+func (mr *MockdependenciesMockRecorder) NewWalletRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWalletRepository", reflect.TypeOf((*Mockdependencies)(nil).NewWalletRepository), db)
+}
@@ -0,0 +1,164 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package get_statements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/generate_statements"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+const (
+	// LinkTTL - время жизни ссылки на выписку в хранилище.
+	LinkTTL = time.Hour
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type StatementRepository interface {
+	GetStatements(ctx context.Context, walletID int64) ([]repoStatement.Statement, error)
+	GetStatement(ctx context.Context, walletID int64, period string) (repoStatement.Statement, error)
+}
+
+// Presigner подписывает ссылки на объекты хранилища. Ссылки отдаются наружу, поэтому подписываются
+// для публичного адреса minio.
+type Presigner interface {
+	PresignedGetObject(
+		ctx context.Context,
+		bucketName, objectName string,
+		expires time.Duration,
+		reqParams url.Values,
+	) (u *url.URL, err error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewStatementRepository(db postgres.Database) StatementRepository
+}
+
+type Service struct {
+	logger    logger
+	db        *sql.DB
+	presigner Presigner
+	deps      dependencies
+}
+
+func New(logger logger, db *sql.DB, presigner Presigner) *Service {
+	return &Service{
+		logger:    logger,
+		db:        db,
+		presigner: presigner,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// GetStatements отдает сформированные выписки пользователя от новых месяцев к старым.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+func (s *Service) GetStatements(ctx context.Context, userID int64) (_ []Statement, err error) {
+	ctx, span := tracing.Start(ctx, "get_statements.GetStatements")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletID, err := s.getWalletID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statementRepo := s.deps.NewStatementRepository(s.db)
+
+	statements, err := statementRepo.GetStatements(ctx, walletID)
+	if err != nil {
+		s.logger.Error(ctx, "get statements", logfield.Error(err))
+		return nil, fmt.Errorf("get statements: %w", err)
+	}
+
+	return adaptStatements(statements), nil
+}
+
+// GetStatement отдает выписку пользователя за месяц period (в формате YYYY-MM) и ссылку на нее в хранилище.
+// Ссылка действует LinkTTL.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - проверяем есть ли выписка за месяц, если нет, то отдаем ошибку ErrStatementNotFound.
+func (s *Service) GetStatement(ctx context.Context, userID int64, period string) (_ Statement, _ Link, err error) {
+	ctx, span := tracing.Start(ctx, "get_statements.GetStatement")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletID, err := s.getWalletID(ctx, userID)
+	if err != nil {
+		return Statement{}, Link{}, err
+	}
+
+	statementRepo := s.deps.NewStatementRepository(s.db)
+
+	statement, err := statementRepo.GetStatement(ctx, walletID, period)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoStatementNotFound) {
+			return Statement{}, Link{}, servicesErrors.ErrStatementNotFound
+		}
+
+		s.logger.Error(ctx, "get statement", logfield.Error(err))
+		return Statement{}, Link{}, fmt.Errorf("get statement: %w", err)
+	}
+
+	expiresAt := time.Now().Add(LinkTTL)
+
+	u, err := s.presigner.PresignedGetObject(
+		ctx,
+		generate_statements.StatementsBucketName,
+		statement.ObjectName,
+		LinkTTL,
+		nil,
+	)
+	if err != nil {
+		s.logger.Error(ctx, "presign object", logfield.Error(err))
+		return Statement{}, Link{}, fmt.Errorf("presign object: %v", err)
+	}
+
+	return adaptStatement(statement), Link{URL: u.String(), ExpiresAt: expiresAt}, nil
+}
+
+// getWalletID отдает кошелек пользователя.
+func (s *Service) getWalletID(ctx context.Context, userID int64) (int64, error) {
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return 0, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return 0, fmt.Errorf("exist wallet: %w", err)
+	}
+
+	return walletID, nil
+}
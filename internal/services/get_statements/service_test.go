@@ -0,0 +1,202 @@
+package get_statements_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/generate_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	mock "github.com/frutonanny/wallet-service/internal/services/get_statements/mock"
+)
+
+const (
+	testUserID     = int64(1)
+	testWalletID   = int64(1)
+	testPeriod     = "2022-11"
+	testObjectName = "2022-11/statement-1-2022-11.json"
+	testURL        = "http://localhost:9000/statements/2022-11/statement-1-2022-11.json?X-Amz-Signature=signature"
+)
+
+var (
+	testError     = errors.New("error")
+	testCreatedAt = time.Date(2022, 12, 1, 3, 0, 0, 0, time.UTC)
+
+	testStatements = []repoStatement.Statement{
+		{
+			WalletID:       testWalletID,
+			Period:         "2022-11",
+			ObjectName:     testObjectName,
+			OpeningBalance: 1000,
+			ClosingBalance: 2000,
+			CreatedAt:      testCreatedAt,
+		},
+		{
+			WalletID:       testWalletID,
+			Period:         "2022-10",
+			ObjectName:     "2022-10/statement-1-2022-10.json",
+			OpeningBalance: 0,
+			ClosingBalance: 1000,
+			CreatedAt:      testCreatedAt,
+		},
+	}
+)
+
+func TestService_GetStatements(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get statements successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().GetStatements(ctx, testWalletID).Return(testStatements, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_statements.New(log, db, nil).WithDependencies(deps)
+
+		statements, err := service.GetStatements(ctx, testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, []get_statements.Statement{
+			{Period: "2022-11", OpeningBalance: 1000, ClosingBalance: 2000, CreatedAt: testCreatedAt},
+			{Period: "2022-10", OpeningBalance: 0, ClosingBalance: 1000, CreatedAt: testCreatedAt},
+		}, statements)
+	})
+
+	t.Run("get statements failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(int64(0), repositories.ErrRepoWalletNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_statements.New(log, db, nil).WithDependencies(deps)
+
+		_, err := service.GetStatements(ctx, testUserID)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+}
+
+func TestService_GetStatement(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get statement successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().GetStatement(ctx, testWalletID, testPeriod).Return(testStatements[0], nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo)
+
+		u, err := url.Parse(testURL)
+		require.NoError(t, err)
+
+		presigner := mock.NewMockPresigner(ctrl)
+		presigner.EXPECT().
+			PresignedGetObject(ctx, generate_statements.StatementsBucketName, testObjectName, get_statements.LinkTTL, nil).
+			Return(u, nil)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_statements.New(log, db, presigner).WithDependencies(deps)
+
+		statement, link, err := service.GetStatement(ctx, testUserID, testPeriod)
+		require.NoError(t, err)
+		assert.Equal(t, testPeriod, statement.Period)
+		assert.Equal(t, int64(2000), statement.ClosingBalance)
+		assert.Equal(t, testURL, link.URL)
+		assert.WithinDuration(t, time.Now().Add(get_statements.LinkTTL), link.ExpiresAt, time.Minute)
+	})
+
+	t.Run("get statement failed, ErrStatementNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().
+			GetStatement(ctx, testWalletID, testPeriod).
+			Return(repoStatement.Statement{}, repositories.ErrRepoStatementNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := get_statements.New(log, db, nil).WithDependencies(deps)
+
+		_, _, err := service.GetStatement(ctx, testUserID, testPeriod)
+		assert.ErrorIs(t, err, servicesErrors.ErrStatementNotFound)
+	})
+
+	t.Run("get statement failed, presign error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		statementRepo := mock.NewMockStatementRepository(ctrl)
+		statementRepo.EXPECT().GetStatement(ctx, testWalletID, testPeriod).Return(testStatements[0], nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewStatementRepository(gomock.Any()).Return(statementRepo)
+
+		presigner := mock.NewMockPresigner(ctrl)
+		presigner.EXPECT().
+			PresignedGetObject(ctx, generate_statements.StatementsBucketName, testObjectName, get_statements.LinkTTL, nil).
+			Return(nil, testError)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_statements.New(log, db, presigner).WithDependencies(deps)
+
+		_, _, err := service.GetStatement(ctx, testUserID, testPeriod)
+		assert.Error(t, err)
+	})
+}
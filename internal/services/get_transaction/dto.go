@@ -0,0 +1,52 @@
+package get_transaction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+type Transaction struct {
+	ID          int64
+	Type        string
+	Description string
+	Amount      int64
+	OrderID     int64 // 0 для операций без заказа.
+	ServiceID   int64 // 0 для операций без заказа.
+	// BalanceAfter - баланс кошелька после операции. nil, если баланс неизвестен.
+	BalanceAfter *int64
+	CreatedAt    time.Time
+}
+
+// adaptTx преобразует транзакцию, полученную из базы, в транзакцию, которую отдает метод.
+func adaptTx(tx transaction.Transaction, locale i18n.Locale) (Transaction, error) {
+	// У зачислений и списаний без заказа нет номера заказа.
+	var orderID int64
+	if transactions.HasOrder(tx.Type) {
+		id, err := transactions.GetOrderID(tx.Payload)
+		if err != nil {
+			return Transaction{}, fmt.Errorf("get order id: %v", err)
+		}
+
+		orderID = id
+	}
+
+	var serviceID int64
+	if tx.ServiceID != nil {
+		serviceID = *tx.ServiceID
+	}
+
+	return Transaction{
+		ID:           tx.ID,
+		Type:         tx.Type,
+		Description:  i18n.TxDescription(locale, tx.Type, orderID, serviceID),
+		Amount:       tx.Amount,
+		OrderID:      orderID,
+		ServiceID:    serviceID,
+		BalanceAfter: tx.BalanceAfter,
+		CreatedAt:    tx.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,104 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package get_transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+}
+
+type TransactionRepository interface {
+	GetTransaction(ctx context.Context, walletID, txID int64) (transaction.Transaction, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+}
+
+type Service struct {
+	db     *sql.DB
+	logger logger
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		deps:   &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// GetTransaction отдает транзакцию пользователя по ее идентификатору.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - ищем транзакцию среди транзакций кошелька пользователя. Если ее нет, то отдаем ошибку ErrTransactionNotFound.
+// Транзакции других пользователей так же считаются ненайденными. Описание транзакции создается на языке locale.
+func (s *Service) GetTransaction(
+	ctx context.Context,
+	userID, txID int64,
+	locale i18n.Locale,
+) (_ Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "get_transaction.GetTransaction")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	// Проверяем есть ли кошелек у пользователя.
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			s.logger.Error(ctx, "wallet not found")
+			return Transaction{}, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
+		return Transaction{}, fmt.Errorf("exist wallet: %w", err)
+	}
+
+	txsRepo := s.deps.NewTransactionRepository(s.db)
+
+	tx, err := txsRepo.GetTransaction(ctx, walletID, txID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoTransactionNotFound) {
+			return Transaction{}, servicesErrors.ErrTransactionNotFound
+		}
+
+		s.logger.Error(ctx, "get transaction", logfield.Error(err))
+		return Transaction{}, fmt.Errorf("get transaction: %w", err)
+	}
+
+	result, err := adaptTx(tx, locale)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("adapt tx: %v", err)
+	}
+
+	return result, nil
+}
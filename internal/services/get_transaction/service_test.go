@@ -0,0 +1,158 @@
+package get_transaction_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
+	mock_get_transaction "github.com/frutonanny/wallet-service/internal/services/get_transaction/mock"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+)
+
+const (
+	testUserID    = int64(1)
+	testWalletID  = int64(1)
+	testTxID      = int64(10)
+	testOrderID   = int64(42)
+	testServiceID = int64(3)
+	testBalance   = int64(3000)
+)
+
+var testError = errors.New("error")
+
+func TestService_GetTransaction(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get transaction successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		payload, err := transactions.WriteOffPayload(testOrderID, testServiceID)
+		require.NoError(t, err)
+
+		serviceID := testServiceID
+		balance := testBalance
+		createdAt := time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+
+		repoWallet := mock_get_transaction.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		repoTx := mock_get_transaction.NewMockTransactionRepository(ctrl)
+		repoTx.EXPECT().GetTransaction(ctx, testWalletID, testTxID).Return(repoTxs.Transaction{
+			ID:           testTxID,
+			Type:         transactions.TypeWriteOff,
+			Payload:      payload,
+			Amount:       500,
+			ServiceID:    &serviceID,
+			BalanceAfter: &balance,
+			CreatedAt:    createdAt,
+		}, nil)
+
+		deps := mock_get_transaction.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTx)
+
+		log := mock_get_transaction.NewMocklogger(ctrl)
+
+		service := get_transaction.New(log, db).WithDependencies(deps)
+
+		tx, err := service.GetTransaction(ctx, testUserID, testTxID, i18n.En)
+		require.NoError(t, err)
+		assert.Equal(t, get_transaction.Transaction{
+			ID:           testTxID,
+			Type:         transactions.TypeWriteOff,
+			Description:  `Payment for order 42, service "XL listing"`,
+			Amount:       500,
+			OrderID:      testOrderID,
+			ServiceID:    testServiceID,
+			BalanceAfter: &balance,
+			CreatedAt:    createdAt,
+		}, tx)
+	})
+
+	t.Run("get transaction failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_transaction.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, repositories.ErrRepoWalletNotFound)
+
+		deps := mock_get_transaction.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+
+		log := mock_get_transaction.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_transaction.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetTransaction(ctx, testUserID, testTxID, i18n.Ru)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("get transaction failed, ErrTransactionNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_transaction.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		repoTx := mock_get_transaction.NewMockTransactionRepository(ctrl)
+		repoTx.
+			EXPECT().
+			GetTransaction(ctx, testWalletID, testTxID).
+			Return(repoTxs.Transaction{}, repositories.ErrRepoTransactionNotFound)
+
+		deps := mock_get_transaction.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTx)
+
+		log := mock_get_transaction.NewMocklogger(ctrl)
+
+		service := get_transaction.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetTransaction(ctx, testUserID, testTxID, i18n.Ru)
+		assert.ErrorIs(t, err, servicesErrors.ErrTransactionNotFound)
+	})
+
+	t.Run("get transaction failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_transaction.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		repoTx := mock_get_transaction.NewMockTransactionRepository(ctrl)
+		repoTx.EXPECT().GetTransaction(ctx, testWalletID, testTxID).Return(repoTxs.Transaction{}, testError)
+
+		deps := mock_get_transaction.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTx)
+
+		log := mock_get_transaction.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := get_transaction.New(log, db).WithDependencies(deps)
+
+		_, err := service.GetTransaction(ctx, testUserID, testTxID, i18n.Ru)
+		assert.Error(t, err)
+	})
+}
@@ -38,27 +38,37 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 }
 
 // Error mocks base method.
-func (m *Mocklogger) Error(msg string) {
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
 }
 
 // MockWalletRepository is a mock of WalletRepository interface.
@@ -123,18 +133,18 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 }
 
 // GetTransactions mocks base method.
-func (m *MockTransactionRepository) GetTransactions(ctx context.Context, walletID, limit, offset int64, sortBy transaction.SortBy, direction transaction.Direction) ([]transaction.Transaction, error) {
+func (m *MockTransactionRepository) GetTransactions(ctx context.Context, walletID, limit, offset int64, sortBy transaction.SortBy, direction transaction.Direction, filter transaction.Filter) ([]transaction.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactions", ctx, walletID, limit, offset, sortBy, direction)
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, walletID, limit, offset, sortBy, direction, filter)
 	ret0, _ := ret[0].([]transaction.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTransactions indicates an expected call of GetTransactions.
-func (mr *MockTransactionRepositoryMockRecorder) GetTransactions(ctx, walletID, limit, offset, sortBy, direction interface{}) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) GetTransactions(ctx, walletID, limit, offset, sortBy, direction, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactions), ctx, walletID, limit, offset, sortBy, direction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactions), ctx, walletID, limit, offset, sortBy, direction, filter)
 }
 
 // Mockdependencies is a mock of dependencies interface.
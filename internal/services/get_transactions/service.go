@@ -7,15 +7,18 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/frutonanny/wallet-service/internal/i18n"
+	"github.com/frutonanny/wallet-service/internal/logfield"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type WalletRepository interface {
@@ -28,6 +31,7 @@ type TransactionRepository interface {
 		walletID, limit, offset int64,
 		sortBy repoTxs.SortBy,
 		direction repoTxs.Direction,
+		filter repoTxs.Filter,
 	) ([]repoTxs.Transaction, error)
 }
 
@@ -59,37 +63,52 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 
 // GetTransactions отдает список транзакций пользователя, отсортированный по переданному параметру.
 // - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
-// - отдает список транзакций.
+// - отдает список транзакций, удовлетворяющих фильтру filter, с описаниями на языке locale.
 func (s *Service) GetTransactions(
 	ctx context.Context,
 	userID, limit, offset int64,
 	sortBy SortBy,
 	direction Direction,
-) ([]Transaction, error) {
+	filter Filter,
+	locale i18n.Locale,
+) (_ []Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "get_transactions.GetTransactions")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
 	walletRepo := s.deps.NewWalletRepository(s.db)
 
 	// Проверяем есть ли кошелек у пользователя.
 	walletID, err := walletRepo.ExistWallet(ctx, userID)
 	if err != nil {
 		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
-			s.logger.Error(fmt.Sprintf("for user %d wallet not found", userID))
+			s.logger.Error(ctx, "wallet not found")
 			return nil, servicesErrors.ErrWalletNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("exist wallet: %s", err))
+		s.logger.Error(ctx, "exist wallet", logfield.Error(err))
 		return nil, fmt.Errorf("exist wallet: %w", err)
 	}
 
 	txsRepo := s.deps.NewTransactionRepository(s.db)
 
 	// Отдаем список транзакций, отсортированный по переданному параметру.
-	txs, err := txsRepo.GetTransactions(ctx, walletID, limit, offset, adaptSortBy(sortBy), adaptDirection(direction))
+	txs, err := txsRepo.GetTransactions(
+		ctx,
+		walletID,
+		limit,
+		offset,
+		adaptSortBy(sortBy),
+		adaptDirection(direction),
+		adaptFilter(filter),
+	)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("get transactions: %s", err))
+		s.logger.Error(ctx, "get transactions", logfield.Error(err))
 		return nil, fmt.Errorf("get transactions: %w", err)
 	}
 
-	result, err := adaptTxs(txs)
+	result, err := adaptTxs(txs, locale)
 	if err != nil {
 		return nil, fmt.Errorf("adapt txs: %v", err)
 	}
@@ -10,11 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/frutonanny/wallet-service/internal/i18n"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 	"github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
 	mock_get_txs "github.com/frutonanny/wallet-service/internal/services/get_transactions/mock"
+	"github.com/frutonanny/wallet-service/internal/transactions"
 )
 
 const (
@@ -22,6 +24,7 @@ const (
 	testWalletID = int64(1)
 	testLimit    = int64(1)
 	testOffset   = int64(1)
+	testOrderID  = int64(42)
 )
 
 var testError = errors.New("error")
@@ -41,7 +44,7 @@ func TestService_GetTransactions(t *testing.T) {
 		repoTxs := mock_get_txs.NewMockTransactionRepository(ctrl)
 		repoTxs.
 			EXPECT().
-			GetTransactions(ctx, testWalletID, testLimit, testOffset, transaction.Amount, transaction.Desc).
+			GetTransactions(ctx, testWalletID, testLimit, testOffset, transaction.Amount, transaction.Desc, transaction.Filter{}).
 			Return(nil, nil)
 
 		deps := mock_get_txs.NewMockdependencies(ctrl)
@@ -57,7 +60,53 @@ func TestService_GetTransactions(t *testing.T) {
 			testLimit,
 			testOffset,
 			get_transactions.Amount,
-			get_transactions.Desc)
+			get_transactions.Desc,
+			get_transactions.Filter{},
+			i18n.Ru)
+		assert.NoError(t, err)
+	})
+
+	t.Run("get transaction with filter successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repoWallet := mock_get_txs.NewMockWalletRepository(ctrl)
+		repoWallet.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+
+		// Фильтр передается в репозиторий без изменений.
+		repoTxs := mock_get_txs.NewMockTransactionRepository(ctrl)
+		repoTxs.
+			EXPECT().
+			GetTransactions(ctx, testWalletID, testLimit, testOffset, transaction.Amount, transaction.Desc,
+				transaction.Filter{
+					Types:      []string{transactions.TypeWriteOff},
+					AmountFrom: 100000,
+					OrderID:    testOrderID,
+				}).
+			Return(nil, nil)
+
+		deps := mock_get_txs.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTxs)
+
+		log := mock_get_txs.NewMocklogger(ctrl)
+
+		server := get_transactions.New(log, db).WithDependencies(deps)
+		_, err := server.GetTransactions(
+			ctx,
+			testUserID,
+			testLimit,
+			testOffset,
+			get_transactions.Amount,
+			get_transactions.Desc,
+			get_transactions.Filter{
+				Types:      []string{transactions.TypeWriteOff},
+				AmountFrom: 100000,
+				OrderID:    testOrderID,
+			},
+			i18n.Ru)
 		assert.NoError(t, err)
 	})
 
@@ -74,7 +123,7 @@ func TestService_GetTransactions(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		server := get_transactions.New(log, db).WithDependencies(deps)
 		_, err := server.GetTransactions(
@@ -83,7 +132,9 @@ func TestService_GetTransactions(t *testing.T) {
 			testLimit,
 			testOffset,
 			get_transactions.Amount,
-			get_transactions.Desc)
+			get_transactions.Desc,
+			get_transactions.Filter{},
+			i18n.Ru)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
 	})
@@ -101,7 +152,7 @@ func TestService_GetTransactions(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		server := get_transactions.New(log, db).WithDependencies(deps)
 		_, err := server.GetTransactions(
@@ -110,7 +161,9 @@ func TestService_GetTransactions(t *testing.T) {
 			testLimit,
 			testOffset,
 			get_transactions.Amount,
-			get_transactions.Desc)
+			get_transactions.Desc,
+			get_transactions.Filter{},
+			i18n.Ru)
 		assert.Error(t, err)
 	})
 
@@ -126,7 +179,7 @@ func TestService_GetTransactions(t *testing.T) {
 		repoTxs := mock_get_txs.NewMockTransactionRepository(ctrl)
 		repoTxs.
 			EXPECT().
-			GetTransactions(ctx, testWalletID, testLimit, testOffset, transaction.Amount, transaction.Desc).
+			GetTransactions(ctx, testWalletID, testLimit, testOffset, transaction.Amount, transaction.Desc, transaction.Filter{}).
 			Return(nil, testError)
 
 		deps := mock_get_txs.NewMockdependencies(ctrl)
@@ -134,7 +187,7 @@ func TestService_GetTransactions(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTxs)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		server := get_transactions.New(log, db).WithDependencies(deps)
 		_, err := server.GetTransactions(
@@ -143,7 +196,9 @@ func TestService_GetTransactions(t *testing.T) {
 			testLimit,
 			testOffset,
 			get_transactions.Amount,
-			get_transactions.Desc)
+			get_transactions.Desc,
+			get_transactions.Filter{},
+			i18n.Ru)
 		assert.Error(t, err)
 	})
 
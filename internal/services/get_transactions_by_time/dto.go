@@ -4,22 +4,30 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/frutonanny/wallet-service/internal/i18n"
 	"github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	"github.com/frutonanny/wallet-service/internal/transactions"
 )
 
 type Transaction struct {
+	ID          int64
+	Type        string
 	Description string
 	Amount      int64
-	CreatedAt   time.Time
+	OrderID     int64 // 0 для операций без заказа.
+	ServiceID   int64 // 0 для операций без заказа.
+	// BalanceAfter - баланс кошелька после операции. nil, если баланс неизвестен.
+	BalanceAfter *int64
+	CreatedAt    time.Time
 }
 
 // adaptTxs преобразует список транзакций, полученный из базы, в список транзакций, который отдает метод.
-func adaptTxs(txs []transaction.Transaction) ([]Transaction, error) {
+// Описания транзакций создаются на языке locale.
+func adaptTxs(txs []transaction.Transaction, locale i18n.Locale) ([]Transaction, error) {
 	result := make([]Transaction, 0, len(txs))
 
 	for i := range txs {
-		tx, err := adaptTx(txs[i])
+		tx, err := adaptTx(txs[i], locale)
 		if err != nil {
 			return nil, fmt.Errorf("adapt tx: %v", err)
 		}
@@ -30,40 +38,51 @@ func adaptTxs(txs []transaction.Transaction) ([]Transaction, error) {
 	return result, nil
 }
 
-func adaptTx(tx transaction.Transaction) (Transaction, error) {
-	desc, err := getTxDescription(tx.Type, tx.Payload)
-	if err != nil {
-		return Transaction{}, fmt.Errorf("get tx description: %v", err)
+func adaptTx(tx transaction.Transaction, locale i18n.Locale) (Transaction, error) {
+	// У зачислений и списаний без заказа нет номера заказа.
+	var orderID int64
+	if transactions.HasOrder(tx.Type) {
+		id, err := transactions.GetOrderID(tx.Payload)
+		if err != nil {
+			return Transaction{}, fmt.Errorf("get order id: %v", err)
+		}
+
+		orderID = id
+	}
+
+	var serviceID int64
+	if tx.ServiceID != nil {
+		serviceID = *tx.ServiceID
 	}
 
 	return Transaction{
-		Description: desc,
-		Amount:      tx.Amount,
-		CreatedAt:   tx.CreatedAt,
+		ID:           tx.ID,
+		Type:         tx.Type,
+		Description:  i18n.TxDescription(locale, tx.Type, orderID, serviceID),
+		Amount:       tx.Amount,
+		OrderID:      orderID,
+		ServiceID:    serviceID,
+		BalanceAfter: tx.BalanceAfter,
+		CreatedAt:    tx.CreatedAt,
 	}, nil
 }
 
-// getTxDescription() - создает описание транзакции в зависимости от полученного типа.
-func getTxDescription(txType string, payload []byte) (string, error) {
-	if txType == transactions.TypeAdd {
-		return "Зачисление средств", nil
-	}
-
-	orderID, err := transactions.GetOrderID(payload)
-	if err != nil {
-		return "", fmt.Errorf("get order id: %v", err)
-	}
+// Filter - условия отбора транзакций. Нулевое значение поля означает, что условие по нему не накладывается.
+// Types - типы транзакций (transactions.TypeAdd, transactions.TypeReserve и т.д.).
+type Filter struct {
+	Types      []string
+	AmountFrom int64
+	AmountTo   int64
+	OrderID    int64
+	ServiceID  int64
+}
 
-	switch txType {
-	case transactions.TypeReserve:
-		return fmt.Sprintf("Резервирование средств по заказу %d", orderID), nil
-	case transactions.TypeWriteOff:
-		return fmt.Sprintf("Списание средств по заказу %d", orderID), nil
-	case transactions.TypeCancel:
-		return fmt.Sprintf("Отмена резервирования средств по заказу %d", orderID), nil
-	default:
-		// Сознательно не возвращаем ошибку, чтобы не блокировать показ всех остальных транзакций,
-		// если такое случится.
-		return "Неизвестный тип транзакции", nil
+func adaptFilter(f Filter) transaction.Filter {
+	return transaction.Filter{
+		Types:      f.Types,
+		AmountFrom: f.AmountFrom,
+		AmountTo:   f.AmountTo,
+		OrderID:    f.OrderID,
+		ServiceID:  f.ServiceID,
 	}
 }
@@ -39,27 +39,37 @@ func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
 }
 
 // Error mocks base method.
-func (m *Mocklogger) Error(msg string) {
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Error", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
 }
 
 // Error indicates an expected call of Error.
-func (mr *MockloggerMockRecorder) Error(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
 }
 
 // Info mocks base method.
-func (m *Mocklogger) Info(msg string) {
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Info", msg)
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
 // Info indicates an expected call of Info.
-func (mr *MockloggerMockRecorder) Info(msg interface{}) *gomock.Call {
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), msg)
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
 }
 
 // MockWalletRepository is a mock of WalletRepository interface.
@@ -124,18 +134,18 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 }
 
 // GetTransactionsByTime mocks base method.
-func (m *MockTransactionRepository) GetTransactionsByTime(ctx context.Context, walletID int64, timeStart, timeEnd time.Time) ([]transaction.Transaction, error) {
+func (m *MockTransactionRepository) GetTransactionsByTime(ctx context.Context, walletID int64, timeStart, timeEnd time.Time, filter transaction.Filter) ([]transaction.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionsByTime", ctx, walletID, timeStart, timeEnd)
+	ret := m.ctrl.Call(m, "GetTransactionsByTime", ctx, walletID, timeStart, timeEnd, filter)
 	ret0, _ := ret[0].([]transaction.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTransactionsByTime indicates an expected call of GetTransactionsByTime.
-func (mr *MockTransactionRepositoryMockRecorder) GetTransactionsByTime(ctx, walletID, timeStart, timeEnd interface{}) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) GetTransactionsByTime(ctx, walletID, timeStart, timeEnd, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByTime", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactionsByTime), ctx, walletID, timeStart, timeEnd)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByTime", reflect.TypeOf((*MockTransactionRepository)(nil).GetTransactionsByTime), ctx, walletID, timeStart, timeEnd, filter)
 }
 
 // Mockdependencies is a mock of dependencies interface.
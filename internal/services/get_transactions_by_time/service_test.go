@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/frutonanny/wallet-service/internal/i18n"
 	"github.com/frutonanny/wallet-service/internal/repositories"
+	"github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
 	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
 	mock_get_txs "github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time/mock"
@@ -45,7 +47,7 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 		repoTxs := mock_get_txs.NewMockTransactionRepository(ctrl)
 		repoTxs.
 			EXPECT().
-			GetTransactionsByTime(ctx, testWalletID, testStart, testEnd).
+			GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, transaction.Filter{}).
 			Return(nil, nil)
 
 		deps := mock_get_txs.NewMockdependencies(ctrl)
@@ -56,7 +58,7 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 
 		service := get_transactions_by_time.New(log, db).WithDependencies(deps)
 
-		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd)
+		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, get_transactions_by_time.Filter{}, i18n.Ru)
 		assert.NoError(t, err)
 	})
 
@@ -73,11 +75,11 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := get_transactions_by_time.New(log, db).WithDependencies(deps)
 
-		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd)
+		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, get_transactions_by_time.Filter{}, i18n.Ru)
 		require.Error(t, err)
 		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
 	})
@@ -95,11 +97,11 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(repoWallet)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := get_transactions_by_time.New(log, db).WithDependencies(deps)
 
-		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd)
+		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, get_transactions_by_time.Filter{}, i18n.Ru)
 		assert.Error(t, err)
 	})
 
@@ -115,7 +117,7 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 		repoTxs := mock_get_txs.NewMockTransactionRepository(ctrl)
 		repoTxs.
 			EXPECT().
-			GetTransactionsByTime(ctx, testWalletID, testStart, testEnd).
+			GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, transaction.Filter{}).
 			Return(nil, testError)
 
 		deps := mock_get_txs.NewMockdependencies(ctrl)
@@ -123,11 +125,11 @@ func TestService_GetTransactionsByTime(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(repoTxs)
 
 		log := mock_get_txs.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := get_transactions_by_time.New(log, db).WithDependencies(deps)
 
-		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd)
+		_, err := service.GetTransactionsByTime(ctx, testWalletID, testStart, testEnd, get_transactions_by_time.Filter{}, i18n.Ru)
 		assert.Error(t, err)
 	})
 }
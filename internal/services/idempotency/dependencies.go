@@ -0,0 +1,12 @@
+package idempotency
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewIdempotencyRepository(db postgres.Database) IdempotencyRepository {
+	return repoIdempotency.New(db)
+}
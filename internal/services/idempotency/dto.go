@@ -0,0 +1,8 @@
+package idempotency
+
+// Response - сохраненный ответ на запрос с ключом идемпотентности.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
@@ -0,0 +1,132 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+const (
+	// keyTTL - сколько хранится ответ на запрос с ключом идемпотентности. Позже ключ можно использовать заново.
+	keyTTL = 24 * time.Hour
+
+	// keyLease - сколько занят ключ запроса без ответа. Если сервис упал посреди запроса и не освободил ключ,
+	// повтор с тем же ключом выполнит запрос заново после этого времени, а не через keyTTL.
+	keyLease = time.Minute
+
+	// WriteTimeout - сколько ждать сохранения ответа или освобождения ключа. Они не зависят от контекста
+	// запроса, но зависшая база не должна держать запрос бесконечно.
+	WriteTimeout = 5 * time.Second
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type IdempotencyRepository interface {
+	Reserve(
+		ctx context.Context,
+		clientKeyID int64,
+		key, path, requestHash string,
+		ttl, lease time.Duration,
+	) (bool, repoIdempotency.Key, error)
+	Complete(
+		ctx context.Context,
+		clientKeyID int64,
+		key, path string,
+		statusCode int,
+		contentType string,
+		response []byte,
+	) error
+	Release(ctx context.Context, clientKeyID int64, key, path string) error
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewIdempotencyRepository(db postgres.Database) IdempotencyRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Begin занимает ключ key клиента clientKeyID под запрос path с телом, хеш которого requestHash. Ключи разных
+// клиентов не пересекаются.
+// - если ключ свободен, то занимаем его и отдаем nil – запрос нужно выполнить и затем вызвать Complete или Release.
+// - если запрос с этим ключом уже выполнен, то отдаем сохраненный ответ.
+// - если ключ использован для запроса с другим телом, то отдаем ошибку ErrIdempotencyKeyReused.
+// - если запрос с этим ключом еще выполняется, то отдаем ошибку ErrRequestInProgress. Ключ без ответа старше
+// keyLease считается брошенным и занимается заново.
+func (s *Service) Begin(ctx context.Context, clientKeyID int64, key, path, requestHash string) (*Response, error) {
+	reserved, k, err := s.deps.NewIdempotencyRepository(s.db).
+		Reserve(ctx, clientKeyID, key, path, requestHash, keyTTL, keyLease)
+	if err != nil {
+		s.logger.Error(ctx, "reserve", logfield.Error(err))
+		return nil, fmt.Errorf("reserve: %v", err)
+	}
+
+	if reserved {
+		return nil, nil
+	}
+
+	if k.RequestHash != requestHash {
+		return nil, servicesErrors.ErrIdempotencyKeyReused
+	}
+
+	if !k.Completed {
+		return nil, servicesErrors.ErrRequestInProgress
+	}
+
+	return &Response{
+		StatusCode:  k.StatusCode,
+		ContentType: k.ContentType,
+		Body:        k.Response,
+	}, nil
+}
+
+// Complete сохраняет ответ на запрос, повторы с тем же ключом получат его.
+func (s *Service) Complete(ctx context.Context, clientKeyID int64, key, path string, resp Response) error {
+	err := s.deps.NewIdempotencyRepository(s.db).
+		Complete(ctx, clientKeyID, key, path, resp.StatusCode, resp.ContentType, resp.Body)
+	if err != nil {
+		s.logger.Error(ctx, "complete", logfield.Error(err))
+		return fmt.Errorf("complete: %v", err)
+	}
+
+	return nil
+}
+
+// Release освобождает ключ запроса, который не удалось выполнить, чтобы повтор выполнил его заново.
+func (s *Service) Release(ctx context.Context, clientKeyID int64, key, path string) error {
+	if err := s.deps.NewIdempotencyRepository(s.db).Release(ctx, clientKeyID, key, path); err != nil {
+		s.logger.Error(ctx, "release", logfield.Error(err))
+		return fmt.Errorf("release: %v", err)
+	}
+
+	return nil
+}
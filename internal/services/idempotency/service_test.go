@@ -0,0 +1,177 @@
+package idempotency_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	mock "github.com/frutonanny/wallet-service/internal/services/idempotency/mock"
+)
+
+const (
+	testClientKeyID = int64(1)
+	testKey         = "key"
+	testPath        = "/v1/reserve"
+	testHash        = "hash"
+)
+
+var testError = errors.New("error")
+
+func TestService_Begin(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("key reserved, request must be executed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Reserve(ctx, testClientKeyID, testKey, testPath, testHash, gomock.Any(), gomock.Any()).Return(true, repoIdempotency.Key{}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		resp, err := service.Begin(ctx, testClientKeyID, testKey, testPath, testHash)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("request completed, saved response replayed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Reserve(ctx, testClientKeyID, testKey, testPath, testHash, gomock.Any(), gomock.Any()).Return(false, repoIdempotency.Key{
+			RequestHash: testHash,
+			Completed:   true,
+			StatusCode:  200,
+			ContentType: "application/json",
+			Response:    []byte(`{}`),
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		resp, err := service.Begin(ctx, testClientKeyID, testKey, testPath, testHash)
+		require.NoError(t, err)
+		assert.Equal(t, &idempotency.Response{
+			StatusCode:  200,
+			ContentType: "application/json",
+			Body:        []byte(`{}`),
+		}, resp)
+	})
+
+	t.Run("begin failed, ErrIdempotencyKeyReused", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Reserve(ctx, testClientKeyID, testKey, testPath, testHash, gomock.Any(), gomock.Any()).Return(false, repoIdempotency.Key{
+			RequestHash: "another hash",
+			Completed:   true,
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		_, err := service.Begin(ctx, testClientKeyID, testKey, testPath, testHash)
+		assert.ErrorIs(t, err, servicesErrors.ErrIdempotencyKeyReused)
+	})
+
+	t.Run("begin failed, ErrRequestInProgress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Reserve(ctx, testClientKeyID, testKey, testPath, testHash, gomock.Any(), gomock.Any()).Return(false, repoIdempotency.Key{
+			RequestHash: testHash,
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		_, err := service.Begin(ctx, testClientKeyID, testKey, testPath, testHash)
+		assert.ErrorIs(t, err, servicesErrors.ErrRequestInProgress)
+	})
+
+	t.Run("begin failed, reserve error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Reserve(ctx, testClientKeyID, testKey, testPath, testHash, gomock.Any(), gomock.Any()).
+			Return(false, repoIdempotency.Key{}, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		_, err := service.Begin(ctx, testClientKeyID, testKey, testPath, testHash)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_Complete(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("complete successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockIdempotencyRepository(ctrl)
+		repo.EXPECT().Complete(ctx, testClientKeyID, testKey, testPath, 200, "application/json", []byte(`{}`)).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewIdempotencyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := idempotency.New(log, db).WithDependencies(deps)
+
+		err := service.Complete(ctx, testClientKeyID, testKey, testPath, idempotency.Response{
+			StatusCode:  200,
+			ContentType: "application/json",
+			Body:        []byte(`{}`),
+		})
+		require.NoError(t, err)
+	})
+}
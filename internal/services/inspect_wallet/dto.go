@@ -0,0 +1,50 @@
+package inspect_wallet
+
+import (
+	"time"
+
+	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+// Wallet - кошелек пользователя. Все суммы в копейках.
+type Wallet struct {
+	UserID      int64   `json:"userID"`
+	WalletID    int64   `json:"walletID"`
+	Balance     int64   `json:"balance"`     // Доступные средства.
+	Reservation int64   `json:"reservation"` // Зарезервированные средства.
+	Orders      []Order `json:"orders"`
+}
+
+// Order - позиция заказа кошелька.
+type Order struct {
+	OrderID   int64     `json:"orderID"`
+	ServiceID int64     `json:"serviceID"`
+	Status    string    `json:"status"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func adaptWallet(b repoWallet.Balance, orders []repoOrder.Order) Wallet {
+	result := Wallet{
+		UserID:      b.UserID,
+		WalletID:    b.WalletID,
+		Balance:     b.Balance,
+		Reservation: b.Reservation,
+		Orders:      make([]Order, 0, len(orders)),
+	}
+
+	for _, o := range orders {
+		result.Orders = append(result.Orders, Order{
+			OrderID:   o.ExternalID,
+			ServiceID: o.ServiceID,
+			Status:    o.Status,
+			Amount:    o.Amount,
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		})
+	}
+
+	return result
+}
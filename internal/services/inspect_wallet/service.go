@@ -0,0 +1,90 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package inspect_wallet
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	GetBalances(ctx context.Context, userIDs []int64) ([]repoWallet.Balance, error)
+}
+
+type OrderRepository interface {
+	GetOrders(ctx context.Context, walletID, limit int64) ([]repoOrder.Order, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewOrderRepository(db postgres.Database) OrderRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		deps:   &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Inspect - отдает кошелек пользователя для разбора оператором: остатки и до limit последних позиций заказов.
+// - если у пользователя нет кошелька, то возвращаем ошибку ErrWalletNotFound;
+// - получаем позиции заказов кошелька от новых к старым.
+func (s *Service) Inspect(ctx context.Context, userID, limit int64) (_ Wallet, err error) {
+	ctx, span := tracing.Start(ctx, "inspect_wallet.Inspect")
+	defer func() { tracing.End(span, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID))
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	// Получаем остатки кошелька.
+	balances, err := walletRepo.GetBalances(ctx, []int64{userID})
+	if err != nil {
+		s.logger.Error(ctx, "get balances", logfield.Error(err))
+		return Wallet{}, fmt.Errorf("get balances: %w", err)
+	}
+
+	if len(balances) == 0 {
+		return Wallet{}, servicesErrors.ErrWalletNotFound
+	}
+
+	b := balances[0]
+
+	orderRepo := s.deps.NewOrderRepository(s.db)
+
+	// Получаем последние позиции заказов.
+	orders, err := orderRepo.GetOrders(ctx, b.WalletID, limit)
+	if err != nil {
+		s.logger.Error(ctx, "get orders", logfield.Error(err))
+		return Wallet{}, fmt.Errorf("get orders: %w", err)
+	}
+
+	return adaptWallet(b, orders), nil
+}
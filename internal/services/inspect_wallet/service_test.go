@@ -0,0 +1,132 @@
+package inspect_wallet_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/orders"
+	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/inspect_wallet"
+	mock "github.com/frutonanny/wallet-service/internal/services/inspect_wallet/mock"
+)
+
+const (
+	testUserID      = int64(1)
+	testWalletID    = int64(3)
+	testBalance     = int64(10_000)
+	testReservation = int64(1_000)
+	testLimit       = int64(20)
+)
+
+var (
+	testError     = errors.New("error")
+	testCreatedAt = time.Date(2022, 11, 2, 12, 0, 0, 0, time.UTC)
+	testUpdatedAt = time.Date(2022, 11, 3, 12, 0, 0, 0, time.UTC)
+)
+
+func TestService_Inspect(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("inspect wallet successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return([]repoWallet.Balance{
+			{WalletID: testWalletID, UserID: testUserID, Balance: testBalance, Reservation: testReservation},
+		}, nil)
+
+		orderRepo := mock.NewMockOrderRepository(ctrl)
+		orderRepo.EXPECT().GetOrders(ctx, testWalletID, testLimit).Return([]repoOrder.Order{
+			{
+				ExternalID: 10,
+				ServiceID:  1,
+				Status:     orders.StatusReserved,
+				Amount:     1_000,
+				CreatedAt:  testCreatedAt,
+				UpdatedAt:  testUpdatedAt,
+			},
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		service := inspect_wallet.New(mock.NewMocklogger(ctrl), db).WithDependencies(deps)
+
+		wallet, err := service.Inspect(ctx, testUserID, testLimit)
+		require.NoError(t, err)
+		assert.Equal(t, inspect_wallet.Wallet{
+			UserID:      testUserID,
+			WalletID:    testWalletID,
+			Balance:     testBalance,
+			Reservation: testReservation,
+			Orders: []inspect_wallet.Order{
+				{
+					OrderID:   10,
+					ServiceID: 1,
+					Status:    orders.StatusReserved,
+					Amount:    1_000,
+					CreatedAt: testCreatedAt,
+					UpdatedAt: testUpdatedAt,
+				},
+			},
+		}, wallet)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return(nil, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		service := inspect_wallet.New(mock.NewMocklogger(ctrl), db).WithDependencies(deps)
+
+		_, err := service.Inspect(ctx, testUserID, testLimit)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("get orders failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetBalances(ctx, []int64{testUserID}).Return([]repoWallet.Balance{
+			{WalletID: testWalletID, UserID: testUserID},
+		}, nil)
+
+		orderRepo := mock.NewMockOrderRepository(ctrl)
+		orderRepo.EXPECT().GetOrders(ctx, testWalletID, testLimit).Return(nil, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := inspect_wallet.New(log, db).WithDependencies(deps)
+
+		_, err := service.Inspect(ctx, testUserID, testLimit)
+		assert.ErrorIs(t, err, testError)
+	})
+}
@@ -0,0 +1,12 @@
+package manage_api_keys
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoAPIKey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewAPIKeyRepository(db postgres.Database) APIKeyRepository {
+	return repoAPIKey.New(db)
+}
@@ -0,0 +1,17 @@
+package manage_api_keys
+
+import "time"
+
+// NewKey - выданный ключ. Key показывается только при выдаче.
+type NewKey struct {
+	ID  int64
+	Key string
+}
+
+type Key struct {
+	ID        int64
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
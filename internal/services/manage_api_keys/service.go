@@ -0,0 +1,141 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package manage_api_keys
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoAPIKey "github.com/frutonanny/wallet-service/internal/repositories/apikey"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+const (
+	// keyLength - длина API-ключа в байтах.
+	keyLength = 32
+
+	// keyPrefix - по префиксу ключ легко узнать в конфигурации и найти сканером секретов.
+	keyPrefix = "wk_"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type APIKeyRepository interface {
+	CreateKey(ctx context.Context, name, keyHash string, scopes []string) (int64, error)
+	GetKeys(ctx context.Context) ([]repoAPIKey.Key, error)
+	RevokeKey(ctx context.Context, id int64) error
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewAPIKeyRepository(db postgres.Database) APIKeyRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// CreateKey выдает клиенту name ключ с правами scopes. Ключ показывается только здесь, в базе хранится его хеш.
+// Если среди прав есть неизвестное, то отдаем ошибку ErrUnknownScope.
+func (s *Service) CreateKey(ctx context.Context, name string, scopes []string) (NewKey, error) {
+	for _, scope := range scopes {
+		if !auth.IsScope(scope) {
+			return NewKey{}, fmt.Errorf("%w: %s", servicesErrors.ErrUnknownScope, scope)
+		}
+	}
+
+	key, err := newKey()
+	if err != nil {
+		s.logger.Error(ctx, "new key", logfield.Error(err))
+		return NewKey{}, fmt.Errorf("new key: %v", err)
+	}
+
+	id, err := s.deps.NewAPIKeyRepository(s.db).CreateKey(ctx, name, auth.HashKey(key), scopes)
+	if err != nil {
+		s.logger.Error(ctx, "create key", logfield.Error(err))
+		return NewKey{}, fmt.Errorf("create key: %v", err)
+	}
+
+	s.logger.Info(ctx, "api key created", "key_id", id, "name", name)
+
+	return NewKey{
+		ID:  id,
+		Key: key,
+	}, nil
+}
+
+// GetKeys отдает все выданные ключи, включая отозванные. Самих ключей среди них нет.
+func (s *Service) GetKeys(ctx context.Context) ([]Key, error) {
+	keys, err := s.deps.NewAPIKeyRepository(s.db).GetKeys(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "get keys", logfield.Error(err))
+		return nil, fmt.Errorf("get keys: %v", err)
+	}
+
+	result := make([]Key, 0, len(keys))
+
+	for _, k := range keys {
+		result = append(result, Key{
+			ID:        k.ID,
+			Name:      k.Name,
+			Scopes:    k.Scopes,
+			CreatedAt: k.CreatedAt,
+			RevokedAt: k.RevokedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// RevokeKey отзывает ключ. Если неотозванного ключа с таким идентификатором нет, то отдаем ошибку ErrAPIKeyNotFound.
+func (s *Service) RevokeKey(ctx context.Context, id int64) error {
+	if err := s.deps.NewAPIKeyRepository(s.db).RevokeKey(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrRepoAPIKeyNotFound) {
+			return servicesErrors.ErrAPIKeyNotFound
+		}
+
+		s.logger.Error(ctx, "revoke key", logfield.Error(err))
+		return fmt.Errorf("revoke key: %v", err)
+	}
+
+	s.logger.Info(ctx, "api key revoked", "key_id", id)
+
+	return nil
+}
+
+func newKey() (string, error) {
+	b := make([]byte, keyLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("read random: %v", err)
+	}
+
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
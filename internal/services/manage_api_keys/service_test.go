@@ -0,0 +1,136 @@
+package manage_api_keys_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/manage_api_keys"
+	mock "github.com/frutonanny/wallet-service/internal/services/manage_api_keys/mock"
+)
+
+var testError = errors.New("error")
+
+func TestService_CreateKey(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("create key successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		scopes := []string{auth.ScopeReserve, auth.ScopeWriteOff}
+
+		var savedHash string
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().CreateKey(ctx, "orders", gomock.Any(), scopes).
+			DoAndReturn(func(_ context.Context, _, keyHash string, _ []string) (int64, error) {
+				savedHash = keyHash
+				return 1, nil
+			})
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_api_keys.New(log, db).WithDependencies(deps)
+
+		key, err := service.CreateKey(ctx, "orders", scopes)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), key.ID)
+		assert.True(t, strings.HasPrefix(key.Key, "wk_"))
+		// В базу попадает только хеш ключа.
+		assert.Equal(t, auth.HashKey(key.Key), savedHash)
+	})
+
+	t.Run("create key failed, ErrUnknownScope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := manage_api_keys.New(log, db).WithDependencies(deps)
+
+		_, err := service.CreateKey(context.Background(), "orders", []string{auth.ScopeReserve, "everything"})
+		assert.ErrorIs(t, err, servicesErrors.ErrUnknownScope)
+	})
+
+	t.Run("create key failed, repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().CreateKey(ctx, "payments", gomock.Any(), []string{auth.ScopeAdd}).Return(int64(0), testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_api_keys.New(log, db).WithDependencies(deps)
+
+		_, err := service.CreateKey(ctx, "payments", []string{auth.ScopeAdd})
+		assert.Error(t, err)
+	})
+}
+
+func TestService_RevokeKey(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("revoke key successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().RevokeKey(ctx, int64(1)).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_api_keys.New(log, db).WithDependencies(deps)
+
+		require.NoError(t, service.RevokeKey(ctx, 1))
+	})
+
+	t.Run("revoke key failed, ErrAPIKeyNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockAPIKeyRepository(ctrl)
+		repo.EXPECT().RevokeKey(ctx, int64(1)).Return(repositories.ErrRepoAPIKeyNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewAPIKeyRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := manage_api_keys.New(log, db).WithDependencies(deps)
+
+		err := service.RevokeKey(ctx, 1)
+		assert.ErrorIs(t, err, servicesErrors.ErrAPIKeyNotFound)
+	})
+}
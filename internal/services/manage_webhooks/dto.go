@@ -0,0 +1,20 @@
+package manage_webhooks
+
+import "time"
+
+type Subscription struct {
+	ID     int64
+	Secret string
+}
+
+// Delivery - доставка, попытки которой исчерпаны.
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	URL            string
+	EventID        int64
+	EventType      string
+	Attempts       int
+	LastError      string
+	FailedAt       time.Time
+}
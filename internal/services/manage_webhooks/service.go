@@ -0,0 +1,167 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package manage_webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+	"github.com/frutonanny/wallet-service/internal/webhookclient"
+)
+
+const (
+	// secretLength - длина секрета подписки в байтах.
+	secretLength = 32
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WebhookRepository interface {
+	AddSubscription(ctx context.Context, url string, eventTypes []string, secret string) (int64, error)
+	GetDeadDeliveries(ctx context.Context, afterID int64, limit int) ([]repoWebhook.Delivery, error)
+	Replay(ctx context.Context, deliveryID int64) error
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWebhookRepository(db postgres.Database) WebhookRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// AddSubscription добавляет подписку на события переданных типов (пустой список – на все события) и отдает
+// ее вместе со сгенерированным секретом. Секрет показывается только здесь, подписчик проверяет им подпись запросов.
+// Если адрес не абсолютный http(s)-адрес или явно ведет во внутреннюю сеть (localhost, внутренний ip-адрес), то отдаем
+// ошибку ErrInvalidWebhookURL. Имена, которые разрешаются во внутренние адреса, не пропустит клиент доставки.
+func (s *Service) AddSubscription(ctx context.Context, rawURL string, eventTypes []string) (_ Subscription, err error) {
+	ctx, span := tracing.Start(ctx, "manage_webhooks.AddSubscription")
+	defer func() { tracing.End(span, err) }()
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || internalHost(u.Hostname()) {
+		return Subscription{}, servicesErrors.ErrInvalidWebhookURL
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		s.logger.Error(ctx, "new secret", logfield.Error(err))
+		return Subscription{}, fmt.Errorf("new secret: %v", err)
+	}
+
+	id, err := s.deps.NewWebhookRepository(s.db).AddSubscription(ctx, rawURL, eventTypes, secret)
+	if err != nil {
+		s.logger.Error(ctx, "add subscription", logfield.Error(err))
+		return Subscription{}, fmt.Errorf("add subscription: %v", err)
+	}
+
+	s.logger.Info(ctx, "webhook subscription added", "subscription_id", id)
+
+	return Subscription{
+		ID:     id,
+		Secret: secret,
+	}, nil
+}
+
+// GetFailedDeliveries отдает не больше limit доставок, попытки которых исчерпаны, начиная со следующей после afterID.
+func (s *Service) GetFailedDeliveries(ctx context.Context, afterID int64, limit int) (_ []Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "manage_webhooks.GetFailedDeliveries")
+	defer func() { tracing.End(span, err) }()
+
+	deliveries, err := s.deps.NewWebhookRepository(s.db).GetDeadDeliveries(ctx, afterID, limit)
+	if err != nil {
+		s.logger.Error(ctx, "get dead deliveries", logfield.Error(err))
+		return nil, fmt.Errorf("get dead deliveries: %v", err)
+	}
+
+	result := make([]Delivery, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		result = append(result, Delivery{
+			ID:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			URL:            d.URL,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Attempts:       d.Attempts,
+			LastError:      d.LastError,
+			FailedAt:       d.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// ReplayDelivery возвращает доставку в очередь с обнуленным счетчиком попыток.
+// Если доставки в статусе dead нет, то отдаем ошибку ErrDeliveryNotFound.
+func (s *Service) ReplayDelivery(ctx context.Context, deliveryID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "manage_webhooks.ReplayDelivery")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.deps.NewWebhookRepository(s.db).Replay(ctx, deliveryID); err != nil {
+		if errors.Is(err, repositories.ErrRepoDeliveryNotFound) {
+			return servicesErrors.ErrDeliveryNotFound
+		}
+
+		s.logger.Error(ctx, "replay", logfield.Error(err))
+		return fmt.Errorf("replay: %v", err)
+	}
+
+	s.logger.Info(ctx, "delivery replayed", "delivery_id", deliveryID)
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretLength)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("read random: %v", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// internalHost - ведет ли имя или адрес host во внутреннюю сеть без разрешения имени.
+func internalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && !webhookclient.Allowed(ip)
+}
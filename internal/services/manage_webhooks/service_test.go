@@ -0,0 +1,190 @@
+package manage_webhooks_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	mock "github.com/frutonanny/wallet-service/internal/services/manage_webhooks/mock"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	testURL        = "https://partner.example/webhooks"
+	testDeliveryID = int64(10)
+)
+
+var testError = errors.New("error")
+
+func TestService_AddSubscription(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("add subscription successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		eventTypes := []string{events.TypeWriteOff}
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().AddSubscription(ctx, testURL, eventTypes, gomock.Any()).Return(int64(1), nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		subscription, err := service.AddSubscription(ctx, testURL, eventTypes)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), subscription.ID)
+		assert.Len(t, subscription.Secret, 64)
+	})
+
+	t.Run("add subscription failed, ErrInvalidWebhookURL", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		for _, u := range []string{
+			"ftp://partner.example",
+			"/webhooks",
+			"https://",
+			"http://localhost:8080/hook",
+			"http://127.0.0.1/hook",
+			"http://[::1]/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"https://10.0.0.5/hook",
+		} {
+			_, err := service.AddSubscription(ctx, u, nil)
+			assert.ErrorIs(t, err, servicesErrors.ErrInvalidWebhookURL, u)
+		}
+	})
+}
+
+func TestService_GetFailedDeliveries(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("get failed deliveries successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		failedAt := time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().GetDeadDeliveries(ctx, int64(0), 100).Return([]repoWebhook.Delivery{{
+			ID:             testDeliveryID,
+			SubscriptionID: 1,
+			URL:            testURL,
+			EventID:        5,
+			EventType:      events.TypeCancel,
+			Attempts:       8,
+			LastError:      "timeout",
+			UpdatedAt:      failedAt,
+		}}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		deliveries, err := service.GetFailedDeliveries(ctx, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []manage_webhooks.Delivery{{
+			ID:             testDeliveryID,
+			SubscriptionID: 1,
+			URL:            testURL,
+			EventID:        5,
+			EventType:      events.TypeCancel,
+			Attempts:       8,
+			LastError:      "timeout",
+			FailedAt:       failedAt,
+		}}, deliveries)
+	})
+}
+
+func TestService_ReplayDelivery(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("replay delivery successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Replay(ctx, testDeliveryID).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		require.NoError(t, service.ReplayDelivery(ctx, testDeliveryID))
+	})
+
+	t.Run("replay delivery failed, ErrDeliveryNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Replay(ctx, testDeliveryID).Return(repositories.ErrRepoDeliveryNotFound)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		assert.ErrorIs(t, service.ReplayDelivery(ctx, testDeliveryID), servicesErrors.ErrDeliveryNotFound)
+	})
+
+	t.Run("replay delivery failed, repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		webhookRepo := mock.NewMockWebhookRepository(ctrl)
+		webhookRepo.EXPECT().Replay(ctx, testDeliveryID).Return(testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWebhookRepository(gomock.Any()).Return(webhookRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := manage_webhooks.New(log, db).WithDependencies(deps)
+
+		assert.Error(t, service.ReplayDelivery(ctx, testDeliveryID))
+	})
+}
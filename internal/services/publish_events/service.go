@@ -0,0 +1,143 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package publish_events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	// eventsBatchSize - сколько событий за раз публикуется.
+	eventsBatchSize = 100
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type OutboxRepository interface {
+	GetUnpublished(ctx context.Context, limit int) ([]events.Event, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+}
+
+// Sink - получатель событий: брокер сообщений, файл и т.п.
+type Sink interface {
+	Publish(ctx context.Context, batch []events.Event) error
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewOutboxRepository(db postgres.Database) OutboxRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	sink   Sink
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB, sink Sink) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		sink:   sink,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Run публикует события из outbox, пока не отменен ctx. Когда публиковать нечего или публикация не удалась,
+// ждет pollInterval и пробует снова.
+func (s *Service) Run(ctx context.Context, pollInterval time.Duration) error {
+	s.logger.Info(ctx, "events relay started")
+
+	for {
+		published, err := s.PublishBatch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "publish batch", logfield.Error(err))
+		}
+
+		// Пачка была полной – скорее всего, в outbox есть еще события, забираем их без паузы.
+		if err == nil && published == eventsBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info(ctx, "events relay stopped")
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// PublishBatch публикует очередную пачку событий и отдает их количество.
+// - в транзакции берем пачку неопубликованных событий, блокируя их от других релеев;
+// - отдаем события получателю;
+// - отмечаем события опубликованными и фиксируем транзакцию.
+//
+// Если релей упадет между публикацией и фиксацией, события будут опубликованы повторно, поэтому доставка – хотя бы
+// один раз, и потребители отбрасывают повторы по идентификатору события.
+func (s *Service) PublishBatch(ctx context.Context) (int, error) {
+	// Стартуем транзакцию.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	unpublished, err := outboxRepo.GetUnpublished(ctx, eventsBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("get unpublished: %w", err)
+	}
+
+	if len(unpublished) == 0 {
+		return 0, nil
+	}
+
+	if err := s.sink.Publish(ctx, unpublished); err != nil {
+		return 0, fmt.Errorf("publish: %w", err)
+	}
+
+	ids := make([]int64, 0, len(unpublished))
+	for _, e := range unpublished {
+		ids = append(ids, e.ID)
+	}
+
+	if err := outboxRepo.MarkPublished(ctx, ids); err != nil {
+		return 0, fmt.Errorf("mark published: %w", err)
+	}
+
+	// Завершаем транзакцию.
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %v", err)
+	}
+
+	return len(unpublished), nil
+}
@@ -0,0 +1,120 @@
+package publish_events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/services/publish_events"
+	mock "github.com/frutonanny/wallet-service/internal/services/publish_events/mock"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+var (
+	testError = errors.New("error")
+
+	testEvents = []events.Event{
+		{ID: 1, Type: events.TypeIncomingTransfer, Version: events.Version},
+		{ID: 2, Type: events.TypeReservation, Version: events.Version},
+	}
+)
+
+func TestService_PublishBatch(t *testing.T) {
+	t.Run("publish batch successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mockDB.ExpectBegin()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetUnpublished(ctx, gomock.Any()).Return(testEvents, nil)
+		outboxRepo.EXPECT().MarkPublished(ctx, []int64{1, 2}).Return(nil)
+
+		sink := mock.NewMockSink(ctrl)
+		sink.EXPECT().Publish(ctx, testEvents).Return(nil)
+
+		mockDB.ExpectCommit()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := publish_events.New(log, db, sink).WithDependencies(deps)
+
+		published, err := service.PublishBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, published)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to publish", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mockDB.ExpectBegin()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetUnpublished(ctx, gomock.Any()).Return(nil, nil)
+
+		mockDB.ExpectRollback()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		sink := mock.NewMockSink(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := publish_events.New(log, db, sink).WithDependencies(deps)
+
+		published, err := service.PublishBatch(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, published)
+	})
+
+	t.Run("publish failed, events stay unpublished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mockDB, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mockDB.ExpectBegin()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetUnpublished(ctx, gomock.Any()).Return(testEvents, nil)
+
+		sink := mock.NewMockSink(ctrl)
+		sink.EXPECT().Publish(ctx, testEvents).Return(testError)
+
+		mockDB.ExpectRollback()
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := publish_events.New(log, db, sink).WithDependencies(deps)
+
+		_, err = service.PublishBatch(ctx)
+		assert.ErrorIs(t, err, testError)
+		assert.NoError(t, mockDB.ExpectationsWereMet())
+	})
+}
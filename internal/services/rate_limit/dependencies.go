@@ -0,0 +1,12 @@
+package rate_limit
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoRateLimit "github.com/frutonanny/wallet-service/internal/repositories/ratelimit"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewRateLimitRepository(db postgres.Database) RateLimitRepository {
+	return repoRateLimit.New(db)
+}
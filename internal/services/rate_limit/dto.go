@@ -0,0 +1,13 @@
+package rate_limit
+
+// Limit - корзина токенов: пополняется на Rate запросов в секунду и вмещает не больше Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limits - ограничения операции на клиента и на пользователя. nil – без ограничения.
+type Limits struct {
+	Client *Limit
+	User   *Limit
+}
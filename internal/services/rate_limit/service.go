@@ -0,0 +1,127 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package rate_limit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+const (
+	// bucketIdleTTL - корзины, которые не использовались дольше, удаляются. К этому времени любая корзина
+	// с разумными ограничениями уже полная.
+	bucketIdleTTL = time.Hour
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type RateLimitRepository interface {
+	Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error)
+	DeleteIdle(ctx context.Context, idle time.Duration) (int64, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewRateLimitRepository(db postgres.Database) RateLimitRepository
+}
+
+type Service struct {
+	logger     logger
+	db         *sql.DB
+	deps       dependencies
+	operations map[string]Limits
+	defaults   Limits
+}
+
+// New создает сервис ограничений. operations - ограничения по операциям из internal/operations, defaults -
+// для остальных операций.
+func New(logger logger, db *sql.DB, operations map[string]Limits, defaults Limits) *Service {
+	return &Service{
+		logger:     logger,
+		db:         db,
+		operations: operations,
+		defaults:   defaults,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Allow расходует по запросу из корзин клиента clientID и пользователя userID (nil – запрос не относится
+// к пользователю) для операции operation. Корзины операции общие для всех транспортов. Если одна из корзин пуста,
+// то отдаем ошибку ErrRateLimited и время, через которое запрос можно повторить.
+// Если хранилище корзин недоступно, то запрос пропускается: ограничение не должно останавливать сервис.
+func (s *Service) Allow(ctx context.Context, operation string, clientID int64, userID *int64) (time.Duration, error) {
+	limits, ok := s.operations[operation]
+	if !ok {
+		limits = s.defaults
+	}
+
+	if limits.Client != nil {
+		key := fmt.Sprintf("client:%d:%s", clientID, operation)
+
+		if wait, err := s.take(ctx, key, *limits.Client); err != nil {
+			return wait, err
+		}
+	}
+
+	if limits.User != nil && userID != nil {
+		key := fmt.Sprintf("user:%d:%s", *userID, operation)
+
+		if wait, err := s.take(ctx, key, *limits.User); err != nil {
+			return wait, err
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *Service) take(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	taken, wait, err := s.deps.NewRateLimitRepository(s.db).Take(ctx, key, limit.Rate, limit.Burst)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "take", "bucket", key, logfield.Error(err))
+		}
+
+		return 0, nil
+	}
+
+	if !taken {
+		return wait, servicesErrors.ErrRateLimited
+	}
+
+	return 0, nil
+}
+
+// Run раз в interval удаляет корзины, которые давно не использовались, пока не завершится ctx.
+func (s *Service) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, err := s.deps.NewRateLimitRepository(s.db).DeleteIdle(ctx, bucketIdleTTL); err != nil &&
+			!errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "delete idle buckets", logfield.Error(err))
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package rate_limit_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/operations"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/rate_limit"
+	mock "github.com/frutonanny/wallet-service/internal/services/rate_limit/mock"
+)
+
+const (
+	testOperation = operations.GetTransactions
+	testClientID  = int64(1)
+	testUserID    = int64(7)
+)
+
+var (
+	testError = errors.New("error")
+
+	testLimits = map[string]rate_limit.Limits{
+		testOperation: {
+			Client: &rate_limit.Limit{Rate: 20, Burst: 40},
+			User:   &rate_limit.Limit{Rate: 2, Burst: 5},
+		},
+	}
+	testDefaults = rate_limit.Limits{
+		Client: &rate_limit.Limit{Rate: 100, Burst: 200},
+	}
+)
+
+func TestService_Allow(t *testing.T) {
+	var db *sql.DB
+
+	userID := testUserID
+
+	t.Run("allowed by client and user buckets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRateLimitRepository(ctrl)
+		repo.EXPECT().Take(ctx, "client:1:getTransactions", float64(20), 40).Return(true, time.Duration(0), nil)
+		repo.EXPECT().Take(ctx, "user:7:getTransactions", float64(2), 5).Return(true, time.Duration(0), nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRateLimitRepository(gomock.Any()).Return(repo).Times(2)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := rate_limit.New(log, db, testLimits, testDefaults).WithDependencies(deps)
+
+		_, err := service.Allow(ctx, testOperation, testClientID, &userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("user bucket is empty, ErrRateLimited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRateLimitRepository(ctrl)
+		repo.EXPECT().Take(ctx, "client:1:getTransactions", float64(20), 40).Return(true, time.Duration(0), nil)
+		repo.EXPECT().Take(ctx, "user:7:getTransactions", float64(2), 5).Return(false, 300*time.Millisecond, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRateLimitRepository(gomock.Any()).Return(repo).Times(2)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := rate_limit.New(log, db, testLimits, testDefaults).WithDependencies(deps)
+
+		wait, err := service.Allow(ctx, testOperation, testClientID, &userID)
+		assert.ErrorIs(t, err, servicesErrors.ErrRateLimited)
+		assert.Equal(t, 300*time.Millisecond, wait)
+	})
+
+	t.Run("defaults for operation without limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRateLimitRepository(ctrl)
+		repo.EXPECT().Take(ctx, "client:1:add", float64(100), 200).Return(true, time.Duration(0), nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRateLimitRepository(gomock.Any()).Return(repo)
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := rate_limit.New(log, db, testLimits, testDefaults).WithDependencies(deps)
+
+		_, err := service.Allow(ctx, operations.Add, testClientID, &userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("no limits, repository is not used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := rate_limit.New(log, db, nil, rate_limit.Limits{}).WithDependencies(deps)
+
+		_, err := service.Allow(context.Background(), testOperation, testClientID, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("repository error, request is allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		repo := mock.NewMockRateLimitRepository(ctrl)
+		repo.EXPECT().Take(ctx, "client:1:getTransactions", float64(20), 40).
+			Return(false, time.Duration(0), testError)
+		repo.EXPECT().Take(ctx, "user:7:getTransactions", float64(2), 5).
+			Return(false, time.Duration(0), testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewRateLimitRepository(gomock.Any()).Return(repo).Times(2)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+		service := rate_limit.New(log, db, testLimits, testDefaults).WithDependencies(deps)
+
+		_, err := service.Allow(ctx, testOperation, testClientID, &userID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,12 @@
+package reconcile
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
@@ -0,0 +1,33 @@
+package reconcile
+
+import repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+
+// Report - результат сверки: сколько кошельков проверено и какие из них расходятся с журналом.
+type Report struct {
+	Checked    int        `json:"checked"`
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+// Mismatch - кошелек, остатки которого расходятся с журналом транзакций и заказами. Все суммы в копейках.
+type Mismatch struct {
+	UserID              int64 `json:"userID"`
+	WalletID            int64 `json:"walletID"`
+	Balance             int64 `json:"balance"`
+	ExpectedBalance     int64 `json:"expectedBalance"`
+	Reservation         int64 `json:"reservation"`
+	ExpectedReservation int64 `json:"expectedReservation"`
+}
+
+// check сравнивает остатки кошелька с журналом. ok = false, если они расходятся.
+func check(l repoWallet.Ledger) (Mismatch, bool) {
+	m := Mismatch{
+		UserID:              l.UserID,
+		WalletID:            l.WalletID,
+		Balance:             l.Balance,
+		ExpectedBalance:     l.Funds - l.Reserved,
+		Reservation:         l.Reservation,
+		ExpectedReservation: l.Reserved,
+	}
+
+	return m, m.Balance == m.ExpectedBalance && m.Reservation == m.ExpectedReservation
+}
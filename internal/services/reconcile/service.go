@@ -0,0 +1,97 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+const (
+	// walletsBatchSize - сколько кошельков за раз берется из базы.
+	walletsBatchSize = 500
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	GetLedgers(ctx context.Context, afterWalletID, limit int64) ([]repoWallet.Ledger, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Reconcile сверяет остатки всех кошельков с журналом транзакций и заказами и отдает расхождения. Сверка только
+// читает данные: расхождение исправляется корректировкой.
+// - резерв кошелька должен равняться сумме открытых резервов по заказам;
+// - доступные средства – средствам по журналу транзакций за вычетом открытых резервов.
+//
+// Кошельки берутся пачками по возрастанию идентификатора, каждая пачка – одним запросом.
+func (s *Service) Reconcile(ctx context.Context) (_ Report, err error) {
+	ctx, span := tracing.Start(ctx, "reconcile.Reconcile")
+	defer func() { tracing.End(span, err) }()
+
+	walletRepo := s.deps.NewWalletRepository(s.db)
+
+	report := Report{
+		Mismatches: []Mismatch{},
+	}
+
+	var afterWalletID int64
+
+	for {
+		ledgers, err := walletRepo.GetLedgers(ctx, afterWalletID, walletsBatchSize)
+		if err != nil {
+			s.logger.Error(ctx, "get ledgers", logfield.Error(err))
+			return Report{}, fmt.Errorf("get ledgers: %w", err)
+		}
+
+		for _, l := range ledgers {
+			report.Checked++
+			afterWalletID = l.WalletID
+
+			if m, ok := check(l); !ok {
+				report.Mismatches = append(report.Mismatches, m)
+			}
+		}
+
+		if len(ledgers) < walletsBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info(ctx, "wallets reconciled", "checked", report.Checked, "mismatches", len(report.Mismatches))
+
+	return report, nil
+}
@@ -0,0 +1,110 @@
+package reconcile_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+	"github.com/frutonanny/wallet-service/internal/services/reconcile"
+	mock "github.com/frutonanny/wallet-service/internal/services/reconcile/mock"
+)
+
+var testError = errors.New("error")
+
+func TestService_Reconcile(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("reconcile successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetLedgers(ctx, int64(0), gomock.Any()).Return([]repoWallet.Ledger{
+			// Сходится: 5000 по журналу, из них 2000 в резерве.
+			{WalletID: 1, UserID: 10, Balance: 3000, Reservation: 2000, Funds: 5000, Reserved: 2000},
+			// Баланс исправлен в обход журнала.
+			{WalletID: 2, UserID: 20, Balance: 900, Reservation: 0, Funds: 700, Reserved: 0},
+			// Резерв не сходится с заказами.
+			{WalletID: 3, UserID: 30, Balance: 0, Reservation: 100, Funds: 100, Reserved: 0},
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := reconcile.New(log, db).WithDependencies(deps)
+
+		report, err := service.Reconcile(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Report{
+			Checked: 3,
+			Mismatches: []reconcile.Mismatch{
+				{UserID: 20, WalletID: 2, Balance: 900, ExpectedBalance: 700},
+				{UserID: 30, WalletID: 3, Balance: 0, ExpectedBalance: 100, Reservation: 100},
+			},
+		}, report)
+	})
+
+	t.Run("reconcile several batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		// Полная пачка – значит, за ней может быть следующая.
+		var batch []repoWallet.Ledger
+		for id := int64(1); id <= 500; id++ {
+			batch = append(batch, repoWallet.Ledger{WalletID: id, UserID: id})
+		}
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetLedgers(ctx, int64(0), int64(500)).Return(batch, nil)
+		walletRepo.EXPECT().GetLedgers(ctx, int64(500), int64(500)).Return([]repoWallet.Ledger{
+			{WalletID: 501, UserID: 501},
+		}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := reconcile.New(log, db).WithDependencies(deps)
+
+		report, err := service.Reconcile(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 501, report.Checked)
+		assert.Empty(t, report.Mismatches)
+	})
+
+	t.Run("reconcile failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		walletRepo := mock.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().GetLedgers(ctx, int64(0), gomock.Any()).Return(nil, testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := reconcile.New(log, db).WithDependencies(deps)
+
+		_, err := service.Reconcile(ctx)
+		assert.ErrorIs(t, err, testError)
+	})
+}
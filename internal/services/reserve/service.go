@@ -6,17 +6,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type WalletRepository interface {
@@ -30,7 +36,17 @@ type OrderRepository interface {
 }
 
 type TransactionRepository interface {
-	AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount int64) (int64, error)
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
+}
+
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
 }
 
 // dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
@@ -39,8 +55,12 @@ type dependencies interface {
 	NewWalletRepository(db postgres.Database) WalletRepository
 	NewOrderRepository(db postgres.Database) OrderRepository
 	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
 }
 
+// operation - имя операции в метриках.
+const operation = "reserve"
+
 type Service struct {
 	db     *sql.DB
 	logger logger
@@ -64,15 +84,25 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 // - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
 // - проверяем достаточно ли средств у пользователя, если нет, то возвращаем ошибку ErrNotEnoughCash.
 // - списываем переданную сумму с баланса пользователя и добавляем эту сумму в резерв.
-// - создаем заказ со статусом "reservation".
+// - создаем заказ со статусом "reservation". Если у заказа уже есть позиция с этой услугой, то возвращаем ошибку
+// ErrOrderExists.
 // - добавляем транзакцию о созданном заказе;
 // - добавляем транзакцию о зарезервированных средствах;
+// - добавляем событие о резервировании в outbox;
 // - в ответ отдаем обновленный баланс пользователя в копейках, без учета зерезервированных денег.
-func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, price int64) (int64, error) {
+func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, price int64) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "reserve.Reserve")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.OrderID(externalID), logfield.Amount(price))
+
 	// Стартуем транзакцию.
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("begin tx: %s", err))
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
 		return 0, fmt.Errorf("begin tx: %v", err)
 	}
 
@@ -82,7 +112,7 @@ func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, pr
 				return
 			}
 
-			s.logger.Error(fmt.Sprintf("rollback: %s", err))
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
 		}
 	}()
 
@@ -95,10 +125,12 @@ func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, pr
 			return 0, servicesErrors.ErrWalletNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("wallet not exist: %s", err))
+		s.logger.Error(ctx, "wallet not exist", logfield.Error(err))
 		return 0, fmt.Errorf("wallet not exist: %v", err)
 	}
 
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
 	// Списываем переданную сумму с баланса пользователя и добавляем эту сумму в резерв.
 	// Одновременно проверяем достаточно ли средств у пользователя.
 	balance, err := walletRepo.Reserve(ctx, walletID, price)
@@ -107,7 +139,7 @@ func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, pr
 			return 0, servicesErrors.ErrNotEnoughCash
 		}
 
-		s.logger.Error(fmt.Sprintf("reserve: %s", err))
+		s.logger.Error(ctx, "reserve", logfield.Error(err))
 		return 0, fmt.Errorf("reserve: %v", err)
 	}
 
@@ -116,38 +148,63 @@ func (s *Service) Reserve(ctx context.Context, userID, serviceID, externalID, pr
 	// Создаем заказ со статусом "reservation".
 	orderID, err := orderRepo.CreateOrder(ctx, walletID, externalID, serviceID, price, orders.StatusReserved)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("create order: %s", err))
+		if errors.Is(err, repositories.ErrRepoOrderExists) {
+			return 0, servicesErrors.ErrOrderExists
+		}
+
+		s.logger.Error(ctx, "create order", logfield.Error(err))
 		return 0, fmt.Errorf("create order: %v", err)
 	}
 
 	// Добавляем транзакцию о созданном заказе
-	if _, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusReserved); err != nil {
-		s.logger.Error(fmt.Sprintf("add order transaction: %s", err))
+	orderTxID, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusReserved)
+	if err != nil {
+		s.logger.Error(ctx, "add order transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add order transaction: %v", err)
 	}
 
+	audit.AddOrderTransaction(ctx, orderTxID)
+
 	// Генерируем payload.
-	payload, err := transactions.ReservationPayload(externalID)
+	payload, err := transactions.ReservationPayload(externalID, serviceID)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("generated payload: %s", err))
+		s.logger.Error(ctx, "generated payload", logfield.Error(err))
 		return 0, fmt.Errorf("generated payload: %v", err)
 	}
 
 	txsRepo := s.deps.NewTransactionRepository(tx)
 
 	// Добавляем транзакцию о зарезервированных средствах
-	if _, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeReserve, payload, price); err != nil {
-		s.logger.Error(fmt.Sprintf("add transaction: %s", err))
+	txID, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeReserve, payload, price, balance)
+	if err != nil {
+		s.logger.Error(ctx, "add transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add transaction: %v", err)
 	}
 
+	audit.AddTransaction(ctx, txID)
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Добавляем событие о резервировании.
+	if _, err := outboxRepo.AddEvent(ctx, events.TypeReservation, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        price,
+		Balance:       balance,
+		OrderID:       externalID,
+		ServiceID:     serviceID,
+	}); err != nil {
+		s.logger.Error(ctx, "add event", logfield.Error(err))
+		return 0, fmt.Errorf("add event: %v", err)
+	}
+
 	// Завершаем транзакцию.
-	if err := tx.Commit(); err != nil {
-		s.logger.Error(fmt.Sprintf("commit tx: %s", err))
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
 		return 0, fmt.Errorf("commit tx: %v", err)
 	}
 
-	s.logger.Info(fmt.Sprintf("cash reserved for wallet: %d", walletID))
+	s.logger.Info(ctx, "cash reserved")
 
 	return balance, nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/frutonanny/wallet-service/internal/services/reserve"
 	mock_reserve "github.com/frutonanny/wallet-service/internal/services/reserve/mock"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 const (
@@ -23,6 +24,7 @@ const (
 	testWalletID   = int64(1)
 	testOrderID    = int64(1)
 	testTxID       = int64(0)
+	testEventID    = int64(1)
 	testExternalID = int64(1)
 	testServiceID  = int64(1)
 	testAmount     = int64(1_000)
@@ -56,18 +58,29 @@ func TestService_Reserve(t *testing.T) {
 
 		txRepo := mock_reserve.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, nil)
 
+		outboxRepo := mock_reserve.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeReservation, events.WalletData{
+			UserID:        testUserID,
+			TransactionID: testTxID,
+			Amount:        testAmount,
+			Balance:       testBalance,
+			OrderID:       testExternalID,
+			ServiceID:     testServiceID,
+		}).Return(testEventID, nil)
+
 		mock.ExpectCommit()
 
 		deps := mock_reserve.NewMockdependencies(ctrl)
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
 
 		log := mock_reserve.NewMocklogger(ctrl)
-		log.EXPECT().Info(gomock.Any())
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := reserve.New(log, db).WithDependencies(deps)
 
@@ -124,7 +137,7 @@ func TestService_Reserve(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_reserve.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := reserve.New(log, db).WithDependencies(deps)
 
@@ -132,6 +145,42 @@ func TestService_Reserve(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("reservation cash failed, ErrOrderExists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_reserve.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Reserve(ctx, testWalletID, testAmount).Return(testBalance, nil)
+
+		orderRepo := mock_reserve.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID, testAmount, orders.StatusReserved).
+			Return(int64(0), repositories.ErrRepoOrderExists)
+
+		mock.ExpectRollback()
+
+		deps := mock_reserve.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+
+		log := mock_reserve.NewMocklogger(ctrl)
+
+		service := reserve.New(log, db).WithDependencies(deps)
+
+		_, err = service.Reserve(ctx, testUserID, testServiceID, testExternalID, testAmount)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrOrderExists)
+	})
+
 	t.Run("reservation cash failed, reserved error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -153,7 +202,7 @@ func TestService_Reserve(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_reserve.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := reserve.New(log, db).WithDependencies(deps)
 
@@ -190,7 +239,7 @@ func TestService_Reserve(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_reserve.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := reserve.New(log, db).WithDependencies(deps)
 
@@ -222,7 +271,7 @@ func TestService_Reserve(t *testing.T) {
 
 		txRepo := mock_reserve.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, testError)
 
 		mock.ExpectRollback()
@@ -233,7 +282,7 @@ func TestService_Reserve(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
 
 		log := mock_reserve.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := reserve.New(log, db).WithDependencies(deps)
 
@@ -0,0 +1,27 @@
+package reserve_cart
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewWalletRepository(db postgres.Database) WalletRepository {
+	return repoWallet.New(db)
+}
+
+func (b *dependenciesImpl) NewOrderRepository(db postgres.Database) OrderRepository {
+	return repoOrder.New(db)
+}
+
+func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) TransactionRepository {
+	return repoTxs.New(db)
+}
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
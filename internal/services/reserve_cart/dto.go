@@ -0,0 +1,33 @@
+package reserve_cart
+
+// Item - позиция корзины: услуга и ее стоимость в копейках.
+type Item struct {
+	ServiceID int64
+	Price     int64
+}
+
+// total считает общую стоимость корзины.
+func total(items []Item) int64 {
+	var sum int64
+
+	for i := range items {
+		sum += items[i].Price
+	}
+
+	return sum
+}
+
+// hasDuplicates проверяет, есть ли в корзине несколько позиций с одной и той же услугой.
+func hasDuplicates(items []Item) bool {
+	seen := make(map[int64]struct{}, len(items))
+
+	for i := range items {
+		if _, ok := seen[items[i].ServiceID]; ok {
+			return true
+		}
+
+		seen[items[i].ServiceID] = struct{}{}
+	}
+
+	return false
+}
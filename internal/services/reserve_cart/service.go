@@ -0,0 +1,240 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package reserve_cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
+	"github.com/frutonanny/wallet-service/internal/orders"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type WalletRepository interface {
+	ExistWallet(ctx context.Context, userID int64) (int64, error)
+	Reserve(ctx context.Context, walletID, cash int64) (int64, error)
+}
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, walletID, externalID, serviceID, amount int64, status string) (int64, error)
+	AddOrderTransactions(ctx context.Context, orderID int64, nameType string) (int64, error)
+}
+
+type TransactionRepository interface {
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
+}
+
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewWalletRepository(db postgres.Database) WalletRepository
+	NewOrderRepository(db postgres.Database) OrderRepository
+	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
+}
+
+// operation - имя операции в метриках.
+const operation = "reserve_cart"
+
+type Service struct {
+	db     *sql.DB
+	logger logger
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+		deps:   &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// ReserveCart - резервирует средства у пользователя сразу под все позиции корзины одного заказа.
+// Резервирование происходит по принципу "все или ничего": если средств не хватает хотя бы на одну позицию,
+// то не резервируется ничего.
+// - проверяем, что в корзине нет повторяющихся услуг, иначе возвращаем ошибку ErrDuplicateService.
+// - проверяем есть ли кошелек у пользователя, если нет, то отдаем ошибку ErrWalletNotFound.
+// - списываем общую стоимость корзины с баланса пользователя и добавляем ее в резерв. Если средств недостаточно,
+// то возвращаем ошибку ErrNotEnoughCash.
+// - для каждой позиции создаем заказ со статусом "reservation" с общим идентификатором внешнего заказа и
+// добавляем транзакции о созданном заказе и о зарезервированных средствах. Дальше каждую позицию можно списать
+// или отменить отдельно, указав услугу. Если у заказа уже есть позиция с одной из услуг, то возвращаем ошибку
+// ErrOrderExists.
+// - в ответ отдаем обновленный баланс пользователя в копейках, без учета зерезервированных денег.
+func (s *Service) ReserveCart(ctx context.Context, userID, externalID int64, items []Item) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "reserve_cart.ReserveCart")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.OrderID(externalID))
+
+	if hasDuplicates(items) {
+		return 0, servicesErrors.ErrDuplicateService
+	}
+
+	// Стартуем транзакцию.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
+		return 0, fmt.Errorf("begin tx: %v", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return
+			}
+
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
+		}
+	}()
+
+	walletRepo := s.deps.NewWalletRepository(tx)
+
+	// Проверяем, есть ли кошелек у пользователя.
+	walletID, err := walletRepo.ExistWallet(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoWalletNotFound) {
+			return 0, servicesErrors.ErrWalletNotFound
+		}
+
+		s.logger.Error(ctx, "wallet not exist", logfield.Error(err))
+		return 0, fmt.Errorf("wallet not exist: %v", err)
+	}
+
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
+	// Резервируем общую стоимость корзины одним изменением баланса.
+	// Одновременно проверяем достаточно ли средств у пользователя.
+	balance, err := walletRepo.Reserve(ctx, walletID, total(items))
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoNotEnoughCash) {
+			return 0, servicesErrors.ErrNotEnoughCash
+		}
+
+		s.logger.Error(ctx, "reserve", logfield.Error(err))
+		return 0, fmt.Errorf("reserve: %v", err)
+	}
+
+	orderRepo := s.deps.NewOrderRepository(tx)
+	txsRepo := s.deps.NewTransactionRepository(tx)
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Баланс до резервирования. Каждая позиция уменьшает его на свою стоимость, так что после последней позиции
+	// баланс совпадает с итоговым.
+	itemBalance := balance + total(items)
+
+	for _, item := range items {
+		itemBalance -= item.Price
+
+		err := s.reserveItem(ctx, orderRepo, txsRepo, outboxRepo, userID, walletID, externalID, itemBalance, item)
+		if err != nil {
+			if errors.Is(err, servicesErrors.ErrOrderExists) {
+				return 0, err
+			}
+
+			s.logger.Error(ctx, "reserve item", "service_id", item.ServiceID, logfield.Error(err))
+			return 0, fmt.Errorf("reserve item for service %d: %v", item.ServiceID, err)
+		}
+	}
+
+	// Завершаем транзакцию.
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
+		return 0, fmt.Errorf("commit tx: %v", err)
+	}
+
+	s.logger.Info(ctx, "cash reserved for cart", "items", len(items))
+
+	return balance, nil
+}
+
+// reserveItem создает позицию заказа и добавляет транзакции и событие о ней. balanceAfter – баланс после
+// резервирования этой позиции.
+func (s *Service) reserveItem(
+	ctx context.Context,
+	orderRepo OrderRepository,
+	txsRepo TransactionRepository,
+	outboxRepo OutboxRepository,
+	userID, walletID, externalID, balanceAfter int64,
+	item Item,
+) error {
+	// Создаем позицию заказа со статусом "reservation".
+	orderID, err := orderRepo.CreateOrder(ctx, walletID, externalID, item.ServiceID, item.Price, orders.StatusReserved)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepoOrderExists) {
+			return servicesErrors.ErrOrderExists
+		}
+
+		return fmt.Errorf("create order: %v", err)
+	}
+
+	// Добавляем транзакцию о созданном заказе.
+	orderTxID, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusReserved)
+	if err != nil {
+		return fmt.Errorf("add order transaction: %v", err)
+	}
+
+	audit.AddOrderTransaction(ctx, orderTxID)
+
+	// Генерируем payload.
+	payload, err := transactions.ReservationPayload(externalID, item.ServiceID)
+	if err != nil {
+		return fmt.Errorf("generated payload: %v", err)
+	}
+
+	// Добавляем транзакцию о зарезервированных средствах.
+	txID, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeReserve, payload, item.Price, balanceAfter)
+	if err != nil {
+		return fmt.Errorf("add transaction: %v", err)
+	}
+
+	audit.AddTransaction(ctx, txID)
+
+	// Добавляем событие о резервировании позиции.
+	if _, err := outboxRepo.AddEvent(ctx, events.TypeReservation, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        item.Price,
+		Balance:       balanceAfter,
+		OrderID:       externalID,
+		ServiceID:     item.ServiceID,
+	}); err != nil {
+		return fmt.Errorf("add event: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package reserve_cart_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/orders"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	mock_reserve_cart "github.com/frutonanny/wallet-service/internal/services/reserve_cart/mock"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	testUserID     = int64(1)
+	testWalletID   = int64(1)
+	testOrderID1   = int64(1)
+	testOrderID2   = int64(2)
+	testTxID       = int64(0)
+	testEventID    = int64(1)
+	testExternalID = int64(1)
+	testServiceID1 = int64(1)
+	testServiceID2 = int64(2)
+	testPrice1     = int64(1_000)
+	testPrice2     = int64(500)
+	testBalance    = int64(1_000)
+)
+
+var (
+	testError = errors.New("error")
+
+	testItems = []reserve_cart.Item{
+		{ServiceID: testServiceID1, Price: testPrice1},
+		{ServiceID: testServiceID2, Price: testPrice2},
+	}
+)
+
+func TestService_ReserveCart(t *testing.T) {
+	t.Run("reservation cart successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		// Резервируем общую стоимость корзины.
+		walletRepo := mock_reserve_cart.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Reserve(ctx, testWalletID, testPrice1+testPrice2).Return(testBalance, nil)
+
+		// Для каждой позиции создаем свой заказ с общим внешним идентификатором.
+		orderRepo := mock_reserve_cart.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID1, testPrice1, orders.StatusReserved).
+			Return(testOrderID1, nil)
+		orderRepo.EXPECT().AddOrderTransactions(ctx, testOrderID1, orders.StatusReserved).Return(testTxID, nil)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID2, testPrice2, orders.StatusReserved).
+			Return(testOrderID2, nil)
+		orderRepo.EXPECT().AddOrderTransactions(ctx, testOrderID2, orders.StatusReserved).Return(testTxID, nil)
+
+		txRepo := mock_reserve_cart.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().AddTransaction(
+			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testPrice1, testBalance+testPrice2).
+			Return(testTxID, nil)
+		txRepo.EXPECT().AddTransaction(
+			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testPrice2, testBalance).
+			Return(testTxID, nil)
+
+		// На каждую позицию – свое событие о резервировании.
+		outboxRepo := mock_reserve_cart.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeReservation, events.WalletData{
+			UserID:    testUserID,
+			Amount:    testPrice1,
+			Balance:   testBalance + testPrice2,
+			OrderID:   testExternalID,
+			ServiceID: testServiceID1,
+		}).Return(testEventID, nil)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeReservation, events.WalletData{
+			UserID:    testUserID,
+			Amount:    testPrice2,
+			Balance:   testBalance,
+			OrderID:   testExternalID,
+			ServiceID: testServiceID2,
+		}).Return(testEventID, nil)
+
+		mock.ExpectCommit()
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		balance, err := service.ReserveCart(ctx, testUserID, testExternalID, testItems)
+		require.NoError(t, err)
+		assert.Equal(t, testBalance, balance)
+	})
+
+	t.Run("reservation cart failed, ErrDuplicateService", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		items := []reserve_cart.Item{
+			{ServiceID: testServiceID1, Price: testPrice1},
+			{ServiceID: testServiceID1, Price: testPrice2},
+		}
+
+		_, err = service.ReserveCart(ctx, testUserID, testExternalID, items)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrDuplicateService)
+	})
+
+	t.Run("reservation cart failed, ErrWalletNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_reserve_cart.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, repositories.ErrRepoWalletNotFound)
+
+		mock.ExpectRollback()
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		_, err = service.ReserveCart(ctx, testUserID, testExternalID, testItems)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrWalletNotFound)
+	})
+
+	t.Run("reservation cart failed, ErrNotEnoughCash", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		// Средств не хватает на всю корзину – не резервируем ни одной позиции.
+		walletRepo := mock_reserve_cart.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.
+			EXPECT().
+			Reserve(ctx, testWalletID, testPrice1+testPrice2).
+			Return(int64(0), repositories.ErrRepoNotEnoughCash)
+
+		mock.ExpectRollback()
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		_, err = service.ReserveCart(ctx, testUserID, testExternalID, testItems)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrNotEnoughCash)
+	})
+
+	t.Run("reservation cart failed, ErrOrderExists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_reserve_cart.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Reserve(ctx, testWalletID, testPrice1+testPrice2).Return(testBalance, nil)
+
+		// Заказ с этой услугой уже зарезервирован раньше.
+		orderRepo := mock_reserve_cart.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID1, testPrice1, orders.StatusReserved).
+			Return(int64(0), repositories.ErrRepoOrderExists)
+
+		txRepo := mock_reserve_cart.NewMockTransactionRepository(ctrl)
+		outboxRepo := mock_reserve_cart.NewMockOutboxRepository(ctrl)
+
+		mock.ExpectRollback()
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		_, err = service.ReserveCart(ctx, testUserID, testExternalID, testItems)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, servicesErrors.ErrOrderExists)
+	})
+
+	t.Run("reservation cart failed, create order error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+
+		walletRepo := mock_reserve_cart.NewMockWalletRepository(ctrl)
+		walletRepo.EXPECT().ExistWallet(ctx, testUserID).Return(testWalletID, nil)
+		walletRepo.EXPECT().Reserve(ctx, testWalletID, testPrice1+testPrice2).Return(testBalance, nil)
+
+		// Первая позиция создана, вторая – нет. Вся корзина откатывается.
+		orderRepo := mock_reserve_cart.NewMockOrderRepository(ctrl)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID1, testPrice1, orders.StatusReserved).
+			Return(testOrderID1, nil)
+		orderRepo.EXPECT().AddOrderTransactions(ctx, testOrderID1, orders.StatusReserved).Return(testTxID, nil)
+		orderRepo.
+			EXPECT().
+			CreateOrder(ctx, testWalletID, testExternalID, testServiceID2, testPrice2, orders.StatusReserved).
+			Return(testOrderID2, testError)
+
+		txRepo := mock_reserve_cart.NewMockTransactionRepository(ctrl)
+		txRepo.EXPECT().AddTransaction(
+			ctx, testWalletID, transactions.TypeReserve, gomock.Any(), testPrice1, testBalance+testPrice2).
+			Return(testTxID, nil)
+
+		outboxRepo := mock_reserve_cart.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeReservation, gomock.Any()).Return(testEventID, nil)
+
+		mock.ExpectRollback()
+
+		deps := mock_reserve_cart.NewMockdependencies(ctrl)
+		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
+		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
+		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
+
+		log := mock_reserve_cart.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := reserve_cart.New(log, db).WithDependencies(deps)
+
+		_, err = service.ReserveCart(ctx, testUserID, testExternalID, testItems)
+		assert.Error(t, err)
+	})
+}
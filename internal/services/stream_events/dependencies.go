@@ -0,0 +1,12 @@
+package stream_events
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
@@ -0,0 +1,277 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package stream_events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	// eventsBatchSize - сколько событий за раз читается из outbox.
+	eventsBatchSize = 500
+
+	// replayLimit - сколько пропущенных событий максимум отдается при возобновлении ленты.
+	replayLimit = 1000
+
+	// subscriptionBuffer - сколько событий может ждать отправки клиенту. Если клиент не успевает, то лента
+	// закрывается, и клиент переподключается с последнего полученного события.
+	subscriptionBuffer = 64
+
+	// gapTimeout - сколько ждем событие с пропущенным идентификатором. Идентификаторы выдаются до фиксации
+	// транзакции, поэтому событие с меньшим идентификатором может появиться позже события с большим. А может и не
+	// появиться вовсе, если транзакция откатилась.
+	gapTimeout = 10 * time.Second
+)
+
+var ErrStopped = errors.New("stream is stopped")
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type OutboxRepository interface {
+	GetLastID(ctx context.Context) (int64, error)
+	GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]repoOutbox.UserEvent, error)
+	GetUserEventsAfter(ctx context.Context, userID, afterID int64, limit int) ([]repoOutbox.UserEvent, error)
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewOutboxRepository(db postgres.Database) OutboxRepository
+}
+
+// Service раздает события из outbox подписчикам этого экземпляра сервиса. Каждый экземпляр сам читает outbox,
+// поэтому подписчик получает события, зафиксированные любым экземпляром.
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+
+	mu            sync.Mutex
+	stopped       bool
+	subscriptions map[int64]map[*Subscription]struct{}
+
+	// cursor - все события с идентификатором не больше cursor уже разосланы или признаны потерянными.
+	cursor int64
+	// seen - разосланные события с идентификатором больше cursor.
+	seen map[int64]struct{}
+	// gaps - когда впервые замечен пропуск в идентификаторах больше cursor.
+	gaps map[int64]time.Time
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+
+		subscriptions: make(map[int64]map[*Subscription]struct{}),
+		seen:          make(map[int64]struct{}),
+		gaps:          make(map[int64]time.Time),
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// Run читает новые события из outbox раз в pollInterval и раздает их подписчикам, пока не отменен ctx.
+// После остановки все подписки закрываются.
+func (s *Service) Run(ctx context.Context, pollInterval time.Duration) error {
+	defer s.stop()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// Пока база недоступна, последний идентификатор запрашивается на каждом тике: лента ждет базу,
+	// а не останавливает сервис.
+	started := false
+
+	for {
+		if !started {
+			started = s.start(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if !started {
+			continue
+		}
+
+		if err := s.Poll(ctx, time.Now()); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "poll", logfield.Error(err))
+		}
+	}
+}
+
+// start ставит курсор на последнее событие outbox: подписчики получают события, добавленные после запуска.
+func (s *Service) start(ctx context.Context) bool {
+	cursor, err := s.deps.NewOutboxRepository(s.db).GetLastID(ctx)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.logger.Error(ctx, "get last id", logfield.Error(err))
+		}
+
+		return false
+	}
+
+	s.cursor = cursor
+
+	return true
+}
+
+// Poll читает события после курсора и раздает новые подписчикам. now – текущее время, по нему решается, не пора ли
+// перестать ждать пропущенные идентификаторы.
+func (s *Service) Poll(ctx context.Context, now time.Time) error {
+	userEvents, err := s.deps.NewOutboxRepository(s.db).GetEventsAfter(ctx, s.cursor, eventsBatchSize)
+	if err != nil {
+		return fmt.Errorf("get events after: %w", err)
+	}
+
+	for _, e := range userEvents {
+		if _, ok := s.seen[e.ID]; ok {
+			continue
+		}
+
+		s.seen[e.ID] = struct{}{}
+		s.dispatch(e)
+	}
+
+	s.advance(userEvents, now)
+
+	return nil
+}
+
+// advance сдвигает курсор через разосланные события и пропуски, которые ждем дольше gapTimeout.
+func (s *Service) advance(userEvents []repoOutbox.UserEvent, now time.Time) {
+	if len(userEvents) == 0 {
+		return
+	}
+
+	last := userEvents[len(userEvents)-1].ID
+
+	for id := s.cursor + 1; id < last; id++ {
+		if _, ok := s.seen[id]; ok {
+			continue
+		}
+
+		if _, ok := s.gaps[id]; !ok {
+			s.gaps[id] = now
+		}
+	}
+
+	for s.cursor < last {
+		next := s.cursor + 1
+
+		if _, ok := s.seen[next]; ok {
+			delete(s.seen, next)
+			s.cursor = next
+			continue
+		}
+
+		if since, ok := s.gaps[next]; ok && now.Sub(since) >= gapTimeout {
+			delete(s.gaps, next)
+			s.cursor = next
+			continue
+		}
+
+		break
+	}
+}
+
+func (s *Service) dispatch(e repoOutbox.UserEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscriptions[e.UserID] {
+		select {
+		case sub.live <- e.Event:
+		default:
+			// Клиент не успевает читать – закрываем ленту, он переподключится с последнего события.
+			s.unsubscribeLocked(sub)
+		}
+	}
+}
+
+// Subscribe подписывает на события пользователя userID. Если передан lastEventID, то сначала отдаются события
+// пользователя после него – так клиент возобновляет ленту после переподключения.
+func (s *Service) Subscribe(ctx context.Context, userID, lastEventID int64) (*Subscription, error) {
+	sub := &Subscription{
+		service:  s,
+		userID:   userID,
+		live:     make(chan events.Event, subscriptionBuffer),
+		replayed: make(map[int64]struct{}),
+	}
+
+	// Подписываемся до чтения пропущенных событий, чтобы не потерять события, появившиеся между чтением и подпиской.
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil, ErrStopped
+	}
+
+	if s.subscriptions[userID] == nil {
+		s.subscriptions[userID] = make(map[*Subscription]struct{})
+	}
+	s.subscriptions[userID][sub] = struct{}{}
+	s.mu.Unlock()
+
+	if lastEventID > 0 {
+		missed, err := s.deps.NewOutboxRepository(s.db).GetUserEventsAfter(ctx, userID, lastEventID, replayLimit)
+		if err != nil {
+			sub.Close()
+
+			s.logger.Error(ctx, "get user events after", logfield.Error(err))
+			return nil, fmt.Errorf("get user events after: %v", err)
+		}
+
+		for _, e := range missed {
+			sub.replay = append(sub.replay, e.Event)
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *Service) unsubscribeLocked(sub *Subscription) {
+	if _, ok := s.subscriptions[sub.userID][sub]; !ok {
+		return
+	}
+
+	delete(s.subscriptions[sub.userID], sub)
+	if len(s.subscriptions[sub.userID]) == 0 {
+		delete(s.subscriptions, sub.userID)
+	}
+
+	close(sub.live)
+}
+
+func (s *Service) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+
+	for _, subs := range s.subscriptions {
+		for sub := range subs {
+			s.unsubscribeLocked(sub)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package stream_events_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
+	mock "github.com/frutonanny/wallet-service/internal/services/stream_events/mock"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	testUserID  = int64(7)
+	testOtherID = int64(8)
+)
+
+func userEvent(userID, id int64) repoOutbox.UserEvent {
+	return repoOutbox.UserEvent{
+		UserID: userID,
+		Event:  events.Event{ID: id, Type: events.TypeIncomingTransfer, Version: events.Version},
+	}
+}
+
+// next отдает идентификатор очередного события или 0, если событий нет.
+func next(t *testing.T, sub *stream_events.Subscription) int64 {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	e, ok := sub.Next(ctx)
+	if !ok {
+		return 0
+	}
+
+	return e.ID
+}
+
+func TestService_Subscribe(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("replay missed events, then live ones without duplicates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetUserEventsAfter(ctx, testUserID, int64(1), gomock.Any()).
+			Return([]repoOutbox.UserEvent{userEvent(testUserID, 2), userEvent(testUserID, 3)}, nil)
+		outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+			Return([]repoOutbox.UserEvent{
+				userEvent(testUserID, 1),
+				userEvent(testUserID, 2),
+				userEvent(testUserID, 3),
+				userEvent(testOtherID, 4),
+				userEvent(testUserID, 5),
+			}, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := stream_events.New(log, db).WithDependencies(deps)
+
+		sub, err := service.Subscribe(ctx, testUserID, 1)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.NoError(t, service.Poll(ctx, now))
+
+		assert.Equal(t, int64(2), next(t, sub))
+		assert.Equal(t, int64(3), next(t, sub))
+		// Событие 1 клиент уже получил до переподключения, но живая лента о нем не знает.
+		assert.Equal(t, int64(1), next(t, sub))
+		assert.Equal(t, int64(5), next(t, sub))
+		assert.Zero(t, next(t, sub))
+	})
+
+	t.Run("event committed out of order is not lost", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		gomock.InOrder(
+			// Транзакция с событием 1 еще не зафиксирована.
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 2)}, nil),
+			// Курсор стоит перед пропуском, событие 2 повторно не отдается.
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 1), userEvent(testUserID, 2)}, nil),
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(2), gomock.Any()).
+				Return(nil, nil),
+		)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := stream_events.New(log, db).WithDependencies(deps)
+
+		sub, err := service.Subscribe(ctx, testUserID, 0)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.NoError(t, service.Poll(ctx, now))
+		require.NoError(t, service.Poll(ctx, now.Add(time.Second)))
+		require.NoError(t, service.Poll(ctx, now.Add(2*time.Second)))
+
+		assert.Equal(t, int64(2), next(t, sub))
+		assert.Equal(t, int64(1), next(t, sub))
+		assert.Zero(t, next(t, sub))
+	})
+
+	t.Run("replayed event committed out of order is not sent twice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetUserEventsAfter(ctx, testUserID, int64(1), gomock.Any()).
+			Return([]repoOutbox.UserEvent{userEvent(testUserID, 3)}, nil)
+		gomock.InOrder(
+			// Событие 3 уже отдано среди пропущенных, а транзакция с событием 2 еще не зафиксирована.
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 1), userEvent(testUserID, 3)}, nil),
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(1), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 2), userEvent(testUserID, 3)}, nil),
+		)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := stream_events.New(log, db).WithDependencies(deps)
+
+		sub, err := service.Subscribe(ctx, testUserID, 1)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.NoError(t, service.Poll(ctx, now))
+		require.NoError(t, service.Poll(ctx, now.Add(time.Second)))
+
+		assert.Equal(t, int64(3), next(t, sub))
+		assert.Equal(t, int64(1), next(t, sub))
+		assert.Equal(t, int64(2), next(t, sub))
+		assert.Zero(t, next(t, sub))
+	})
+
+	t.Run("lost id is skipped after timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		now := time.Now()
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		gomock.InOrder(
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 2)}, nil),
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).
+				Return([]repoOutbox.UserEvent{userEvent(testUserID, 2)}, nil),
+			outboxRepo.EXPECT().GetEventsAfter(ctx, int64(2), gomock.Any()).
+				Return(nil, nil),
+		)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := stream_events.New(log, db).WithDependencies(deps)
+
+		require.NoError(t, service.Poll(ctx, now))
+		require.NoError(t, service.Poll(ctx, now.Add(time.Minute)))
+		require.NoError(t, service.Poll(ctx, now.Add(2*time.Minute)))
+	})
+
+	t.Run("slow subscriber is closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		var batch []repoOutbox.UserEvent
+		for id := int64(1); id <= 100; id++ {
+			batch = append(batch, userEvent(testUserID, id))
+		}
+
+		outboxRepo := mock.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().GetEventsAfter(ctx, int64(0), gomock.Any()).Return(batch, nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo).AnyTimes()
+
+		log := mock.NewMocklogger(ctrl)
+
+		service := stream_events.New(log, db).WithDependencies(deps)
+
+		sub, err := service.Subscribe(ctx, testUserID, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Poll(ctx, time.Now()))
+
+		var received int
+		for next(t, sub) != 0 {
+			received++
+		}
+
+		assert.Less(t, received, len(batch))
+
+		// Повторная отписка закрытой ленты ничего не ломает.
+		sub.Close()
+	})
+}
@@ -0,0 +1,60 @@
+package stream_events
+
+import (
+	"context"
+
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+// Subscription - лента событий одного клиента.
+type Subscription struct {
+	service *Service
+	userID  int64
+
+	replay []events.Event
+	live   chan events.Event
+
+	// replayed - отданные пропущенные события, которые еще могут прийти в живой ленте. Живая лента отдает каждое
+	// событие не больше одного раза, поэтому событие удаляется, как только пришло в ней, а пропущенных событий
+	// не больше replayLimit.
+	replayed map[int64]struct{}
+}
+
+// Next отдает очередное событие: сначала пропущенные, затем новые по мере появления. Второе значение false
+// означает, что лента закрыта: отменен ctx, клиент не успевал читать или сервис остановлен.
+func (s *Subscription) Next(ctx context.Context) (events.Event, bool) {
+	if len(s.replay) > 0 {
+		var e events.Event
+
+		e, s.replay = s.replay[0], s.replay[1:]
+		s.replayed[e.ID] = struct{}{}
+
+		return e, true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return events.Event{}, false
+		case e, ok := <-s.live:
+			if !ok {
+				return events.Event{}, false
+			}
+
+			if _, ok := s.replayed[e.ID]; ok {
+				delete(s.replayed, e.ID)
+				continue
+			}
+
+			return e, true
+		}
+	}
+}
+
+// Close отписывает от событий.
+func (s *Subscription) Close() {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+
+	s.service.unsubscribeLocked(s)
+}
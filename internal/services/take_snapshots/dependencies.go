@@ -0,0 +1,12 @@
+package take_snapshots
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+)
+
+type dependenciesImpl struct{}
+
+func (b *dependenciesImpl) NewBalanceRepository(db postgres.Database) BalanceRepository {
+	return repoBalance.New(db)
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package mock_take_snapshots is a generated GoMock package.
+package mock_take_snapshots
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	postgres "github.com/frutonanny/wallet-service/internal/postgres"
+	balance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	take_snapshots "github.com/frutonanny/wallet-service/internal/services/take_snapshots"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, msg}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(ctx, msg interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, msg}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), varargs...)
+}
+
+// MockBalanceRepository is a mock of BalanceRepository interface.
+type MockBalanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepositoryMockRecorder
+}
+
+// MockBalanceRepositoryMockRecorder is the mock recorder for MockBalanceRepository.
+type MockBalanceRepositoryMockRecorder struct {
+	mock *MockBalanceRepository
+}
+
+// NewMockBalanceRepository creates a new mock instance.
+func NewMockBalanceRepository(ctrl *gomock.Controller) *MockBalanceRepository {
+	mock := &MockBalanceRepository{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepository) EXPECT() *MockBalanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddSnapshot mocks base method.
+func (m *MockBalanceRepository) AddSnapshot(ctx context.Context, walletID int64, takenAt time.Time, b balance.Balance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSnapshot", ctx, walletID, takenAt, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSnapshot indicates an expected call of AddSnapshot.
+func (mr *MockBalanceRepositoryMockRecorder) AddSnapshot(ctx, walletID, takenAt, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSnapshot", reflect.TypeOf((*MockBalanceRepository)(nil).AddSnapshot), ctx, walletID, takenAt, b)
+}
+
+// GetBalanceBefore mocks base method.
+func (m *MockBalanceRepository) GetBalanceBefore(ctx context.Context, walletID int64, t time.Time) (balance.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceBefore", ctx, walletID, t)
+	ret0, _ := ret[0].(balance.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceBefore indicates an expected call of GetBalanceBefore.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceBefore(ctx, walletID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceBefore", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceBefore), ctx, walletID, t)
+}
+
+// GetWalletsWithoutSnapshot mocks base method.
+func (m *MockBalanceRepository) GetWalletsWithoutSnapshot(ctx context.Context, takenAt time.Time, afterWalletID, limit int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletsWithoutSnapshot", ctx, takenAt, afterWalletID, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletsWithoutSnapshot indicates an expected call of GetWalletsWithoutSnapshot.
+func (mr *MockBalanceRepositoryMockRecorder) GetWalletsWithoutSnapshot(ctx, takenAt, afterWalletID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletsWithoutSnapshot", reflect.TypeOf((*MockBalanceRepository)(nil).GetWalletsWithoutSnapshot), ctx, takenAt, afterWalletID, limit)
+}
+
+// Mockdependencies is a mock of dependencies interface.
+type Mockdependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockdependenciesMockRecorder
+}
+
+// MockdependenciesMockRecorder is the mock recorder for Mockdependencies.
+type MockdependenciesMockRecorder struct {
+	mock *Mockdependencies
+}
+
+// NewMockdependencies creates a new mock instance.
+func NewMockdependencies(ctrl *gomock.Controller) *Mockdependencies {
+	mock := &Mockdependencies{ctrl: ctrl}
+	mock.recorder = &MockdependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockdependencies) EXPECT() *MockdependenciesMockRecorder {
+	return m.recorder
+}
+
+// NewBalanceRepository mocks base method.
+func (m *Mockdependencies) NewBalanceRepository(db postgres.Database) take_snapshots.BalanceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewBalanceRepository", db)
+	ret0, _ := ret[0].(take_snapshots.BalanceRepository)
+	return ret0
+}
+
+// NewBalanceRepository indicates an expected call of NewBalanceRepository.
+func (mr *MockdependenciesMockRecorder) NewBalanceRepository(db interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBalanceRepository", reflect.TypeOf((*Mockdependencies)(nil).NewBalanceRepository), db)
+}
@@ -0,0 +1,106 @@
+//go:generate mockgen --source=service.go --destination=mock/service.go
+package take_snapshots
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+)
+
+const (
+	// walletsBatchSize - сколько кошельков за раз берется из базы.
+	walletsBatchSize = 100
+)
+
+type logger interface {
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
+}
+
+type BalanceRepository interface {
+	GetWalletsWithoutSnapshot(ctx context.Context, takenAt time.Time, afterWalletID, limit int64) ([]int64, error)
+	GetBalanceBefore(ctx context.Context, walletID int64, t time.Time) (repoBalance.Balance, error)
+	AddSnapshot(ctx context.Context, walletID int64, takenAt time.Time, b repoBalance.Balance) error
+}
+
+// dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
+// Нужен для написания юнит-тестов без подключения к базе.
+type dependencies interface {
+	NewBalanceRepository(db postgres.Database) BalanceRepository
+}
+
+type Service struct {
+	logger logger
+	db     *sql.DB
+	deps   dependencies
+}
+
+func New(logger logger, db *sql.DB) *Service {
+	return &Service{
+		logger: logger,
+		db:     db,
+
+		deps: &dependenciesImpl{},
+	}
+}
+
+func (s *Service) WithDependencies(deps dependencies) *Service {
+	s.deps = deps
+	return s
+}
+
+// TakeSnapshots делает снимки баланса всех кошельков на момент at и отдает количество сделанных снимков.
+// - проверяем, что момент at уже наступил, иначе отдаем ошибку ErrPeriodNotFinished.
+// - берем из базы пачку кошельков без снимка на момент at, по возрастанию идентификатора.
+// - считаем баланс каждого кошелька от предыдущего снимка и сохраняем, и так пока кошельки не закончатся.
+//
+// Снимок каждого кошелька сохраняется сразу, поэтому прерванный запуск можно повторить – он продолжит
+// с кошельков, по которым снимка еще нет.
+func (s *Service) TakeSnapshots(ctx context.Context, at time.Time) (int, error) {
+	if at.After(time.Now()) {
+		return 0, servicesErrors.ErrPeriodNotFinished
+	}
+
+	balanceRepo := s.deps.NewBalanceRepository(s.db)
+
+	var taken int
+	var afterWalletID int64
+
+	for {
+		wallets, err := balanceRepo.GetWalletsWithoutSnapshot(ctx, at, afterWalletID, walletsBatchSize)
+		if err != nil {
+			s.logger.Error(ctx, "get wallets without snapshot", logfield.Error(err))
+			return taken, fmt.Errorf("get wallets without snapshot: %w", err)
+		}
+
+		for _, walletID := range wallets {
+			balance, err := balanceRepo.GetBalanceBefore(ctx, walletID, at)
+			if err != nil {
+				s.logger.Error(ctx, "get balance before", logfield.WalletID(walletID), logfield.Error(err))
+				return taken, fmt.Errorf("get balance before for wallet %d: %w", walletID, err)
+			}
+
+			if err := balanceRepo.AddSnapshot(ctx, walletID, at, balance); err != nil {
+				s.logger.Error(ctx, "add snapshot", logfield.WalletID(walletID), logfield.Error(err))
+				return taken, fmt.Errorf("add snapshot for wallet %d: %w", walletID, err)
+			}
+
+			taken++
+			afterWalletID = walletID
+		}
+
+		if len(wallets) < walletsBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info(ctx, "balance snapshots taken", "count", taken, "at", at.Format(time.RFC3339))
+
+	return taken, nil
+}
@@ -0,0 +1,93 @@
+package take_snapshots_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/services/take_snapshots"
+	mock "github.com/frutonanny/wallet-service/internal/services/take_snapshots/mock"
+)
+
+var (
+	testError = errors.New("error")
+
+	testAt = time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestService_TakeSnapshots(t *testing.T) {
+	var db *sql.DB
+
+	t.Run("take snapshots successfully", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().GetWalletsWithoutSnapshot(ctx, testAt, int64(0), gomock.Any()).Return([]int64{1, 2}, nil)
+		balanceRepo.EXPECT().GetBalanceBefore(ctx, int64(1), testAt).Return(repoBalance.Balance{Available: 100}, nil)
+		balanceRepo.EXPECT().AddSnapshot(ctx, int64(1), testAt, repoBalance.Balance{Available: 100}).Return(nil)
+		balanceRepo.EXPECT().GetBalanceBefore(ctx, int64(2), testAt).Return(repoBalance.Balance{Reserved: 50}, nil)
+		balanceRepo.EXPECT().AddSnapshot(ctx, int64(2), testAt, repoBalance.Balance{Reserved: 50}).Return(nil)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := take_snapshots.New(log, db).WithDependencies(deps)
+
+		taken, err := service.TakeSnapshots(ctx, testAt)
+		require.NoError(t, err)
+		assert.Equal(t, 2, taken)
+	})
+
+	t.Run("take snapshots failed, ErrPeriodNotFinished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		deps := mock.NewMockdependencies(ctrl)
+		log := mock.NewMocklogger(ctrl)
+
+		service := take_snapshots.New(log, db).WithDependencies(deps)
+
+		_, err := service.TakeSnapshots(ctx, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, servicesErrors.ErrPeriodNotFinished)
+	})
+
+	t.Run("take snapshots stopped, add snapshot error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		balanceRepo := mock.NewMockBalanceRepository(ctrl)
+		balanceRepo.EXPECT().GetWalletsWithoutSnapshot(ctx, testAt, int64(0), gomock.Any()).Return([]int64{1, 2}, nil)
+		balanceRepo.EXPECT().GetBalanceBefore(ctx, int64(1), testAt).Return(repoBalance.Balance{}, nil)
+		balanceRepo.EXPECT().AddSnapshot(ctx, int64(1), testAt, repoBalance.Balance{}).Return(testError)
+
+		deps := mock.NewMockdependencies(ctrl)
+		deps.EXPECT().NewBalanceRepository(gomock.Any()).Return(balanceRepo)
+
+		log := mock.NewMocklogger(ctrl)
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
+
+		service := take_snapshots.New(log, db).WithDependencies(deps)
+
+		taken, err := service.TakeSnapshots(ctx, testAt)
+		assert.ErrorIs(t, err, testError)
+		assert.Equal(t, 0, taken)
+	})
+}
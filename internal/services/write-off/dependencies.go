@@ -3,6 +3,7 @@ package write_off
 import (
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
 	repoReport "github.com/frutonanny/wallet-service/internal/repositories/report"
 	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
 	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
@@ -24,3 +25,7 @@ func (b *dependenciesImpl) NewTransactionRepository(db postgres.Database) Transa
 func (b *dependenciesImpl) NewReportRepository(db postgres.Database) ReportRepository {
 	return repoReport.New(db)
 }
+
+func (b *dependenciesImpl) NewOutboxRepository(db postgres.Database) OutboxRepository {
+	return repoOutbox.New(db)
+}
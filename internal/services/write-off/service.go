@@ -8,16 +8,21 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/frutonanny/wallet-service/internal/audit"
+	"github.com/frutonanny/wallet-service/internal/logfield"
+	"github.com/frutonanny/wallet-service/internal/metrics"
 	"github.com/frutonanny/wallet-service/internal/orders"
 	"github.com/frutonanny/wallet-service/internal/postgres"
 	"github.com/frutonanny/wallet-service/internal/repositories"
 	servicesErrors "github.com/frutonanny/wallet-service/internal/services/errors"
+	"github.com/frutonanny/wallet-service/internal/tracing"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 type logger interface {
-	Info(msg string)
-	Error(msg string)
+	Info(ctx context.Context, msg string, args ...interface{})
+	Error(ctx context.Context, msg string, args ...interface{})
 }
 
 type WalletRepository interface {
@@ -31,22 +36,36 @@ type OrderRepository interface {
 }
 
 type TransactionRepository interface {
-	AddTransaction(ctx context.Context, walletID int64, action string, payload []byte, amount int64) (int64, error)
+	AddTransaction(
+		ctx context.Context,
+		walletID int64,
+		action string,
+		payload []byte,
+		amount, balanceAfter int64,
+	) (int64, error)
 }
 
 type ReportRepository interface {
 	AddRecord(ctx context.Context, serviceID, amount int64, now time.Time) error
 }
 
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, eventType string, data events.WalletData) (int64, error)
+}
+
 // dependencies умеет налету создавать репозиторий поверх *sql.DB, *sql.Tx.
 // Нужен для написания юнит-тестов без подключения к базе.
 type dependencies interface {
 	NewWalletRepository(db postgres.Database) WalletRepository
 	NewOrderRepository(db postgres.Database) OrderRepository
 	NewTransactionRepository(db postgres.Database) TransactionRepository
+	NewOutboxRepository(db postgres.Database) OutboxRepository
 	NewReportRepository(db postgres.Database) ReportRepository
 }
 
+// operation - имя операции в метриках.
+const operation = "write_off"
+
 type Service struct {
 	db     *sql.DB
 	logger logger
@@ -75,13 +94,22 @@ func (s *Service) WithDependencies(deps dependencies) *Service {
 // - обновляем информацию о заказе.
 // - добавляем транзакцию об обновленном заказе;
 // - добавляем транзакцию о списанных средствах;
+// - добавляем событие о списании в outbox;
 // - Записываем в отчет списание.
 // - в ответ отдаем обновленный баланс пользователя в копейках.
-func (s *Service) WriteOff(ctx context.Context, userID, serviceID, externalID, price int64) (int64, error) {
+func (s *Service) WriteOff(ctx context.Context, userID, serviceID, externalID, price int64) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "write_off.WriteOff")
+	defer func() { tracing.End(span, err) }()
+
+	started := time.Now()
+	defer func() { metrics.ObserveOperation(operation, started, err) }()
+
+	logfield.Add(ctx, logfield.UserID(userID), logfield.OrderID(externalID), logfield.Amount(price))
+
 	// Стартуем транзакцию.
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("begin tx: %s", err))
+		s.logger.Error(ctx, "begin tx", logfield.Error(err))
 		return 0, fmt.Errorf("begin tx: %v", err)
 	}
 
@@ -91,7 +119,7 @@ func (s *Service) WriteOff(ctx context.Context, userID, serviceID, externalID, p
 				return
 			}
 
-			s.logger.Error(fmt.Sprintf("rollback: %s", err))
+			s.logger.Error(ctx, "rollback", logfield.Error(err))
 		}
 	}()
 
@@ -104,10 +132,12 @@ func (s *Service) WriteOff(ctx context.Context, userID, serviceID, externalID, p
 			return 0, servicesErrors.ErrWalletNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("wallet not exist: %s", err))
+		s.logger.Error(ctx, "wallet not exist", logfield.Error(err))
 		return 0, fmt.Errorf("wallet not exist: %v", err)
 	}
 
+	logfield.Add(ctx, logfield.WalletID(walletID))
+
 	orderRepo := s.deps.NewOrderRepository(tx)
 
 	// Проверяем есть ли заказ с переданным идентификатором внешнего заказа.
@@ -117,71 +147,93 @@ func (s *Service) WriteOff(ctx context.Context, userID, serviceID, externalID, p
 			return 0, servicesErrors.ErrOrderNotFound
 		}
 
-		s.logger.Error(fmt.Sprintf("order exist: %s", err))
+		s.logger.Error(ctx, "order exist", logfield.Error(err))
 		return 0, fmt.Errorf("order exist: %v", err)
 	}
 
 	// Проверяем соответствие статуса и стоимости.
 	if ok := orders.IsOrderReserved(status); !ok {
-		s.logger.Error(fmt.Sprintf("order has wrong status %v", status))
+		s.logger.Error(ctx, "order has wrong status", "status", status)
 		return 0, fmt.Errorf("order has wrong status %v", status)
 	}
 
 	// Не даем списать бОльшую сумму, чем сейчас находится в резерве по этому заказу.
 	if price > amount {
-		s.logger.Error(fmt.Sprintf("the new value is greater than the reserved amount"))
+		s.logger.Error(ctx, "the new value is greater than the reserved amount")
 		return 0, fmt.Errorf("the new value is greater than the reserved amount")
 	}
 
 	// Обновляем информацию в заказе.
 	if err := orderRepo.UpdateOrder(ctx, orderID, price, orders.StatusWrittenOff); err != nil {
-		s.logger.Error(fmt.Sprintf("update order: %s", err))
+		s.logger.Error(ctx, "update order", logfield.Error(err))
 		return 0, fmt.Errorf("update order: %v", err)
 	}
 
 	// Добавляем транзакцию об изменении статуса заказа.
-	if _, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusWrittenOff); err != nil {
-		s.logger.Error(fmt.Sprintf("add order transaction: %s", err))
+	orderTxID, err := orderRepo.AddOrderTransactions(ctx, orderID, orders.StatusWrittenOff)
+	if err != nil {
+		s.logger.Error(ctx, "add order transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add order transaction: %v", err)
 	}
 
+	audit.AddOrderTransaction(ctx, orderTxID)
+
 	// Списываем переданную сумму с резерва. Если сумма списанная меньше зарезервированной, то возвращаем разницу в
 	// баланс.
 	balance, err := walletRepo.WriteOff(ctx, walletID, amount, amount-price)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("write-off: %s", err))
+		s.logger.Error(ctx, "write-off", logfield.Error(err))
 		return 0, fmt.Errorf("write-off: %v", err)
 	}
 
 	// Генерируем payload.
-	payload, err := transactions.WriteOffPayload(externalID)
+	payload, err := transactions.WriteOffPayload(externalID, serviceID)
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("write-off payload: %s", err))
+		s.logger.Error(ctx, "write-off payload", logfield.Error(err))
 		return 0, fmt.Errorf("write-off payload: %v", err)
 	}
 
 	txsRepo := s.deps.NewTransactionRepository(tx)
 
 	// Добавляем транзакцию о зарезервированных средствах.
-	if _, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeWriteOff, payload, price); err != nil {
-		s.logger.Error(fmt.Sprintf("add transaction: %s", err))
+	txID, err := txsRepo.AddTransaction(ctx, walletID, transactions.TypeWriteOff, payload, price, balance)
+	if err != nil {
+		s.logger.Error(ctx, "add transaction", logfield.Error(err))
 		return 0, fmt.Errorf("add transaction: %v", err)
 	}
 
+	audit.AddTransaction(ctx, txID)
+
+	outboxRepo := s.deps.NewOutboxRepository(tx)
+
+	// Добавляем событие о списании.
+	if _, err := outboxRepo.AddEvent(ctx, events.TypeWriteOff, events.WalletData{
+		UserID:        userID,
+		TransactionID: txID,
+		Amount:        price,
+		Balance:       balance,
+		OrderID:       externalID,
+		ServiceID:     serviceID,
+	}); err != nil {
+		s.logger.Error(ctx, "add event", logfield.Error(err))
+		return 0, fmt.Errorf("add event: %v", err)
+	}
+
 	reportRepo := s.deps.NewReportRepository(tx)
 
-	if err := reportRepo.AddRecord(ctx, serviceID, amount, time.Now()); err != nil {
-		s.logger.Error(fmt.Sprintf("add record: %s", err))
+	// Записываем в отчет фактически списанную сумму по услуге заказа.
+	if err := reportRepo.AddRecord(ctx, serviceID, price, time.Now()); err != nil {
+		s.logger.Error(ctx, "add record", logfield.Error(err))
 		return 0, fmt.Errorf("add record: %v", err)
 	}
 
 	// Завершаем транзакцию.
-	if err := tx.Commit(); err != nil {
-		s.logger.Error(fmt.Sprintf("commit tx: %s", err))
+	if err := postgres.Commit(ctx, tx); err != nil {
+		s.logger.Error(ctx, "commit tx", logfield.Error(err))
 		return 0, fmt.Errorf("commit tx: %v", err)
 	}
 
-	s.logger.Info(fmt.Sprintf("cash written-off for wallet: %d", walletID))
+	s.logger.Info(ctx, "cash written-off")
 
 	return balance, nil
 }
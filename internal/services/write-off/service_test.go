@@ -16,6 +16,7 @@ import (
 	write_off "github.com/frutonanny/wallet-service/internal/services/write-off"
 	mock_write_off "github.com/frutonanny/wallet-service/internal/services/write-off/mock"
 	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
 )
 
 const (
@@ -23,6 +24,7 @@ const (
 	testWalletID   = int64(1)
 	testOrderID    = int64(1)
 	testTxID       = int64(0)
+	testEventID    = int64(1)
 	testExternalID = int64(1)
 	testServiceID  = int64(1)
 	testAmount     = int64(1_000)
@@ -64,19 +66,23 @@ func TestService_WriteOff(t *testing.T) {
 
 		txRepo := mock_write_off.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, nil)
 
+		outboxRepo := mock_write_off.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeWriteOff, gomock.Any()).Return(testEventID, nil)
+
 		mock.ExpectCommit()
 
 		deps := mock_write_off.NewMockdependencies(ctrl)
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
 		deps.EXPECT().NewReportRepository(gomock.Any()).Return(reportRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Info(gomock.Any())
+		log.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -133,7 +139,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -202,7 +208,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -237,7 +243,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -273,7 +279,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -310,7 +316,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -342,7 +348,7 @@ func TestService_WriteOff(t *testing.T) {
 
 		txRepo := mock_write_off.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, testError)
 
 		mock.ExpectRollback()
@@ -353,7 +359,7 @@ func TestService_WriteOff(t *testing.T) {
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -385,22 +391,26 @@ func TestService_WriteOff(t *testing.T) {
 
 		txRepo := mock_write_off.NewMockTransactionRepository(ctrl)
 		txRepo.EXPECT().AddTransaction(
-			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount).
+			ctx, testWalletID, transactions.TypeWriteOff, gomock.Any(), testAmount, testBalance).
 			Return(testTxID, nil)
 
 		reportRepo := mock_write_off.NewMockReportRepository(ctrl)
 		reportRepo.EXPECT().AddRecord(ctx, testServiceID, testAmount, gomock.Any()).Return(testError)
 
+		outboxRepo := mock_write_off.NewMockOutboxRepository(ctrl)
+		outboxRepo.EXPECT().AddEvent(ctx, events.TypeWriteOff, gomock.Any()).Return(testEventID, nil)
+
 		mock.ExpectRollback()
 
 		deps := mock_write_off.NewMockdependencies(ctrl)
 		deps.EXPECT().NewWalletRepository(gomock.Any()).Return(walletRepo)
 		deps.EXPECT().NewOrderRepository(gomock.Any()).Return(orderRepo)
 		deps.EXPECT().NewTransactionRepository(gomock.Any()).Return(txRepo)
+		deps.EXPECT().NewOutboxRepository(gomock.Any()).Return(outboxRepo)
 		deps.EXPECT().NewReportRepository(gomock.Any()).Return(reportRepo)
 
 		log := mock_write_off.NewMocklogger(ctrl)
-		log.EXPECT().Error(gomock.Any())
+		log.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any())
 
 		service := write_off.New(log, db).WithDependencies(deps)
 
@@ -0,0 +1,85 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+const (
+	headerEventType    = "event-type"
+	headerEventVersion = "event-version"
+)
+
+// Kafka публикует события в топик Kafka или совместимого брокера (Redpanda и т.п.). Ключ сообщения –
+// идентификатор пользователя, так что события одного кошелька попадают в одну партицию и читаются по порядку.
+type Kafka struct {
+	w *kafka.Writer
+}
+
+func NewKafka(brokers []string, topic string) *Kafka {
+	return &Kafka{
+		w: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: 10 * time.Millisecond,
+		},
+	}
+}
+
+func (s *Kafka) Publish(ctx context.Context, batch []events.Event) error {
+	messages, err := Messages(batch)
+	if err != nil {
+		return err
+	}
+
+	if err := s.w.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("write messages: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Kafka) Close() error {
+	return s.w.Close()
+}
+
+// Messages превращает события в сообщения Kafka.
+func Messages(batch []events.Event) ([]kafka.Message, error) {
+	messages := make([]kafka.Message, 0, len(batch))
+
+	for _, e := range batch {
+		var data struct {
+			UserID int64 `json:"userID"`
+		}
+
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal data of event %d: %v", e.ID, err)
+		}
+
+		value, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("marshal event %d: %v", e.ID, err)
+		}
+
+		messages = append(messages, kafka.Message{
+			Key:   []byte(strconv.FormatInt(data.UserID, 10)),
+			Value: value,
+			Headers: []kafka.Header{
+				{Key: headerEventType, Value: []byte(e.Type)},
+				{Key: headerEventVersion, Value: []byte(strconv.Itoa(e.Version))},
+			},
+			Time: e.OccurredAt,
+		})
+	}
+
+	return messages, nil
+}
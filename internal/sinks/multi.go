@@ -0,0 +1,30 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+// Sink - получатель событий.
+type Sink interface {
+	Publish(ctx context.Context, batch []events.Event) error
+}
+
+// Multi публикует события по очереди во все переданные получатели. Если один из них вернул ошибку, то вся пачка
+// будет опубликована повторно, в том числе в те получатели, которые ее уже приняли.
+type Multi []Sink
+
+func NewMulti(sinks ...Sink) Multi {
+	return sinks
+}
+
+func (m Multi) Publish(ctx context.Context, batch []events.Event) error {
+	for _, s := range m {
+		if err := s.Publish(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
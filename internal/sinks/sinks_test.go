@@ -0,0 +1,79 @@
+package sinks_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/sinks"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+var testEvents = []events.Event{
+	{
+		ID:         1,
+		Type:       events.TypeIncomingTransfer,
+		Version:    events.Version,
+		OccurredAt: time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+		Data:       json.RawMessage(`{"userID":7,"transactionID":10,"amount":1000,"balance":1000}`),
+	},
+	{
+		ID:         2,
+		Type:       events.TypeReservation,
+		Version:    events.Version,
+		OccurredAt: time.Date(2022, 11, 1, 13, 0, 0, 0, time.UTC),
+		Data:       json.RawMessage(`{"userID":8,"transactionID":11,"amount":500,"balance":0,"orderID":1,"serviceID":2}`),
+	},
+}
+
+func TestWriter_Publish(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := sinks.NewWriter(buf).Publish(context.Background(), testEvents)
+	require.NoError(t, err)
+
+	expected := `{"id":1,"type":"wallet.incoming_transfer","version":1,"occurredAt":"2022-11-01T12:00:00Z",` +
+		`"data":{"userID":7,"transactionID":10,"amount":1000,"balance":1000}}` + "\n" +
+		`{"id":2,"type":"wallet.reservation","version":1,"occurredAt":"2022-11-01T13:00:00Z",` +
+		`"data":{"userID":8,"transactionID":11,"amount":500,"balance":0,"orderID":1,"serviceID":2}}` + "\n"
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestMessages(t *testing.T) {
+	messages, err := sinks.Messages(testEvents)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	assert.Equal(t, "7", string(messages[0].Key))
+	assert.Equal(t, "8", string(messages[1].Key))
+	assert.Equal(t, testEvents[1].OccurredAt, messages[1].Time)
+
+	var e events.Event
+	require.NoError(t, json.Unmarshal(messages[0].Value, &e))
+	assert.Equal(t, testEvents[0].ID, e.ID)
+	assert.Equal(t, testEvents[0].Type, e.Type)
+}
+
+type failingSink struct{}
+
+func (failingSink) Publish(context.Context, []events.Event) error {
+	return errors.New("error")
+}
+
+func TestMulti_Publish(t *testing.T) {
+	first, second := &bytes.Buffer{}, &bytes.Buffer{}
+
+	err := sinks.NewMulti(sinks.NewWriter(first), sinks.NewWriter(second)).Publish(context.Background(), testEvents)
+	require.NoError(t, err)
+	assert.Equal(t, first.String(), second.String())
+
+	err = sinks.NewMulti(sinks.NewWriter(first), failingSink{}).Publish(context.Background(), testEvents)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+// Writer пишет события в io.Writer в формате NDJSON: по конверту события на строку. Нужен для локальной разработки
+// и отладки, когда брокера под рукой нет.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// NewStdout пишет события в стандартный вывод.
+func NewStdout() *Writer {
+	return NewWriter(os.Stdout)
+}
+
+// MustFile пишет события в конец файла path, создавая его при необходимости.
+func MustFile(path string) (*Writer, func() error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		panic(fmt.Errorf("open events file: %v", err))
+	}
+
+	return NewWriter(f), f.Close
+}
+
+func (s *Writer) Publish(_ context.Context, batch []events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enc := json.NewEncoder(s.w)
+
+	for _, e := range batch {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("encode event %d: %v", e.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package tracing - трассировка запросов в OpenTelemetry. Контекст трассировки принимается и передается дальше
+// в формате W3C Trace Context (заголовок traceparent).
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Куда выгружаются спаны.
+const (
+	ExporterOTLP   = "otlp"   // Коллектор OpenTelemetry, Jaeger и т.п. по OTLP/gRPC.
+	ExporterStdout = "stdout" // Стандартный вывод, по спану в строке. Для локального запуска.
+	ExporterNone   = ""       // Спаны не выгружаются, но контекст трассировки передается дальше.
+)
+
+const tracerName = "github.com/frutonanny/wallet-service"
+
+// enabled - спаны выгружаются. Иначе Start их не создает и не меняет контекст.
+var enabled int32
+
+// Enabled - выгружаются ли спаны. Позволяет не готовить атрибуты спана, который все равно не будет записан.
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) == 1
+}
+
+// Init настраивает трассировку процесса serviceName: выгрузку спанов в exporter и передачу контекста
+// трассировки. endpoint - адрес коллектора для ExporterOTLP, insecure - подключаться к нему без TLS.
+// Возвращаемая функция выгружает оставшиеся спаны, ее нужно вызвать при завершении.
+func Init(
+	ctx context.Context,
+	serviceName, exporter, endpoint string,
+	insecure bool,
+) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exp sdktrace.SpanExporter
+	var err error
+
+	switch exporter {
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exp, err = otlptracegrpc.New(ctx, opts...)
+
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", exporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("new %s exporter: %w", exporter, err)
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	atomic.StoreInt32(&enabled, 1)
+
+	return provider.Shutdown, nil
+}
+
+// Start начинает спан name. Если спаны не выгружаются, то контекст остается прежним, а спан ничего не записывает.
+func Start(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	if !Enabled() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer начинает спан входящего запроса name. Контекст трассировки вызывающего берется из carrier,
+// например, из заголовков http-запроса.
+func StartServer(
+	ctx context.Context,
+	name string,
+	carrier propagation.TextMapCarrier,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, carrier)
+
+	return otel.Tracer(tracerName).Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// End завершает спан, отмечая в нем ошибку err, если она есть.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
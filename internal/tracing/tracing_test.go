@@ -0,0 +1,35 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/internal/tracing"
+)
+
+func TestInit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown exporter", func(t *testing.T) {
+		_, err := tracing.Init(ctx, "wallet-service", "zipkin", "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("spans are not exported", func(t *testing.T) {
+		shutdown, err := tracing.Init(ctx, "wallet-service", tracing.ExporterNone, "", false)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, shutdown(ctx)) }()
+
+		spanCtx, span := tracing.Start(ctx, "reserve.Reserve")
+		tracing.End(span, errors.New("not enough cash"))
+
+		// Контекст остается прежним, поэтому моки в тестах сервисов сравнивают его как есть.
+		assert.Equal(t, ctx, spanCtx)
+		assert.False(t, span.IsRecording())
+		assert.False(t, tracing.Enabled())
+	})
+}
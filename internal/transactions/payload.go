@@ -7,14 +7,20 @@ import (
 
 const (
 	typeEnrollment = "enrollment"
+	typeAdjustment = "adjustment"
 )
 
 type payload struct {
-	OrderID int64 `json:"order_id"`
+	OrderID   int64 `json:"order_id"`
+	ServiceID int64 `json:"service_id,omitempty"`
 }
 
 type addPayload struct {
 	Type string `json:"type"`
+
+	// Reason и Operator есть только у корректировок: почему и кем изменен баланс.
+	Reason   string `json:"reason,omitempty"`
+	Operator string `json:"operator,omitempty"`
 }
 
 func EnrollmentPayload() (json.RawMessage, error) {
@@ -30,21 +36,39 @@ func EnrollmentPayload() (json.RawMessage, error) {
 	return b, nil
 }
 
-func ReservationPayload(orderID int64) (json.RawMessage, error) {
-	return commonPayload(orderID)
+// AdjustmentPayload - payload ручной корректировки баланса: зачисления (TypeAdd) или списания (TypeOutgoing).
+func AdjustmentPayload(reason, operator string) (json.RawMessage, error) {
+	d := addPayload{
+		Type:     typeAdjustment,
+		Reason:   reason,
+		Operator: operator,
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %v", err)
+	}
+
+	return b, nil
+}
+
+func ReservationPayload(orderID, serviceID int64) (json.RawMessage, error) {
+	return commonPayload(orderID, serviceID)
 }
 
-func WriteOffPayload(orderID int64) (json.RawMessage, error) {
-	return commonPayload(orderID)
+func WriteOffPayload(orderID, serviceID int64) (json.RawMessage, error) {
+	return commonPayload(orderID, serviceID)
 }
 
-func CancelPayload(orderID int64) (json.RawMessage, error) {
-	return commonPayload(orderID)
+// CancelPayload - serviceID может быть не передан (0), если заказ отменяется без указания услуги.
+func CancelPayload(orderID, serviceID int64) (json.RawMessage, error) {
+	return commonPayload(orderID, serviceID)
 }
 
-func commonPayload(orderID int64) (json.RawMessage, error) {
+func commonPayload(orderID, serviceID int64) (json.RawMessage, error) {
 	d := payload{
-		OrderID: orderID,
+		OrderID:   orderID,
+		ServiceID: serviceID,
 	}
 
 	b, err := json.Marshal(d)
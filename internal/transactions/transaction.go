@@ -7,8 +7,21 @@ const (
 	TypeReserve  = "reservation"
 	TypeWriteOff = "write_off"
 	TypeCancel   = "cancel"
+	// TypeOutgoing - списание средств с баланса без заказа, например, ручная корректировка.
+	TypeOutgoing = "outgoing_transfer"
 )
 
+// HasOrder отвечает, относится ли транзакция типа txType к заказу. У зачислений и списаний без заказа в payload
+// нет номера заказа.
+func HasOrder(txType string) bool {
+	switch txType {
+	case TypeReserve, TypeWriteOff, TypeCancel:
+		return true
+	default:
+		return false
+	}
+}
+
 type Transaction struct {
 	Description string
 	Amount      int64
@@ -0,0 +1,70 @@
+package webhookclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout         = 5 * time.Second
+	keepAlive           = 30 * time.Second
+	tlsHandshakeTimeout = 5 * time.Second
+	idleConnTimeout     = 90 * time.Second
+	maxIdleConns        = 100
+)
+
+// ErrForbiddenAddress - адрес подписчика ведет во внутреннюю сеть.
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// New создает http-клиент для запросов к подписчикам webhook-ов. Адрес подписки задает клиент API, поэтому:
+// - редиректы не выполняются, ответ 3xx считается ответом подписчика;
+// - соединения с loopback, link-local, частными и прочими внутренними адресами запрещены. Адрес проверяется
+// при соединении, после разрешения имени, так что не помогает и имя, которое указывает на внутренний адрес;
+// - прокси из окружения не используется, иначе проверялся бы адрес прокси, а не подписчика.
+func New() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: keepAlive,
+		Control:   control,
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: tlsHandshakeTimeout,
+			IdleConnTimeout:     idleConnTimeout,
+			MaxIdleConns:        maxIdleConns,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Allowed - можно ли отправлять запросы на адрес ip.
+func Allowed(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+func control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("split host port: %v", err)
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !Allowed(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package webhookclient_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/frutonanny/wallet-service/internal/webhookclient"
+)
+
+func TestAllowed(t *testing.T) {
+	cases := []struct {
+		ip      string
+		allowed bool
+	}{
+		{ip: "93.184.216.34", allowed: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", allowed: true},
+		{ip: "127.0.0.1", allowed: false},
+		{ip: "::1", allowed: false},
+		{ip: "10.1.2.3", allowed: false},
+		{ip: "172.16.0.1", allowed: false},
+		{ip: "192.168.1.1", allowed: false},
+		{ip: "169.254.169.254", allowed: false},
+		{ip: "fe80::1", allowed: false},
+		{ip: "fd00::1", allowed: false},
+		{ip: "0.0.0.0", allowed: false},
+		{ip: "224.0.0.1", allowed: false},
+		{ip: "::ffff:127.0.0.1", allowed: false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.allowed, webhookclient.Allowed(net.ParseIP(tc.ip)), tc.ip)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("internal address is refused", func(t *testing.T) {
+		called := false
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer srv.Close()
+
+		_, err := webhookclient.New().Post(srv.URL, "application/json", nil)
+		assert.ErrorIs(t, err, webhookclient.ErrForbiddenAddress)
+		assert.False(t, called)
+	})
+
+	t.Run("redirect is not followed", func(t *testing.T) {
+		client := webhookclient.New()
+
+		err := client.CheckRedirect(httptest.NewRequest(http.MethodPost, "http://partner.example", nil), nil)
+		assert.ErrorIs(t, err, http.ErrUseLastResponse)
+	})
+}
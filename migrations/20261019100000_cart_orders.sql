@@ -0,0 +1,11 @@
+-- +goose Up
+-- Заказ может состоять из нескольких позиций (корзина): у каждой позиции своя услуга, но общий внешний
+-- идентификатор заказа. Уникальной теперь является пара (external_id, service_id).
+alter table orders drop constraint orders_external_id_key;
+
+create unique index orders_external_service_idx on orders (external_id, service_id);
+
+-- +goose Down
+drop index orders_external_service_idx;
+
+alter table orders add constraint orders_external_id_key unique (external_id);
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Индекс для постраничного (keyset) вывода истории транзакций кошелька: позволяет не сканировать и не сортировать
+-- всю историю кошелька при переходе на дальние страницы.
+create index transactions_wallet_created_id_idx on transactions (wallet_id, created_at, id);
+
+-- +goose Down
+drop index transactions_wallet_created_id_idx;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Индексы под фильтры истории транзакций. Все запросы истории ограничены кошельком, поэтому wallet_id – первая колонка.
+-- Фильтр по услуге отдельного индекса не имеет: услуга может браться из заказа (см. orders_external_idx), а внутри
+-- кошелька выборку сужают индексы ниже.
+create index transactions_wallet_type_created_idx on transactions (wallet_id, "type", created_at desc);
+create index transactions_wallet_amount_idx on transactions (wallet_id, amount);
+create index transactions_wallet_order_idx on transactions (wallet_id, ((payload ->> 'order_id')::bigint));
+
+-- +goose Down
+drop index transactions_wallet_order_idx;
+drop index transactions_wallet_amount_idx;
+drop index transactions_wallet_type_created_idx;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Баланс кошелька после операции. Для транзакций, записанных ранее, баланс неизвестен (null).
+alter table transactions add column balance_after bigint;
+
+-- +goose Down
+alter table transactions drop column balance_after;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- В таблицу statements заносятся сформированные ежемесячные выписки пользователей. Сама выписка лежит в minio-бакете
+-- statements под именем object_name.
+create table statements
+(
+    id              serial primary key,
+    wallet_id       integer     not null references wallets (id),
+    "period"        text        not null, -- месяц выписки в формате YYYY-MM
+    object_name     text        not null,
+    opening_balance bigint      not null, -- все, что касается денег – в копейках
+    closing_balance bigint      not null,
+    created_at      timestamptz not null default now()
+);
+
+create unique index statements_wallet_period_idx on statements (wallet_id, period);
+
+-- +goose Down
+drop index statements_wallet_period_idx;
+drop table statements;
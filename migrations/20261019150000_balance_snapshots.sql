@@ -0,0 +1,16 @@
+-- +goose Up
+-- В таблицу balance_snapshots периодически заносится баланс кошельков. Снимок на момент taken_at учитывает
+-- все транзакции, созданные до taken_at, поэтому баланс на произвольный момент считается от ближайшего
+-- предшествующего снимка, а не от начала истории.
+create table balance_snapshots
+(
+    wallet_id  integer     not null references wallets (id),
+    taken_at   timestamptz not null,
+    available  bigint      not null, -- все, что касается денег – в копейках
+    reserved   bigint      not null,
+    created_at timestamptz not null default now(),
+    primary key (wallet_id, taken_at)
+);
+
+-- +goose Down
+drop table balance_snapshots;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Индекс для вывода открытых резервов кошелька: заказов в статусе reserved немного, поэтому индекс частичный.
+create index orders_wallet_reserved_idx on orders (wallet_id, created_at) where status = 'reserved';
+
+-- +goose Down
+drop index orders_wallet_reserved_idx;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- События об изменении баланса. Пишутся в той же транзакции, что и сама операция, и затем публикуются релеем.
+create table outbox
+(
+    id           bigserial primary key,
+    event_type   text        not null,
+    version      integer     not null,
+    payload      jsonb       not null,
+    created_at   timestamptz not null default now(),
+    published_at timestamptz
+);
+
+-- Релей выбирает только неопубликованные события, их немного, поэтому индекс частичный.
+create index outbox_unpublished_idx on outbox (id) where published_at is null;
+
+-- +goose Down
+drop table outbox;
@@ -0,0 +1,49 @@
+-- +goose Up
+-- Подписки партнеров на события. Пустой event_types – подписка на все события.
+create table webhook_subscriptions
+(
+    id          serial primary key,
+    url         text        not null,
+    event_types text[]      not null default '{}',
+    secret      text        not null, -- ключ для HMAC-подписи запросов
+    created_at  timestamptz not null default now()
+);
+
+-- Доставки событий подписчикам. status: pending – ждет очередной попытки, delivered – доставлено,
+-- dead – попытки исчерпаны, доставку можно повторить вручную.
+create table webhook_deliveries
+(
+    id              bigserial primary key,
+    subscription_id integer     not null references webhook_subscriptions (id),
+    event_id        bigint      not null references outbox (id),
+    event_type      text        not null,
+    payload         jsonb       not null, -- конверт события, он же тело запроса
+    status          text        not null default 'pending',
+    attempts        integer     not null default 0,
+    next_attempt_at timestamptz not null default now(),
+    last_error      text,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz not null default now()
+);
+
+create unique index webhook_deliveries_subscription_event_idx on webhook_deliveries (subscription_id, event_id);
+create index webhook_deliveries_pending_idx on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index webhook_deliveries_dead_idx on webhook_deliveries (id) where status = 'dead';
+
+-- Журнал попыток доставки.
+create table webhook_delivery_attempts
+(
+    id           bigserial primary key,
+    delivery_id  bigint      not null references webhook_deliveries (id),
+    status_code  integer, -- отсутствует, если ответа не было
+    error        text,
+    duration_ms  integer     not null,
+    attempted_at timestamptz not null default now()
+);
+
+create index webhook_delivery_attempts_delivery_idx on webhook_delivery_attempts (delivery_id);
+
+-- +goose Down
+drop table webhook_delivery_attempts;
+drop table webhook_deliveries;
+drop table webhook_subscriptions;
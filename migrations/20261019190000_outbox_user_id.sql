@@ -0,0 +1,11 @@
+-- +goose Up
+-- Пользователь события – для живой ленты событий пользователя и ее возобновления с последнего полученного события.
+alter table outbox add column user_id bigint;
+update outbox set user_id = (payload ->> 'userID')::bigint;
+alter table outbox alter column user_id set not null;
+
+create index outbox_user_id_idx on outbox (user_id, id);
+
+-- +goose Down
+drop index outbox_user_id_idx;
+alter table outbox drop column user_id;
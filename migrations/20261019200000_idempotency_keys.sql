@@ -0,0 +1,19 @@
+-- +goose Up
+-- Ключи идемпотентности изменяющих запросов. Пока запрос выполняется, ответа нет; после выполнения повтор с тем же
+-- ключом получает сохраненный ответ, а не выполняет операцию еще раз.
+create table idempotency_keys
+(
+    key          text        not null,
+    path         text        not null,
+    request_hash text        not null,
+    status_code  int,
+    content_type text,
+    response     bytea,
+    created_at   timestamptz not null default now(),
+    primary key (key, path)
+);
+
+create index idempotency_keys_created_at_idx on idempotency_keys (created_at);
+
+-- +goose Down
+drop table idempotency_keys;
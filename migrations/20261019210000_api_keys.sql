@@ -0,0 +1,14 @@
+-- +goose Up
+-- API-ключи вызывающих сервисов. Сам ключ не хранится, только его SHA-256. scopes – права из internal/auth.
+create table api_keys
+(
+    id         serial primary key,
+    name       text        not null, -- кому выдан ключ, например, orders
+    key_hash   text        not null unique,
+    scopes     text[]      not null default '{}',
+    created_at timestamptz not null default now(),
+    revoked_at timestamptz
+);
+
+-- +goose Down
+drop table api_keys;
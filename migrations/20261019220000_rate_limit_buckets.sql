@@ -0,0 +1,14 @@
+-- +goose Up
+-- Корзины токенов для ограничения частоты запросов, общие для всех реплик. key – операция и клиент или пользователь,
+-- tokens – сколько запросов можно сделать на момент updated_at.
+create table rate_limit_buckets
+(
+    key        text             not null primary key,
+    tokens     double precision not null,
+    updated_at timestamptz      not null default now()
+);
+
+create index rate_limit_buckets_updated_at_idx on rate_limit_buckets (updated_at);
+
+-- +goose Down
+drop table rate_limit_buckets;
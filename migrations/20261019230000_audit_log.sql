@@ -0,0 +1,73 @@
+-- +goose Up
+-- Журнал аудита: каждый изменяющий запрос к API и действие администратора. Строки только добавляются,
+-- изменить или удалить их не дают триггеры ниже.
+create table audit_log
+(
+    id            bigserial primary key,
+    created_at    timestamptz not null default now(),
+    request_id    text        not null,
+    client_key_id integer references api_keys (id), -- отсутствует, если запрос не аутентифицирован
+    client_name   text        not null,             -- имя клиента из api_keys, cli:<пользователь ОС> для cmd/apikeys
+    remote_addr   text        not null,
+    endpoint      text        not null,             -- путь http-запроса или полный метод gRPC
+    user_id       bigint,                           -- пользователь кошелька, если он есть в запросе
+    request       jsonb       not null,             -- тело запроса без секретов
+    result_code   text        not null              -- ok или код ошибки из pkg/errcodes
+);
+
+create index audit_log_user_id_idx on audit_log (user_id, id) where user_id is not null;
+create index audit_log_client_name_idx on audit_log (client_name, id);
+create index audit_log_created_at_idx on audit_log (created_at);
+
+-- Транзакции и операции по заказам, созданные запросом.
+create table audit_log_transactions
+(
+    audit_id       bigint  not null references audit_log (id),
+    transaction_id integer not null references transactions (id),
+    primary key (audit_id, transaction_id)
+);
+
+create index audit_log_transactions_transaction_idx on audit_log_transactions (transaction_id);
+
+create table audit_log_order_transactions
+(
+    audit_id             bigint  not null references audit_log (id),
+    order_transaction_id integer not null references order_transactions (id),
+    primary key (audit_id, order_transaction_id)
+);
+
+-- +goose StatementBegin
+create function audit_log_append_only() returns trigger as
+$$
+begin
+    raise exception 'audit log is append-only: % on % is not allowed', tg_op, tg_table_name;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+create trigger audit_log_append_only
+    before update or delete on audit_log
+    for each row execute procedure audit_log_append_only();
+create trigger audit_log_append_only_truncate
+    before truncate on audit_log
+    for each statement execute procedure audit_log_append_only();
+
+create trigger audit_log_transactions_append_only
+    before update or delete on audit_log_transactions
+    for each row execute procedure audit_log_append_only();
+create trigger audit_log_transactions_append_only_truncate
+    before truncate on audit_log_transactions
+    for each statement execute procedure audit_log_append_only();
+
+create trigger audit_log_order_transactions_append_only
+    before update or delete on audit_log_order_transactions
+    for each row execute procedure audit_log_append_only();
+create trigger audit_log_order_transactions_append_only_truncate
+    before truncate on audit_log_order_transactions
+    for each statement execute procedure audit_log_append_only();
+
+-- +goose Down
+drop table audit_log_order_transactions;
+drop table audit_log_transactions;
+drop table audit_log;
+drop function audit_log_append_only();
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Ключ идемпотентности выбирает клиент, поэтому два клиента могут прислать один и тот же. Ключи разделяются
+-- по API-ключу клиента, иначе второй клиент получит сохраненный ответ первого. Сохраненные ответы клиента
+-- не определить, а живут они сутки, поэтому удаляются.
+delete from idempotency_keys;
+
+alter table idempotency_keys
+    add column client_key_id integer not null references api_keys (id),
+    drop constraint idempotency_keys_pkey,
+    add primary key (client_key_id, key, path);
+
+-- +goose Down
+delete from idempotency_keys;
+
+alter table idempotency_keys
+    drop constraint idempotency_keys_pkey,
+    drop column client_key_id,
+    add primary key (key, path);
@@ -0,0 +1,1006 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.11.0 DO NOT EDIT.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
+)
+
+const (
+	ApiKeyScopes = "ApiKey.Scopes"
+)
+
+// Defines values for EventType.
+const (
+	WalletCancel           EventType = "wallet.cancel"
+	WalletIncomingTransfer EventType = "wallet.incoming_transfer"
+	WalletOutgoingTransfer EventType = "wallet.outgoing_transfer"
+	WalletReservation      EventType = "wallet.reservation"
+	WalletWriteOff         EventType = "wallet.write_off"
+)
+
+// Defines values for ExportStatementRequestDelivery.
+const (
+	Link   ExportStatementRequestDelivery = "link"
+	Stream ExportStatementRequestDelivery = "stream"
+)
+
+// Defines values for ExportStatementRequestFormat.
+const (
+	Csv    ExportStatementRequestFormat = "csv"
+	Ndjson ExportStatementRequestFormat = "ndjson"
+)
+
+// Defines values for GetTransactionsRequestDirection.
+const (
+	Asc  GetTransactionsRequestDirection = "asc"
+	Desc GetTransactionsRequestDirection = "desc"
+)
+
+// Defines values for GetTransactionsRequestSortBy.
+const (
+	Amount    GetTransactionsRequestSortBy = "amount"
+	CreatedAt GetTransactionsRequestSortBy = "created_at"
+)
+
+// Defines values for TransactionType.
+const (
+	Cancel           TransactionType = "cancel"
+	IncomingTransfer TransactionType = "incoming_transfer"
+	OutgoingTransfer TransactionType = "outgoing_transfer"
+	Reservation      TransactionType = "reservation"
+	WriteOff         TransactionType = "write_off"
+)
+
+// AddData defines model for AddData.
+type AddData struct {
+	// Текущий баланс пользователя в копейках с учетом пополнения.
+	Balance int64 `json:"balance"`
+}
+
+// AddRequest defines model for AddRequest.
+type AddRequest struct {
+	// Сумма в копейках.
+	Cash int64 `json:"cash"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// AddResponse defines model for AddResponse.
+type AddResponse struct {
+	Data  *AddData `json:"data,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// AddWebhookData defines model for AddWebhookData.
+type AddWebhookData struct {
+	// Секрет для проверки HMAC-подписи запросов (заголовок X-Wallet-Signature).
+	Secret string `json:"secret"`
+
+	// Идентификатор подписки.
+	SubscriptionID int64 `json:"subscriptionID"`
+}
+
+// AddWebhookRequest defines model for AddWebhookRequest.
+type AddWebhookRequest struct {
+	// Типы событий. Если не переданы, то подписка на все события.
+	EventTypes *[]EventType `json:"eventTypes,omitempty"`
+
+	// Адрес, на который отправляются события.
+	Url string `json:"url"`
+}
+
+// AddWebhookResponse defines model for AddWebhookResponse.
+type AddWebhookResponse struct {
+	Data  *AddWebhookData `json:"data,omitempty"`
+	Error *Error          `json:"error,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Идентификатор API-ключа. Отсутствует, если запрос не аутентифицирован.
+	ClientKeyID *int64 `json:"clientKeyID,omitempty"`
+
+	// Имя API-ключа. Пустое, если запрос не аутентифицирован.
+	ClientName string    `json:"clientName"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Endpoint   string    `json:"endpoint"`
+	Id         int64     `json:"id"`
+
+	// Операции по заказам, созданные запросом.
+	OrderTransactionIDs []int64 `json:"orderTransactionIDs"`
+	RemoteAddr          string  `json:"remoteAddr"`
+
+	// Тело запроса. Значения секретов, например, secret webhook-а, скрыты.
+	Request AuditEntry_Request `json:"request"`
+
+	// Идентификатор запроса из заголовка X-Request-ID.
+	RequestID string `json:"requestID"`
+
+	// ok или код ошибки.
+	ResultCode string `json:"resultCode"`
+
+	// Транзакции, созданные запросом.
+	TransactionIDs []int64 `json:"transactionIDs"`
+
+	// Пользователь, если он есть в запросе.
+	UserID *int64 `json:"userID,omitempty"`
+}
+
+// Тело запроса. Значения секретов, например, secret webhook-а, скрыты.
+type AuditEntry_Request struct {
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// Balance defines model for Balance.
+type Balance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+}
+
+// CancelData defines model for CancelData.
+type CancelData struct {
+	// Текущий баланс пользователя в копейках с учетом разрезервированных средств.
+	Balance int64 `json:"balance"`
+}
+
+// CancelRequest defines model for CancelRequest.
+type CancelRequest struct {
+	// Идентификатор заказа
+	OrderID int64 `json:"orderID"`
+
+	// Идентификатор услуги. Обязателен, если заказ состоит из нескольких позиций (корзина).
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// CancelResponse defines model for CancelResponse.
+type CancelResponse struct {
+	Data  *CancelData `json:"data,omitempty"`
+	Error *Error      `json:"error,omitempty"`
+}
+
+// CartItem defines model for CartItem.
+type CartItem struct {
+	// Стоимость позиции в копейках.
+	Price int64 `json:"price"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+}
+
+// DailyBalance defines model for DailyBalance.
+type DailyBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// День, на конец которого указан баланс.
+	Date openapi_types.Date `json:"date"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+}
+
+// DetailedBalance defines model for DetailedBalance.
+type DetailedBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Тип события об изменении баланса.
+type EventType string
+
+// ExportStatementData defines model for ExportStatementData.
+type ExportStatementData struct {
+	// Время, до которого действует ссылка.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Ссылка на выписку в хранилище.
+	Url string `json:"url"`
+}
+
+// ExportStatementRequest defines model for ExportStatementRequest.
+type ExportStatementRequest struct {
+	// Способ получения выписки: в теле ответа или по ссылке на хранилище.
+	Delivery *ExportStatementRequestDelivery `json:"delivery,omitempty"`
+
+	// Временная точка в формате RFC3339, которой заканчивается выписка.
+	End time.Time `json:"end"`
+
+	// Формат выписки.
+	Format ExportStatementRequestFormat `json:"format"`
+
+	// Временная точка в формате RFC3339, от которой начинается выписка.
+	Start time.Time `json:"start"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// Способ получения выписки: в теле ответа или по ссылке на хранилище.
+type ExportStatementRequestDelivery string
+
+// Формат выписки.
+type ExportStatementRequestFormat string
+
+// ExportStatementResponse defines model for ExportStatementResponse.
+type ExportStatementResponse struct {
+	Data  *ExportStatementData `json:"data,omitempty"`
+	Error *Error               `json:"error,omitempty"`
+}
+
+// FailedDelivery defines model for FailedDelivery.
+type FailedDelivery struct {
+	// Количество сделанных попыток.
+	Attempts int `json:"attempts"`
+
+	// Идентификатор события.
+	EventID   int64  `json:"eventID"`
+	EventType string `json:"eventType"`
+
+	// Время последней попытки.
+	FailedAt time.Time `json:"failedAt"`
+
+	// Идентификатор доставки.
+	Id int64 `json:"id"`
+
+	// Ошибка последней попытки.
+	LastError      string `json:"lastError"`
+	SubscriptionID int64  `json:"subscriptionID"`
+	Url            string `json:"url"`
+}
+
+// GetAuditLogData defines model for GetAuditLogData.
+type GetAuditLogData struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// GetAuditLogRequest defines model for GetAuditLogRequest.
+type GetAuditLogRequest struct {
+	// Идентификатор последней записи предыдущей страницы.
+	AfterID *int64 `json:"afterID,omitempty"`
+
+	// Только запросы клиента с таким именем API-ключа.
+	ClientName *string `json:"clientName,omitempty"`
+
+	// Только запросы к методу: путь v1, метод и путь v2 или полный метод gRPC.
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Начало периода, включительно.
+	From *time.Time `json:"from,omitempty"`
+
+	// Количество записей на странице.
+	Limit *int `json:"limit,omitempty"`
+
+	// Конец периода, не включительно.
+	To *time.Time `json:"to,omitempty"`
+
+	// Только запрос, который создал транзакцию.
+	TransactionID *int64 `json:"transactionID,omitempty"`
+
+	// Только запросы по пользователю.
+	UserID *int64 `json:"userID,omitempty"`
+}
+
+// GetAuditLogResponse defines model for GetAuditLogResponse.
+type GetAuditLogResponse struct {
+	Data  *GetAuditLogData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
+// GetBalanceAtRequest defines model for GetBalanceAtRequest.
+type GetBalanceAtRequest struct {
+	// Момент времени в формате RFC3339.
+	At time.Time `json:"at"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetBalanceAtResponse defines model for GetBalanceAtResponse.
+type GetBalanceAtResponse struct {
+	Data  *Balance `json:"data,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// GetBalanceData defines model for GetBalanceData.
+type GetBalanceData struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Текущий баланс пользователя в копейках. Совпадает с available, оставлен для совместимости.
+	Balance int64 `json:"balance"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
+}
+
+// GetBalanceRequest defines model for GetBalanceRequest.
+type GetBalanceRequest struct {
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetBalanceResponse defines model for GetBalanceResponse.
+type GetBalanceResponse struct {
+	Data  *GetBalanceData `json:"data,omitempty"`
+	Error *Error          `json:"error,omitempty"`
+}
+
+// GetBalancesData defines model for GetBalancesData.
+type GetBalancesData struct {
+	// Балансы в порядке запроса. Пользователи без кошелька в ответ не попадают.
+	Balances []UserBalance `json:"balances"`
+}
+
+// GetBalancesRequest defines model for GetBalancesRequest.
+type GetBalancesRequest struct {
+	// Идентификаторы пользователей.
+	UserIDs []int64 `json:"userIDs"`
+}
+
+// GetBalancesResponse defines model for GetBalancesResponse.
+type GetBalancesResponse struct {
+	Data  *GetBalancesData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
+// GetDailyBalancesData defines model for GetDailyBalancesData.
+type GetDailyBalancesData struct {
+	Balances []DailyBalance `json:"balances"`
+}
+
+// GetDailyBalancesRequest defines model for GetDailyBalancesRequest.
+type GetDailyBalancesRequest struct {
+	// Первый день промежутка.
+	From openapi_types.Date `json:"from"`
+
+	// Последний день промежутка.
+	To openapi_types.Date `json:"to"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetDailyBalancesResponse defines model for GetDailyBalancesResponse.
+type GetDailyBalancesResponse struct {
+	Data  *GetDailyBalancesData `json:"data,omitempty"`
+	Error *Error                `json:"error,omitempty"`
+}
+
+// GetFailedDeliveriesData defines model for GetFailedDeliveriesData.
+type GetFailedDeliveriesData struct {
+	Deliveries []FailedDelivery `json:"deliveries"`
+}
+
+// GetFailedDeliveriesRequest defines model for GetFailedDeliveriesRequest.
+type GetFailedDeliveriesRequest struct {
+	// Идентификатор последней доставки предыдущей страницы.
+	AfterID *int64 `json:"afterID,omitempty"`
+
+	// Количество доставок на странице.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// GetFailedDeliveriesResponse defines model for GetFailedDeliveriesResponse.
+type GetFailedDeliveriesResponse struct {
+	Data  *GetFailedDeliveriesData `json:"data,omitempty"`
+	Error *Error                   `json:"error,omitempty"`
+}
+
+// GetHistoryData defines model for GetHistoryData.
+type GetHistoryData struct {
+	// Токен следующей страницы. Отсутствует, если это последняя страница.
+	NextPageToken *string `json:"nextPageToken,omitempty"`
+
+	// Страница истории транзакций пользователя userID.
+	Transactions []Transaction `json:"transactions"`
+}
+
+// GetHistoryRequest defines model for GetHistoryRequest.
+type GetHistoryRequest struct {
+	// Количество записей на странице.
+	Limit int64 `json:"limit"`
+
+	// Токен страницы из поля nextPageToken предыдущего ответа. Для первой страницы не передается.
+	PageToken *string `json:"pageToken,omitempty"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetHistoryResponse defines model for GetHistoryResponse.
+type GetHistoryResponse struct {
+	Data  *GetHistoryData `json:"data,omitempty"`
+	Error *Error          `json:"error,omitempty"`
+}
+
+// GetReportData defines model for GetReportData.
+type GetReportData struct {
+	// Ссылка на CSV файл.
+	Url string `json:"url"`
+}
+
+// GetReportRequest defines model for GetReportRequest.
+type GetReportRequest struct {
+	// Время в формате 'yyyy-mm'.
+	Period string `json:"period"`
+}
+
+// GetReportResponse defines model for GetReportResponse.
+type GetReportResponse struct {
+	Data  *GetReportData `json:"data,omitempty"`
+	Error *Error         `json:"error,omitempty"`
+}
+
+// GetStatementData defines model for GetStatementData.
+type GetStatementData struct {
+	// Время, до которого действует ссылка.
+	ExpiresAt time.Time `json:"expiresAt"`
+	Statement Statement `json:"statement"`
+
+	// Ссылка на выписку в хранилище (JSON-документ с итогами по типам операций и списком операций).
+	Url string `json:"url"`
+}
+
+// GetStatementRequest defines model for GetStatementRequest.
+type GetStatementRequest struct {
+	// Месяц выписки в формате YYYY-MM.
+	Period string `json:"period"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetStatementResponse defines model for GetStatementResponse.
+type GetStatementResponse struct {
+	Data  *GetStatementData `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GetStatementsData defines model for GetStatementsData.
+type GetStatementsData struct {
+	Statements []Statement `json:"statements"`
+}
+
+// GetStatementsRequest defines model for GetStatementsRequest.
+type GetStatementsRequest struct {
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetStatementsResponse defines model for GetStatementsResponse.
+type GetStatementsResponse struct {
+	Data  *GetStatementsData `json:"data,omitempty"`
+	Error *Error             `json:"error,omitempty"`
+}
+
+// GetTransactionRequest defines model for GetTransactionRequest.
+type GetTransactionRequest struct {
+	// Идентификатор транзакции.
+	TransactionID int64 `json:"transactionID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetTransactionResponse defines model for GetTransactionResponse.
+type GetTransactionResponse struct {
+	Data  *Transaction `json:"data,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// GetTransactionsByTimeData defines model for GetTransactionsByTimeData.
+type GetTransactionsByTimeData struct {
+	// Список транзакций пользователя userID.
+	Transactions []Transaction `json:"transactions"`
+}
+
+// GetTransactionsByTimeRequest defines model for GetTransactionsByTimeRequest.
+type GetTransactionsByTimeRequest struct {
+	// Временная точка в формате RFC3339, до которой происходит поиск транзакций.
+	End time.Time `json:"end"`
+
+	// Условия отбора транзакций. Все переданные условия объединяются через "И".
+	Filter *TransactionsFilter `json:"filter,omitempty"`
+
+	// Временная точка в формате RFC3339, от которой начинается поиск транзакций.
+	Start time.Time `json:"start"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// GetTransactionsByTimeResponse defines model for GetTransactionsByTimeResponse.
+type GetTransactionsByTimeResponse struct {
+	Data  *GetTransactionsByTimeData `json:"data,omitempty"`
+	Error *Error                     `json:"error,omitempty"`
+}
+
+// GetTransactionsData defines model for GetTransactionsData.
+type GetTransactionsData struct {
+	// Отсортированный список транзакций пользователя userID.
+	Transactions []Transaction `json:"transactions"`
+}
+
+// GetTransactionsRequest defines model for GetTransactionsRequest.
+type GetTransactionsRequest struct {
+	// Направление сортировки (по возрастанию / убыванию).
+	Direction GetTransactionsRequestDirection `json:"direction"`
+
+	// Условия отбора транзакций. Все переданные условия объединяются через "И".
+	Filter *TransactionsFilter `json:"filter,omitempty"`
+
+	// Количество записей.
+	Limit int64 `json:"limit"`
+
+	// Смещение по записям.
+	Offset int64 `json:"offset"`
+
+	// Поле, по которому происходит сортировка (по дате / по сумме).
+	SortBy GetTransactionsRequestSortBy `json:"sortBy"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// Направление сортировки (по возрастанию / убыванию).
+type GetTransactionsRequestDirection string
+
+// Поле, по которому происходит сортировка (по дате / по сумме).
+type GetTransactionsRequestSortBy string
+
+// GetTransactionsResponse defines model for GetTransactionsResponse.
+type GetTransactionsResponse struct {
+	Data  *GetTransactionsData `json:"data,omitempty"`
+	Error *Error               `json:"error,omitempty"`
+}
+
+// Hold defines model for Hold.
+type Hold struct {
+	// Зарезервированная сумма в копейках.
+	Amount int64 `json:"amount"`
+
+	// Время резервирования.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Идентификатор заказа.
+	OrderID int64 `json:"orderID"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+}
+
+// ReplayDeliveryData defines model for ReplayDeliveryData.
+type ReplayDeliveryData struct {
+	DeliveryID int64 `json:"deliveryID"`
+}
+
+// ReplayDeliveryRequest defines model for ReplayDeliveryRequest.
+type ReplayDeliveryRequest struct {
+	// Идентификатор доставки.
+	DeliveryID int64 `json:"deliveryID"`
+}
+
+// ReplayDeliveryResponse defines model for ReplayDeliveryResponse.
+type ReplayDeliveryResponse struct {
+	Data  *ReplayDeliveryData `json:"data,omitempty"`
+	Error *Error              `json:"error,omitempty"`
+}
+
+// ReserveCartData defines model for ReserveCartData.
+type ReserveCartData struct {
+	// Текущий баланс пользователя в копейках за вычетом зарезервированных средств.
+	Balance int64 `json:"balance"`
+}
+
+// ReserveCartRequest defines model for ReserveCartRequest.
+type ReserveCartRequest struct {
+	// Позиции корзины. Услуги в корзине не должны повторяться.
+	Items []CartItem `json:"items"`
+
+	// Идентификатор заказа
+	OrderID int64 `json:"orderID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// ReserveCartResponse defines model for ReserveCartResponse.
+type ReserveCartResponse struct {
+	Data  *ReserveCartData `json:"data,omitempty"`
+	Error *Error           `json:"error,omitempty"`
+}
+
+// ReserveData defines model for ReserveData.
+type ReserveData struct {
+	// Текущий баланс пользователя в копейках за вычетом зарезервированных средств.
+	Balance int64 `json:"balance"`
+}
+
+// ReserveRequest defines model for ReserveRequest.
+type ReserveRequest struct {
+	// Идентификатор заказа
+	OrderID int64 `json:"orderID"`
+
+	// Стоимость заказа в копейках.
+	Price int64 `json:"price"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// ReserveResponse defines model for ReserveResponse.
+type ReserveResponse struct {
+	Data  *ReserveData `json:"data,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// Statement defines model for Statement.
+type Statement struct {
+	// Средства на конец месяца (доступные и зарезервированные вместе) в копейках.
+	ClosingBalance int64 `json:"closingBalance"`
+
+	// Время формирования выписки.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Средства на начало месяца (доступные и зарезервированные вместе) в копейках.
+	OpeningBalance int64 `json:"openingBalance"`
+
+	// Месяц выписки в формате YYYY-MM.
+	Period string `json:"period"`
+}
+
+// Ответ отдается, только если ленту открыть не удалось.
+type StreamEventsResponse struct {
+	Error *Error `json:"error,omitempty"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	// Количество денежных средств, задействованных в данной денежной операции.
+	Amount int64 `json:"amount"`
+
+	// Баланс пользователя в копейках после операции. Отсутствует у транзакций, проведенных до появления этой информации.
+	BalanceAfter *int64 `json:"balanceAfter,omitempty"`
+
+	// Время, когда была совершена операция.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Описание денежной операции.
+	Description string `json:"description"`
+
+	// Идентификатор транзакции.
+	Id int64 `json:"id"`
+
+	// Идентификатор заказа. Отсутствует у зачислений.
+	OrderID *int64 `json:"orderID,omitempty"`
+
+	// Идентификатор услуги. Отсутствует у зачислений.
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
+	// Тип транзакции: зачисление / резервирование / списание / отмена резервирования / списание без заказа.
+	Type TransactionType `json:"type"`
+}
+
+// Тип транзакции: зачисление / резервирование / списание / отмена резервирования / списание без заказа.
+type TransactionType string
+
+// Условия отбора транзакций. Все переданные условия объединяются через "И".
+type TransactionsFilter struct {
+	// Минимальная сумма транзакции в копейках (включительно).
+	AmountFrom *int64 `json:"amountFrom,omitempty"`
+
+	// Максимальная сумма транзакции в копейках (включительно).
+	AmountTo *int64 `json:"amountTo,omitempty"`
+
+	// Идентификатор заказа.
+	OrderID *int64 `json:"orderID,omitempty"`
+
+	// Идентификатор услуги.
+	ServiceID *int64 `json:"serviceID,omitempty"`
+
+	// Типы транзакций: зачисление / резервирование / списание / отмена резервирования.
+	Types *[]TransactionType `json:"types,omitempty"`
+}
+
+// UserBalance defines model for UserBalance.
+type UserBalance struct {
+	// Доступные средства в копейках.
+	Available int64 `json:"available"`
+
+	// Открытые резервы: позиции заказов, средства под которые зарезервированы, от старых к новым.
+	Holds []Hold `json:"holds"`
+
+	// Зарезервированные средства в копейках.
+	Reserved int64 `json:"reserved"`
+
+	// Все средства (доступные и зарезервированные) в копейках.
+	Total int64 `json:"total"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// WriteOffData defines model for WriteOffData.
+type WriteOffData struct {
+	// Текущий баланс пользователя в копейках за вычетом списанных средств.
+	Balance int64 `json:"balance"`
+}
+
+// WriteOffRequest defines model for WriteOffRequest.
+type WriteOffRequest struct {
+	// Идентификатор заказа
+	OrderID int64 `json:"orderID"`
+
+	// Стоимость заказа в копейках.
+	Price int64 `json:"price"`
+
+	// Идентификатор услуги.
+	ServiceID int64 `json:"serviceID"`
+
+	// Идентификатор пользователя.
+	UserID int64 `json:"userID"`
+}
+
+// WriteOffResponse defines model for WriteOffResponse.
+type WriteOffResponse struct {
+	Data  *WriteOffData `json:"data,omitempty"`
+	Error *Error        `json:"error,omitempty"`
+}
+
+// AcceptLanguage defines model for AcceptLanguage.
+type AcceptLanguage = string
+
+// PostAddJSONBody defines parameters for PostAdd.
+type PostAddJSONBody = AddRequest
+
+// PostAdminAddWebhookJSONBody defines parameters for PostAdminAddWebhook.
+type PostAdminAddWebhookJSONBody = AddWebhookRequest
+
+// PostAdminGetAuditLogJSONBody defines parameters for PostAdminGetAuditLog.
+type PostAdminGetAuditLogJSONBody = GetAuditLogRequest
+
+// PostAdminGetFailedDeliveriesJSONBody defines parameters for PostAdminGetFailedDeliveries.
+type PostAdminGetFailedDeliveriesJSONBody = GetFailedDeliveriesRequest
+
+// PostAdminReplayDeliveryJSONBody defines parameters for PostAdminReplayDelivery.
+type PostAdminReplayDeliveryJSONBody = ReplayDeliveryRequest
+
+// PostCancelJSONBody defines parameters for PostCancel.
+type PostCancelJSONBody = CancelRequest
+
+// PostExportStatementJSONBody defines parameters for PostExportStatement.
+type PostExportStatementJSONBody = ExportStatementRequest
+
+// PostExportStatementParams defines parameters for PostExportStatement.
+type PostExportStatementParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostGetBalanceJSONBody defines parameters for PostGetBalance.
+type PostGetBalanceJSONBody = GetBalanceRequest
+
+// PostGetBalanceAtJSONBody defines parameters for PostGetBalanceAt.
+type PostGetBalanceAtJSONBody = GetBalanceAtRequest
+
+// PostGetBalancesJSONBody defines parameters for PostGetBalances.
+type PostGetBalancesJSONBody = GetBalancesRequest
+
+// PostGetDailyBalancesJSONBody defines parameters for PostGetDailyBalances.
+type PostGetDailyBalancesJSONBody = GetDailyBalancesRequest
+
+// PostGetHistoryJSONBody defines parameters for PostGetHistory.
+type PostGetHistoryJSONBody = GetHistoryRequest
+
+// PostGetHistoryParams defines parameters for PostGetHistory.
+type PostGetHistoryParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostGetReportJSONBody defines parameters for PostGetReport.
+type PostGetReportJSONBody = GetReportRequest
+
+// PostGetStatementJSONBody defines parameters for PostGetStatement.
+type PostGetStatementJSONBody = GetStatementRequest
+
+// PostGetStatementsJSONBody defines parameters for PostGetStatements.
+type PostGetStatementsJSONBody = GetStatementsRequest
+
+// PostGetTransactionJSONBody defines parameters for PostGetTransaction.
+type PostGetTransactionJSONBody = GetTransactionRequest
+
+// PostGetTransactionParams defines parameters for PostGetTransaction.
+type PostGetTransactionParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostGetTransactionsJSONBody defines parameters for PostGetTransactions.
+type PostGetTransactionsJSONBody = GetTransactionsRequest
+
+// PostGetTransactionsParams defines parameters for PostGetTransactions.
+type PostGetTransactionsParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostGetTransactionsByTimeJSONBody defines parameters for PostGetTransactionsByTime.
+type PostGetTransactionsByTimeJSONBody = GetTransactionsByTimeRequest
+
+// PostGetTransactionsByTimeParams defines parameters for PostGetTransactionsByTime.
+type PostGetTransactionsByTimeParams struct {
+	// Предпочитаемые языки описаний транзакций (например, "en-US,en;q=0.9"). Поддерживаются ru и en, по умолчанию ru.
+	AcceptLanguage *AcceptLanguage `json:"Accept-Language,omitempty"`
+}
+
+// PostReserveJSONBody defines parameters for PostReserve.
+type PostReserveJSONBody = ReserveRequest
+
+// PostReserveCartJSONBody defines parameters for PostReserveCart.
+type PostReserveCartJSONBody = ReserveCartRequest
+
+// GetStreamEventsParams defines parameters for GetStreamEvents.
+type GetStreamEventsParams struct {
+	// Идентификатор пользователя.
+	UserID int64 `form:"userID" json:"userID"`
+
+	// Идентификатор последнего полученного события.
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// PostWriteOffJSONBody defines parameters for PostWriteOff.
+type PostWriteOffJSONBody = WriteOffRequest
+
+// PostAddJSONRequestBody defines body for PostAdd for application/json ContentType.
+type PostAddJSONRequestBody = PostAddJSONBody
+
+// PostAdminAddWebhookJSONRequestBody defines body for PostAdminAddWebhook for application/json ContentType.
+type PostAdminAddWebhookJSONRequestBody = PostAdminAddWebhookJSONBody
+
+// PostAdminGetAuditLogJSONRequestBody defines body for PostAdminGetAuditLog for application/json ContentType.
+type PostAdminGetAuditLogJSONRequestBody = PostAdminGetAuditLogJSONBody
+
+// PostAdminGetFailedDeliveriesJSONRequestBody defines body for PostAdminGetFailedDeliveries for application/json ContentType.
+type PostAdminGetFailedDeliveriesJSONRequestBody = PostAdminGetFailedDeliveriesJSONBody
+
+// PostAdminReplayDeliveryJSONRequestBody defines body for PostAdminReplayDelivery for application/json ContentType.
+type PostAdminReplayDeliveryJSONRequestBody = PostAdminReplayDeliveryJSONBody
+
+// PostCancelJSONRequestBody defines body for PostCancel for application/json ContentType.
+type PostCancelJSONRequestBody = PostCancelJSONBody
+
+// PostExportStatementJSONRequestBody defines body for PostExportStatement for application/json ContentType.
+type PostExportStatementJSONRequestBody = PostExportStatementJSONBody
+
+// PostGetBalanceJSONRequestBody defines body for PostGetBalance for application/json ContentType.
+type PostGetBalanceJSONRequestBody = PostGetBalanceJSONBody
+
+// PostGetBalanceAtJSONRequestBody defines body for PostGetBalanceAt for application/json ContentType.
+type PostGetBalanceAtJSONRequestBody = PostGetBalanceAtJSONBody
+
+// PostGetBalancesJSONRequestBody defines body for PostGetBalances for application/json ContentType.
+type PostGetBalancesJSONRequestBody = PostGetBalancesJSONBody
+
+// PostGetDailyBalancesJSONRequestBody defines body for PostGetDailyBalances for application/json ContentType.
+type PostGetDailyBalancesJSONRequestBody = PostGetDailyBalancesJSONBody
+
+// PostGetHistoryJSONRequestBody defines body for PostGetHistory for application/json ContentType.
+type PostGetHistoryJSONRequestBody = PostGetHistoryJSONBody
+
+// PostGetReportJSONRequestBody defines body for PostGetReport for application/json ContentType.
+type PostGetReportJSONRequestBody = PostGetReportJSONBody
+
+// PostGetStatementJSONRequestBody defines body for PostGetStatement for application/json ContentType.
+type PostGetStatementJSONRequestBody = PostGetStatementJSONBody
+
+// PostGetStatementsJSONRequestBody defines body for PostGetStatements for application/json ContentType.
+type PostGetStatementsJSONRequestBody = PostGetStatementsJSONBody
+
+// PostGetTransactionJSONRequestBody defines body for PostGetTransaction for application/json ContentType.
+type PostGetTransactionJSONRequestBody = PostGetTransactionJSONBody
+
+// PostGetTransactionsJSONRequestBody defines body for PostGetTransactions for application/json ContentType.
+type PostGetTransactionsJSONRequestBody = PostGetTransactionsJSONBody
+
+// PostGetTransactionsByTimeJSONRequestBody defines body for PostGetTransactionsByTime for application/json ContentType.
+type PostGetTransactionsByTimeJSONRequestBody = PostGetTransactionsByTimeJSONBody
+
+// PostReserveJSONRequestBody defines body for PostReserve for application/json ContentType.
+type PostReserveJSONRequestBody = PostReserveJSONBody
+
+// PostReserveCartJSONRequestBody defines body for PostReserveCart for application/json ContentType.
+type PostReserveCartJSONRequestBody = PostReserveCartJSONBody
+
+// PostWriteOffJSONRequestBody defines body for PostWriteOff for application/json ContentType.
+type PostWriteOffJSONRequestBody = PostWriteOffJSONBody
+
+// Getter for additional properties for AuditEntry_Request. Returns the specified
+// element and whether it was found
+func (a AuditEntry_Request) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for AuditEntry_Request
+func (a *AuditEntry_Request) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for AuditEntry_Request to handle AdditionalProperties
+func (a *AuditEntry_Request) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for AuditEntry_Request to handle AdditionalProperties
+func (a AuditEntry_Request) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
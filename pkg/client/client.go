@@ -0,0 +1,326 @@
+// Package client - клиент http API кошелька (api/schema.yaml). Типы запросов и ответов сгенерированы
+// из схемы в пакет pkg/client/api.
+//
+// Клиент:
+//   - возвращает ошибки сервиса как *Error, их можно сравнивать с ErrWalletNotFound и другими через errors.Is;
+//   - передает в изменяющие запросы заголовок Idempotency-Key, один и тот же для всех попыток вызова;
+//   - повторяет запрос с экспоненциальной задержкой только после ошибок, повтор которых безопасен:
+//     сетевых ошибок, ответов 429, 502, 503, 504 и кодов internal_error и request_in_progress;
+//   - не повторяет запрос после истечения дедлайна контекста и не ждет дольше него.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 100 * time.Millisecond
+	defaultMaxDelay    = 2 * time.Second
+	defaultTimeout     = 5 * time.Second
+
+	// maxErrorBody - сколько байт тела ответа без конверта попадет в текст ошибки.
+	maxErrorBody = 256
+)
+
+type Client struct {
+	server      string
+	httpClient  *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	timeout     time.Duration
+	apiKey      string
+}
+
+type Option func(c *Client)
+
+// WithHTTPClient задает http-клиент. По умолчанию – http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry задает число попыток вызова и границы задержки между ними. maxAttempts = 1 отключает повторы.
+// Задержка перед n-й повторной попыткой – около baseDelay * 2^(n-1), но не больше maxDelay.
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.baseDelay = baseDelay
+		c.maxDelay = maxDelay
+	}
+}
+
+// WithAPIKey задает API-ключ, который передается в заголовке Authorization. Ключ выдает команда cmd/apikeys.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithTimeout задает таймаут одной попытки. Общее время вызова ограничивается дедлайном контекста.
+// 0 – без таймаута.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// New создает клиент. server – адрес сервиса без версии API, например, http://localhost:8081.
+func New(server string, opts ...Option) (*Client, error) {
+	if server == "" {
+		return nil, errors.New("empty server address")
+	}
+
+	c := &Client{
+		server:      strings.TrimSuffix(server, "/"),
+		httpClient:  http.DefaultClient,
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+		maxDelay:    defaultMaxDelay,
+		timeout:     defaultTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.maxAttempts < 1 {
+		return nil, fmt.Errorf("invalid max attempts: %d", c.maxAttempts)
+	}
+
+	return c, nil
+}
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey задает ключ идемпотентности для изменяющего вызова. Пригодится, если вызов повторяется
+// уже после того, как клиент исчерпал свои попытки, например, после перезапуска потребителя.
+// Без него клиент генерирует новый ключ на каждый вызов.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	return uuid.NewString()
+}
+
+// envelope - общий вид ответа v1 API.
+type envelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call выполняет POST-запрос к path с повторами. Для изменяющих запросов (mutating) передается ключ идемпотентности.
+// Данные ответа раскладываются в out.
+func (c *Client) call(ctx context.Context, path string, mutating bool, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	var key string
+	if mutating {
+		key = idempotencyKey(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = c.attempt(ctx, path, key, body, out)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= c.maxAttempts || !c.retryable(ctx, err) {
+			return err
+		}
+
+		if !c.wait(ctx, c.delay(attempt, err)) {
+			return err
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, path, key string, body []byte, out interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.server+"/v1"+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &transportError{err: fmt.Errorf("do request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &transportError{err: fmt.Errorf("read response: %w", err)}
+	}
+
+	var env envelope
+	if resp.StatusCode != http.StatusOK || json.Unmarshal(respBody, &env) != nil {
+		return statusError(resp, respBody)
+	}
+
+	if env.Error != nil {
+		return &Error{
+			Code:       env.Error.Code,
+			Message:    env.Error.Message,
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("unmarshal data: %w", err)
+	}
+
+	return nil
+}
+
+// statusError - ошибка для ответа со статусом не 200: с конвертом (например, 429 от ограничения частоты запросов)
+// или без него, например, от валидатора запросов или балансировщика.
+func statusError(resp *http.Response, body []byte) *Error {
+	var env envelope
+	if json.Unmarshal(body, &env) == nil && env.Error != nil {
+		return &Error{
+			Code:       env.Error.Code,
+			Message:    env.Error.Message,
+			StatusCode: resp.StatusCode,
+			retryAfter: retryAfter(resp),
+		}
+	}
+
+	code := errcodes.InternalError
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		code = errcodes.InvalidRequest
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorBody {
+		msg = msg[:maxErrorBody]
+	}
+	if msg == "" {
+		msg = resp.Status
+	}
+
+	return &Error{
+		Code:       code,
+		Message:    msg,
+		StatusCode: resp.StatusCode,
+		retryAfter: retryAfter(resp),
+	}
+}
+
+// transportError - запрос не дошел до сервиса или ответ не удалось прочитать.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// retryable сообщает, можно ли повторить попытку. Сетевые ошибки повторяются, пока не истек контекст вызова.
+func (c *Client) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.retryable()
+	}
+
+	// Ошибки подготовки запроса и разбора ответа повтор не исправит.
+	var tErr *transportError
+	return errors.As(err, &tErr)
+}
+
+// delay - задержка перед попыткой attempt+1: экспоненциальная, с разбросом в половину значения.
+// Заголовок Retry-After ответа имеет приоритет, если не превышает maxDelay.
+func (c *Client) delay(attempt int, err error) time.Duration {
+	var e *Error
+	if errors.As(err, &e) && e.retryAfter > 0 && e.retryAfter <= c.maxDelay {
+		return e.retryAfter
+	}
+
+	d := c.baseDelay << (attempt - 1)
+	if d > c.maxDelay || d <= 0 {
+		d = c.maxDelay
+	}
+
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// wait ждет d. Возвращает false, если контекст завершится раньше или его дедлайн наступит до конца ожидания.
+func (c *Client) wait(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,192 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/pkg/client"
+	"github.com/frutonanny/wallet-service/pkg/client/api"
+)
+
+var reserveReq = api.ReserveRequest{UserID: 1, ServiceID: 2, OrderID: 3, Price: 100}
+
+func newClient(t *testing.T, handler http.HandlerFunc, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]client.Option{client.WithRetry(3, time.Millisecond, 10*time.Millisecond)}, opts...)
+
+	c, err := client.New(srv.URL, opts...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestClient_Reserve(t *testing.T) {
+	t.Run("retry with the same idempotency key", func(t *testing.T) {
+		var calls int32
+		keys := make(chan string, 3)
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/reserve", r.URL.Path)
+			keys <- r.Header.Get(client.HeaderIdempotencyKey)
+
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data":{"balance":900}}`))
+		})
+
+		data, err := c.Reserve(context.Background(), reserveReq)
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), data.Balance)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		close(keys)
+		first := <-keys
+		assert.NotEmpty(t, first)
+		for key := range keys {
+			assert.Equal(t, first, key)
+		}
+	})
+
+	t.Run("caller idempotency key", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "key", r.Header.Get(client.HeaderIdempotencyKey))
+			_, _ = w.Write([]byte(`{"data":{"balance":900}}`))
+		})
+
+		_, err := c.Reserve(client.WithIdempotencyKey(context.Background(), "key"), reserveReq)
+		require.NoError(t, err)
+	})
+
+	t.Run("service error is not retried", func(t *testing.T) {
+		var calls int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			_, _ = w.Write([]byte(`{"error":{"code":"not_enough_cash","message":"not enough cash"}}`))
+		})
+
+		_, err := c.Reserve(context.Background(), reserveReq)
+		require.ErrorIs(t, err, client.ErrNotEnoughCash)
+		assert.False(t, errors.Is(err, client.ErrWalletNotFound))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		var e *client.Error
+		require.True(t, errors.As(err, &e))
+		assert.Equal(t, "not enough cash", e.Message)
+	})
+
+	t.Run("retries are exhausted", func(t *testing.T) {
+		var calls int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			_, _ = w.Write([]byte(`{"error":{"code":"internal_error","message":"internal server error"}}`))
+		})
+
+		_, err := c.Reserve(context.Background(), reserveReq)
+		require.ErrorIs(t, err, client.ErrInternal)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("invalid request is not retried", func(t *testing.T) {
+		var calls int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		_, err := c.Reserve(context.Background(), reserveReq)
+		require.ErrorIs(t, err, client.ErrInvalidRequest)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("no retry after context deadline", func(t *testing.T) {
+		var calls int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}, client.WithRetry(5, time.Second, time.Second))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := c.Reserve(ctx, reserveReq)
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClient_GetBalance(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(client.HeaderIdempotencyKey))
+		_, _ = w.Write([]byte(`{"error":{"code":"wallet_not_found","message":"wallet not found"}}`))
+	})
+
+	_, err := c.GetBalance(context.Background(), api.GetBalanceRequest{UserID: 1})
+	assert.ErrorIs(t, err, client.ErrWalletNotFound)
+}
+
+func TestClient_APIKey(t *testing.T) {
+	t.Run("key is sent as bearer token", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer wk_key", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"data":{"balance":900}}`))
+		}, client.WithAPIKey("wk_key"))
+
+		_, err := c.Reserve(context.Background(), reserveReq)
+		require.NoError(t, err)
+	})
+
+	t.Run("forbidden is not retried", func(t *testing.T) {
+		var calls int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			_, _ = w.Write([]byte(`{"error":{"code":"forbidden","message":"api key has no access to this method"}}`))
+		}, client.WithAPIKey("wk_key"))
+
+		_, err := c.Reserve(context.Background(), reserveReq)
+		assert.ErrorIs(t, err, client.ErrForbidden)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	var calls int32
+
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"code":"rate_limited","message":"rate limit exceeded"}}`))
+	}, client.WithRetry(2, time.Millisecond, 10*time.Millisecond))
+
+	_, err := c.GetBalance(context.Background(), api.GetBalanceRequest{UserID: 1})
+	assert.ErrorIs(t, err, client.ErrRateLimited)
+
+	var e *client.Error
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusTooManyRequests, e.StatusCode)
+	// Retry-After больше maxDelay, поэтому ждем обычную задержку, но попытку все равно повторяем.
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/frutonanny/wallet-service/pkg/errcodes"
+)
+
+// Error - ошибка, которую вернул сервис кошелька. Code – код из pkg/errcodes.
+//
+// Ошибки сравниваются по коду, поэтому проверять их удобно через errors.Is:
+//
+//	if errors.Is(err, client.ErrNotEnoughCash) { ... }
+type Error struct {
+	Code    string
+	Message string
+	// StatusCode - http-статус ответа. 0 – для ошибок-образцов ниже.
+	StatusCode int
+
+	// retryAfter - значение заголовка Retry-After ответа.
+	retryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Code
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is сравнивает ошибки по коду.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Ошибки сервиса кошелька, по одной на каждый код из pkg/errcodes.
+var (
+	ErrInternal             = &Error{Code: errcodes.InternalError}
+	ErrWalletNotFound       = &Error{Code: errcodes.WalletNotFound}
+	ErrNotEnoughCash        = &Error{Code: errcodes.NotEnoughCash}
+	ErrOrderNotFound        = &Error{Code: errcodes.OrderNotFound}
+	ErrAmbiguousOrder       = &Error{Code: errcodes.AmbiguousOrder}
+	ErrDuplicateService     = &Error{Code: errcodes.DuplicateService}
+	ErrOrderExists          = &Error{Code: errcodes.OrderExists}
+	ErrInvalidPageToken     = &Error{Code: errcodes.InvalidPageToken}
+	ErrTransactionNotFound  = &Error{Code: errcodes.TransactionNotFound}
+	ErrInvalidPeriod        = &Error{Code: errcodes.InvalidPeriod}
+	ErrStatementNotFound    = &Error{Code: errcodes.StatementNotFound}
+	ErrPeriodTooLong        = &Error{Code: errcodes.PeriodTooLong}
+	ErrInvalidWebhookURL    = &Error{Code: errcodes.InvalidWebhookURL}
+	ErrDeliveryNotFound     = &Error{Code: errcodes.DeliveryNotFound}
+	ErrInvalidRequest       = &Error{Code: errcodes.InvalidRequest}
+	ErrIdempotencyKeyReused = &Error{Code: errcodes.IdempotencyKeyReused}
+	ErrRequestInProgress    = &Error{Code: errcodes.RequestInProgress}
+	ErrUnauthenticated      = &Error{Code: errcodes.Unauthenticated}
+	ErrForbidden            = &Error{Code: errcodes.Forbidden}
+	ErrRateLimited          = &Error{Code: errcodes.RateLimited}
+)
+
+// retryable сообщает, безопасно ли повторить запрос после ошибки сервиса.
+// Изменяющие запросы повторяются с тем же ключом идемпотентности, поэтому повтор не выполнит операцию дважды.
+func (e *Error) retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	switch e.Code {
+	case errcodes.InternalError, errcodes.RequestInProgress, errcodes.RateLimited:
+		return true
+	}
+
+	return false
+}
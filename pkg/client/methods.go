@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+
+	"github.com/frutonanny/wallet-service/pkg/client/api"
+)
+
+// Add зачисляет средства на счет пользователя.
+func (c *Client) Add(ctx context.Context, req api.AddRequest) (api.AddData, error) {
+	var data api.AddData
+	err := c.call(ctx, "/add", true, req, &data)
+	return data, err
+}
+
+// Reserve резервирует средства под услугу.
+func (c *Client) Reserve(ctx context.Context, req api.ReserveRequest) (api.ReserveData, error) {
+	var data api.ReserveData
+	err := c.call(ctx, "/reserve", true, req, &data)
+	return data, err
+}
+
+// ReserveCart резервирует средства под заказ из нескольких услуг.
+func (c *Client) ReserveCart(ctx context.Context, req api.ReserveCartRequest) (api.ReserveCartData, error) {
+	var data api.ReserveCartData
+	err := c.call(ctx, "/reserveCart", true, req, &data)
+	return data, err
+}
+
+// WriteOff списывает зарезервированные средства.
+func (c *Client) WriteOff(ctx context.Context, req api.WriteOffRequest) (api.WriteOffData, error) {
+	var data api.WriteOffData
+	err := c.call(ctx, "/writeOff", true, req, &data)
+	return data, err
+}
+
+// Cancel отменяет резерв.
+func (c *Client) Cancel(ctx context.Context, req api.CancelRequest) (api.CancelData, error) {
+	var data api.CancelData
+	err := c.call(ctx, "/cancel", true, req, &data)
+	return data, err
+}
+
+// GetBalance отдает баланс пользователя.
+func (c *Client) GetBalance(ctx context.Context, req api.GetBalanceRequest) (api.GetBalanceData, error) {
+	var data api.GetBalanceData
+	err := c.call(ctx, "/getBalance", false, req, &data)
+	return data, err
+}
+
+// GetBalances отдает балансы нескольких пользователей.
+func (c *Client) GetBalances(ctx context.Context, req api.GetBalancesRequest) (api.GetBalancesData, error) {
+	var data api.GetBalancesData
+	err := c.call(ctx, "/getBalances", false, req, &data)
+	return data, err
+}
+
+// GetTransaction отдает транзакцию пользователя.
+func (c *Client) GetTransaction(ctx context.Context, req api.GetTransactionRequest) (api.Transaction, error) {
+	var data api.Transaction
+	err := c.call(ctx, "/getTransaction", false, req, &data)
+	return data, err
+}
+
+// GetHistory отдает страницу истории транзакций пользователя.
+func (c *Client) GetHistory(ctx context.Context, req api.GetHistoryRequest) (api.GetHistoryData, error) {
+	var data api.GetHistoryData
+	err := c.call(ctx, "/getHistory", false, req, &data)
+	return data, err
+}
@@ -12,4 +12,52 @@ const (
 
 	// OrderNotFound - заказ не найден.
 	OrderNotFound = "order_not_found"
+
+	// AmbiguousOrder - заказ состоит из нескольких позиций, необходимо указать услугу.
+	AmbiguousOrder = "ambiguous_order"
+
+	// DuplicateService - в корзине несколько позиций с одной и той же услугой.
+	DuplicateService = "duplicate_service"
+
+	// OrderExists - у заказа уже есть позиция с этой услугой.
+	OrderExists = "order_exists"
+
+	// InvalidPageToken - некорректный токен страницы.
+	InvalidPageToken = "invalid_page_token"
+
+	// TransactionNotFound - транзакция не найдена.
+	TransactionNotFound = "transaction_not_found"
+
+	// InvalidPeriod - начало периода позже его окончания.
+	InvalidPeriod = "invalid_period"
+
+	// StatementNotFound - выписка за месяц не найдена.
+	StatementNotFound = "statement_not_found"
+
+	// PeriodTooLong - период длиннее допустимого.
+	PeriodTooLong = "period_too_long"
+
+	// InvalidWebhookURL - адрес подписки не является абсолютным http(s)-адресом.
+	InvalidWebhookURL = "invalid_webhook_url"
+
+	// DeliveryNotFound - доставка в статусе dead не найдена.
+	DeliveryNotFound = "delivery_not_found"
+
+	// InvalidRequest - запрос не соответствует схеме API.
+	InvalidRequest = "invalid_request"
+
+	// IdempotencyKeyReused - ключ идемпотентности уже использован для другого запроса.
+	IdempotencyKeyReused = "idempotency_key_reused"
+
+	// RequestInProgress - запрос с тем же ключом идемпотентности еще выполняется, его можно повторить позже.
+	RequestInProgress = "request_in_progress"
+
+	// Unauthenticated - API-ключ не передан, неизвестен или отозван.
+	Unauthenticated = "unauthenticated"
+
+	// Forbidden - у API-ключа нет права на операцию.
+	Forbidden = "forbidden"
+
+	// RateLimited - превышено ограничение частоты запросов клиента или по пользователю, запрос можно повторить позже.
+	RateLimited = "rate_limited"
 )
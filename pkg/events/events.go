@@ -0,0 +1,61 @@
+// Package events описывает события об изменении баланса кошелька, которые сервис публикует для других команд.
+//
+// Схема версионируется: при обратно несовместимом изменении Data поднимается Version, а потребители смотрят на поле
+// version конверта и пропускают события, которые не умеют разбирать. Добавление новых необязательных полей версию не
+// меняет.
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Version - текущая версия схемы событий.
+const Version = 1
+
+const (
+	// TypeIncomingTransfer - на кошелек зачислены средства.
+	TypeIncomingTransfer = "wallet.incoming_transfer"
+
+	// TypeReservation - средства зарезервированы под позицию заказа.
+	TypeReservation = "wallet.reservation"
+
+	// TypeWriteOff - зарезервированные средства списаны.
+	TypeWriteOff = "wallet.write_off"
+
+	// TypeCancel - резерв отменен, средства вернулись на баланс.
+	TypeCancel = "wallet.cancel"
+
+	// TypeOutgoingTransfer - средства списаны с кошелька без заказа.
+	TypeOutgoingTransfer = "wallet.outgoing_transfer"
+)
+
+// Event - конверт события. ID монотонно растет и уникален, по нему потребитель может отбрасывать повторы: релей
+// гарантирует доставку хотя бы один раз.
+type Event struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WalletData - данные событий версии 1. Все суммы в копейках.
+type WalletData struct {
+	UserID int64 `json:"userID"`
+
+	// TransactionID - идентификатор транзакции, которую породила операция.
+	TransactionID int64 `json:"transactionID"`
+
+	// Amount - сумма операции.
+	Amount int64 `json:"amount"`
+
+	// Balance - доступный баланс после операции, без учета резерва.
+	Balance int64 `json:"balance"`
+
+	// OrderID - идентификатор внешнего заказа, для операций без заказа не передается.
+	OrderID int64 `json:"orderID,omitempty"`
+
+	// ServiceID - идентификатор услуги. Может отсутствовать при отмене заказа без указания услуги.
+	ServiceID int64 `json:"serviceID,omitempty"`
+}
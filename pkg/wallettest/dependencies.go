@@ -0,0 +1,230 @@
+package wallettest
+
+import (
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/services/add"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/cancel"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	"github.com/frutonanny/wallet-service/internal/services/get_report"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/reserve"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
+	write_off "github.com/frutonanny/wallet-service/internal/services/write-off"
+)
+
+// Зависимости сервисов: вместо репозиториев поверх базы отдают репозитории поверх store.
+// У каждого сервиса свой набор интерфейсов, поэтому и тип зависимостей у каждого свой.
+
+type addDeps struct{ st *store }
+
+func (b *addDeps) NewWalletRepository(db postgres.Database) add.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *addDeps) NewTransactionRepository(db postgres.Database) add.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+func (b *addDeps) NewOutboxRepository(db postgres.Database) add.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+type reserveDeps struct{ st *store }
+
+func (b *reserveDeps) NewWalletRepository(db postgres.Database) reserve.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *reserveDeps) NewOrderRepository(db postgres.Database) reserve.OrderRepository {
+	return &orderRepository{st: b.st, db: db}
+}
+
+func (b *reserveDeps) NewTransactionRepository(db postgres.Database) reserve.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+func (b *reserveDeps) NewOutboxRepository(db postgres.Database) reserve.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+type reserveCartDeps struct{ st *store }
+
+func (b *reserveCartDeps) NewWalletRepository(db postgres.Database) reserve_cart.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *reserveCartDeps) NewOrderRepository(db postgres.Database) reserve_cart.OrderRepository {
+	return &orderRepository{st: b.st, db: db}
+}
+
+func (b *reserveCartDeps) NewTransactionRepository(db postgres.Database) reserve_cart.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+func (b *reserveCartDeps) NewOutboxRepository(db postgres.Database) reserve_cart.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+type writeOffDeps struct{ st *store }
+
+func (b *writeOffDeps) NewWalletRepository(db postgres.Database) write_off.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *writeOffDeps) NewOrderRepository(db postgres.Database) write_off.OrderRepository {
+	return &orderRepository{st: b.st, db: db}
+}
+
+func (b *writeOffDeps) NewTransactionRepository(db postgres.Database) write_off.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+func (b *writeOffDeps) NewOutboxRepository(db postgres.Database) write_off.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+func (b *writeOffDeps) NewReportRepository(db postgres.Database) write_off.ReportRepository {
+	return &reportRepository{st: b.st, db: db}
+}
+
+type cancelDeps struct{ st *store }
+
+func (b *cancelDeps) NewWalletRepository(db postgres.Database) cancel.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *cancelDeps) NewOrderRepository(db postgres.Database) cancel.OrderRepository {
+	return &orderRepository{st: b.st, db: db}
+}
+
+func (b *cancelDeps) NewTransactionRepository(db postgres.Database) cancel.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+func (b *cancelDeps) NewOutboxRepository(db postgres.Database) cancel.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+type getBalanceDeps struct{ st *store }
+
+func (b *getBalanceDeps) NewRepository(db postgres.Database) get_balance.Repository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getBalanceDeps) NewOrderRepository(db postgres.Database) get_balance.OrderRepository {
+	return &orderRepository{st: b.st, db: db}
+}
+
+type getBalanceAtDeps struct{ st *store }
+
+func (b *getBalanceAtDeps) NewWalletRepository(db postgres.Database) get_balance_at.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getBalanceAtDeps) NewBalanceRepository(db postgres.Database) get_balance_at.BalanceRepository {
+	return &balanceRepository{st: b.st, db: db}
+}
+
+type getTransactionsDeps struct{ st *store }
+
+func (b *getTransactionsDeps) NewWalletRepository(db postgres.Database) get_transactions.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getTransactionsDeps) NewTransactionRepository(db postgres.Database) get_transactions.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+type getTransactionsByTimeDeps struct{ st *store }
+
+func (b *getTransactionsByTimeDeps) NewWalletRepository(
+	db postgres.Database,
+) get_transactions_by_time.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getTransactionsByTimeDeps) NewTransactionRepository(
+	db postgres.Database,
+) get_transactions_by_time.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+type getTransactionDeps struct{ st *store }
+
+func (b *getTransactionDeps) NewWalletRepository(db postgres.Database) get_transaction.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getTransactionDeps) NewTransactionRepository(db postgres.Database) get_transaction.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+type getHistoryDeps struct{ st *store }
+
+func (b *getHistoryDeps) NewWalletRepository(db postgres.Database) get_history.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getHistoryDeps) NewTransactionRepository(db postgres.Database) get_history.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+type exportStatementDeps struct{ st *store }
+
+func (b *exportStatementDeps) NewWalletRepository(db postgres.Database) export_statement.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *exportStatementDeps) NewTransactionRepository(db postgres.Database) export_statement.TransactionRepository {
+	return &transactionRepository{st: b.st, db: db}
+}
+
+type getStatementsDeps struct{ st *store }
+
+func (b *getStatementsDeps) NewWalletRepository(db postgres.Database) get_statements.WalletRepository {
+	return &walletRepository{st: b.st, db: db}
+}
+
+func (b *getStatementsDeps) NewStatementRepository(postgres.Database) get_statements.StatementRepository {
+	return &statementRepository{}
+}
+
+type getReportDeps struct{ st *store }
+
+func (b *getReportDeps) NewRepository(db postgres.Database) get_report.Repository {
+	return &reportRepository{st: b.st, db: db}
+}
+
+type streamEventsDeps struct{ st *store }
+
+func (b *streamEventsDeps) NewOutboxRepository(db postgres.Database) stream_events.OutboxRepository {
+	return &outboxRepository{st: b.st, db: db}
+}
+
+type manageWebhooksDeps struct{ st *store }
+
+func (b *manageWebhooksDeps) NewWebhookRepository(db postgres.Database) manage_webhooks.WebhookRepository {
+	return &webhookRepository{st: b.st, db: db}
+}
+
+type idempotencyDeps struct{ st *store }
+
+func (b *idempotencyDeps) NewIdempotencyRepository(db postgres.Database) idempotency.IdempotencyRepository {
+	return &idempotencyRepository{st: b.st, db: db}
+}
+
+type auditLogDeps struct{ st *store }
+
+func (b *auditLogDeps) NewAuditRepository(db postgres.Database) audit_log.AuditRepository {
+	return &auditRepository{st: b.st, db: db}
+}
@@ -0,0 +1,950 @@
+package wallettest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/orders"
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	"github.com/frutonanny/wallet-service/internal/repositories"
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	repoBalance "github.com/frutonanny/wallet-service/internal/repositories/balance"
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+	repoOrder "github.com/frutonanny/wallet-service/internal/repositories/order"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+	repoReport "github.com/frutonanny/wallet-service/internal/repositories/report"
+	repoStatement "github.com/frutonanny/wallet-service/internal/repositories/statement"
+	repoTxs "github.com/frutonanny/wallet-service/internal/repositories/transaction"
+	repoWallet "github.com/frutonanny/wallet-service/internal/repositories/wallet"
+	repoWebhook "github.com/frutonanny/wallet-service/internal/repositories/webhook"
+	"github.com/frutonanny/wallet-service/internal/transactions"
+	"github.com/frutonanny/wallet-service/pkg/events"
+)
+
+// errNegativeReservation - ошибка ограничения таблицы. Сервисы не ждут ее от репозиториев и отвечают на нее
+// internal_error, как и с базой.
+var errNegativeReservation = errors.New(`violates check constraint "wallets_reservation_check"`)
+
+// Репозитории повторяют репозитории из internal/repositories поверх store.
+
+type walletRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *walletRepository) ExistWallet(_ context.Context, userID int64) (int64, error) {
+	var walletID int64
+
+	err := r.st.read(r.db, func(s *state) error {
+		id, ok := s.walletByUser[userID]
+		if !ok {
+			return repositories.ErrRepoWalletNotFound
+		}
+
+		walletID = id
+		return nil
+	})
+
+	return walletID, err
+}
+
+func (r *walletRepository) CreateIfNotExist(_ context.Context, userID int64) (int64, error) {
+	var walletID int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		if id, ok := s.walletByUser[userID]; ok {
+			walletID = id
+			return nil
+		}
+
+		walletID = int64(len(s.wallets)) + 1
+		s.wallets[walletID] = wallet{id: walletID, userID: userID}
+		s.walletByUser[userID] = walletID
+
+		return nil
+	})
+
+	return walletID, err
+}
+
+func (r *walletRepository) Add(_ context.Context, walletID, amount int64) (int64, error) {
+	return r.update(walletID, 0, amount)
+}
+
+// Reserve, как и в базе, не дает балансу уйти в минус.
+func (r *walletRepository) Reserve(_ context.Context, walletID, cash int64) (int64, error) {
+	return r.update(walletID, cash, -cash)
+}
+
+func (r *walletRepository) WriteOff(_ context.Context, walletID, amount, delta int64) (int64, error) {
+	return r.update(walletID, -amount, delta)
+}
+
+func (r *walletRepository) Cancel(_ context.Context, walletID, cash int64) (int64, error) {
+	return r.update(walletID, -cash, cash)
+}
+
+// update меняет резерв и доступные средства кошелька и запоминает новый баланс в истории.
+func (r *walletRepository) update(walletID, reservationDelta, balanceDelta int64) (int64, error) {
+	var balance int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		w, ok := s.wallets[walletID]
+		if !ok {
+			return fmt.Errorf("wallet %d: %w", walletID, repositories.ErrRepoWalletNotFound)
+		}
+
+		w.balance += balanceDelta
+		w.reservation += reservationDelta
+
+		if w.balance < 0 {
+			return repositories.ErrRepoNotEnoughCash
+		}
+
+		if w.reservation < 0 {
+			return errNegativeReservation
+		}
+
+		s.wallets[walletID] = w
+		s.history[walletID] = append(s.history[walletID], balancePoint{
+			at:          time.Now(),
+			balance:     w.balance,
+			reservation: w.reservation,
+		})
+
+		balance = w.balance
+
+		return nil
+	})
+
+	return balance, err
+}
+
+// GetBalances отдает балансы кошельков пользователей userIDs. Пользователи без кошелька пропускаются.
+func (r *walletRepository) GetBalances(_ context.Context, userIDs []int64) ([]repoWallet.Balance, error) {
+	var result []repoWallet.Balance
+
+	err := r.st.read(r.db, func(s *state) error {
+		for _, userID := range userIDs {
+			id, ok := s.walletByUser[userID]
+			if !ok {
+				continue
+			}
+
+			w := s.wallets[id]
+			result = append(result, repoWallet.Balance{
+				WalletID:    w.id,
+				UserID:      w.userID,
+				Balance:     w.balance,
+				Reservation: w.reservation,
+			})
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type balanceRepository struct {
+	st *store
+	db postgres.Database
+}
+
+// GetBalanceBefore отдает баланс кошелька после последней операции, выполненной до t.
+func (r *balanceRepository) GetBalanceBefore(
+	_ context.Context,
+	walletID int64,
+	t time.Time,
+) (repoBalance.Balance, error) {
+	var result repoBalance.Balance
+
+	err := r.st.read(r.db, func(s *state) error {
+		for _, p := range s.history[walletID] {
+			if !p.at.Before(t) {
+				break
+			}
+
+			result = repoBalance.Balance{
+				Available: p.balance,
+				Reserved:  p.reservation,
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// GetDailyBalances отдает балансы кошелька на начало каждых следующих суток промежутка [from, to].
+func (r *balanceRepository) GetDailyBalances(
+	ctx context.Context,
+	walletID int64,
+	from, to time.Time,
+) ([]repoBalance.DailyBalance, error) {
+	var result []repoBalance.DailyBalance
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		balance, err := r.GetBalanceBefore(ctx, walletID, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, repoBalance.DailyBalance{Day: day, Balance: balance})
+	}
+
+	return result, nil
+}
+
+type orderRepository struct {
+	st *store
+	db postgres.Database
+}
+
+// CreateOrder, как и в базе, не дает создать две позиции заказа с одной услугой и отдает ErrRepoOrderExists.
+func (r *orderRepository) CreateOrder(
+	_ context.Context,
+	walletID, externalID, serviceID, amount int64,
+	status string,
+) (int64, error) {
+	var orderID int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		for _, o := range s.orders {
+			if o.externalID == externalID && o.serviceID == serviceID {
+				return repositories.ErrRepoOrderExists
+			}
+		}
+
+		orderID = int64(len(s.orders)) + 1
+		s.orders = append(s.orders, order{
+			id:         orderID,
+			walletID:   walletID,
+			externalID: externalID,
+			serviceID:  serviceID,
+			amount:     amount,
+			status:     status,
+			createdAt:  time.Now(),
+		})
+
+		return nil
+	})
+
+	return orderID, err
+}
+
+func (r *orderRepository) GetOrderByServiceID(
+	_ context.Context,
+	externalID, serviceID int64,
+) (int64, string, int64, error) {
+	var o order
+
+	err := r.st.read(r.db, func(s *state) error {
+		for _, item := range s.orders {
+			if item.externalID == externalID && item.serviceID == serviceID {
+				o = item
+				return nil
+			}
+		}
+
+		return repositories.ErrRepoOrderNotFound
+	})
+
+	return o.id, o.status, o.amount, err
+}
+
+// GetOrder отдает заказ из одной позиции. Для корзины возвращает ErrRepoAmbiguousOrder.
+func (r *orderRepository) GetOrder(_ context.Context, externalID int64) (int64, string, int64, error) {
+	var o order
+
+	err := r.st.read(r.db, func(s *state) error {
+		items := 0
+
+		for _, item := range s.orders {
+			if item.externalID == externalID {
+				o = item
+				items++
+			}
+		}
+
+		switch {
+		case items == 0:
+			return repositories.ErrRepoOrderNotFound
+		case items > 1:
+			return repositories.ErrRepoAmbiguousOrder
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	return o.id, o.status, o.amount, nil
+}
+
+func (r *orderRepository) UpdateOrder(_ context.Context, orderID, amount int64, status string) error {
+	return r.st.write(r.db, func(s *state) error {
+		s.orders[orderID-1].amount = amount
+		s.orders[orderID-1].status = status
+
+		return nil
+	})
+}
+
+func (r *orderRepository) UpdateOrderStatus(_ context.Context, orderID int64, status string) error {
+	return r.st.write(r.db, func(s *state) error {
+		s.orders[orderID-1].status = status
+		return nil
+	})
+}
+
+func (r *orderRepository) AddOrderTransactions(_ context.Context, _ int64, _ string) (int64, error) {
+	var id int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		s.orderTxs++
+		id = s.orderTxs
+
+		return nil
+	})
+
+	return id, err
+}
+
+// GetHolds отдает открытые резервы кошельков от старых к новым.
+func (r *orderRepository) GetHolds(_ context.Context, walletIDs []int64) ([]repoOrder.Hold, error) {
+	var result []repoOrder.Hold
+
+	err := r.st.read(r.db, func(s *state) error {
+		wallets := make(map[int64]bool, len(walletIDs))
+		for _, id := range walletIDs {
+			wallets[id] = true
+		}
+
+		for _, o := range s.orders {
+			if !wallets[o.walletID] || !orders.IsOrderReserved(o.status) {
+				continue
+			}
+
+			result = append(result, repoOrder.Hold{
+				WalletID:   o.walletID,
+				ExternalID: o.externalID,
+				ServiceID:  o.serviceID,
+				Amount:     o.amount,
+				CreatedAt:  o.createdAt,
+			})
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type transactionRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *transactionRepository) AddTransaction(
+	_ context.Context,
+	walletID int64,
+	action string,
+	payload []byte,
+	amount, balanceAfter int64,
+) (int64, error) {
+	var id int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		id = int64(len(s.transactions)) + 1
+		s.transactions = append(s.transactions, transaction{
+			id:           id,
+			walletID:     walletID,
+			txType:       action,
+			payload:      payload,
+			amount:       amount,
+			balanceAfter: balanceAfter,
+			createdAt:    time.Now(),
+		})
+
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *transactionRepository) GetTransaction(_ context.Context, walletID, txID int64) (repoTxs.Transaction, error) {
+	var result repoTxs.Transaction
+
+	err := r.st.read(r.db, func(s *state) error {
+		if txID < 1 || txID > int64(len(s.transactions)) || s.transactions[txID-1].walletID != walletID {
+			return repositories.ErrRepoTransactionNotFound
+		}
+
+		result = s.adaptTx(s.transactions[txID-1])
+		return nil
+	})
+
+	return result, err
+}
+
+func (r *transactionRepository) GetTransactions(
+	_ context.Context,
+	walletID, limit, offset int64,
+	sortBy repoTxs.SortBy,
+	direction repoTxs.Direction,
+	filter repoTxs.Filter,
+) ([]repoTxs.Transaction, error) {
+	var result []repoTxs.Transaction
+
+	err := r.st.read(r.db, func(s *state) error {
+		txs := s.walletTxs(walletID, filter, func(tx repoTxs.Transaction) bool { return true })
+
+		sort.SliceStable(txs, func(i, j int) bool {
+			a, b := txs[i], txs[j]
+			if direction != repoTxs.Asc {
+				a, b = b, a
+			}
+
+			if sortBy == repoTxs.Amount {
+				return a.Amount < b.Amount
+			}
+
+			return a.CreatedAt.Before(b.CreatedAt)
+		})
+
+		result = page(txs, offset, limit)
+		return nil
+	})
+
+	return result, err
+}
+
+// GetTransactionsByTime отдает транзакции кошелька за промежуток [timeStart, timeEnd] от новых к старым.
+func (r *transactionRepository) GetTransactionsByTime(
+	_ context.Context,
+	walletID int64,
+	timeStart, timeEnd time.Time,
+	filter repoTxs.Filter,
+) ([]repoTxs.Transaction, error) {
+	var result []repoTxs.Transaction
+
+	err := r.st.read(r.db, func(s *state) error {
+		result = s.walletTxs(walletID, filter, inPeriod(timeStart, timeEnd))
+		reverse(result)
+
+		return nil
+	})
+
+	return result, err
+}
+
+// StreamTransactionsByTime передает в fn транзакции кошелька за промежуток [timeStart, timeEnd] от старых к новым.
+func (r *transactionRepository) StreamTransactionsByTime(
+	_ context.Context,
+	walletID int64,
+	timeStart, timeEnd time.Time,
+	fn func(tx repoTxs.Transaction) error,
+) error {
+	var txs []repoTxs.Transaction
+
+	if err := r.st.read(r.db, func(s *state) error {
+		txs = s.walletTxs(walletID, repoTxs.Filter{}, inPeriod(timeStart, timeEnd))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTransactionsPage отдает страницу истории транзакций кошелька от новых к старым после курсора after.
+func (r *transactionRepository) GetTransactionsPage(
+	_ context.Context,
+	walletID, limit int64,
+	after *repoTxs.Cursor,
+) ([]repoTxs.Transaction, error) {
+	var result []repoTxs.Transaction
+
+	err := r.st.read(r.db, func(s *state) error {
+		txs := s.walletTxs(walletID, repoTxs.Filter{}, func(tx repoTxs.Transaction) bool {
+			return after == nil || tx.CreatedAt.Before(after.CreatedAt) ||
+				tx.CreatedAt.Equal(after.CreatedAt) && tx.ID < after.ID
+		})
+		reverse(txs)
+
+		result = page(txs, 0, limit)
+		return nil
+	})
+
+	return result, err
+}
+
+// walletTxs отдает транзакции кошелька, подходящие под фильтр и условие match, в порядке создания.
+func (s *state) walletTxs(
+	walletID int64,
+	filter repoTxs.Filter,
+	match func(tx repoTxs.Transaction) bool,
+) []repoTxs.Transaction {
+	var result []repoTxs.Transaction
+
+	for _, t := range s.transactions {
+		if t.walletID != walletID {
+			continue
+		}
+
+		tx := s.adaptTx(t)
+		if matchFilter(tx, filter) && match(tx) {
+			result = append(result, tx)
+		}
+	}
+
+	return result
+}
+
+// adaptTx преобразует транзакцию в ту, что отдает репозиторий. Услуга берется из payload, а для отмены заказа
+// без указания услуги – из заказа.
+func (s *state) adaptTx(t transaction) repoTxs.Transaction {
+	balanceAfter := t.balanceAfter
+
+	tx := repoTxs.Transaction{
+		ID:           t.id,
+		Type:         t.txType,
+		Payload:      t.payload,
+		Amount:       t.amount,
+		BalanceAfter: &balanceAfter,
+		CreatedAt:    t.createdAt,
+	}
+
+	if !transactions.HasOrder(t.txType) {
+		return tx
+	}
+
+	p := parsePayload(t.payload)
+
+	serviceID := p.ServiceID
+	if serviceID == 0 {
+		for _, o := range s.orders {
+			if o.walletID == t.walletID && o.externalID == p.OrderID {
+				serviceID = o.serviceID
+				break
+			}
+		}
+	}
+
+	tx.ServiceID = &serviceID
+
+	return tx
+}
+
+type payload struct {
+	OrderID   int64 `json:"order_id"`
+	ServiceID int64 `json:"service_id"`
+}
+
+func parsePayload(raw []byte) payload {
+	var p payload
+	_ = json.Unmarshal(raw, &p)
+
+	return p
+}
+
+func matchFilter(tx repoTxs.Transaction, f repoTxs.Filter) bool {
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			found = found || t == tx.Type
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if f.AmountFrom != 0 && tx.Amount < f.AmountFrom {
+		return false
+	}
+
+	if f.AmountTo != 0 && tx.Amount > f.AmountTo {
+		return false
+	}
+
+	if f.OrderID != 0 && (!transactions.HasOrder(tx.Type) || parsePayload(tx.Payload).OrderID != f.OrderID) {
+		return false
+	}
+
+	if f.ServiceID != 0 && (tx.ServiceID == nil || *tx.ServiceID != f.ServiceID) {
+		return false
+	}
+
+	return true
+}
+
+func inPeriod(start, end time.Time) func(tx repoTxs.Transaction) bool {
+	return func(tx repoTxs.Transaction) bool {
+		return !tx.CreatedAt.Before(start) && !tx.CreatedAt.After(end)
+	}
+}
+
+func page(txs []repoTxs.Transaction, offset, limit int64) []repoTxs.Transaction {
+	if offset >= int64(len(txs)) {
+		return nil
+	}
+
+	txs = txs[offset:]
+	if limit < int64(len(txs)) {
+		txs = txs[:limit]
+	}
+
+	return txs
+}
+
+func reverse(txs []repoTxs.Transaction) {
+	for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+		txs[i], txs[j] = txs[j], txs[i]
+	}
+}
+
+type outboxRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *outboxRepository) AddEvent(_ context.Context, eventType string, data events.WalletData) (int64, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("marshal data: %v", err)
+	}
+
+	var id int64
+
+	err = r.st.write(r.db, func(s *state) error {
+		id = int64(len(s.events)) + 1
+		s.events = append(s.events, repoOutbox.UserEvent{
+			UserID: data.UserID,
+			Event: events.Event{
+				ID:         id,
+				Type:       eventType,
+				Version:    events.Version,
+				OccurredAt: time.Now(),
+				Data:       payload,
+			},
+		})
+
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *outboxRepository) GetLastID(_ context.Context) (int64, error) {
+	var id int64
+
+	err := r.st.read(r.db, func(s *state) error {
+		id = int64(len(s.events))
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *outboxRepository) GetEventsAfter(_ context.Context, afterID int64, limit int) ([]repoOutbox.UserEvent, error) {
+	return r.eventsAfter(afterID, limit, func(e repoOutbox.UserEvent) bool { return true })
+}
+
+func (r *outboxRepository) GetUserEventsAfter(
+	_ context.Context,
+	userID, afterID int64,
+	limit int,
+) ([]repoOutbox.UserEvent, error) {
+	return r.eventsAfter(afterID, limit, func(e repoOutbox.UserEvent) bool { return e.UserID == userID })
+}
+
+func (r *outboxRepository) eventsAfter(
+	afterID int64,
+	limit int,
+	match func(e repoOutbox.UserEvent) bool,
+) ([]repoOutbox.UserEvent, error) {
+	var result []repoOutbox.UserEvent
+
+	err := r.st.read(r.db, func(s *state) error {
+		for _, e := range s.events {
+			if len(result) == limit {
+				break
+			}
+
+			if e.ID > afterID && match(e) {
+				result = append(result, e)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+type reportRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *reportRepository) AddRecord(_ context.Context, serviceID, amount int64, now time.Time) error {
+	return r.st.write(r.db, func(s *state) error {
+		period := now.Format(repoReport.PeriodLayout)
+
+		if s.report[period] == nil {
+			s.report[period] = make(map[int64]int64)
+		}
+
+		s.report[period][serviceID] += amount
+
+		return nil
+	})
+}
+
+func (r *reportRepository) GetReport(_ context.Context, period string) ([]repoReport.Service, error) {
+	var result []repoReport.Service
+
+	err := r.st.read(r.db, func(s *state) error {
+		for serviceID, revenue := range s.report[period] {
+			result = append(result, repoReport.Service{
+				ServiceID:    serviceID,
+				TotalRevenue: revenue,
+			})
+		}
+
+		sort.Slice(result, func(i, j int) bool { return result[i].ServiceID < result[j].ServiceID })
+
+		return nil
+	})
+
+	return result, err
+}
+
+// statementRepository - ежемесячные выписки формирует фоновая задача, которой в фейке нет, поэтому выписок нет.
+type statementRepository struct{}
+
+func (r *statementRepository) GetStatements(_ context.Context, _ int64) ([]repoStatement.Statement, error) {
+	return nil, nil
+}
+
+func (r *statementRepository) GetStatement(_ context.Context, _ int64, _ string) (repoStatement.Statement, error) {
+	return repoStatement.Statement{}, repositories.ErrRepoStatementNotFound
+}
+
+// webhookRepository - webhook-и фейк не доставляет, поэтому подписки только выдают идентификатор,
+// а доставок с исчерпанными попытками нет.
+type webhookRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *webhookRepository) AddSubscription(_ context.Context, _ string, _ []string, _ string) (int64, error) {
+	var id int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		s.subscriptions++
+		id = s.subscriptions
+
+		return nil
+	})
+
+	return id, err
+}
+
+func (r *webhookRepository) GetDeadDeliveries(_ context.Context, _ int64, _ int) ([]repoWebhook.Delivery, error) {
+	return nil, nil
+}
+
+func (r *webhookRepository) Replay(_ context.Context, _ int64) error {
+	return repositories.ErrRepoDeliveryNotFound
+}
+
+type idempotencyRepository struct {
+	st *store
+	db postgres.Database
+}
+
+// Reserve занимает ключ клиента, если его нет, он с ответом и старше ttl или без ответа и старше lease.
+// Иначе отдает сохраненный запрос.
+func (r *idempotencyRepository) Reserve(
+	_ context.Context,
+	clientKeyID int64,
+	key, path, requestHash string,
+	ttl, lease time.Duration,
+) (bool, repoIdempotency.Key, error) {
+	var (
+		reserved bool
+		k        repoIdempotency.Key
+	)
+
+	err := r.st.write(r.db, func(s *state) error {
+		id := idempotencyID{clientKeyID: clientKeyID, key: key, path: path}
+
+		if existing, ok := s.idempotency[id]; ok {
+			age := time.Since(existing.createdAt)
+			if age < ttl && (existing.Completed || age < lease) {
+				k = existing.Key
+				return nil
+			}
+		}
+
+		s.idempotency[id] = idempotencyKey{
+			Key:       repoIdempotency.Key{RequestHash: requestHash},
+			createdAt: time.Now(),
+		}
+		reserved = true
+
+		return nil
+	})
+
+	return reserved, k, err
+}
+
+func (r *idempotencyRepository) Complete(
+	_ context.Context,
+	clientKeyID int64,
+	key, path string,
+	statusCode int,
+	contentType string,
+	response []byte,
+) error {
+	return r.st.write(r.db, func(s *state) error {
+		id := idempotencyID{clientKeyID: clientKeyID, key: key, path: path}
+
+		k, ok := s.idempotency[id]
+		if !ok {
+			return nil
+		}
+
+		k.Completed = true
+		k.StatusCode = statusCode
+		k.ContentType = contentType
+		k.Response = response
+		s.idempotency[id] = k
+
+		return nil
+	})
+}
+
+func (r *idempotencyRepository) Release(_ context.Context, clientKeyID int64, key, path string) error {
+	return r.st.write(r.db, func(s *state) error {
+		id := idempotencyID{clientKeyID: clientKeyID, key: key, path: path}
+
+		if k, ok := s.idempotency[id]; ok && !k.Completed {
+			delete(s.idempotency, id)
+		}
+
+		return nil
+	})
+}
+
+type auditRepository struct {
+	st *store
+	db postgres.Database
+}
+
+func (r *auditRepository) AddEntry(_ context.Context, e repoAudit.Entry) (int64, error) {
+	var id int64
+
+	err := r.st.write(r.db, func(s *state) error {
+		id = int64(len(s.auditLog)) + 1
+
+		e.ID = id
+		e.CreatedAt = time.Now()
+		s.auditLog = append(s.auditLog, e)
+
+		return nil
+	})
+
+	return id, err
+}
+
+// AddTransactions дописывает связи к записи, добавленной в этой же транзакции, поэтому зафиксированные записи
+// не меняются.
+func (r *auditRepository) AddTransactions(_ context.Context, auditID int64, transactionIDs []int64) error {
+	return r.st.write(r.db, func(s *state) error {
+		e := s.auditLog[auditID-1]
+		e.TransactionIDs = append(e.TransactionIDs[:len(e.TransactionIDs):len(e.TransactionIDs)], transactionIDs...)
+		s.auditLog[auditID-1] = e
+
+		return nil
+	})
+}
+
+func (r *auditRepository) AddOrderTransactions(_ context.Context, auditID int64, orderTransactionIDs []int64) error {
+	return r.st.write(r.db, func(s *state) error {
+		e := s.auditLog[auditID-1]
+		e.OrderTransactionIDs = append(
+			e.OrderTransactionIDs[:len(e.OrderTransactionIDs):len(e.OrderTransactionIDs)],
+			orderTransactionIDs...,
+		)
+		s.auditLog[auditID-1] = e
+
+		return nil
+	})
+}
+
+func (r *auditRepository) GetEntries(
+	_ context.Context,
+	filter repoAudit.Filter,
+	afterID, limit int64,
+) ([]repoAudit.Entry, error) {
+	var result []repoAudit.Entry
+
+	err := r.st.read(r.db, func(s *state) error {
+		for _, e := range s.auditLog {
+			if int64(len(result)) == limit {
+				break
+			}
+
+			if e.ID > afterID && matchAudit(e, filter) {
+				result = append(result, e)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func matchAudit(e repoAudit.Entry, f repoAudit.Filter) bool {
+	switch {
+	case f.UserID != 0 && (e.UserID == nil || *e.UserID != f.UserID):
+		return false
+	case f.ClientName != "" && e.ClientName != f.ClientName:
+		return false
+	case f.Endpoint != "" && e.Endpoint != f.Endpoint:
+		return false
+	case !f.From.IsZero() && e.CreatedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !e.CreatedAt.Before(f.To):
+		return false
+	}
+
+	if f.TransactionID == 0 {
+		return true
+	}
+
+	for _, id := range e.TransactionIDs {
+		if id == f.TransactionID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package wallettest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// storagePath - префикс адресов файлов хранилища на сервере фейка.
+const storagePath = "/storage/"
+
+type object struct {
+	contentType string
+	data        []byte
+}
+
+// storage заменяет minio: хранит файлы выписок и отчетов в памяти и раздает их по ссылкам на сервере фейка.
+type storage struct {
+	baseURL string
+
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+func newStorage(serverURL string) *storage {
+	return &storage{
+		baseURL: serverURL + strings.TrimSuffix(storagePath, "/"),
+		objects: make(map[string]object),
+	}
+}
+
+func (s *storage) PutObject(
+	_ context.Context,
+	bucketName, objectName string,
+	reader io.Reader,
+	_ int64,
+	opts minio.PutObjectOptions,
+) (minio.UploadInfo, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return minio.UploadInfo{}, fmt.Errorf("read object: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[bucketName+"/"+objectName] = object{
+		contentType: opts.ContentType,
+		data:        data,
+	}
+
+	return minio.UploadInfo{
+		Bucket: bucketName,
+		Key:    objectName,
+		Size:   int64(len(data)),
+	}, nil
+}
+
+func (s *storage) PresignedGetObject(
+	_ context.Context,
+	bucketName, objectName string,
+	_ time.Duration,
+	_ url.Values,
+) (*url.URL, error) {
+	return url.Parse(s.baseURL + "/" + bucketName + "/" + objectName)
+}
+
+func (s *storage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	obj, ok := s.objects[strings.TrimPrefix(r.URL.Path, storagePath)]
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if obj.contentType != "" {
+		w.Header().Set("Content-Type", obj.contentType)
+	}
+
+	_, _ = io.Copy(w, bytes.NewReader(obj.data))
+}
@@ -0,0 +1,263 @@
+package wallettest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/postgres"
+	repoAudit "github.com/frutonanny/wallet-service/internal/repositories/audit"
+	repoIdempotency "github.com/frutonanny/wallet-service/internal/repositories/idempotency"
+	repoOutbox "github.com/frutonanny/wallet-service/internal/repositories/outbox"
+)
+
+var (
+	errNoSQL  = errors.New("wallettest: sql queries are not supported")
+	errTxDone = errors.New("wallettest: transaction is done")
+)
+
+// state - данные кошельков. Заменяет таблицы базы.
+type state struct {
+	wallets      map[int64]wallet
+	walletByUser map[int64]int64
+	// history - балансы кошелька после каждой операции, для баланса на момент времени.
+	history      map[int64][]balancePoint
+	orders       []order
+	orderTxs     int64
+	transactions []transaction
+	events       []repoOutbox.UserEvent
+	// report - выручка по услугам: период -> услуга -> сумма.
+	report        map[string]map[int64]int64
+	subscriptions int64
+	idempotency   map[idempotencyID]idempotencyKey
+	auditLog      []repoAudit.Entry
+}
+
+type wallet struct {
+	id          int64
+	userID      int64
+	balance     int64
+	reservation int64
+}
+
+type balancePoint struct {
+	at          time.Time
+	balance     int64
+	reservation int64
+}
+
+type order struct {
+	id         int64
+	walletID   int64
+	externalID int64
+	serviceID  int64
+	amount     int64
+	status     string
+	createdAt  time.Time
+}
+
+type transaction struct {
+	id           int64
+	walletID     int64
+	txType       string
+	payload      []byte
+	amount       int64
+	balanceAfter int64
+	createdAt    time.Time
+}
+
+type idempotencyID struct {
+	clientKeyID int64
+	key         string
+	path        string
+}
+
+type idempotencyKey struct {
+	repoIdempotency.Key
+	createdAt time.Time
+}
+
+func newState() *state {
+	return &state{
+		wallets:      make(map[int64]wallet),
+		walletByUser: make(map[int64]int64),
+		history:      make(map[int64][]balancePoint),
+		report:       make(map[string]map[int64]int64),
+		idempotency:  make(map[idempotencyID]idempotencyKey),
+	}
+}
+
+// clone копирует данные для транзакции. Срезы и значения, которые не меняются после записи, не копируются.
+func (s *state) clone() *state {
+	c := *s
+
+	c.wallets = make(map[int64]wallet, len(s.wallets))
+	for k, v := range s.wallets {
+		c.wallets[k] = v
+	}
+
+	c.walletByUser = make(map[int64]int64, len(s.walletByUser))
+	for k, v := range s.walletByUser {
+		c.walletByUser[k] = v
+	}
+
+	c.history = make(map[int64][]balancePoint, len(s.history))
+	for k, v := range s.history {
+		c.history[k] = v[:len(v):len(v)]
+	}
+
+	c.orders = append([]order(nil), s.orders...)
+	c.transactions = s.transactions[:len(s.transactions):len(s.transactions)]
+	c.events = s.events[:len(s.events):len(s.events)]
+	c.auditLog = s.auditLog[:len(s.auditLog):len(s.auditLog)]
+
+	c.report = make(map[string]map[int64]int64, len(s.report))
+	for period, services := range s.report {
+		c.report[period] = make(map[int64]int64, len(services))
+		for k, v := range services {
+			c.report[period][k] = v
+		}
+	}
+
+	c.idempotency = make(map[idempotencyID]idempotencyKey, len(s.idempotency))
+	for k, v := range s.idempotency {
+		c.idempotency[k] = v
+	}
+
+	return &c
+}
+
+// store хранит данные в памяти и выдает себя за базу через *sql.DB с драйвером, который умеет только транзакции.
+//
+// Транзакции выполняются по одной: начало транзакции копирует данные, репозитории внутри транзакции работают
+// с копией, фиксация подменяет ею данные, а откат – выбрасывает. Запросы вне транзакции видят только
+// зафиксированные данные, как в Postgres с read committed.
+type store struct {
+	db *sql.DB
+
+	// txMu - занят, пока идет транзакция.
+	txMu sync.Mutex
+
+	mu        sync.Mutex
+	committed *state
+	current   *state // Копия данных открытой транзакции, nil – транзакции нет.
+}
+
+func newStore() *store {
+	st := &store{committed: newState()}
+	st.db = sql.OpenDB(connector{st: st})
+
+	return st
+}
+
+// read выполняет fn над данными, которые видит запрос через db: *sql.Tx – данные транзакции, иначе зафиксированные.
+func (st *store) read(db postgres.Database, fn func(s *state) error) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	s := st.committed
+	if _, ok := db.(*sql.Tx); ok {
+		if st.current == nil {
+			return errTxDone
+		}
+
+		s = st.current
+	}
+
+	return fn(s)
+}
+
+// write выполняет fn, меняющую данные. Изменение вне транзакции фиксируется сразу.
+func (st *store) write(db postgres.Database, fn func(s *state) error) error {
+	if _, ok := db.(*sql.Tx); ok {
+		return st.read(db, fn)
+	}
+
+	st.txMu.Lock()
+	defer st.txMu.Unlock()
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	s := st.committed.clone()
+	if err := fn(s); err != nil {
+		return err
+	}
+
+	st.committed = s
+
+	return nil
+}
+
+func (st *store) begin() {
+	st.txMu.Lock()
+
+	st.mu.Lock()
+	st.current = st.committed.clone()
+	st.mu.Unlock()
+}
+
+func (st *store) end(commit bool) {
+	st.mu.Lock()
+	if commit && st.current != nil {
+		st.committed = st.current
+	}
+	st.current = nil
+	st.mu.Unlock()
+
+	st.txMu.Unlock()
+}
+
+// connector, conn и tx - драйвер database/sql, который не выполняет запросы, а только открывает транзакции store.
+type connector struct {
+	st *store
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{st: c.st}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+type sqlDriver struct{}
+
+func (sqlDriver) Open(string) (driver.Conn, error) {
+	return nil, errNoSQL
+}
+
+type conn struct {
+	st *store
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errNoSQL
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	c.st.begin()
+	return &tx{st: c.st}, nil
+}
+
+type tx struct {
+	st   *store
+	once sync.Once
+}
+
+func (t *tx) Commit() error {
+	t.once.Do(func() { t.st.end(true) })
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.once.Do(func() { t.st.end(false) })
+	return nil
+}
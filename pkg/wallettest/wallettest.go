@@ -0,0 +1,276 @@
+// Package wallettest - фейковый сервис кошелька для интеграционных тестов потребителей.
+//
+// New поднимает внутри теста http-сервер с API v1 (api/schema.yaml) без Postgres и MinIO. Запросы обрабатывают те же
+// обработчики и сервисы, что и в настоящем сервисе, а данные хранятся в памяти. Поэтому фейк следует тем же правилам:
+// баланс не уходит в минус, списать или отменить можно только зарезервированный заказ, повтор запроса
+// с тем же Idempotency-Key получает сохраненный ответ и т.д.
+//
+//	srv := wallettest.New(t)
+//	srv.SeedWallet(1, 1000)
+//
+//	c, _ := client.New(srv.URL)
+//	_, err := c.Reserve(ctx, api.ReserveRequest{UserID: 1, ServiceID: 1, OrderID: 1, Price: 500})
+//
+// Ежемесячных выписок и доставки webhook-ов в фейке нет: фоновых задач он не запускает. API-ключи фейк не проверяет:
+// любой запрос выполняется со всеми правами, а частоту запросов фейк не ограничивает. Журнал аудита фейк ведет
+// в памяти, его можно прочитать запросом /v1/admin/getAuditLog.
+package wallettest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frutonanny/wallet-service/internal/auth"
+	"github.com/frutonanny/wallet-service/internal/generated/server/v1"
+	"github.com/frutonanny/wallet-service/internal/health"
+	server "github.com/frutonanny/wallet-service/internal/server/v1"
+	"github.com/frutonanny/wallet-service/internal/server/v1/handlers"
+	"github.com/frutonanny/wallet-service/internal/services/add"
+	"github.com/frutonanny/wallet-service/internal/services/audit_log"
+	"github.com/frutonanny/wallet-service/internal/services/cancel"
+	"github.com/frutonanny/wallet-service/internal/services/export_statement"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance"
+	"github.com/frutonanny/wallet-service/internal/services/get_balance_at"
+	"github.com/frutonanny/wallet-service/internal/services/get_history"
+	"github.com/frutonanny/wallet-service/internal/services/get_report"
+	"github.com/frutonanny/wallet-service/internal/services/get_statements"
+	"github.com/frutonanny/wallet-service/internal/services/get_transaction"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions"
+	"github.com/frutonanny/wallet-service/internal/services/get_transactions_by_time"
+	"github.com/frutonanny/wallet-service/internal/services/idempotency"
+	"github.com/frutonanny/wallet-service/internal/services/manage_webhooks"
+	"github.com/frutonanny/wallet-service/internal/services/rate_limit"
+	"github.com/frutonanny/wallet-service/internal/services/reserve"
+	"github.com/frutonanny/wallet-service/internal/services/reserve_cart"
+	"github.com/frutonanny/wallet-service/internal/services/stream_events"
+	write_off "github.com/frutonanny/wallet-service/internal/services/write-off"
+)
+
+// streamPollInterval - как часто лента событий проверяет новые события.
+const streamPollInterval = 50 * time.Millisecond
+
+// Server - фейковый сервис кошелька.
+type Server struct {
+	// URL - адрес сервера без версии API, например, http://127.0.0.1:40000.
+	URL string
+
+	t   testing.TB
+	ts  *httptest.Server
+	st  *store
+	add *add.Service
+
+	stopStream context.CancelFunc
+	streamDone chan struct{}
+	closeOnce  sync.Once
+
+	mu       sync.Mutex
+	failures map[string][]failure
+}
+
+// failure - подготовленный ответ вместо обработки запроса: ошибка с кодом code или пустой ответ со статусом status.
+type failure struct {
+	code   string
+	status int
+}
+
+// New запускает фейковый сервис. Сервис останавливается по завершении теста.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	swagger, err := v1.GetSwagger()
+	if err != nil {
+		t.Fatalf("get swagger: %v", err)
+	}
+
+	ts := httptest.NewUnstartedServer(nil)
+	serverURL := "http://" + ts.Listener.Addr().String()
+
+	st := newStore()
+	files := newStorage(serverURL)
+	logger := discardLogger{}
+
+	s := &Server{
+		URL:        serverURL,
+		t:          t,
+		ts:         ts,
+		st:         st,
+		add:        add.New(logger, st.db).WithDependencies(&addDeps{st: st}),
+		streamDone: make(chan struct{}),
+		failures:   make(map[string][]failure),
+	}
+
+	auditLog := audit_log.New(logger, st.db).WithDependencies(&auditLogDeps{st: st})
+	streamEvents := stream_events.New(logger, st.db).WithDependencies(&streamEventsDeps{st: st})
+
+	h := handlers.NewHandlers(
+		get_balance.New(logger, st.db).WithDependencies(&getBalanceDeps{st: st}),
+		get_balance_at.New(logger, st.db).WithDependencies(&getBalanceAtDeps{st: st}),
+		s.add,
+		reserve.New(logger, st.db).WithDependencies(&reserveDeps{st: st}),
+		reserve_cart.New(logger, st.db).WithDependencies(&reserveCartDeps{st: st}),
+		write_off.New(logger, st.db).WithDependencies(&writeOffDeps{st: st}),
+		cancel.New(logger, st.db).WithDependencies(&cancelDeps{st: st}),
+		get_transactions.New(logger, st.db).WithDependencies(&getTransactionsDeps{st: st}),
+		get_transactions_by_time.New(logger, st.db).WithDependencies(&getTransactionsByTimeDeps{st: st}),
+		get_transaction.New(logger, st.db).WithDependencies(&getTransactionDeps{st: st}),
+		get_history.New(logger, st.db).WithDependencies(&getHistoryDeps{st: st}),
+		export_statement.New(logger, st.db, files, files).WithDependencies(&exportStatementDeps{st: st}),
+		get_statements.New(logger, st.db, files).WithDependencies(&getStatementsDeps{st: st}),
+		get_report.New(logger, st.db, files, files.baseURL).WithDependencies(&getReportDeps{st: st}),
+		streamEvents,
+		manage_webhooks.New(logger, st.db).WithDependencies(&manageWebhooksDeps{st: st}),
+		auditLog,
+	)
+
+	idempotencyService := idempotency.New(logger, st.db).WithDependencies(&idempotencyDeps{st: st})
+	// Частоту запросов фейк не ограничивает.
+	rateLimitService := rate_limit.New(logger, st.db, nil, rate_limit.Limits{})
+
+	srv := server.New(
+		ts.Listener.Addr().String(),
+		h,
+		swagger,
+		allowAll{},
+		rateLimitService,
+		idempotencyService,
+		auditLog,
+		// Зависимостей у фейка нет, он всегда готов.
+		health.New(logger),
+	)
+
+	mux := http.NewServeMux()
+	mux.Handle(storagePath, files)
+	mux.Handle("/", s.injectFailures(srv.Handler()))
+
+	ts.Config.Handler = mux
+	ts.Start()
+
+	ctx, stop := context.WithCancel(context.Background())
+	s.stopStream = stop
+
+	go func() {
+		defer close(s.streamDone)
+		_ = streamEvents.Run(ctx, streamPollInterval)
+	}()
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Close останавливает сервис. Открытые ленты событий закрываются.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		s.stopStream()
+		<-s.streamDone
+
+		s.ts.Close()
+		_ = s.st.db.Close()
+	})
+}
+
+// SeedWallet зачисляет amount копеек на кошелек пользователя, создавая его при необходимости.
+// Зачисление проходит как обычный запрос /add: с транзакцией и событием.
+func (s *Server) SeedWallet(userID, amount int64) {
+	s.t.Helper()
+
+	if _, err := s.add.Add(context.Background(), userID, amount); err != nil {
+		s.t.Fatalf("seed wallet %d: %v", userID, err)
+	}
+}
+
+// Balance отдает доступные и зарезервированные средства пользователя. ok = false, если кошелька нет.
+func (s *Server) Balance(userID int64) (available, reserved int64, ok bool) {
+	_ = s.st.read(s.st.db, func(st *state) error {
+		var walletID int64
+
+		walletID, ok = st.walletByUser[userID]
+		if ok {
+			available = st.wallets[walletID].balance
+			reserved = st.wallets[walletID].reservation
+		}
+
+		return nil
+	})
+
+	return available, reserved, ok
+}
+
+// FailNext отвечает на n следующих запросов к path (например, /v1/reserve) ошибкой с кодом code из pkg/errcodes,
+// не выполняя их.
+func (s *Server) FailNext(path string, n int, code string) {
+	s.fail(path, n, failure{code: code})
+}
+
+// FailNextWithStatus отвечает на n следующих запросов к path пустым ответом со статусом status, не выполняя их.
+// Пригодится для проверки повторов после ответов балансировщика вроде 502 и 503.
+func (s *Server) FailNextWithStatus(path string, n, status int) {
+	s.fail(path, n, failure{status: status})
+}
+
+func (s *Server) fail(path string, n int, f failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures[path] = append(s.failures[path], f)
+	}
+}
+
+// injectFailures отвечает на запрос подготовленной ошибкой, если она есть.
+func (s *Server) injectFailures(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := s.nextFailure(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if f.status != 0 {
+			w.WriteHeader(f.status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		_ = json.NewEncoder(w).Encode(struct {
+			Error v1.Error `json:"error"`
+		}{
+			Error: v1.Error{
+				Code:    f.code,
+				Message: "injected failure",
+			},
+		})
+	})
+}
+
+func (s *Server) nextFailure(path string) (failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.failures[path]
+	if len(queue) == 0 {
+		return failure{}, false
+	}
+
+	s.failures[path] = queue[1:]
+
+	return queue[0], true
+}
+
+// allowAll выдает любому запросу, даже без ключа, все права.
+type allowAll struct{}
+
+func (allowAll) Authenticate(context.Context, string) (auth.Client, error) {
+	return auth.Client{Name: "wallettest", Scopes: auth.Scopes}, nil
+}
+
+// discardLogger - сервисы фейка ничего не пишут в лог.
+type discardLogger struct{}
+
+func (discardLogger) Info(context.Context, string, ...interface{})  {}
+func (discardLogger) Error(context.Context, string, ...interface{}) {}
@@ -0,0 +1,299 @@
+package wallettest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/frutonanny/wallet-service/pkg/client"
+	"github.com/frutonanny/wallet-service/pkg/client/api"
+	"github.com/frutonanny/wallet-service/pkg/wallettest"
+)
+
+const (
+	userID    = int64(1)
+	serviceID = int64(1)
+	orderID   = int64(10)
+)
+
+func newClient(t *testing.T, srv *wallettest.Server) *client.Client {
+	t.Helper()
+
+	c, err := client.New(srv.URL, client.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestServer_OrderFlow(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 1000)
+
+	c := newClient(t, srv)
+
+	reserveReq := api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 600}
+
+	reserved, err := c.Reserve(ctx, reserveReq)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), reserved.Balance)
+
+	available, reservedCash, ok := srv.Balance(userID)
+	require.True(t, ok)
+	assert.Equal(t, int64(400), available)
+	assert.Equal(t, int64(600), reservedCash)
+
+	// Списание меньше резерва возвращает разницу в доступные средства.
+	writeOffReq := api.WriteOffRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 500}
+
+	writtenOff, err := c.WriteOff(ctx, writeOffReq)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), writtenOff.Balance)
+
+	balance, err := c.GetBalance(ctx, api.GetBalanceRequest{UserID: userID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), balance.Available)
+	assert.Equal(t, int64(0), balance.Reserved)
+	assert.Empty(t, balance.Holds)
+
+	history, err := c.GetHistory(ctx, api.GetHistoryRequest{UserID: userID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, history.Transactions, 3)
+	assert.Equal(t, int64(500), history.Transactions[0].Amount)
+
+	// Списанный заказ нельзя отменить.
+	_, err = c.Cancel(ctx, api.CancelRequest{UserID: userID, OrderID: orderID})
+	assert.ErrorIs(t, err, client.ErrInternal)
+
+	available, _, _ = srv.Balance(userID)
+	assert.Equal(t, int64(500), available)
+}
+
+func TestServer_Rules(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 100)
+
+	c := newClient(t, srv)
+
+	t.Run("not enough cash", func(t *testing.T) {
+		_, err := c.Reserve(ctx, api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: 1, Price: 500})
+		assert.ErrorIs(t, err, client.ErrNotEnoughCash)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		_, err := c.Reserve(ctx, api.ReserveRequest{UserID: 2, ServiceID: serviceID, OrderID: 2, Price: 1})
+		assert.ErrorIs(t, err, client.ErrWalletNotFound)
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		_, err := c.WriteOff(ctx, api.WriteOffRequest{UserID: userID, ServiceID: serviceID, OrderID: 3, Price: 1})
+		assert.ErrorIs(t, err, client.ErrOrderNotFound)
+	})
+
+	t.Run("cart is reserved all or nothing", func(t *testing.T) {
+		_, err := c.ReserveCart(ctx, api.ReserveCartRequest{
+			UserID:  userID,
+			OrderID: 4,
+			Items:   []api.CartItem{{ServiceID: 1, Price: 60}, {ServiceID: 2, Price: 60}},
+		})
+		assert.ErrorIs(t, err, client.ErrNotEnoughCash)
+
+		available, reserved, _ := srv.Balance(userID)
+		assert.Equal(t, int64(100), available)
+		assert.Equal(t, int64(0), reserved)
+	})
+
+	t.Run("failed transaction is rolled back", func(t *testing.T) {
+		req := api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: 5, Price: 10}
+
+		_, err := c.Reserve(ctx, req)
+		require.NoError(t, err)
+
+		// Повтор заказа с новым ключом идемпотентности нарушает уникальность заказа уже после резервирования.
+		_, err = c.Reserve(ctx, req)
+		assert.ErrorIs(t, err, client.ErrOrderExists)
+
+		available, reserved, _ := srv.Balance(userID)
+		assert.Equal(t, int64(90), available)
+		assert.Equal(t, int64(10), reserved)
+	})
+}
+
+func TestServer_Idempotency(t *testing.T) {
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 1000)
+
+	c := newClient(t, srv)
+	ctx := client.WithIdempotencyKey(context.Background(), "key")
+
+	req := api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 100}
+
+	first, err := c.Reserve(ctx, req)
+	require.NoError(t, err)
+
+	second, err := c.Reserve(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	available, _, _ := srv.Balance(userID)
+	assert.Equal(t, int64(900), available)
+}
+
+func TestServer_FailNext(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 1000)
+
+	c := newClient(t, srv)
+	req := api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 100}
+
+	t.Run("error code", func(t *testing.T) {
+		srv.FailNext("/v1/reserve", 1, "not_enough_cash")
+
+		_, err := c.Reserve(ctx, req)
+		assert.ErrorIs(t, err, client.ErrNotEnoughCash)
+	})
+
+	t.Run("status is retried by client", func(t *testing.T) {
+		srv.FailNextWithStatus("/v1/reserve", 2, http.StatusServiceUnavailable)
+
+		data, err := c.Reserve(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), data.Balance)
+	})
+}
+
+func TestServer_Report(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 1000)
+
+	c := newClient(t, srv)
+
+	_, err := c.Reserve(ctx, api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 300})
+	require.NoError(t, err)
+	_, err = c.WriteOff(ctx, api.WriteOffRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 300})
+	require.NoError(t, err)
+
+	// Отчета нет в клиенте, поэтому запрашиваем его напрямую.
+	body := `{"period":"` + time.Now().Format("2006-01") + `"}`
+	resp, err := http.Post(srv.URL+"/v1/getReport", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var report struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+
+	file, err := http.Get(report.Data.URL)
+	require.NoError(t, err)
+	defer file.Body.Close()
+
+	content, err := io.ReadAll(file.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), ",300")
+}
+
+func TestServer_AuditLog(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 100)
+
+	c := newClient(t, srv)
+
+	_, err := c.Reserve(ctx, api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 60})
+	require.NoError(t, err)
+	_, err = c.Reserve(ctx, api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: 11, Price: 60})
+	require.ErrorIs(t, err, client.ErrNotEnoughCash)
+
+	// Журнала нет в клиенте, поэтому запрашиваем его напрямую.
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/admin/getAuditLog", strings.NewReader(`{"userID":1}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "audit-request")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "audit-request", resp.Header.Get("X-Request-ID"))
+
+	var auditLog struct {
+		Data struct {
+			Entries []struct {
+				RequestID           string  `json:"requestID"`
+				ClientName          string  `json:"clientName"`
+				Endpoint            string  `json:"endpoint"`
+				ResultCode          string  `json:"resultCode"`
+				TransactionIDs      []int64 `json:"transactionIDs"`
+				OrderTransactionIDs []int64 `json:"orderTransactionIDs"`
+			} `json:"entries"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&auditLog))
+
+	entries := auditLog.Data.Entries
+	require.Len(t, entries, 2)
+
+	assert.NotEmpty(t, entries[0].RequestID)
+	assert.Equal(t, "wallettest", entries[0].ClientName)
+	assert.Equal(t, "/v1/reserve", entries[0].Endpoint)
+	assert.Equal(t, "ok", entries[0].ResultCode)
+	assert.Len(t, entries[0].TransactionIDs, 1)
+	assert.Len(t, entries[0].OrderTransactionIDs, 1)
+
+	// Неуспешный запрос записан без транзакций: они откатились.
+	assert.Equal(t, "not_enough_cash", entries[1].ResultCode)
+	assert.Empty(t, entries[1].TransactionIDs)
+}
+
+func TestServer_Metrics(t *testing.T) {
+	ctx := context.Background()
+
+	srv := wallettest.New(t)
+	srv.SeedWallet(userID, 100)
+
+	c := newClient(t, srv)
+
+	_, err := c.Reserve(ctx, api.ReserveRequest{UserID: userID, ServiceID: serviceID, OrderID: orderID, Price: 500})
+	require.ErrorIs(t, err, client.ErrNotEnoughCash)
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	metrics := string(body)
+	assert.Contains(t, metrics, `wallet_requests_total{code="not_enough_cash",endpoint="POST /v1/reserve"`)
+	assert.Contains(t, metrics, `wallet_operations_total{operation="reserve",result="not_enough_cash"}`)
+}
+
+func TestServer_Health(t *testing.T) {
+	srv := wallettest.New(t)
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+	}
+}
@@ -0,0 +1,82 @@
+// Package webhooks описывает подпись webhook-запросов сервиса. Пригодится партнерам для проверки того, что запрос
+// действительно пришел от кошелька.
+//
+// Каждый запрос – POST с конвертом события (см. pkg/events) в теле и заголовками:
+//   - X-Wallet-Event-Type – тип события;
+//   - X-Wallet-Delivery-ID – идентификатор доставки, одинаковый для всех попыток;
+//   - X-Wallet-Signature – подпись в виде "t=<unix-время>,v1=<hex(HMAC-SHA256(secret, "<t>.<тело>"))>".
+//
+// Время входит в подпись, поэтому получатель может отбрасывать старые запросы и защититься от их повтора.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEventType  = "X-Wallet-Event-Type"
+	HeaderDeliveryID = "X-Wallet-Delivery-ID"
+	HeaderSignature  = "X-Wallet-Signature"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// Sign отдает значение заголовка X-Wallet-Signature для тела body, подписанного в момент t.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, mac(secret, ts, body))
+}
+
+// Verify проверяет заголовок X-Wallet-Signature. Подписи старше tolerance отклоняются с ErrSignatureExpired,
+// нулевой tolerance отключает эту проверку.
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var ts, sig string
+
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sig = v
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(mac(secret, ts, body))) {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)) > tolerance {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
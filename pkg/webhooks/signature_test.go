@@ -0,0 +1,44 @@
+package webhooks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/frutonanny/wallet-service/pkg/webhooks"
+)
+
+const testSecret = "secret"
+
+var testBody = []byte(`{"id":1,"type":"wallet.incoming_transfer"}`)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Now()
+
+	t.Run("valid signature", func(t *testing.T) {
+		header := webhooks.Sign(testSecret, now, testBody)
+		assert.NoError(t, webhooks.Verify(testSecret, header, testBody, time.Minute))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		header := webhooks.Sign("other", now, testBody)
+		assert.ErrorIs(t, webhooks.Verify(testSecret, header, testBody, time.Minute), webhooks.ErrInvalidSignature)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		header := webhooks.Sign(testSecret, now, testBody)
+		err := webhooks.Verify(testSecret, header, []byte(`{"id":2}`), time.Minute)
+		assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+	})
+
+	t.Run("expired signature", func(t *testing.T) {
+		header := webhooks.Sign(testSecret, now.Add(-time.Hour), testBody)
+		err := webhooks.Verify(testSecret, header, testBody, time.Minute)
+		assert.ErrorIs(t, err, webhooks.ErrSignatureExpired)
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		assert.ErrorIs(t, webhooks.Verify(testSecret, "garbage", testBody, 0), webhooks.ErrInvalidSignature)
+	})
+}